@@ -27,6 +27,8 @@ const (
 	CapellaVersion   StateVersion = 3
 	DenebVersion     StateVersion = 4
 	ElectraVersion   StateVersion = 5
+	// FocilVersion - only version of Engine API methods with inclusion lists (EIP-7805), beacon state doesn't have it
+	FocilVersion StateVersion = 6
 )
 
 func (v StateVersion) String() string {
@@ -43,6 +45,8 @@ func (v StateVersion) String() string {
 		return "deneb"
 	case ElectraVersion:
 		return "electra"
+	case FocilVersion:
+		return "focil"
 	default:
 		panic("unsupported fork version")
 	}
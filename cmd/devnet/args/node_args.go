@@ -40,6 +40,7 @@ type NodeArgs struct {
 	BuildDir                  string `arg:"positional" default:"./build/bin/devnet" json:"builddir"`
 	DataDir                   string `arg:"--datadir" default:"./dev" json:"datadir"`
 	Chain                     string `arg:"--chain" default:"dev" json:"chain"`
+	DevClique                 bool   `arg:"--dev.clique" flag:"" default:"false" json:"dev.clique,omitempty"`
 	Port                      int    `arg:"--port" json:"port,omitempty"`
 	AllowedPorts              string `arg:"--p2p.allowed-ports" json:"p2p.allowed-ports,omitempty"`
 	NAT                       string `arg:"--nat" default:"none" json:"nat"`
@@ -84,6 +85,8 @@ func (node *NodeArgs) Configure(base NodeArgs, nodeNumber int) error {
 	node.LogDirPrefix = node.Name
 
 	node.Chain = base.Chain
+	// devnet nodes mine and sync the pre-merge Clique dev chain
+	node.DevClique = base.Chain == networkname.Dev
 
 	node.StaticPeers = base.StaticPeers
 
@@ -0,0 +1,87 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/core/vm"
+)
+
+// an example of a stateful precompile, active only on chains which schedule it in the chain config:
+//
+//	"customPrecompiles": {"allowlist": {"block": 0}}
+//
+// The roles (0 - none, 1 - allowed, 2 - admin) are kept in the storage of the precompile account,
+// so they can be seeded in the genesis alloc. The account needs a non-zero nonce there,
+// otherwise it's cleared as empty (EIP-161) once touched.
+var allowlistAddress = common.HexToAddress("0x0200000000000000000000000000000000000001")
+
+const roleAdmin = 2
+
+func init() {
+	vm.RegisterPrecompile("allowlist", allowlistAddress, &allowlist{})
+}
+
+// allowlist returns the role of an address for a 32-byte input (the address, left-padded),
+// and lets admins set the role of an address for a 64-byte input (the address and the role)
+type allowlist struct{}
+
+func (a *allowlist) RequiredGas(input []byte) uint64 {
+	if len(input) == 64 {
+		return params.SstoreSetGasEIP2200
+	}
+	return params.ColdSloadCostEIP2929
+}
+
+func (a *allowlist) Run(input []byte) ([]byte, error) {
+	// only called via RunStateful
+	return nil, vm.ErrExecutionReverted
+}
+
+func (a *allowlist) RunStateful(env *vm.PrecompileEnvironment, input []byte) ([]byte, error) {
+	ibs := env.EVM.IntraBlockState()
+	switch len(input) {
+	case 32:
+		var role uint256.Int
+		if err := ibs.GetState(allowlistAddress, common.BytesToHash(input), &role); err != nil {
+			return nil, err
+		}
+		b := role.Bytes32()
+		return b[:], nil
+	case 64:
+		if env.ReadOnly {
+			return nil, vm.ErrWriteProtection
+		}
+		var callerRole uint256.Int
+		if err := ibs.GetState(allowlistAddress, common.BytesToHash(env.Caller[:]), &callerRole); err != nil {
+			return nil, err
+		}
+		role := new(uint256.Int).SetBytes(input[32:])
+		if !callerRole.Eq(uint256.NewInt(roleAdmin)) || role.GtUint64(roleAdmin) {
+			return nil, vm.ErrExecutionReverted
+		}
+		if err := ibs.SetState(allowlistAddress, common.BytesToHash(input[:32]), *role); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	default:
+		return nil, vm.ErrExecutionReverted
+	}
+}
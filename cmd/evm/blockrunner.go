@@ -0,0 +1,199 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/direct"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/eth/tracers"
+	"github.com/erigontech/erigon/eth/tracers/logger"
+	"github.com/erigontech/erigon/tests"
+	"github.com/erigontech/erigon/turbo/engineapi"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+var blockTestCommand = cli.Command{
+	Action:    blockTestCmd,
+	Name:      "blocktest",
+	Usage:     "executes the given blockchain tests",
+	ArgsUsage: "<file|dir>",
+	Flags:     []cli.Flag{&RunFlag, &TraceFlag},
+}
+
+var engineTestCommand = cli.Command{
+	Action:    engineTestCmd,
+	Name:      "enginetest",
+	Usage:     "executes the given blockchain tests with blocks sent via Engine API (execution-spec-tests blockchain_test_engine fixtures)",
+	ArgsUsage: "<file|dir>",
+	Flags:     []cli.Flag{&RunFlag, &TraceFlag},
+}
+
+// BlocktestResult contains the execution status after running a blockchain or EOF test
+type BlocktestResult struct {
+	Name  string `json:"name"`
+	Pass  bool   `json:"pass"`
+	Fork  string `json:"fork"`
+	Error string `json:"error,omitempty"`
+}
+
+// blockchainTest - common part of tests.BlockTest and tests.EngineTest
+type blockchainTest interface {
+	Network() string
+}
+
+func blockTestCmd(ctx *cli.Context) error {
+	return runBlockchainTests(ctx, func(src []byte) (map[string]blockchainTest, error) {
+		return decodeBlockchainTests[*tests.BlockTest](src)
+	}, func(test blockchainTest, tracer *tracers.Tracer) error {
+		return test.(*tests.BlockTest).RunWithTracer(true /* checkStateRoot */, tracer)
+	})
+}
+
+func engineTestCmd(ctx *cli.Context) error {
+	return runBlockchainTests(ctx, func(src []byte) (map[string]blockchainTest, error) {
+		return decodeBlockchainTests[*tests.EngineTest](src)
+	}, func(test blockchainTest, tracer *tracers.Tracer) error {
+		return test.(*tests.EngineTest).Run(tracer, newEngineServer)
+	})
+}
+
+func newEngineServer(m *mock.MockSentry) tests.EngineAPI {
+	return engineapi.NewEngineServer(m.Log, m.ChainConfig, direct.NewExecutionClientDirect(m.Eth1ExecutionService), m.HeaderDownload(),
+		nil /* blockDownloader */, nil /* txnProvider */, false /* caplin */, true /* test */, false /* proposing */, true /* consuming */)
+}
+
+func decodeBlockchainTests[T interface {
+	*tests.BlockTest | *tests.EngineTest
+	blockchainTest
+}](src []byte) (map[string]blockchainTest, error) {
+	var decoded map[string]T
+	if err := json.Unmarshal(src, &decoded); err != nil {
+		return nil, err
+	}
+	result := make(map[string]blockchainTest, len(decoded))
+	for name, test := range decoded {
+		result[name] = test
+	}
+	return result, nil
+}
+
+// runBlockchainTests - runs tests of all JSON files in given file or directory one by one and prints results as JSON
+// array to stdout. Returns error if any test failed.
+func runBlockchainTests(ctx *cli.Context,
+	decode func(src []byte) (map[string]blockchainTest, error),
+	run func(test blockchainTest, tracer *tracers.Tracer) error,
+) error {
+	if ctx.Args().Len() != 1 {
+		return errors.New("path to a test file or directory is required")
+	}
+	if ctx.Bool(MachineFlag.Name) || ctx.Bool(TraceFlag.Name) {
+		log.Root().SetHandler(log.DiscardHandler())
+	} else {
+		log.Root().SetHandler(log.LvlFilterHandler(log.LvlWarn, log.StderrHandler))
+	}
+	var filter *regexp.Regexp
+	if pattern := ctx.String(RunFlag.Name); pattern != "" {
+		var err error
+		if filter, err = regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid --%s: %w", RunFlag.Name, err)
+		}
+	}
+	var tracer *tracers.Tracer
+	if ctx.Bool(TraceFlag.Name) {
+		tracer = logger.NewJSONLogger(&logger.LogConfig{
+			DisableMemory:     ctx.Bool(DisableMemoryFlag.Name),
+			DisableStack:      ctx.Bool(DisableStackFlag.Name),
+			DisableStorage:    ctx.Bool(DisableStorageFlag.Name),
+			DisableReturnData: ctx.Bool(DisableReturnDataFlag.Name),
+		}, os.Stderr).Tracer()
+	}
+
+	files, err := collectJSONFiles(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	results := make([]BlocktestResult, 0)
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		testsByName, err := decode(src)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		names := make([]string, 0, len(testsByName))
+		for name := range testsByName {
+			if filter == nil || filter.MatchString(name) {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			test := testsByName[name]
+			result := BlocktestResult{Name: name, Fork: test.Network(), Pass: true}
+			if err := run(test, tracer); err != nil {
+				result.Pass, result.Error = false, err.Error()
+			}
+			results = append(results, result)
+		}
+	}
+
+	out, _ := json.MarshalIndent(results, "", "  ")
+	fmt.Println(string(out))
+	for _, result := range results {
+		if !result.Pass {
+			return errors.New("some tests failed")
+		}
+	}
+	return nil
+}
+
+// collectJSONFiles - path itself if it's a file, otherwise all .json files in the directory tree in lexical order
+func collectJSONFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	var files []string
+	err = filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(d.Name(), ".json") {
+			files = append(files, file)
+		}
+		return nil
+	})
+	return files, err
+}
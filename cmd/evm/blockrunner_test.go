@@ -0,0 +1,77 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/turbo/cmdtest"
+)
+
+func TestBlockchainTestCommands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	for _, tc := range []struct {
+		input    []string
+		want     []BlocktestResult
+		exitCode int
+		trace    bool
+	}{
+		{
+			input: []string{"blocktest", "./testdata/blocktest"},
+			want: []BlocktestResult{
+				{Name: "transfer", Pass: true, Fork: "Paris"},
+				{Name: "transfer_wrong_post_state", Fork: "Paris", Error: "post state validation failed: account balance mismatch for addr: bb00000000000000000000000000000000000000, want: 2001, have: 2000"},
+			},
+			exitCode: 1,
+		},
+		{
+			input: []string{"blocktest", "--run", "^transfer$", "--trace", "./testdata/blocktest/transfer.json"},
+			want:  []BlocktestResult{{Name: "transfer", Pass: true, Fork: "Paris"}},
+			trace: true,
+		},
+		{
+			input: []string{"enginetest", "./testdata/enginetest"},
+			want:  []BlocktestResult{{Name: "transfer", Pass: true, Fork: "Paris"}},
+		},
+		{
+			input: []string{"enginetest", "--run", "nothing", "./testdata/enginetest"},
+			want:  []BlocktestResult{},
+		},
+	} {
+		tt := cmdtest.NewTestCmd(t, nil)
+		tt.Logf("args: go run ./cmd/evm %v\n", strings.Join(tc.input, " "))
+		tt.Run("evm-test", tc.input...)
+
+		output := tt.Output()
+		tt.WaitExit()
+		require.Equal(t, tc.exitCode, tt.ExitStatus(), "stderr: %s", tt.StderrText())
+		var have []BlocktestResult
+		require.NoError(t, json.Unmarshal(output, &have))
+		require.Equal(t, tc.want, have)
+		if tc.trace {
+			require.Contains(t, tt.StderrText(), `"opName":"SSTORE"`)
+		}
+	}
+}
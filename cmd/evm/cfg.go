@@ -0,0 +1,71 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+
+	"github.com/erigontech/erigon/core/vm"
+)
+
+var cfgCommand = cli.Command{
+	Action:    cfgCmd,
+	Name:      "cfg",
+	Usage:     "recovers control flow graph of legacy bytecode by abstract interpretation, unresolved jumps are highlighted",
+	ArgsUsage: "<code|file>",
+	Flags:     []cli.Flag{&CfgFormatFlag},
+}
+
+func cfgCmd(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		return errors.New("hex-encoded code or path to a file with it is expected")
+	}
+	in := ctx.Args().First()
+	if input, err := os.ReadFile(in); err == nil {
+		in = string(input)
+	}
+	code, err := hexutil.Decode(ensureHexPrefix(strings.TrimSpace(in)))
+	if err != nil {
+		return fmt.Errorf("invalid code: %w", err)
+	}
+
+	cfg := vm.AnalyzeCfg(ctx.Context, code, vm.DefaultCfgLimits)
+	switch format := ctx.String(CfgFormatFlag.Name); format {
+	case "dot":
+		fmt.Print(cfg.Dot())
+	case "json":
+		out, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+	default:
+		return fmt.Errorf("unknown --%s: %s", CfgFormatFlag.Name, format)
+	}
+	if !cfg.Complete {
+		_, _ = fmt.Fprintf(os.Stderr, "incomplete graph: %s\n", cfg.Error)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/turbo/cmdtest"
+)
+
+func TestCfg(t *testing.T) {
+	t.Parallel()
+	tt := cmdtest.NewTestCmd(t, nil)
+	tt.Run("evm-test", "cfg", "--format", "json", "0x5f35600857600a565b005b5f3556fe")
+	output := tt.Output()
+	tt.WaitExit()
+	require.Equal(t, 0, tt.ExitStatus(), "stderr: %s", tt.StderrText())
+	var cfg vm.ContractCfg
+	require.NoError(t, json.Unmarshal(output, &cfg))
+	require.False(t, cfg.Complete)
+	require.Equal(t, []int{13}, cfg.UnresolvedJumps)
+	require.Len(t, cfg.Blocks, 5)
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/tests"
+)
+
+var eofParseCommand = cli.Command{
+	Action:    eofParseCmd,
+	Name:      "eofparse",
+	Usage:     "validates EOF containers: hex-encoded ones from stdin, one per line, or the given EOF tests (execution-spec-tests eof_tests fixtures)",
+	ArgsUsage: "[<file|dir>]",
+	Flags:     []cli.Flag{&RunFlag, &InitcodeFlag},
+}
+
+func eofParseCmd(ctx *cli.Context) error {
+	if ctx.Args().Len() > 0 {
+		return runEOFTests(ctx)
+	}
+	kind := vm.RuntimeContainer
+	if ctx.Bool(InitcodeFlag.Name) {
+		kind = vm.InitcodeContainer
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 1024*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		code, err := hexutil.Decode(ensureHexPrefix(line))
+		if err == nil {
+			_, err = vm.ValidateEOF(code, kind)
+		}
+		if err != nil {
+			fmt.Printf("err: %v\n", err)
+		} else {
+			fmt.Println("OK")
+		}
+	}
+	return scanner.Err()
+}
+
+func ensureHexPrefix(s string) string {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return s
+	}
+	return "0x" + s
+}
+
+// runEOFTests - runs EOF tests of all JSON files in given file or directory and prints results as JSON array to
+// stdout. Returns error if any test failed.
+func runEOFTests(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		return errors.New("one path to a test file or directory is expected")
+	}
+	var filter *regexp.Regexp
+	if pattern := ctx.String(RunFlag.Name); pattern != "" {
+		var err error
+		if filter, err = regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid --%s: %w", RunFlag.Name, err)
+		}
+	}
+	files, err := collectJSONFiles(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	results := make([]BlocktestResult, 0)
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		var testsByName map[string]*tests.EOFTest
+		if err := json.Unmarshal(src, &testsByName); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		names := make([]string, 0, len(testsByName))
+		for name := range testsByName {
+			if filter == nil || filter.MatchString(name) {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			test := testsByName[name]
+			result := BlocktestResult{Name: name, Fork: strings.Join(test.Forks(), ","), Pass: true}
+			if err := test.Run(); err != nil {
+				result.Pass, result.Error = false, err.Error()
+			}
+			results = append(results, result)
+		}
+	}
+
+	out, _ := json.MarshalIndent(results, "", "  ")
+	fmt.Println(string(out))
+	for _, result := range results {
+		if !result.Pass {
+			return errors.New("some tests failed")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/turbo/cmdtest"
+)
+
+func TestEOFParse(t *testing.T) {
+	t.Parallel()
+	tt := cmdtest.NewTestCmd(t, nil)
+	tt.Run("evm-test", "eofparse", "./testdata/eoftest")
+	output := tt.Output()
+	tt.WaitExit()
+	require.Equal(t, 1, tt.ExitStatus(), "stderr: %s", tt.StderrText())
+	var have []BlocktestResult
+	require.NoError(t, json.Unmarshal(output, &have))
+	require.Equal(t, []BlocktestResult{
+		{Name: "legacy_jump", Pass: true, Fork: "Osaka"},
+		{Name: "legacy_jump_expected_valid", Fork: "Osaka", Error: "vector 0, Osaka: unexpected validation error: code section 0: undefined instruction: 0x56 at 1"},
+		{Name: "stop", Pass: true, Fork: "Osaka"},
+	}, have)
+
+	tt = cmdtest.NewTestCmd(t, nil)
+	tt.Run("evm-test", "eofparse", "--initcode")
+	tt.InputLine("ef00010100040200010001ff0000000080000000")
+	tt.InputLine("0xef00010100040200010004ff000000008000025f5fee00")
+	tt.CloseStdin()
+	tt.Expect(`
+err: code section 0: instruction not allowed in container kind: STOP at 0
+err: code section 0: invalid container section index: RETURNCONTRACT 0 at 2
+`)
+	tt.ExpectExit()
+}
@@ -29,7 +29,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/log/v3"
 
-	"github.com/erigontech/erigon/cmd/evm/internal/t8ntool"
+	"github.com/erigontech/erigon/cmd/evm/t8ntool"
 	"github.com/erigontech/erigon/cmd/utils/flags"
 	"github.com/erigontech/erigon/params"
 	cli2 "github.com/erigontech/erigon/turbo/cli"
@@ -50,6 +50,14 @@ var (
 		Name:  "cpuprofile",
 		Usage: "creates a CPU profile at the given path",
 	}
+	VMProfileFlag = cli.StringFlag{
+		Name:  "vmprofile",
+		Usage: "creates a pprof profile of calls, gas and time per contract and opcode, and per precompile, at the given path",
+	}
+	FuseFlag = cli.BoolFlag{
+		Name:  "vm.fuse",
+		Usage: "execute common opcode sequences as superinstructions",
+	}
 	StatDumpFlag = cli.BoolFlag{
 		Name:  "statdump",
 		Usage: "displays stack and heap memory information",
@@ -133,6 +141,23 @@ var (
 		Name:  "noreturndata",
 		Usage: "disable return data output",
 	}
+	RunFlag = cli.StringFlag{
+		Name:  "run",
+		Usage: "run only tests with names matching the regular expression",
+	}
+	TraceFlag = cli.BoolFlag{
+		Name:  "trace",
+		Usage: "output EIP-3155 trace of executed blocks to stderr",
+	}
+	InitcodeFlag = cli.BoolFlag{
+		Name:  "initcode",
+		Usage: "validate EOF containers as initcode (EOFCREATE targets) instead of runtime code",
+	}
+	CfgFormatFlag = cli.StringFlag{
+		Name:  "format",
+		Usage: "output format of control flow graph: dot or json",
+		Value: "dot",
+	}
 )
 
 var stateTransitionCommand = cli.Command{
@@ -174,6 +199,8 @@ func init() {
 		&InputFileFlag,
 		&MemProfileFlag,
 		&CPUProfileFlag,
+		&VMProfileFlag,
+		&FuseFlag,
 		&StatDumpFlag,
 		&GenesisFlag,
 		&MachineFlag,
@@ -190,6 +217,10 @@ func init() {
 		&runCommand,
 		&stateTestCommand,
 		&stateTransitionCommand,
+		&blockTestCommand,
+		&engineTestCommand,
+		&eofParseCommand,
+		&cfgCommand,
 	}
 }
 
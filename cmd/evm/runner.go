@@ -126,6 +126,19 @@ func timedExec(bench bool, execFunc func() ([]byte, uint64, error)) (output []by
 	return output, gasLeft, stats, err
 }
 
+// writeVMProfile writes the profile collected by the EVM profiler as pprof file at the given path
+func writeVMProfile(path string, profiler *vm.Profiler) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create VM profile: %w", err)
+	}
+	defer f.Close()
+	if err := profiler.WriteProfile(f); err != nil {
+		return fmt.Errorf("could not write VM profile: %w", err)
+	}
+	return nil
+}
+
 func runCmd(ctx *cli.Context) error {
 	machineFriendlyOutput := ctx.Bool(MachineFlag.Name)
 	if machineFriendlyOutput {
@@ -265,6 +278,10 @@ func runCmd(ctx *cli.Context) error {
 		runtimeConfig.EVMConfig.Tracer = tracer.Hooks
 	}
 	runtimeConfig.EVMConfig.JumpDestCache = vm.NewJumpDestCache(16)
+	runtimeConfig.EVMConfig.FuseInstructions = ctx.Bool(FuseFlag.Name)
+	if ctx.String(VMProfileFlag.Name) != "" {
+		runtimeConfig.EVMConfig.Profiler = vm.NewProfiler()
+	}
 
 	if cpuProfilePath := ctx.String(CPUProfileFlag.Name); cpuProfilePath != "" {
 		f, err := os.Create(cpuProfilePath)
@@ -328,6 +345,12 @@ func runCmd(ctx *cli.Context) error {
 		fmt.Println(string(state.NewDumper(tx, rawdbv3.TxNums, 0).DefaultDump()))
 	}
 
+	if vmProfilePath := ctx.String(VMProfileFlag.Name); vmProfilePath != "" {
+		if err := writeVMProfile(vmProfilePath, runtimeConfig.EVMConfig.Profiler); err != nil {
+			return err
+		}
+	}
+
 	if memProfilePath := ctx.String(MemProfileFlag.Name); memProfilePath != "" {
 		f, err := os.Create(memProfilePath)
 		if err != nil {
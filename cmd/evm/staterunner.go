@@ -87,6 +87,15 @@ func stateTestCmd(ctx *cli.Context) error {
 		cfg.Tracer = logger.NewStructLogger(config).Tracer().Hooks
 	}
 
+	if vmProfilePath := ctx.String(VMProfileFlag.Name); vmProfilePath != "" {
+		cfg.Profiler = vm.NewProfiler()
+		defer func() {
+			if err := writeVMProfile(vmProfilePath, cfg.Profiler); err != nil {
+				log.Error("Failed to write VM profile", "err", err)
+			}
+		}()
+	}
+
 	if len(ctx.Args().First()) != 0 {
 		return runStateTest(ctx.Args().First(), cfg, ctx.Bool(MachineFlag.Name))
 	}
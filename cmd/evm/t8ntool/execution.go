@@ -35,7 +35,6 @@ import (
 	"github.com/erigontech/erigon/core/state"
 	"github.com/erigontech/erigon/core/tracing"
 	"github.com/erigontech/erigon/execution/consensus/ethash"
-	"github.com/erigontech/erigon/rpc/rpchelper"
 )
 
 type Prestate struct {
@@ -67,6 +66,8 @@ type stEnv struct {
 	Withdrawals      []*types.Withdrawal                 `json:"withdrawals,omitempty"`
 	WithdrawalsHash  *common.Hash                        `json:"withdrawalsRoot,omitempty"`
 	RequestsHash     *common.Hash                        `json:"requestsHash,omitempty"`
+	ExcessBlobGas    *uint64                             `json:"currentExcessBlobGas,omitempty"`
+	ParentBeaconRoot *common.Hash                        `json:"parentBeaconBlockRoot,omitempty"`
 }
 
 type stEnvMarshaling struct {
@@ -79,10 +80,11 @@ type stEnvMarshaling struct {
 	Timestamp        math.HexOrDecimal64
 	ParentTimestamp  math.HexOrDecimal64
 	BaseFee          *math.HexOrDecimal256
+	ExcessBlobGas    *math.HexOrDecimal64
 }
 
 func MakePreState(chainRules *chain.Rules, tx kv.TemporalRwTx, sd *state3.SharedDomains, accounts types.GenesisAlloc, blockNum, txNum uint64) (state.StateReader, state.StateWriter) {
-	stateReader, stateWriter := rpchelper.NewLatestStateReader(tx), state.NewWriter(sd.AsPutDel(tx), nil, txNum)
+	stateReader, stateWriter := state.NewReaderV3(sd.AsGetter(tx)), state.NewWriter(sd.AsPutDel(tx), nil, txNum)
 	statedb := state.New(stateReader) //ibs
 	for addr, a := range accounts {
 		statedb.SetCode(addr, a.Code)
@@ -37,10 +37,8 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/common/hexutil"
-	"github.com/erigontech/erigon-lib/common/length"
 	"github.com/erigontech/erigon-lib/common/math"
 	"github.com/erigontech/erigon-lib/crypto"
-	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/rawdbv3"
 	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
 	"github.com/erigontech/erigon-lib/log/v3"
@@ -92,7 +90,8 @@ var (
 	_ cli.ExitCoder = (*NumberedError)(nil)
 )
 
-type input struct {
+// Input - prestate, block environment and txs of a transition, as read from stdin
+type Input struct {
 	Alloc types.GenesisAlloc `json:"alloc,omitempty"`
 	Env   *stEnv             `json:"env,omitempty"`
 	Txs   []*txWithKey       `json:"txs,omitempty"`
@@ -142,22 +141,16 @@ func Main(ctx *cli.Context) error {
 			prevFile = traceFile
 			return trace_logger.NewJSONLogger(logConfig, traceFile).Tracer().Hooks, nil
 		}
-	} else {
-		getTracer = func(txIndex int, txHash common.Hash) (tracer *tracing.Hooks, err error) {
-			return nil, nil
-		}
 	}
 	// We need to load three things: alloc, env and transactions. May be either in
 	// stdin input or in files.
 	// Check if anything needs to be read from stdin
 	var (
-		prestate Prestate
-		txs      types.Transactions // txs to apply
 		allocStr = ctx.String(InputAllocFlag.Name)
 
 		envStr    = ctx.String(InputEnvFlag.Name)
 		txStr     = ctx.String(InputTxsFlag.Name)
-		inputData = &input{}
+		inputData = &Input{}
 	)
 	// Figure out the prestate alloc
 	if allocStr == stdinSelector || envStr == stdinSelector || txStr == stdinSelector {
@@ -176,8 +169,6 @@ func Main(ctx *cli.Context) error {
 		}
 	}
 
-	prestate.Pre = inputData.Alloc
-
 	// Set the block environment
 	if envStr != stdinSelector {
 		inFile, err1 := os.Open(envStr)
@@ -192,6 +183,44 @@ func Main(ctx *cli.Context) error {
 		}
 		inputData.Env = &env
 	}
+
+	if txStr != stdinSelector {
+		inFile, err1 := os.Open(txStr)
+		if err1 != nil {
+			return NewError(ErrorIO, fmt.Errorf("failed reading txs file: %v", err1))
+		}
+		defer inFile.Close()
+		decoder := json.NewDecoder(inFile)
+		if err = decoder.Decode(&inputData.Txs); err != nil {
+			return NewError(ErrorJson, fmt.Errorf("failed unmarshaling txs-file: %v", err))
+		}
+	}
+
+	result, collector, body, err := Transition(inputData, ctx.String(ForknameFlag.Name), ctx.Int64(ChainIDFlag.Name), getTracer)
+	if err != nil {
+		return err
+	}
+	return dispatchOutput(ctx, baseDir, result, collector, body)
+}
+
+// Transition executes txs of the input on top of its alloc as a single block of the fork, in memory.
+// It returns the execution result, post-state alloc and RLP of the txs, which Main writes out.
+// getTracer may be nil.
+func Transition(inputData *Input, fork string, chainID int64, getTracer func(txIndex int, txHash common.Hash) (*tracing.Hooks, error)) (*core.EphemeralExecResult, Alloc, hexutil.Bytes, error) {
+	if inputData.Env == nil {
+		return nil, nil, nil, NewError(ErrorJson, errors.New("missing env"))
+	}
+	if getTracer == nil {
+		getTracer = func(txIndex int, txHash common.Hash) (tracer *tracing.Hooks, err error) {
+			return nil, nil
+		}
+	}
+	var (
+		err      error
+		prestate Prestate
+		txs      types.Transactions // txs to apply
+	)
+	prestate.Pre = inputData.Alloc
 	prestate.Env = *inputData.Env
 
 	vmConfig := vm.Config{
@@ -200,48 +229,43 @@ func Main(ctx *cli.Context) error {
 	}
 	// Construct the chainconfig
 	var chainConfig *chain.Config
-	if cConf, extraEips, err1 := tests.GetChainConfig(ctx.String(ForknameFlag.Name)); err1 != nil {
-		return NewError(ErrorVMConfig, fmt.Errorf("failed constructing chain configuration: %v", err1))
+	if cConf, extraEips, err1 := tests.GetChainConfig(fork); err1 != nil {
+		return nil, nil, nil, NewError(ErrorVMConfig, fmt.Errorf("failed constructing chain configuration: %v", err1))
 	} else {
 		chainConfig = cConf
 		vmConfig.ExtraEips = extraEips
 	}
 	// Set the chain id
-	chainConfig.ChainID = big.NewInt(ctx.Int64(ChainIDFlag.Name))
+	chainConfig.ChainID = big.NewInt(chainID)
 
-	var txsWithKeys []*txWithKey
-	if txStr != stdinSelector {
-		inFile, err1 := os.Open(txStr)
-		if err1 != nil {
-			return NewError(ErrorIO, fmt.Errorf("failed reading txs file: %v", err1))
-		}
-		defer inFile.Close()
-		decoder := json.NewDecoder(inFile)
-		if err = decoder.Decode(&txsWithKeys); err != nil {
-			return NewError(ErrorJson, fmt.Errorf("failed unmarshaling txs-file: %v", err))
-		}
-	} else {
-		txsWithKeys = inputData.Txs
-	}
 	// We may have to sign the transactions.
 	signer := types.MakeSigner(chainConfig, prestate.Env.Number, prestate.Env.Timestamp)
 
-	if txs, err = signUnsignedTransactions(txsWithKeys, *signer); err != nil {
-		return NewError(ErrorJson, fmt.Errorf("failed signing transactions: %v", err))
+	if txs, err = signUnsignedTransactions(inputData.Txs, *signer); err != nil {
+		return nil, nil, nil, NewError(ErrorJson, fmt.Errorf("failed signing transactions: %v", err))
 	}
 
 	eip1559 := chainConfig.IsLondon(prestate.Env.Number)
 	// Sanity check, to not `panic` in state_transition
 	if eip1559 {
 		if prestate.Env.BaseFee == nil {
-			return NewError(ErrorVMConfig, errors.New("EIP-1559 config but missing 'currentBaseFee' in env section"))
+			return nil, nil, nil, NewError(ErrorVMConfig, errors.New("EIP-1559 config but missing 'currentBaseFee' in env section"))
 		}
 	} else {
 		prestate.Env.Random = nil
 	}
 
 	if chainConfig.IsShanghai(prestate.Env.Timestamp) && prestate.Env.Withdrawals == nil {
-		return NewError(ErrorVMConfig, errors.New("shanghai config but missing 'withdrawals' in env section"))
+		return nil, nil, nil, NewError(ErrorVMConfig, errors.New("shanghai config but missing 'withdrawals' in env section"))
+	}
+
+	if chainConfig.IsCancun(prestate.Env.Timestamp) {
+		if prestate.Env.ParentBeaconRoot == nil {
+			return nil, nil, nil, NewError(ErrorVMConfig, errors.New("post-cancun env requires parentBeaconBlockRoot to be set"))
+		}
+		if prestate.Env.ExcessBlobGas == nil {
+			prestate.Env.ExcessBlobGas = new(uint64)
+		}
 	}
 
 	isMerged := chainConfig.TerminalTotalDifficulty != nil && chainConfig.TerminalTotalDifficulty.BitLen() == 0
@@ -252,20 +276,20 @@ func Main(ctx *cli.Context) error {
 		// - difficulty must be zero
 		switch {
 		case env.Random == nil:
-			return NewError(ErrorVMConfig, errors.New("post-merge requires currentRandom to be defined in env"))
+			return nil, nil, nil, NewError(ErrorVMConfig, errors.New("post-merge requires currentRandom to be defined in env"))
 		case env.Difficulty != nil && env.Difficulty.BitLen() != 0:
-			return NewError(ErrorVMConfig, errors.New("post-merge difficulty must be zero (or omitted) in env"))
+			return nil, nil, nil, NewError(ErrorVMConfig, errors.New("post-merge difficulty must be zero (or omitted) in env"))
 		}
 		prestate.Env.Difficulty = nil
 	} else if env.Difficulty == nil {
 		// If difficulty was not provided by caller, we need to calculate it.
 		switch {
 		case env.ParentDifficulty == nil:
-			return NewError(ErrorVMConfig, errors.New("currentDifficulty was not provided, and cannot be calculated due to missing parentDifficulty"))
+			return nil, nil, nil, NewError(ErrorVMConfig, errors.New("currentDifficulty was not provided, and cannot be calculated due to missing parentDifficulty"))
 		case env.Number == 0:
-			return NewError(ErrorVMConfig, errors.New("currentDifficulty needs to be provided for block number 0"))
+			return nil, nil, nil, NewError(ErrorVMConfig, errors.New("currentDifficulty needs to be provided for block number 0"))
 		case env.Timestamp <= env.ParentTimestamp:
-			return NewError(ErrorVMConfig, fmt.Errorf("currentDifficulty cannot be calculated -- currentTime (%d) needs to be after parent time (%d)",
+			return nil, nil, nil, NewError(ErrorVMConfig, fmt.Errorf("currentDifficulty cannot be calculated -- currentTime (%d) needs to be after parent time (%d)",
 				env.Timestamp, env.ParentTimestamp))
 		}
 		prestate.Env.Difficulty = calcDifficulty(chainConfig, env.Number, env.Timestamp,
@@ -297,18 +321,24 @@ func Main(ctx *cli.Context) error {
 		return h
 	}
 
-	db := temporaltest.NewTestDB(nil, datadir.New(""))
+	// in-process callers run transitions concurrently, so each one has its own datadir
+	dataDir, err := os.MkdirTemp("", "t8n")
+	if err != nil {
+		return nil, nil, nil, NewError(ErrorIO, fmt.Errorf("failed creating datadir: %v", err))
+	}
+	defer os.RemoveAll(dataDir)
+	db := temporaltest.NewTestDB(nil, datadir.New(dataDir))
 	defer db.Close()
 
 	tx, err := db.BeginTemporalRw(context.Background())
 	if err != nil {
-		return err
+		return nil, nil, nil, err
 	}
 	defer tx.Rollback()
 
 	sd, err := libstate.NewSharedDomains(tx, log.New())
 	if err != nil {
-		return err
+		return nil, nil, nil, err
 	}
 	defer sd.Close()
 
@@ -328,27 +358,39 @@ func Main(ctx *cli.Context) error {
 	chainReader := consensuschain.NewReader(chainConfig, tx, nil, t8logger)
 	result, err := core.ExecuteBlockEphemerally(chainConfig, &vmConfig, getHash, engine, block, reader, writer, chainReader, getTracer, t8logger)
 	if hashError != nil {
-		return NewError(ErrorMissingBlockhash, fmt.Errorf("blockhash error: %v", err))
+		return nil, nil, nil, NewError(ErrorMissingBlockhash, fmt.Errorf("blockhash error: %w", hashError))
 	}
 
 	if err != nil {
-		return fmt.Errorf("error on EBE: %w", err)
+		return nil, nil, nil, fmt.Errorf("error on EBE: %w", err)
 	}
 
 	// state root calculation
-	root, err := CalculateStateRoot(tx, blockNum, txNum)
+	root, err := CalculateStateRoot(sd, blockNum, txNum)
 	if err != nil {
-		return err
+		return nil, nil, nil, err
 	}
 	result.StateRoot = *root
+	// post-state is in the shared domains until flushed, while the dump reads tx as of the end of the block
+	if err = sd.Flush(context.Background(), tx); err != nil {
+		return nil, nil, nil, err
+	}
+	if err = rawdbv3.TxNums.Append(tx, 0, txNum-1); err != nil {
+		return nil, nil, nil, err
+	}
+	if err = rawdbv3.TxNums.Append(tx, blockNum, txNum); err != nil {
+		return nil, nil, nil, err
+	}
 
 	// Dump the execution result
 	body, _ := rlp.EncodeToBytes(txs)
 	collector := make(Alloc)
 
-	dumper := state.NewDumper(tx, rawdbv3.TxNums, prestate.Env.Number)
-	dumper.DumpToCollector(collector, false, false, common.Address{}, 0)
-	return dispatchOutput(ctx, baseDir, result, collector, body)
+	dumper := state.NewDumper(tx, rawdbv3.TxNums, blockNum)
+	if _, err = dumper.DumpToCollector(collector, false, false, common.Address{}, 0); err != nil {
+		return nil, nil, nil, err
+	}
+	return result, collector, body, nil
 }
 
 // txWithKey is a helper-struct, to allow us to use the types.Transaction along with
@@ -623,58 +665,18 @@ func NewHeader(env stEnv) *types.Header {
 	header.UncleHash = env.UncleHash
 	header.WithdrawalsHash = env.WithdrawalsHash
 	header.RequestsHash = env.RequestsHash
+	header.ExcessBlobGas = env.ExcessBlobGas
+	header.ParentBeaconBlockRoot = env.ParentBeaconRoot
+	// parent hash is stored into the history contract since Prague
+	if env.Number > 0 {
+		header.ParentHash = env.BlockHashes[math.HexOrDecimal64(env.Number-1)]
+	}
 
 	return &header
 }
 
-func CalculateStateRoot(tx kv.TemporalRwTx, blockNum uint64, txNum uint64) (*common.Hash, error) {
-	// Generate hashed state
-	c, err := tx.RwCursor(kv.PlainState)
-	if err != nil {
-		return nil, err
-	}
-	defer c.Close()
-	h := common.NewHasher()
-	defer common.ReturnHasherToPool(h)
-	domains, err := libstate.NewSharedDomains(tx, log.New())
-	if err != nil {
-		return nil, fmt.Errorf("NewSharedDomains: %w", err)
-	}
-	defer domains.Close()
-
-	for k, v, err := c.First(); k != nil; k, v, err = c.Next() {
-		if err != nil {
-			return nil, fmt.Errorf("interate over plain state: %w", err)
-		}
-		var newK []byte
-		if len(k) == length.Addr {
-			newK = make([]byte, length.Hash)
-		} else {
-			newK = make([]byte, length.Hash*2+length.Incarnation)
-		}
-		h.Sha.Reset()
-		//nolint:errcheck
-		h.Sha.Write(k[:length.Addr])
-		//nolint:errcheck
-		h.Sha.Read(newK[:length.Hash])
-		if len(k) > length.Addr {
-			copy(newK[length.Hash:], k[length.Addr:length.Addr+length.Incarnation])
-			h.Sha.Reset()
-			//nolint:errcheck
-			h.Sha.Write(k[length.Addr+length.Incarnation:])
-			//nolint:errcheck
-			h.Sha.Read(newK[length.Hash+length.Incarnation:])
-			if err = tx.Put(kv.HashedStorageDeprecated, newK, common.CopyBytes(v)); err != nil {
-				return nil, fmt.Errorf("insert hashed key: %w", err)
-			}
-		} else {
-			if err = tx.Put(kv.HashedAccountsDeprecated, newK, common.CopyBytes(v)); err != nil {
-				return nil, fmt.Errorf("insert hashed key: %w", err)
-			}
-		}
-	}
-	c.Close()
-	root, err := domains.ComputeCommitment(context.Background(), true, blockNum, txNum, "")
+func CalculateStateRoot(sd *libstate.SharedDomains, blockNum uint64, txNum uint64) (*common.Hash, error) {
+	root, err := sd.ComputeCommitment(context.Background(), true, blockNum, txNum, "")
 	if err != nil {
 		return nil, err
 	}
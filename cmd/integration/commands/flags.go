@@ -39,7 +39,8 @@ var (
 	integrityFast, integritySlow bool
 	file                         string
 	HeimdallURL                  string
-	txtrace                      bool // Whether to trace the execution (should only be used together with `block`)
+	txtrace                      bool   // Whether to trace the execution (should only be used together with `block`)
+	profileEVM                   string // Path of pprof file with gas and time per contract and opcode
 	unwindTypes                  []string
 	chain                        string // Which chain to use (mainnet, sepolia, etc.)
 	outputCsvFile                string
@@ -160,6 +161,10 @@ func withTxTrace(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&txtrace, "txtrace", false, "enable tracing of transactions")
 }
 
+func withProfileEVM(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&profileEVM, "profile-evm", "", "aggregate calls, gas and time per contract and opcode, and per precompile, and write them as pprof profile to the given file")
+}
+
 func withChain(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&chain, "chain", "", "pick a chain to assume (mainnet, sepolia, etc.)")
 	must(cmd.MarkFlagRequired("chain"))
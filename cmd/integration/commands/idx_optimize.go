@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
 
@@ -194,6 +195,7 @@ var idxOptimize = &cobra.Command{
 				IndexFile:  idxPath,
 				Salt:       salt,
 				NoFsync:    false,
+				Workers:    recsplitWorkers,
 			}
 			data, err := seg.NewDecompressor(dirs.SnapIdx + file.Name() + ".new")
 			if err != nil {
@@ -210,7 +212,10 @@ var idxOptimize = &cobra.Command{
 	},
 }
 
+var recsplitWorkers int
+
 func init() {
 	withDataDir(idxOptimize)
+	idxOptimize.Flags().IntVar(&recsplitWorkers, "recsplit.workers", runtime.NumCPU(), "amount of goroutines building one .efi accessor")
 	rootCmd.AddCommand(idxOptimize)
 }
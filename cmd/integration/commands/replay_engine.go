@@ -0,0 +1,198 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/semaphore"
+
+	chain2 "github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/dbg"
+	"github.com/erigontech/erigon-lib/direct"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/wrap"
+	p2p "github.com/erigontech/erigon-p2p"
+	"github.com/erigontech/erigon-p2p/sentry"
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/eth/consensuschain"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/eth/ethconfig/estimate"
+	"github.com/erigontech/erigon/eth/stagedsync"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/execution/consensus"
+	"github.com/erigontech/erigon/execution/eth1"
+	"github.com/erigontech/erigon/execution/sentry_multi_client"
+	"github.com/erigontech/erigon/turbo/debug"
+	"github.com/erigontech/erigon/turbo/engineapi"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_helpers"
+	"github.com/erigontech/erigon/turbo/shards"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+	stages2 "github.com/erigontech/erigon/turbo/stages"
+)
+
+var engineRecording string
+
+func init() {
+	withConfig(cmdReplayEngine)
+	withDataDir(cmdReplayEngine)
+	withChain(cmdReplayEngine)
+	cmdReplayEngine.Flags().StringVar(&engineRecording, "recording", "", "Engine API recording, written by erigon --authrpc.record")
+	must(cmdReplayEngine.MarkFlagFilename("recording"))
+	must(cmdReplayEngine.MarkFlagRequired("recording"))
+	rootCmd.AddCommand(cmdReplayEngine)
+}
+
+var cmdReplayEngine = &cobra.Command{
+	Use:   "replay_engine",
+	Short: "Feed engine_newPayload/engine_forkchoiceUpdated calls of Engine API recording to EngineServer and report divergences of returned payload statuses",
+	Long: `Datadir must be fresh - created by erigon started with --no-downloader - or synced up to the parent of the first payload of recording:
+blocks are executed from engine_newPayload calls of recording, there is no block downloader.`,
+	Example: "go run ./cmd/integration replay_engine --datadir=... --chain=mainnet --recording=engine.jsonl",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := debug.SetupCobra(cmd, "integration")
+		ctx, _ := common.RootContext()
+
+		db, err := openDB(dbCfg(kv.ChainDB, chaindata), true, logger)
+		if err != nil {
+			logger.Error("Opening DB", "error", err)
+			return err
+		}
+		defer db.Close()
+
+		res, err := replayEngine(ctx, db, logger)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Error(err.Error())
+			}
+			return err
+		}
+		logger.Info("[engine replay] done", "replayed", res.Replayed, "skipped", res.Skipped, "divergences", res.Divergences)
+		if res.Divergences > 0 {
+			return fmt.Errorf("engine replay: %d divergences from recording", res.Divergences)
+		}
+		return nil
+	},
+}
+
+func replayEngine(ctx context.Context, db kv.TemporalRwDB, logger log.Logger) (engineapi.ReplayResult, error) {
+	f, err := os.Open(engineRecording)
+	if err != nil {
+		return engineapi.ReplayResult{}, err
+	}
+	defer f.Close()
+
+	srv, err := newReplayEngineServer(ctx, db, logger)
+	if err != nil {
+		return engineapi.ReplayResult{}, err
+	}
+	return engineapi.ReplayEngineRecording(ctx, srv, f, logger)
+}
+
+// newReplayEngineServer - EngineServer on top of execution module, wired as in erigon, but without block downloader:
+// payloads with unknown parent are answered by SYNCING
+func newReplayEngineServer(ctx context.Context, db kv.TemporalRwDB, logger log.Logger) (*engineapi.EngineServer, error) {
+	dirs := datadir.New(datadirCli)
+	genesis := core.GenesisBlockByChainName(chain)
+	chainConfig, genesisBlock, genesisErr := core.CommitGenesisBlock(db, genesis, dirs, logger)
+	if _, ok := genesisErr.(*chain2.ConfigCompatError); genesisErr != nil && !ok {
+		return nil, genesisErr
+	}
+
+	cfg := ethconfig.Defaults
+	cfg.Sync = syncCfg
+	cfg.Prune = fromdb.PruneMode(db)
+	cfg.TxPool.Disable = true
+	cfg.Genesis = genesis
+	cfg.Dirs = dirs
+	allSn, _, agg, _, _, _, err := allSnapshots(ctx, db, logger)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Snapshot = allSn.Cfg()
+	blockSnapBuildSema := semaphore.NewWeighted(int64(dbg.BuildSnapshotAllowance))
+	agg.SetSnapshotBuildSema(blockSnapBuildSema)
+
+	blockReader, blockWriter := blocksIO(db, logger)
+	engine, _ := initConsensusEngine(ctx, chainConfig, dirs.DataDir, db, blockReader, logger)
+	statusDataProvider := sentry.NewStatusDataProvider(db, chainConfig, genesisBlock, chainConfig.ChainID.Uint64(), logger)
+	maxBlockBroadcastPeers := func(header *types.Header) uint { return 0 }
+	sentryControlServer, err := sentry_multi_client.NewMultiClient(db, chainConfig, engine, nil, cfg.Sync, blockReader, blockBufferSize,
+		statusDataProvider, false, maxBlockBroadcastPeers, false /* disableBlockDownload */, logger)
+	if err != nil {
+		return nil, err
+	}
+	notifications := shards.NewNotifications(nil)
+	blockRetire := freezeblocks.NewBlockRetire(estimate.CompressSnapshot.Workers(), dirs, blockReader, blockWriter, db, nil, nil, chainConfig, &cfg, notifications.Events, blockSnapBuildSema, logger)
+
+	var currentBlock *types.Block
+	if err := db.View(ctx, func(tx kv.Tx) error {
+		currentBlock, err = blockReader.CurrentBlock(tx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	currentBlockNumber := uint64(0)
+	if currentBlock != nil {
+		currentBlockNumber = currentBlock.NumberU64()
+	}
+
+	inMemoryExecution := func(txc wrap.TxContainer, header *types.Header, body *types.RawBody, unwindPoint uint64, headersChain []*types.Header, bodiesChain []*types.RawBody,
+		notifications *shards.Notifications) error {
+		terseLogger := log.New()
+		terseLogger.SetHandler(log.LvlFilterHandler(log.LvlWarn, log.StderrHandler))
+		// Needs its own notifications to not update RPC daemon and txpool about pending blocks
+		stateSync := stages2.NewInMemoryExecution(ctx, db, &cfg, sentryControlServer, dirs, notifications, blockReader, blockWriter, nil, terseLogger, nil)
+		chainReader := consensuschain.NewReader(chainConfig, txc.Tx, blockReader, logger)
+		if err := stages2.StateStep(ctx, chainReader, engine, txc, stateSync, header, body, unwindPoint, headersChain, bodiesChain, false); err != nil {
+			logger.Warn("Could not validate block", "err", err)
+			return errors.Join(consensus.ErrInvalidBlock, err)
+		}
+		progress, err := stages.GetStageProgress(txc.Tx, stages.Execution)
+		if err != nil {
+			return err
+		}
+		if progress < header.Number.Uint64() {
+			return fmt.Errorf("unsuccessful execution, progress %d < expected %d", progress, header.Number.Uint64())
+		}
+		return nil
+	}
+	forkValidator := engine_helpers.NewForkValidator(ctx, currentBlockNumber, inMemoryExecution, dirs.Tmp, blockReader)
+
+	pipelineStages := stages2.NewPipelineStages(ctx, db, &cfg, p2p.Config{}, sentryControlServer, notifications, nil, blockReader, blockRetire, nil, forkValidator, logger, nil, true /* checkStateRoot */)
+	pipelineSync := stagedsync.New(cfg.Sync, pipelineStages, stagedsync.PipelineUnwindOrder, stagedsync.PipelinePruneOrder, logger, stages.ModeApplyingBlocks)
+	// payload attributes are not replayed: no block builder
+	executionModule := eth1.NewEthereumExecutionModule(blockReader, db, pipelineSync, forkValidator, chainConfig, nil, 0, nil,
+		notifications.Accumulator, notifications.RecentLogs, notifications.StateChangesConsumer, logger, engine, cfg.Sync, ctx)
+	executionModule.Start(ctx) // process frozen blocks before first payload
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	executionRpc := direct.NewExecutionClientDirect(executionModule)
+	return engineapi.NewEngineServer(logger, chainConfig, executionRpc, sentryControlServer.Hd, nil,
+		nil /* txnProvider */, false /* caplin */, true /* test: no block downloader */, false /* proposing */, true /* consuming */), nil
+}
@@ -0,0 +1,235 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commands
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/ethconfig/estimate"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/execution/exec3"
+	"github.com/erigontech/erigon/turbo/debug"
+)
+
+var (
+	replayFromBlock, replayToBlock uint64
+	gasRulesFile                   string
+	replayWorkers                  int
+)
+
+// replayRulesBatch - blocks re-executed with both gas schedules before their results are compared, bounds memory
+const replayRulesBatch = 10_000
+
+func init() {
+	withConfig(cmdReplayWithRules)
+	withDataDir(cmdReplayWithRules)
+	withChain(cmdReplayWithRules)
+	withOutputCsvFile(cmdReplayWithRules)
+	cmdReplayWithRules.Flags().Uint64Var(&replayFromBlock, "from", 0, "first block to re-execute")
+	cmdReplayWithRules.Flags().Uint64Var(&replayToBlock, "to", 0, "re-execute blocks before this one, 0 means up to the execution progress")
+	cmdReplayWithRules.Flags().StringVar(&gasRulesFile, "rules", "", "JSON file with gas of opcodes: {\"SLOAD\": {\"constantGas\": 800, \"dynamicGas\": \"gasSLoadEIP2929\"}}")
+	must(cmdReplayWithRules.MarkFlagFilename("rules"))
+	must(cmdReplayWithRules.MarkFlagRequired("rules"))
+	cmdReplayWithRules.Flags().IntVar(&replayWorkers, "workers", estimate.AlmostAllCPUs(), "historical trace workers re-executing txs in parallel")
+	rootCmd.AddCommand(cmdReplayWithRules)
+}
+
+var cmdReplayWithRules = &cobra.Command{
+	Use:   "replay_with_rules",
+	Short: "Re-execute a block range with a modified gas schedule and report gas used deltas per txn and txns which would run out of gas",
+	Long: `Every block of the range is executed twice by historical trace workers on top of state history: with the gas schedule
+of its fork and with the schedule modified by --rules. Nothing is written to the db.
+Dynamic gas functions available to --rules: ` + fmt.Sprint(vm.DynamicGasFuncs()),
+	Example: "go run ./cmd/integration replay_with_rules --datadir=... --chain=mainnet --from=20000000 --to=20001000 --rules=rules.json --output.csv.file=deltas.csv",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := debug.SetupCobra(cmd, "integration")
+		ctx, _ := common.RootContext()
+
+		db, err := openDB(dbCfg(kv.ChainDB, chaindata), true, logger)
+		if err != nil {
+			logger.Error("Opening DB", "error", err)
+			return err
+		}
+		defer db.Close()
+
+		res, err := replayWithRules(ctx, db, logger)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Error(err.Error())
+			}
+			return err
+		}
+		logger.Info("[replay_with_rules] done", "txs", res.Txs, "changed", res.Changed, "gasDelta", res.GasDelta, "outOfGas", res.OutOfGas)
+		return nil
+	},
+}
+
+type replayWithRulesResult struct {
+	Txs      uint64
+	Changed  uint64 // txs with different gas used
+	GasDelta int64  // sum of gas used deltas
+	OutOfGas uint64 // txs which run out of gas only with the modified schedule
+}
+
+// replayTx - outcome of a txn with the gas schedule of its fork
+type replayTx struct {
+	gasUsed uint64
+	failed  bool
+	err     error
+}
+
+func replayWithRules(ctx context.Context, db kv.TemporalRwDB, logger log.Logger) (res replayWithRulesResult, err error) {
+	rules, err := vm.LoadGasRules(gasRulesFile)
+	if err != nil {
+		return res, err
+	}
+
+	dirs := datadir.New(datadirCli)
+	chainConfig := fromdb.ChainConfig(db)
+	blockReader, _ := blocksIO(db, logger)
+	engine, _ := initConsensusEngine(ctx, chainConfig, dirs.DataDir, db, blockReader, logger)
+	baseArgs := &exec3.ExecArgs{
+		ChainDB:     db,
+		Genesis:     core.GenesisBlockByChainName(chain),
+		BlockReader: blockReader,
+		Engine:      engine,
+		Dirs:        dirs,
+		ChainConfig: chainConfig,
+		Workers:     replayWorkers,
+	}
+	modifiedArgs := *baseArgs
+	modifiedArgs.GasRules = rules
+
+	tx, err := db.BeginTemporalRo(ctx)
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback()
+
+	toBlock := replayToBlock
+	if toBlock == 0 {
+		execProgress, err := stages.GetStageProgress(tx, stages.Execution)
+		if err != nil {
+			return res, err
+		}
+		toBlock = execProgress + 1
+	}
+	if replayFromBlock >= toBlock {
+		return res, fmt.Errorf("empty block range [%d, %d)", replayFromBlock, toBlock)
+	}
+
+	var out *csv.Writer
+	if outputCsvFile != "" {
+		f, err := os.Create(outputCsvFile)
+		if err != nil {
+			return res, err
+		}
+		defer f.Close()
+		out = csv.NewWriter(f)
+		defer out.Flush()
+		if err := out.Write([]string{"block", "txIndex", "txHash", "gasLimit", "gasUsed", "newGasUsed", "delta", "failed", "newFailed", "outOfGas"}); err != nil {
+			return res, err
+		}
+	}
+
+	for from := replayFromBlock; from < toBlock; from += replayRulesBatch {
+		to := min(from+replayRulesBatch, toBlock)
+
+		// txs of system calls and block finalisation have no gas, they are skipped
+		base := map[uint64]replayTx{}
+		if err := exec3.CustomTraceMapReduce(from, to, exec3.TraceConsumer{
+			Reduce: func(txTask *state.TxTask, tx kv.TemporalTx) error {
+				if txTask.Error != nil {
+					return fmt.Errorf("block %d, txn %d: %w", txTask.BlockNum, txTask.TxIndex, txTask.Error)
+				}
+				if txTask.Tx != nil {
+					base[txTask.TxNum] = replayTx{gasUsed: txTask.GasUsed, failed: txTask.Failed, err: txTask.ExecutionErr}
+				}
+				return nil
+			},
+		}, ctx, tx, baseArgs, logger); err != nil {
+			return res, err
+		}
+
+		if err := exec3.CustomTraceMapReduce(from, to, exec3.TraceConsumer{
+			Reduce: func(txTask *state.TxTask, tx kv.TemporalTx) error {
+				if txTask.Error != nil {
+					return fmt.Errorf("block %d, txn %d: %w", txTask.BlockNum, txTask.TxIndex, txTask.Error)
+				}
+				if txTask.Tx == nil {
+					return nil
+				}
+				was, ok := base[txTask.TxNum]
+				if !ok {
+					return fmt.Errorf("block %d, txn %d: not executed with the gas schedule of the fork", txTask.BlockNum, txTask.TxIndex)
+				}
+				delta := int64(txTask.GasUsed) - int64(was.gasUsed)
+				outOfGas := errors.Is(txTask.ExecutionErr, vm.ErrOutOfGas) && !errors.Is(was.err, vm.ErrOutOfGas)
+				res.Txs++
+				res.GasDelta += delta
+				if delta != 0 {
+					res.Changed++
+				}
+				if outOfGas {
+					res.OutOfGas++
+					logger.Info("[replay_with_rules] out of gas", "block", txTask.BlockNum, "txIndex", txTask.TxIndex, "txHash", txTask.Tx.Hash(),
+						"gasLimit", txTask.Tx.GetGasLimit(), "gasUsed", was.gasUsed)
+				}
+				if out == nil || (delta == 0 && txTask.Failed == was.failed) {
+					return nil
+				}
+				return out.Write([]string{
+					strconv.FormatUint(txTask.BlockNum, 10),
+					strconv.Itoa(txTask.TxIndex),
+					txTask.Tx.Hash().String(),
+					strconv.FormatUint(txTask.Tx.GetGasLimit(), 10),
+					strconv.FormatUint(was.gasUsed, 10),
+					strconv.FormatUint(txTask.GasUsed, 10),
+					strconv.FormatInt(delta, 10),
+					strconv.FormatBool(was.failed),
+					strconv.FormatBool(txTask.Failed),
+					strconv.FormatBool(outOfGas),
+				})
+			},
+		}, ctx, tx, &modifiedArgs, logger); err != nil {
+			return res, err
+		}
+		if out != nil {
+			out.Flush()
+			if err := out.Error(); err != nil {
+				return res, err
+			}
+		}
+		logger.Info("[replay_with_rules] progress", "block", to, "txs", res.Txs, "changed", res.Changed, "gasDelta", res.GasDelta, "outOfGas", res.OutOfGas)
+	}
+	return res, nil
+}
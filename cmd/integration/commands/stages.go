@@ -481,6 +481,7 @@ func init() {
 	withPruneTo(cmdStageExec)
 	withBatchSize(cmdStageExec)
 	withTxTrace(cmdStageExec)
+	withProfileEVM(cmdStageExec)
 	withChain(cmdStageExec)
 	withHeimdall(cmdStageExec)
 	withWorkers(cmdStageExec)
@@ -813,6 +814,16 @@ func stageExec(db kv.TemporalRwDB, ctx context.Context, logger log.Logger) error
 		// Activate tracing and writing into json files for each transaction
 		vmConfig.Tracer = &tracing.Hooks{}
 	}
+	if profileEVM != "" {
+		vmConfig.Profiler = vm.NewProfiler()
+		defer func() {
+			if err := writeEVMProfile(profileEVM, vmConfig.Profiler); err != nil {
+				logger.Error("Failed to write EVM profile", "err", err)
+				return
+			}
+			logger.Info("EVM profile", "file", profileEVM)
+		}()
+	}
 
 	var batchSize datasize.ByteSize
 	must(batchSize.UnmarshalText([]byte(batchSizeStr)))
@@ -945,6 +956,15 @@ func stageExec(db kv.TemporalRwDB, ctx context.Context, logger log.Logger) error
 	return nil
 }
 
+func writeEVMProfile(path string, profiler *vm.Profiler) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return profiler.WriteProfile(f)
+}
+
 func stageCustomTrace(db kv.TemporalRwDB, ctx context.Context, logger log.Logger) error {
 	dirs := datadir.New(datadirCli)
 	if err := datadir.ApplyMigrations(dirs); err != nil {
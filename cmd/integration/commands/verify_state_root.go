@@ -0,0 +1,162 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/erigontech/erigon-lib/commitment"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/log/v3"
+	libstate "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon/turbo/debug"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+var (
+	verifyFromStep, verifyToStep uint64
+	verifyConcurrentCommitment   bool
+)
+
+func init() {
+	withDataDir(cmdVerifyStateRoot)
+	cmdVerifyStateRoot.Flags().Uint64Var(&verifyFromStep, "step", 0, "verify state root at the end of state files which contain this step")
+	cmdVerifyStateRoot.Flags().Uint64Var(&verifyToStep, "to-step", 0, "verify all steps from --step up to this one (inclusive)")
+	cmdVerifyStateRoot.Flags().BoolVar(&verifyConcurrentCommitment, "concurrent", false, "use concurrent patricia trie to recompute commitment")
+	must(cmdVerifyStateRoot.MarkFlagRequired("step"))
+	rootCmd.AddCommand(cmdVerifyStateRoot)
+}
+
+var cmdVerifyStateRoot = &cobra.Command{
+	Use:     "verify_state_root",
+	Short:   "Recompute commitment from accounts/storage domain files and compare it with state root of canonical header",
+	Example: "go run ./cmd/integration verify_state_root --datadir=... --step=1024 --to-step=1100 --concurrent",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := debug.SetupCobra(cmd, "integration")
+		ctx, _ := common.RootContext()
+		dirs := datadir.New(datadirCli)
+
+		db, err := openDB(dbCfg(kv.ChainDB, dirs.Chaindata), true, logger)
+		if err != nil {
+			logger.Error("Opening DB", "error", err)
+			return err
+		}
+		defer db.Close()
+
+		if err := verifyStateRoot(ctx, db, dirs, logger); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Error(err.Error())
+			}
+			return err
+		}
+		return nil
+	},
+}
+
+func verifyStateRoot(ctx context.Context, db kv.TemporalRwDB, dirs datadir.Dirs, logger log.Logger) error {
+	blockReader, _ := blocksIO(db, logger)
+	txNumsReader := rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(ctx, blockReader))
+
+	variant := commitment.VariantHexPatriciaTrie
+	if verifyConcurrentCommitment {
+		variant = commitment.VariantConcurrentHexPatricia
+	}
+	toStep := max(verifyToStep, verifyFromStep)
+
+	tx, err := db.BeginTemporalRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	aggTx := libstate.AggTx(tx)
+	stepSize := aggTx.StepSize()
+	inFiles := aggTx.TxNumsInFiles(kv.AccountsDomain, kv.StorageDomain, kv.CommitmentDomain)
+	if (toStep+1)*stepSize > inFiles {
+		return fmt.Errorf("step %d is not covered by state files: files end at step %d", toStep, inFiles/stepSize)
+	}
+
+	var verifiedTxNum uint64
+	for step := verifyFromStep; step <= toStep; step++ {
+		// steps inside of merged files share state at the end of their file
+		toTxNum, err := aggTx.StateFilesEnd((step + 1) * stepSize)
+		if err != nil {
+			return fmt.Errorf("step %d: %w", step, err)
+		}
+		if toTxNum == verifiedTxNum {
+			continue
+		}
+		storedRoot, commitmentTxNum, blockNum, err := libstate.CommitmentStateInFiles(tx, toTxNum)
+		if err != nil {
+			return fmt.Errorf("step %d: %w", step, err)
+		}
+		if commitmentTxNum+1 != toTxNum {
+			return fmt.Errorf("step %d: commitment in files is at txNum %d, but state files end at txNum %d", step, commitmentTxNum, toTxNum)
+		}
+		maxTxNum, err := txNumsReader.Max(tx, blockNum)
+		if err != nil {
+			return err
+		}
+		if maxTxNum != commitmentTxNum {
+			logger.Warn("[verify_state_root] commitment is not at the end of block", "step", step, "block", blockNum, "txNum", commitmentTxNum, "blockMaxTxNum", maxTxNum)
+		}
+		header, err := blockReader.HeaderByNumber(ctx, tx, blockNum)
+		if err != nil {
+			return err
+		}
+		if header == nil {
+			return fmt.Errorf("step %d: header %d not found", step, blockNum)
+		}
+
+		start := time.Now()
+		rc, err := libstate.RecomputeCommitment(ctx, tx, toTxNum, variant, dirs.Tmp, logger)
+		if err != nil {
+			return fmt.Errorf("step %d: %w", step, err)
+		}
+		if !bytes.Equal(rc.Root, header.Root[:]) {
+			mismatch, err := rc.FirstMismatch(tx)
+			if err != nil {
+				return fmt.Errorf("step %d: %w", step, err)
+			}
+			prefix, cells := "", ""
+			if mismatch != nil {
+				prefix, cells = fmt.Sprintf("%x", mismatch.Prefix), fmt.Sprintf("%v", mismatch.Nibbles)
+			}
+			logger.Error("[verify_state_root] state root mismatch", "step", step, "block", blockNum, "txNum", commitmentTxNum,
+				"computed", fmt.Sprintf("%x", rc.Root), "header", header.Root, "stored", fmt.Sprintf("%x", storedRoot),
+				"prefix", prefix, "cells", cells)
+			return fmt.Errorf("state root mismatch at step %d (block %d)", step, blockNum)
+		}
+		if !bytes.Equal(storedRoot, rc.Root) {
+			logger.Warn("[verify_state_root] root stored in commitment files differs from header", "step", step, "block", blockNum,
+				"stored", fmt.Sprintf("%x", storedRoot), "header", header.Root)
+		}
+		logger.Info("[verify_state_root] ok", "step", step, "filesEndStep", toTxNum/stepSize, "block", blockNum, "root", header.Root,
+			"keys", common.PrettyCounter(rc.Keys), "took", time.Since(start))
+		verifiedTxNum = toTxNum
+	}
+	return nil
+}
@@ -103,8 +103,11 @@ var rootCmd = &cobra.Command{
 }
 
 var (
-	stateCacheStr string
-	polygonSync   bool
+	stateCacheStr          string
+	stateCacheStorageStr   string
+	stateCachePinnedStrs   []string
+	stateCacheStorageSlots int
+	polygonSync            bool
 )
 
 type HeimdallReader interface {
@@ -138,6 +141,9 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 	rootCmd.PersistentFlags().StringVar(&cfg.TxPoolApiAddr, "txpool.api.addr", "", "txpool api network address, for example: 127.0.0.1:9090 (default: use value of --private.api.addr)")
 
 	rootCmd.PersistentFlags().StringVar(&stateCacheStr, "state.cache", "0MB", "Amount of data to store in StateCache (enabled if no --datadir set). Set 0 to disable StateCache. Defaults to 0MB RAM")
+	rootCmd.PersistentFlags().StringVar(&stateCacheStorageStr, "state.cache.storage", "", "Part of --state.cache to store contracts storage, the rest is for accounts. Defaults to half of --state.cache")
+	rootCmd.PersistentFlags().IntVar(&stateCacheStorageSlots, "state.cache.storage.slots", kvcache.DefaultCoherentConfig.StorageSlotsPerContract, "Max amount of storage slots of one contract to keep in StateCache. Set 0 for unlimited")
+	rootCmd.PersistentFlags().StringSliceVar(&stateCachePinnedStrs, "state.cache.storage.pinned", []string{}, "Comma separated list of hot contracts, which storage is evicted from StateCache last")
 	rootCmd.PersistentFlags().BoolVar(&cfg.GRPCServerEnabled, "grpc", false, "Enable GRPC server")
 	rootCmd.PersistentFlags().StringVar(&cfg.GRPCListenAddress, "grpc.addr", nodecfg.DefaultGRPCHost, "GRPC server listening interface")
 	rootCmd.PersistentFlags().IntVar(&cfg.GRPCPort, "grpc.port", nodecfg.DefaultGRPCPort, "GRPC server listening port")
@@ -207,6 +213,26 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 			return fmt.Errorf("state.cache value of %v is not valid", stateCacheStr)
 		}
 
+		cfg.StateCache.StorageCacheSize = cfg.StateCache.CacheSize / 2
+		if stateCacheStorageStr != "" {
+			err = cfg.StateCache.StorageCacheSize.UnmarshalText([]byte(stateCacheStorageStr))
+			if err != nil {
+				return fmt.Errorf("state.cache.storage value of %v is not valid", stateCacheStorageStr)
+			}
+			if cfg.StateCache.StorageCacheSize > cfg.StateCache.CacheSize {
+				return fmt.Errorf("state.cache.storage value of %v is bigger than state.cache", stateCacheStorageStr)
+			}
+		}
+		cfg.StateCache.CacheSize -= cfg.StateCache.StorageCacheSize
+		cfg.StateCache.StorageSlotsPerContract = stateCacheStorageSlots
+		cfg.StateCache.PinnedContracts = make([]common.Address, 0, len(stateCachePinnedStrs))
+		for _, addr := range stateCachePinnedStrs {
+			if !common.IsHexAddress(addr) {
+				return fmt.Errorf("state.cache.storage.pinned value of %v is not valid address", addr)
+			}
+			cfg.StateCache.PinnedContracts = append(cfg.StateCache.PinnedContracts, common.HexToAddress(addr))
+		}
+
 		cfg.WithDatadir = cfg.DataDir != ""
 		if cfg.WithDatadir {
 			if cfg.DataDir == "" {
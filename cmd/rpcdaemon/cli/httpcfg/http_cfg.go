@@ -87,6 +87,7 @@ type HttpCfg struct {
 	SocketListenUrl     string
 
 	JWTSecretPath             string // Engine API Authentication
+	AuthRpcRecordPath         string // Engine API calls are appended to this file, if set
 	TraceRequests             bool   // Print requests to logs at INFO level
 	DebugSingleRequest        bool   // Print single-request-related debugging info to logs at INFO level
 	HTTPTimeouts              rpccfg.HTTPTimeouts
@@ -635,7 +635,7 @@ func (c comparitor) compareBodies(ctx context.Context, f1ents []*BodyEntry, f2en
 
 					logger.Info("Indexing " + ent1.Body.Name())
 
-					return coresnaptype.Bodies.BuildIndexes(ctx, info, nil, c.chainConfig(), c.session1.LocalFsRoot(), nil, log.LvlDebug, logger)
+					return coresnaptype.Bodies.BuildIndexes(ctx, info, nil, c.chainConfig(), c.session1.LocalFsRoot(), 0, nil, log.LvlDebug, logger)
 				})
 
 				g.Go(func() error {
@@ -673,7 +673,7 @@ func (c comparitor) compareBodies(ctx context.Context, f1ents []*BodyEntry, f2en
 					}()
 
 					logger.Info("Indexing " + ent1.Transactions.Name())
-					return coresnaptype.Transactions.BuildIndexes(ctx, info, nil, c.chainConfig(), c.session1.LocalFsRoot(), nil, log.LvlDebug, logger)
+					return coresnaptype.Transactions.BuildIndexes(ctx, info, nil, c.chainConfig(), c.session1.LocalFsRoot(), 0, nil, log.LvlDebug, logger)
 				})
 
 				b2err := make(chan error, 1)
@@ -709,7 +709,7 @@ func (c comparitor) compareBodies(ctx context.Context, f1ents []*BodyEntry, f2en
 					}()
 
 					logger.Info("Indexing " + ent2.Body.Name())
-					return coresnaptype.Bodies.BuildIndexes(ctx, info, nil, c.chainConfig(), c.session1.LocalFsRoot(), nil, log.LvlDebug, logger)
+					return coresnaptype.Bodies.BuildIndexes(ctx, info, nil, c.chainConfig(), c.session1.LocalFsRoot(), 0, nil, log.LvlDebug, logger)
 				})
 
 				g.Go(func() error {
@@ -750,7 +750,7 @@ func (c comparitor) compareBodies(ctx context.Context, f1ents []*BodyEntry, f2en
 					}()
 
 					logger.Info("Indexing " + ent2.Transactions.Name())
-					return coresnaptype.Transactions.BuildIndexes(ctx, info, nil, c.chainConfig(), c.session2.LocalFsRoot(), nil, log.LvlDebug, logger)
+					return coresnaptype.Transactions.BuildIndexes(ctx, info, nil, c.chainConfig(), c.session2.LocalFsRoot(), 0, nil, log.LvlDebug, logger)
 				})
 
 				if err := g.Wait(); err != nil {
@@ -0,0 +1,82 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
+	txpool_proto "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon/txnprovider/txpool"
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{exportCmd, importCmd} {
+		cmd.Flags().StringVar(&txpoolApiAddr, "txpool.api.addr", "localhost:9094", "txpool service <host>:<port>")
+		rootCmd.AddCommand(cmd)
+	}
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export <path>",
+	Short: "Dump all sub-pools of running txpool (with blob sidecars) to new local file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := txpoolClient()
+		if err != nil {
+			return err
+		}
+		count, err := txpool.ExportToFile(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("exported %d transactions to %s\n", count, args[0])
+		return nil
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <path>",
+	Short: "Add transactions from local file, written by export, to running txpool - with usual validation",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := txpoolClient()
+		if err != nil {
+			return err
+		}
+		reply, err := txpool.ImportFromFile(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("imported from %s: added %d, discarded %d, queued remote %d\n", args[0], reply.Added, reply.Discarded, reply.Queued)
+		return nil
+	},
+}
+
+func txpoolClient() (txpool_proto.TxpoolClient, error) {
+	creds, err := grpcutil.TLS(TLSCACert, TLSCertfile, TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to txpool: %w", err)
+	}
+	conn, err := grpcutil.Connect(creds, txpoolApiAddr)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to txpool: %w", err)
+	}
+	return txpool_proto.NewTxpoolClient(conn), nil
+}
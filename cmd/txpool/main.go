@@ -49,11 +49,12 @@ import (
 )
 
 var (
-	sentryAddr     []string // Address of the sentry <host>:<port>
-	traceSenders   []string
-	privateApiAddr string
-	txpoolApiAddr  string
-	datadirCli     string // Path to td working dir
+	sentryAddr      []string // Address of the sentry <host>:<port>
+	traceSenders    []string
+	prioritySenders []string
+	privateApiAddr  string
+	txpoolApiAddr   string
+	datadirCli      string // Path to td working dir
 
 	TLSCertfile string
 	TLSCACert   string
@@ -69,6 +70,8 @@ var (
 	totalBlobPoolLimit uint64
 	priceBump          uint64
 	blobPriceBump      uint64
+	ordering           string
+	localTipWeight     uint64
 
 	noTxGossip bool
 
@@ -103,6 +106,9 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&noTxGossip, utils.TxPoolGossipDisableFlag.Name, utils.TxPoolGossipDisableFlag.Value, utils.TxPoolGossipDisableFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&mdbxWriteMap, utils.DbWriteMapFlag.Name, utils.DbWriteMapFlag.Value, utils.DbWriteMapFlag.Usage)
 	rootCmd.Flags().StringSliceVar(&traceSenders, utils.TxPoolTraceSendersFlag.Name, []string{}, utils.TxPoolTraceSendersFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&ordering, utils.TxPoolOrderingFlag.Name, utils.TxPoolOrderingFlag.Value, utils.TxPoolOrderingFlag.Usage)
+	rootCmd.PersistentFlags().Uint64Var(&localTipWeight, utils.TxPoolLocalTipWeightFlag.Name, utils.TxPoolLocalTipWeightFlag.Value, utils.TxPoolLocalTipWeightFlag.Usage)
+	rootCmd.Flags().StringSliceVar(&prioritySenders, utils.TxPoolPrioritySendersFlag.Name, []string{}, utils.TxPoolPrioritySendersFlag.Usage)
 }
 
 var rootCmd = &cobra.Command{
@@ -172,6 +178,10 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 	cfg.BlobPriceBump = blobPriceBump
 	cfg.NoGossip = noTxGossip
 	cfg.MdbxWriteMap = mdbxWriteMap
+	if cfg.Ordering, err = txpoolcfg.ParseOrdering(ordering); err != nil {
+		return err
+	}
+	cfg.LocalTipWeight = localTipWeight
 
 	cacheConfig := kvcache.DefaultCoherentConfig
 	cacheConfig.MetricsLabel = "txpool"
@@ -181,6 +191,11 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 		sender := common.HexToAddress(senderHex)
 		cfg.TracedSenders[i] = string(sender[:])
 	}
+	cfg.PrioritySenders = make([]string, len(prioritySenders))
+	for i, senderHex := range prioritySenders {
+		sender := common.HexToAddress(senderHex)
+		cfg.PrioritySenders[i] = string(sender[:])
+	}
 
 	notifyMiner := func() {}
 	txPool, txpoolGrpcServer, err := txpool.Assemble(
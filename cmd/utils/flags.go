@@ -47,6 +47,7 @@ import (
 	libkzg "github.com/erigontech/erigon-lib/crypto/kzg"
 	"github.com/erigontech/erigon-lib/direct"
 	downloadercfg2 "github.com/erigontech/erigon-lib/downloader/downloadercfg"
+	"github.com/erigontech/erigon-lib/kv/kvcache"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/state"
 	"github.com/erigontech/erigon-lib/types"
@@ -58,6 +59,7 @@ import (
 	"github.com/erigontech/erigon/cmd/downloader/downloadernat"
 	"github.com/erigontech/erigon/cmd/utils/flags"
 	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/eth/ethconfig"
 	"github.com/erigontech/erigon/eth/gasprice/gaspricecfg"
 	"github.com/erigontech/erigon/execution/consensus/ethash/ethashcfg"
@@ -108,6 +110,10 @@ var (
 		Name:  "dev.period",
 		Usage: "Block period to use in developer mode (0 = mine only if transaction pending)",
 	}
+	DeveloperCliqueFlag = cli.BoolFlag{
+		Name:  "dev.clique",
+		Usage: "Run developer chain pre-merge with Clique (requires --mine). By default developer chain is post-merge: blocks are produced every --dev.period seconds by in-process simulated beacon through Engine API (0 = only by dev_mine RPC)",
+	}
 	ChainFlag = cli.StringFlag{
 		Name:  "chain",
 		Usage: "name of the network to join",
@@ -218,6 +224,21 @@ var (
 		Usage: "How often transactions should be committed to the storage",
 		Value: txpoolcfg.DefaultConfig.CommitEvery,
 	}
+	TxPoolOrderingFlag = cli.StringFlag{
+		Name:  "txpool.ordering",
+		Usage: "Order in which pending transactions are offered to block builder: tip, fifo (by arrival), weighted-local (by tip, boosted for local and priority senders). Nonce order of every sender is always preserved",
+		Value: string(txpoolcfg.DefaultConfig.Ordering),
+	}
+	TxPoolLocalTipWeightFlag = cli.Uint64Flag{
+		Name:  "txpool.ordering.localweight",
+		Usage: "Effective tip multiplier of local and priority senders transactions for --txpool.ordering=weighted-local",
+		Value: txpoolcfg.DefaultConfig.LocalTipWeight,
+	}
+	TxPoolPrioritySendersFlag = cli.StringFlag{
+		Name:  "txpool.ordering.prioritysenders",
+		Usage: "Comma separated list of addresses, whose transactions are treated as local by --txpool.ordering=weighted-local",
+		Value: "",
+	}
 	// Miner settings
 	MiningEnabledFlag = cli.BoolFlag{
 		Name:  "mine",
@@ -260,6 +281,11 @@ var (
 		Usage: "Time interval to recreate the block being mined",
 		Value: ethconfig.Defaults.Miner.Recommit,
 	}
+	MinerPayloadRebuildIntervalFlag = cli.DurationFlag{
+		Name:  "miner.payload.rebuild",
+		Usage: "Time interval to rebuild PoS payload with fresh transactions until it's requested by CL, the most valuable one is returned (0 = build once)",
+		Value: ethconfig.Defaults.Miner.PayloadRebuild,
+	}
 	MinerNoVerfiyFlag = cli.BoolFlag{
 		Name:  "miner.noverify",
 		Usage: "Disable remote sealing verification",
@@ -504,6 +530,19 @@ var (
 		Value: "0MB",
 		Usage: "Amount of data to store in StateCache (enabled if no --datadir set). Set 0 to disable StateCache. Defaults to 0MB",
 	}
+	StateCacheStorageFlag = cli.StringFlag{
+		Name:  "state.cache.storage",
+		Usage: "Part of --state.cache to store contracts storage, the rest is for accounts. Defaults to half of --state.cache",
+	}
+	StateCacheStorageSlotsFlag = cli.IntFlag{
+		Name:  "state.cache.storage.slots",
+		Value: kvcache.DefaultCoherentConfig.StorageSlotsPerContract,
+		Usage: "Max amount of storage slots of one contract to keep in StateCache. Set 0 for unlimited",
+	}
+	StateCacheStoragePinnedFlag = cli.StringSliceFlag{
+		Name:  "state.cache.storage.pinned",
+		Usage: "Comma separated list of hot contracts, which storage is evicted from StateCache last",
+	}
 
 	// Network Settings
 	MaxPeersFlag = cli.IntFlag{
@@ -680,6 +719,11 @@ var (
 		Usage: "Skip state download and start from genesis block",
 		Value: false,
 	}
+	SnapRecsplitWorkersFlag = cli.IntFlag{
+		Name:  "snap.recsplit.workers",
+		Usage: "Amount of goroutines building one index of block snapshots (0 = RECSPLIT_WORKERS env variable, by default GOMAXPROCS)",
+		Value: 0,
+	}
 	TorrentVerbosityFlag = cli.IntFlag{
 		Name:  "torrent.verbosity",
 		Value: 2,
@@ -1130,6 +1174,11 @@ var (
 		Usage: "EXPERIMENTAL: enables concurrent trie for commitment",
 		Value: false,
 	}
+	ExperimentalEVMFuseFlag = cli.BoolFlag{
+		Name:  "experimental.evm-fuse",
+		Usage: "EXPERIMENTAL: execute common opcode sequences as superinstructions",
+		Value: false,
+	}
 	GDBMeFlag = cli.BoolFlag{
 		Name:  "gdbme",
 		Usage: "restart erigon under gdb for debug purposes",
@@ -1592,6 +1641,24 @@ func setTxPool(ctx *cli.Context, dbDir string, fullCfg *ethconfig.Config) {
 	if ctx.IsSet(TxPoolBlobPriceBumpFlag.Name) {
 		cfg.BlobPriceBump = ctx.Uint64(TxPoolBlobPriceBumpFlag.Name)
 	}
+	if ctx.IsSet(TxPoolOrderingFlag.Name) {
+		ordering, err := txpoolcfg.ParseOrdering(ctx.String(TxPoolOrderingFlag.Name))
+		if err != nil {
+			Fatalf("Option %s: %v", TxPoolOrderingFlag.Name, err)
+		}
+		cfg.Ordering = ordering
+	}
+	if ctx.IsSet(TxPoolLocalTipWeightFlag.Name) {
+		cfg.LocalTipWeight = ctx.Uint64(TxPoolLocalTipWeightFlag.Name)
+	}
+	if ctx.IsSet(TxPoolPrioritySendersFlag.Name) {
+		senderHexes := common.CliString2Array(ctx.String(TxPoolPrioritySendersFlag.Name))
+		cfg.PrioritySenders = make([]string, len(senderHexes))
+		for i, senderHex := range senderHexes {
+			sender := common.HexToAddress(senderHex)
+			cfg.PrioritySenders[i] = string(sender[:])
+		}
+	}
 	if ctx.IsSet(DbWriteMapFlag.Name) {
 		cfg.MdbxWriteMap = ctx.Bool(DbWriteMapFlag.Name)
 	}
@@ -1763,6 +1830,9 @@ func setMiner(ctx *cli.Context, cfg *params2.MiningConfig) {
 	if ctx.IsSet(MinerRecommitIntervalFlag.Name) {
 		cfg.Recommit = ctx.Duration(MinerRecommitIntervalFlag.Name)
 	}
+	if ctx.IsSet(MinerPayloadRebuildIntervalFlag.Name) {
+		cfg.PayloadRebuild = ctx.Duration(MinerPayloadRebuildIntervalFlag.Name)
+	}
 	if ctx.IsSet(MinerNoVerfiyFlag.Name) {
 		cfg.Noverify = ctx.Bool(MinerNoVerfiyFlag.Name)
 	}
@@ -1962,6 +2032,7 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 	cfg.Snapshot.ProduceE2 = !ctx.Bool(SnapStopFlag.Name)
 	cfg.Snapshot.ProduceE3 = !ctx.Bool(SnapStateStopFlag.Name)
 	cfg.Snapshot.DisableDownloadE3 = ctx.Bool(SnapSkipStateSnapshotDownloadFlag.Name)
+	cfg.Snapshot.RecsplitWorkers = ctx.Int(SnapRecsplitWorkersFlag.Name)
 	cfg.Snapshot.NoDownloader = ctx.Bool(NoDownloaderFlag.Name)
 	cfg.Snapshot.Verify = ctx.Bool(DownloaderVerifyFlag.Name)
 	cfg.Snapshot.DownloaderAddr = strings.TrimSpace(ctx.String(DownloaderAddrFlag.Name))
@@ -1996,6 +2067,9 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 		// cfg.ExperimentalConcurrentCommitment = true
 		state.ExperimentalConcurrentCommitment = true
 	}
+	if ctx.Bool(ExperimentalEVMFuseFlag.Name) {
+		vm.FuseInstructions = true
+	}
 
 	if ctx.IsSet(RPCGlobalGasCapFlag.Name) {
 		cfg.RPCGasCap = ctx.Uint64(RPCGlobalGasCapFlag.Name)
@@ -2043,8 +2117,18 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 		logger.Info("Using developer account", "address", developer)
 
 		// Create a new developer genesis block or reuse existing one
-		cfg.Genesis = core.DeveloperGenesisBlock(uint64(ctx.Int(DeveloperPeriodFlag.Name)), developer)
-		logger.Info("Using custom developer period", "seconds", cfg.Genesis.Config.Clique.Period)
+		if ctx.Bool(DeveloperCliqueFlag.Name) {
+			cfg.Genesis = core.DeveloperGenesisBlock(uint64(ctx.Int(DeveloperPeriodFlag.Name)), developer)
+			logger.Info("Using custom developer period", "seconds", cfg.Genesis.Config.Clique.Period)
+		} else {
+			if ctx.Bool(MiningEnabledFlag.Name) {
+				Fatalf("--%s is not supported by post-merge developer chain, blocks are produced by simulated beacon (use --%s for Clique)", MiningEnabledFlag.Name, DeveloperCliqueFlag.Name)
+			}
+			cfg.Genesis = core.DeveloperPoSGenesisBlock(developer)
+			cfg.DevSimulatedBeacon = true
+			cfg.DevPeriod = time.Duration(ctx.Int(DeveloperPeriodFlag.Name)) * time.Second
+			logger.Info("Using simulated beacon", "period", cfg.DevPeriod)
+		}
 		if !ctx.IsSet(MinerGasPriceFlag.Name) {
 			cfg.Miner.GasPrice = big.NewInt(1)
 		}
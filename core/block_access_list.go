@@ -0,0 +1,71 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/execution/consensus"
+)
+
+// ExecuteBlockAccessList executes the block on top of stateReader (state of its parent) and returns
+// accounts and storage slots accessed by it, with changes per block access index (EIP-7928).
+// Nothing is written, results of the execution are not checked against the header.
+func ExecuteBlockAccessList(
+	chainConfig *chain.Config, vmConfig vm.Config,
+	blockHashFunc func(n uint64) common.Hash,
+	engine consensus.Engine, block *types.Block,
+	stateReader state.StateReader, chainReader consensus.ChainReader,
+	logger log.Logger,
+) (types.BlockAccessList, error) {
+	txs := block.Transactions()
+	recorder := state.NewAccessRecorder(stateReader, len(txs))
+	ibs := state.New(recorder)
+	header := block.Header()
+
+	gasUsed := new(uint64)
+	usedBlobGas := new(uint64)
+	gp := new(GasPool)
+	gp.AddGas(block.GasLimit()).AddBlobGas(chainConfig.GetMaxBlobGasPerBlock(block.Time()))
+
+	if err := InitializeBlockExecution(engine, chainReader, header, chainConfig, ibs, recorder, logger, nil); err != nil {
+		return nil, err
+	}
+
+	receipts := make(types.Receipts, 0, len(txs))
+	for i, txn := range txs {
+		recorder.SetTxIndex(i)
+		ibs.SetTxContext(i)
+		receipt, _, err := ApplyTransaction(chainConfig, blockHashFunc, engine, nil, gp, ibs, recorder, header, txn, gasUsed, usedBlobGas, vmConfig)
+		if err != nil {
+			return nil, fmt.Errorf("could not apply txn %d from block %d [%v]: %w", i, block.NumberU64(), txn.Hash().Hex(), err)
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	recorder.SetTxIndex(len(txs))
+	if _, _, _, _, err := FinalizeBlockExecution(engine, recorder, header, txs, block.Uncles(), recorder, chainConfig, ibs, receipts, block.Withdrawals(), chainReader, false, logger, nil); err != nil {
+		return nil, err
+	}
+	return recorder.VersionedIO().BlockAccessList(), nil
+}
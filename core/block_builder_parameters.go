@@ -31,4 +31,5 @@ type BlockBuilderParameters struct {
 	SuggestedFeeRecipient common.Address
 	Withdrawals           []*types.Withdrawal // added in Shapella (EIP-4895)
 	ParentBeaconBlockRoot *common.Hash        // added in Dencun (EIP-4788)
+	InclusionList         types.Transactions  // added in FOCIL (EIP-7805): included ahead of txpool transactions
 }
@@ -29,6 +29,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/chain/networkname"
+	params2 "github.com/erigontech/erigon-lib/chain/params"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/crypto"
@@ -108,6 +109,25 @@ func TestGenesisBlockRoots(t *testing.T) {
 	}
 }
 
+func TestDeveloperPoSGenesisBlock(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	faucet := common.Address{1}
+	g := core.DeveloperPoSGenesisBlock(faucet)
+	require.Contains(g.Alloc, faucet)
+	for _, addr := range []common.Address{params2.BeaconRootsAddress, params2.HistoryStorageAddress, params2.WithdrawalRequestAddress, params2.ConsolidationRequestAddress} {
+		require.NotEmpty(g.Alloc[addr].Code, addr)
+	}
+
+	block, _, err := core.GenesisToBlock(g, datadir.New(t.TempDir()), log.Root())
+	require.NoError(err)
+	require.Zero(block.Difficulty().Sign())
+	require.NotNil(block.Header().WithdrawalsHash)
+	require.NotNil(block.Header().ParentBeaconBlockRoot)
+	require.NotNil(block.Header().RequestsHash)
+}
+
 func TestCommitGenesisIdempotency(t *testing.T) {
 	t.Parallel()
 	logger := log.New()
@@ -202,10 +202,15 @@ func WriteGenesisBlock(tx kv.RwTx, genesis *types.Genesis, overridePragueTime *b
 	}
 	// Check config compatibility and write the config. Compatibility errors
 	// are returned to the caller unless we're already at block zero.
-	height := rawdb.ReadHeaderNumber(tx, rawdb.ReadHeadHeaderHash(tx))
+	headHash := rawdb.ReadHeadHeaderHash(tx)
+	height := rawdb.ReadHeaderNumber(tx, headHash)
 	if height != nil {
-		compatibilityErr := storedCfg.CheckCompatible(newCfg, *height)
-		if compatibilityErr != nil && *height != 0 && compatibilityErr.RewindTo != 0 {
+		var headTime uint64
+		if head := rawdb.ReadHeader(tx, headHash, *height); head != nil {
+			headTime = head.Time
+		}
+		compatibilityErr := storedCfg.CheckCompatible(newCfg, *height, headTime)
+		if compatibilityErr != nil && *height != 0 && (compatibilityErr.RewindTo != 0 || compatibilityErr.RewindToTime != 0) {
 			return newCfg, storedBlock, compatibilityErr
 		}
 	}
@@ -419,6 +424,25 @@ func DeveloperGenesisBlock(period uint64, faucet common.Address) *types.Genesis
 	}
 }
 
+// DeveloperPoSGenesisBlock returns post-merge genesis of dev chain (all forks up to Prague active from genesis).
+// Blocks are produced by simulated beacon through Engine API, Prague system contracts are pre-deployed.
+func DeveloperPoSGenesisBlock(faucet common.Address) *types.Genesis {
+	config := *chain.AllProtocolChanges
+	config.ChainName = networkname.Dev
+	config.ChainID = params2.AllCliqueProtocolChanges.ChainID
+
+	alloc := readPrealloc("allocs/dev_pos.json")
+	if _, ok := alloc[faucet]; !ok {
+		alloc[faucet] = alloc[DevnetEtherbase]
+	}
+	return &types.Genesis{
+		Config:     &config,
+		GasLimit:   30_000_000,
+		Difficulty: big.NewInt(0),
+		Alloc:      alloc,
+	}
+}
+
 // GenesisToBlock creates the genesis block and writes state of a genesis specification
 // to the given database (or discards it if nil).
 func GenesisToBlock(g *types.Genesis, dirs datadir.Dirs, logger log.Logger) (*types.Block, *state.IntraBlockState, error) {
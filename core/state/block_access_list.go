@@ -0,0 +1,294 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"slices"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/empty"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
+)
+
+// AccessRecorder is a StateReader and StateWriter which records reads and changes of a block execution
+// into VersionedIO, per tx index (-1 is the begin system tx, len(txs) is the end of the block).
+// IntraBlockState writes whole dirty objects on every FinalizeTx, so writes which don't change
+// the last known value are dropped.
+type AccessRecorder struct {
+	reader  StateReader
+	io      *VersionedIO
+	txIndex int
+	reads   ReadSet
+	writes  WriteSet
+
+	accounts map[common.Address]*recordedAccount
+	storage  map[common.Address]map[common.Hash]uint256.Int
+}
+
+type recordedAccount struct {
+	balance  uint256.Int
+	nonce    uint64
+	codeHash common.Hash
+}
+
+func NewAccessRecorder(reader StateReader, numTx int) *AccessRecorder {
+	return &AccessRecorder{
+		reader:   reader,
+		io:       NewVersionedIO(numTx + 1),
+		txIndex:  -1,
+		reads:    ReadSet{},
+		writes:   WriteSet{},
+		accounts: map[common.Address]*recordedAccount{},
+		storage:  map[common.Address]map[common.Hash]uint256.Int{},
+	}
+}
+
+// SetTxIndex records accesses of the previous tx index and starts the next one
+func (r *AccessRecorder) SetTxIndex(txIndex int) {
+	r.flush()
+	r.txIndex = txIndex
+}
+
+// VersionedIO returns reads and writes recorded so far
+func (r *AccessRecorder) VersionedIO() *VersionedIO {
+	r.flush()
+	return r.io
+}
+
+func (r *AccessRecorder) flush() {
+	if r.reads.Len() > 0 {
+		reads := r.io.ReadSet(r.txIndex)
+		if reads == nil {
+			reads = ReadSet{}
+		}
+		r.reads.Scan(func(vr *VersionedRead) bool {
+			reads.Set(*vr)
+			return true
+		})
+		r.io.RecordReads(r.txIndex, reads)
+		r.reads = ReadSet{}
+	}
+	if r.writes.Len() > 0 {
+		writes := r.io.AllWriteSet(r.txIndex)
+		r.writes.Scan(func(vw *VersionedWrite) bool {
+			writes = append(writes, vw)
+			return true
+		})
+		r.io.RecordAllWrites(r.txIndex, writes)
+		r.writes = WriteSet{}
+	}
+}
+
+func (r *AccessRecorder) account(address common.Address, original *accounts.Account) *recordedAccount {
+	acc, ok := r.accounts[address]
+	if !ok {
+		acc = &recordedAccount{codeHash: empty.CodeHash}
+		if original != nil {
+			acc.balance, acc.nonce = original.Balance, original.Nonce
+			if original.CodeHash != (common.Hash{}) {
+				acc.codeHash = original.CodeHash
+			}
+		}
+		r.accounts[address] = acc
+	}
+	return acc
+}
+
+func (r *AccessRecorder) write(address common.Address, path AccountPath, key common.Hash, val any) {
+	r.writes.Set(VersionedWrite{Address: address, Path: path, Key: key, Version: Version{TxIndex: r.txIndex}, Val: val})
+}
+
+func (r *AccessRecorder) ReadAccountData(address common.Address) (*accounts.Account, error) {
+	acc, err := r.reader.ReadAccountData(address)
+	if err != nil {
+		return nil, err
+	}
+	r.account(address, acc)
+	r.reads.Set(VersionedRead{Address: address, Path: AddressPath, Source: StorageRead, Version: Version{TxIndex: r.txIndex}, Val: acc})
+	return acc, nil
+}
+
+func (r *AccessRecorder) ReadAccountDataForDebug(address common.Address) (*accounts.Account, error) {
+	return r.reader.ReadAccountDataForDebug(address)
+}
+
+func (r *AccessRecorder) ReadAccountStorage(address common.Address, key common.Hash) ([]byte, error) {
+	enc, err := r.reader.ReadAccountStorage(address, key)
+	if err != nil {
+		return nil, err
+	}
+	var value uint256.Int
+	value.SetBytes(enc)
+	slots, ok := r.storage[address]
+	if !ok {
+		slots = map[common.Hash]uint256.Int{}
+		r.storage[address] = slots
+	}
+	if _, ok := slots[key]; !ok {
+		slots[key] = value
+	}
+	r.reads.Set(VersionedRead{Address: address, Path: StatePath, Key: key, Source: StorageRead, Version: Version{TxIndex: r.txIndex}, Val: value})
+	return enc, nil
+}
+
+func (r *AccessRecorder) ReadAccountCode(address common.Address) ([]byte, error) {
+	return r.reader.ReadAccountCode(address)
+}
+
+func (r *AccessRecorder) ReadAccountCodeSize(address common.Address) (int, error) {
+	return r.reader.ReadAccountCodeSize(address)
+}
+
+func (r *AccessRecorder) ReadAccountIncarnation(address common.Address) (uint64, error) {
+	return r.reader.ReadAccountIncarnation(address)
+}
+
+func (r *AccessRecorder) UpdateAccountData(address common.Address, original, account *accounts.Account) error {
+	acc := r.account(address, original)
+	if acc.balance != account.Balance {
+		acc.balance = account.Balance
+		r.write(address, BalancePath, common.Hash{}, account.Balance)
+	}
+	if acc.nonce != account.Nonce {
+		acc.nonce = account.Nonce
+		r.write(address, NoncePath, common.Hash{}, account.Nonce)
+	}
+	return nil
+}
+
+func (r *AccessRecorder) UpdateAccountCode(address common.Address, incarnation uint64, codeHash common.Hash, code []byte) error {
+	acc := r.account(address, nil)
+	if acc.codeHash != codeHash {
+		acc.codeHash = codeHash
+		r.write(address, CodePath, common.Hash{}, common.CopyBytes(code))
+	}
+	return nil
+}
+
+func (r *AccessRecorder) DeleteAccount(address common.Address, original *accounts.Account) error {
+	acc := r.account(address, original)
+	if !acc.balance.IsZero() {
+		acc.balance.Clear()
+		r.write(address, BalancePath, common.Hash{}, uint256.Int{})
+	}
+	if acc.nonce != 0 {
+		acc.nonce = 0
+		r.write(address, NoncePath, common.Hash{}, uint64(0))
+	}
+	if acc.codeHash != empty.CodeHash {
+		acc.codeHash = empty.CodeHash
+		r.write(address, CodePath, common.Hash{}, []byte{})
+	}
+	return nil
+}
+
+func (r *AccessRecorder) WriteAccountStorage(address common.Address, incarnation uint64, key common.Hash, original, value uint256.Int) error {
+	slots, ok := r.storage[address]
+	if !ok {
+		slots = map[common.Hash]uint256.Int{}
+		r.storage[address] = slots
+	}
+	known, ok := slots[key]
+	if !ok {
+		known = original
+	}
+	slots[key] = value
+	if known == value {
+		return nil
+	}
+	r.write(address, StatePath, key, value)
+	return nil
+}
+
+func (r *AccessRecorder) CreateContract(address common.Address) error {
+	return nil
+}
+
+// BlockAccessList builds block access list (EIP-7928) of recorded reads and all writes, where
+// block access index of a tx is its index in VersionedIO.
+func (io *VersionedIO) BlockAccessList() types.BlockAccessList {
+	changes := map[common.Address]*types.AccountChanges{}
+	account := func(address common.Address) *types.AccountChanges {
+		acc, ok := changes[address]
+		if !ok {
+			acc = &types.AccountChanges{Address: address, StorageChanges: []*types.SlotChanges{}, StorageReads: []common.Hash{},
+				BalanceChanges: []*types.BalanceChange{}, NonceChanges: []*types.NonceChange{}, CodeChanges: []*types.CodeChange{}}
+			changes[address] = acc
+		}
+		return acc
+	}
+
+	reads := map[common.Address]map[common.Hash]struct{}{}
+	for _, input := range io.inputs {
+		input.Scan(func(vr *VersionedRead) bool {
+			account(vr.Address)
+			if vr.Path == StatePath {
+				if reads[vr.Address] == nil {
+					reads[vr.Address] = map[common.Hash]struct{}{}
+				}
+				reads[vr.Address][vr.Key] = struct{}{}
+			}
+			return true
+		})
+	}
+
+	slots := map[common.Address]map[common.Hash]*types.SlotChanges{}
+	for i, output := range io.allOutputs {
+		index := hexutil.Uint64(i)
+		for _, vw := range output {
+			acc := account(vw.Address)
+			switch vw.Path {
+			case BalancePath:
+				acc.BalanceChanges = append(acc.BalanceChanges, &types.BalanceChange{Index: index, Balance: vw.Val.(uint256.Int)})
+			case NoncePath:
+				acc.NonceChanges = append(acc.NonceChanges, &types.NonceChange{Index: index, Nonce: hexutil.Uint64(vw.Val.(uint64))})
+			case CodePath:
+				acc.CodeChanges = append(acc.CodeChanges, &types.CodeChange{Index: index, Code: vw.Val.([]byte)})
+			case StatePath:
+				if slots[vw.Address] == nil {
+					slots[vw.Address] = map[common.Hash]*types.SlotChanges{}
+				}
+				slot, ok := slots[vw.Address][vw.Key]
+				if !ok {
+					slot = &types.SlotChanges{Slot: vw.Key}
+					slots[vw.Address][vw.Key] = slot
+					acc.StorageChanges = append(acc.StorageChanges, slot)
+				}
+				value := vw.Val.(uint256.Int)
+				slot.Changes = append(slot.Changes, &types.StorageChange{Index: index, Value: value.Bytes32()})
+			}
+		}
+	}
+
+	bal := make(types.BlockAccessList, 0, len(changes))
+	for address, acc := range changes {
+		for key := range reads[address] {
+			if _, ok := slots[address][key]; !ok {
+				acc.StorageReads = append(acc.StorageReads, key)
+			}
+		}
+		slices.SortFunc(acc.StorageChanges, func(a, b *types.SlotChanges) int { return a.Slot.Cmp(b.Slot) })
+		slices.SortFunc(acc.StorageReads, func(a, b common.Hash) int { return a.Cmp(b) })
+		bal = append(bal, acc)
+	}
+	slices.SortFunc(bal, func(a, b *types.AccountChanges) int { return a.Address.Cmp(b.Address) })
+	return bal
+}
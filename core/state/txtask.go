@@ -63,6 +63,7 @@ type TxTask struct {
 	TxIndex         int // -1 for block initialisation
 	Final           bool
 	Failed          bool
+	ExecutionErr    error // why the txn failed, e.g. vm.ErrOutOfGas
 	Tx              types.Transaction
 	GetHashFn       func(n uint64) common.Hash
 	TxAsMessage     *types.Message
@@ -203,6 +204,7 @@ func (t *TxTask) Reset() *TxTask {
 	t.TraceTos = nil
 	t.Error = nil
 	t.Failed = false
+	t.ExecutionErr = nil
 	return t
 }
 
@@ -118,11 +118,9 @@ func (c0 AbsValue) Stringify() string {
 	if c0.kind == InvalidValue || c0.kind == TopValue {
 		return c0.kind.String()
 	} else if c0.kind == ConcreteValue {
-		b, err := c0.value.MarshalText()
-		if err != nil {
-			log.Fatal("Can't unmarshall")
-		}
-		return string(b)
+		// hex, as expected by AbsValueDestringify: the decimal MarshalText made serialized proofs unreadable,
+		// CheckCfg failed on any proof with a concrete value on the stack
+		return c0.value.Hex()
 	}
 
 	log.Fatal("Invalid abs value kind")
@@ -449,3 +447,22 @@ func Lub(st0 *astate, st1 *astate) *astate {
 	}
 	return newState
 }
+
+// newAbsIntInstructionSet - opcodes known to the analysis, of the latest legacy (non-EOF) fork.
+// It used to be Istanbul, so that the analysis of code deployed since Shanghai stopped at the first PUSH0
+// (undefined opcode) and left every jump after it unresolved. Opcodes of newer forks only add instructions,
+// the stack effects of older ones are the same, so older code is analysed exactly as before.
+func newAbsIntInstructionSet() JumpTable {
+	return newPragueInstructionSet()
+}
+
+// haltsExecution - instruction has no successors. Only missing jump table entries used to end blocks, but the
+// tables have entries for all opcodes, so the analysis fell through STOP, RETURN, REVERT, SELFDESTRUCT and
+// undefined opcodes into the following bytes: metadata and dead code got edges, and jumps in them were unresolved.
+func haltsExecution(opcode OpCode, op *operation) bool {
+	switch opcode {
+	case STOP, RETURN, REVERT, SELFDESTRUCT:
+		return true
+	}
+	return op == nil || op.undefined
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emicklei/dot"
+
+	"github.com/erigontech/erigon-lib/common/dbg"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// CfgLimits bound the abstract interpretation, which is exponential in the worst case
+type CfgLimits struct {
+	AnlyCounter int           // edges processed
+	StackLen    int           // length of an abstract stack
+	StackCount  int           // abstract stacks at a pc
+	Timeout     time.Duration // 0 - no limit
+}
+
+var DefaultCfgLimits = CfgLimits{AnlyCounter: 1_000_000, StackLen: 1024, StackCount: 10_000, Timeout: 10 * time.Second}
+
+// RpcCfgLimits - for analysis requested by remote RPC clients. Big contracts may get incomplete graph
+var RpcCfgLimits = CfgLimits{AnlyCounter: 100_000, StackLen: 1024, StackCount: 1_000, Timeout: time.Second}
+
+// ContractCfg - control flow graph of legacy bytecode, recovered by abstract interpretation (see GenCfg)
+type ContractCfg struct {
+	Blocks          []*CfgBlock `json:"blocks"`
+	Edges           []CfgEdge   `json:"edges"`
+	UnresolvedJumps []int       `json:"unresolvedJumps"` // pcs of jumps with destination which the analysis couldn't resolve
+	Complete        bool        `json:"complete"`        // all jumps are resolved and the analysis reached fixpoint
+	Error           string      `json:"error,omitempty"` // why the graph is not complete
+}
+
+// CfgBlock - basic block: entry is pc 0, JUMPDEST or instruction after JUMPI, exit is JUMP, JUMPI or halting instruction
+type CfgBlock struct {
+	Entry        int      `json:"entry"`
+	Exit         int      `json:"exit"`
+	Instructions []string `json:"instructions"`
+	Reachable    bool     `json:"reachable"`
+	Unresolved   bool     `json:"unresolved"` // ends with an unresolved jump
+}
+
+// CfgEdge - edge between blocks, identified by their entry pcs
+type CfgEdge struct {
+	From int  `json:"from"`
+	To   int  `json:"to"`
+	Jump bool `json:"jump"` // false for fall-through
+}
+
+// AnalyzeCfg recovers control flow graph of the code. Unlike GenCfg, it doesn't stop at unresolved jumps,
+// so that the rest of the graph is still available. If the analysis fails, is cancelled or times out, the graph is partial.
+func AnalyzeCfg(ctx context.Context, code []byte, limits CfgLimits) (res *ContractCfg) {
+	res = &ContractCfg{Blocks: []*CfgBlock{}, Edges: []CfgEdge{}, UnresolvedJumps: []int{}}
+	if len(code) == 0 {
+		res.Complete = true
+		return res
+	}
+	defer func() {
+		if rec := recover(); rec != nil {
+			log.Warn("[cfg] analysis panic", "err", rec, "stack", dbg.Stack())
+			res.Complete, res.Error = false, fmt.Sprintf("cfg analysis panic: %s", rec)
+		}
+	}()
+	if limits.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limits.Timeout)
+		defer cancel()
+	}
+
+	cfg, err := genCfg(ctx, code, limits.AnlyCounter, limits.StackLen, limits.StackCount, &CfgMetrics{}, true /* tolerateBadJumps */)
+	res.Complete = err == nil
+	if err != nil {
+		res.Error = err.Error()
+	}
+
+	stmts := cfg.Program.Stmts
+	blockOf := make(map[int]*CfgBlock) // pc of instruction -> its block
+	var block *CfgBlock
+	for pc, stmt := range stmts {
+		if stmt.inferredAsData {
+			continue
+		}
+		if block == nil || stmt.isBlockEntry {
+			block = &CfgBlock{Entry: pc, Reachable: pc == 0 || stmt.covered}
+			res.Blocks = append(res.Blocks, block)
+		}
+		block.Exit = pc
+		block.Instructions = append(block.Instructions, stmt.instruction())
+		blockOf[pc] = block
+		if stmt.isBlockExit {
+			block = nil
+		}
+	}
+
+	for pc := range cfg.BadJumps {
+		res.UnresolvedJumps = append(res.UnresolvedJumps, pc)
+		blockOf[pc].Unresolved = true
+	}
+	sort.Ints(res.UnresolvedJumps)
+
+	for pc1, pc0s := range cfg.PrevEdgeMap {
+		to := blockOf[pc1]
+		if to == nil || to.Entry != pc1 {
+			continue // inside of a block
+		}
+		for pc0 := range pc0s {
+			from := blockOf[pc0]
+			if from == nil {
+				continue
+			}
+			jump := stmts[pc0].opcode == JUMP || (stmts[pc0].opcode == JUMPI && pc1 != pc0+1)
+			res.Edges = append(res.Edges, CfgEdge{From: from.Entry, To: to.Entry, Jump: jump})
+		}
+	}
+	sort.Slice(res.Edges, func(i, j int) bool {
+		if res.Edges[i].From != res.Edges[j].From {
+			return res.Edges[i].From < res.Edges[j].From
+		}
+		return res.Edges[i].To < res.Edges[j].To
+	})
+	return res
+}
+
+func (stmt *Astmt) instruction() string {
+	if stmt.opcode.IsPushWithImmediateArgs() {
+		return fmt.Sprintf("%d: %v %v", stmt.pc, stmt.opcode, stmt.value.Hex())
+	}
+	return fmt.Sprintf("%d: %v", stmt.pc, stmt.opcode)
+}
+
+// Dot renders the graph in Graphviz format. Unresolved jumps and unreachable blocks are highlighted.
+func (c *ContractCfg) Dot() string {
+	g := dot.NewGraph(dot.Directed)
+	nodes := make(map[int]dot.Node, len(c.Blocks))
+	var unresolved dot.Node
+	for _, block := range c.Blocks {
+		n := g.Node(strconv.Itoa(block.Entry)).Box().Attr("label", dot.Literal(`"`+strings.Join(block.Instructions, `\l`)+`\l"`))
+		switch {
+		case block.Unresolved:
+			n.Attr("color", "red").Attr("penwidth", "2")
+		case !block.Reachable:
+			n.Attr("style", "dashed").Attr("color", "gray")
+		}
+		nodes[block.Entry] = n
+	}
+	for _, e := range c.Edges {
+		edge := g.Edge(nodes[e.From], nodes[e.To])
+		if !e.Jump {
+			edge.Dashed()
+		}
+	}
+	for _, block := range c.Blocks {
+		if !block.Unresolved {
+			continue
+		}
+		if unresolved.ID() == "" {
+			unresolved = g.Node("unresolved").Attr("shape", "octagon").Attr("color", "red").Label("?")
+		}
+		g.Edge(nodes[block.Entry], unresolved).Attr("color", "red")
+	}
+	return g.String()
+}
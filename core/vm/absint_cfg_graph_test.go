@@ -0,0 +1,114 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeCfg(t *testing.T) {
+	t.Parallel()
+	code := []byte{
+		byte(PUSH0), byte(CALLDATALOAD), byte(PUSH1), 8, byte(JUMPI), // 0: block 0
+		byte(PUSH1), 10, byte(JUMP), // 5: block 5
+		byte(JUMPDEST), byte(STOP), // 8: block 8
+		byte(JUMPDEST), byte(PUSH0), byte(CALLDATALOAD), byte(JUMP), // 10: block 10, unresolved jump
+		byte(INVALID), // 14: unreachable
+	}
+	cfg := AnalyzeCfg(context.Background(), code, DefaultCfgLimits)
+	require.False(t, cfg.Complete)
+	require.Equal(t, []int{13}, cfg.UnresolvedJumps)
+
+	entries := make([]int, len(cfg.Blocks))
+	for i, block := range cfg.Blocks {
+		entries[i] = block.Entry
+	}
+	require.Equal(t, []int{0, 5, 8, 10, 14}, entries)
+	require.Equal(t, []string{"5: PUSH1 0xa", "7: JUMP"}, cfg.Blocks[1].Instructions)
+	require.True(t, cfg.Blocks[3].Unresolved)
+	require.True(t, cfg.Blocks[3].Reachable)
+	require.False(t, cfg.Blocks[4].Reachable)
+	require.Equal(t, []CfgEdge{{From: 0, To: 5}, {From: 0, To: 8, Jump: true}, {From: 5, To: 10, Jump: true}}, cfg.Edges)
+
+	dot := cfg.Dot()
+	require.True(t, strings.Contains(dot, `label="?"`), dot)
+	require.True(t, strings.Contains(dot, `10: JUMPDEST\l11: PUSH0\l12: CALLDATALOAD\l13: JUMP\l`), dot)
+}
+
+func TestAnalyzeCfgCancelled(t *testing.T) {
+	t.Parallel()
+	code := []byte{byte(JUMPDEST), byte(PUSH0), byte(JUMP)}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cfg := AnalyzeCfg(ctx, code, DefaultCfgLimits)
+	require.False(t, cfg.Complete)
+	require.Equal(t, context.Canceled.Error(), cfg.Error)
+	require.NotEmpty(t, cfg.Blocks, "partial graph is still returned")
+}
+
+func TestCfgProofHaltingOpcodes(t *testing.T) {
+	t.Parallel()
+	// jump destinations are resolved through PUSH0, halting opcodes have no successors
+	code := []byte{
+		byte(PUSH0), byte(PUSH1), 7, byte(JUMPI), // 0
+		byte(PUSH0), byte(PUSH0), byte(RETURN), // 4
+		byte(JUMPDEST), byte(PUSH1), 13, byte(PUSH0), byte(POP), byte(JUMP), // 7
+		byte(JUMPDEST), byte(STOP), // 13
+	}
+	cfg, err := GenCfg(code, 0, 1024, 1000, &CfgMetrics{})
+	require.NoError(t, err)
+	require.True(t, cfg.Metrics.Valid)
+	require.True(t, CheckCfg(code, DeserializeCfgProof(cfg.GenerateProof().Serialize())))
+	require.Equal(t, map[int]bool{3: true}, cfg.PrevEdgeMap[7], "no fall-through after RETURN")
+}
+
+func TestAbsValueStringify(t *testing.T) {
+	t.Parallel()
+	for _, v := range []AbsValue{AbsValueConcrete(*uint256.NewInt(255)), AbsValueTop(-1), AbsValueInvalid()} {
+		require.Equal(t, v.Stringify(), AbsValueDestringify(v.Stringify()).Stringify())
+	}
+	require.Equal(t, "0xff", AbsValueConcrete(*uint256.NewInt(255)).Stringify())
+}
+
+func TestCfgPostIstanbulOpcodes(t *testing.T) {
+	t.Parallel()
+	// PUSH0 (Shanghai), TLOAD and MCOPY (Cancun) don't stop the analysis
+	code := []byte{
+		byte(PUSH0), byte(TLOAD), byte(PUSH0), byte(PUSH0), byte(PUSH0), byte(MCOPY), byte(PUSH1), 9, byte(JUMP), // 0
+		byte(JUMPDEST), byte(STOP), // 9
+	}
+	cfg, err := GenCfg(code, 0, 1024, 1000, &CfgMetrics{})
+	require.NoError(t, err)
+	require.True(t, cfg.Metrics.Valid)
+	require.Equal(t, map[int]bool{8: true}, cfg.PrevEdgeMap[9])
+}
+
+func TestCfgNoFallThroughHalt(t *testing.T) {
+	t.Parallel()
+	// bytes after STOP are metadata, the jump in them is not analysed
+	code := []byte{byte(PUSH1), 3, byte(JUMP), byte(JUMPDEST), byte(STOP), byte(CALLDATASIZE), byte(JUMP)}
+	cfg := AnalyzeCfg(context.Background(), code, DefaultCfgLimits)
+	require.True(t, cfg.Complete, cfg.Error)
+	require.Empty(t, cfg.UnresolvedJumps)
+	require.Equal(t, []CfgEdge{{From: 0, To: 3, Jump: true}}, cfg.Edges)
+	require.False(t, cfg.Blocks[len(cfg.Blocks)-1].Reachable)
+}
@@ -37,12 +37,12 @@ type CfgOpSem struct {
 type CfgAbsSem map[OpCode]*CfgOpSem
 
 func NewCfgAbsSem() *CfgAbsSem {
-	jt := newIstanbulInstructionSet()
+	jt := newAbsIntInstructionSet()
 
 	sem := CfgAbsSem{}
 
 	for opcode, op := range jt {
-		if op == nil {
+		if haltsExecution(OpCode(opcode), op) {
 			continue
 		}
 		opsem := CfgOpSem{}
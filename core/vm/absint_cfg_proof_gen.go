@@ -18,6 +18,7 @@ package vm
 
 import (
 	"bufio"
+	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -89,7 +90,7 @@ func (program *Program) isJumpDest(value *uint256.Int) bool {
 }
 
 func toProgram(code []byte) *Program {
-	jt := newIstanbulInstructionSet()
+	jt := newAbsIntInstructionSet()
 
 	program := &Program{Code: code}
 
@@ -103,7 +104,7 @@ func toProgram(code []byte) *Program {
 		op := OpCode(code[pc])
 		stmt.opcode = op
 		stmt.operation = jt[op]
-		stmt.ends = stmt.operation == nil
+		stmt.ends = haltsExecution(op, stmt.operation)
 		//fmt.Printf("%v %v %v", pc, stmt.opcode, stmt.operation.valid)
 
 		if op.IsPushWithImmediateArgs() {
@@ -149,7 +150,7 @@ func toProgram(code []byte) *Program {
 
 			if pc == len(program.Stmts)-1 {
 				stmt.isBlockExit = true
-			} else if stmt.opcode == JUMP || stmt.opcode == JUMPI {
+			} else if stmt.opcode == JUMP || stmt.opcode == JUMPI || stmt.ends {
 				stmt.isBlockExit = true
 			}
 		}
@@ -280,7 +281,9 @@ func resolve(cfg *Cfg, pc0 int) ([]edge, error) {
 		cfg.BadJumps[stmt.pc] = true
 		cfg.Metrics.Unresolved = true
 		cfg.checkRep()
-		return nil, errors.New("unresolvable jumps found")
+		if !cfg.tolerateBadJumps {
+			return nil, errors.New("unresolvable jumps found")
+		}
 	}
 
 	edges = sortAndUnique(edges)
@@ -448,6 +451,8 @@ type Cfg struct {
 	D               map[int]*astate
 	Metrics         *CfgMetrics
 	ProofSerialized []byte
+
+	tolerateBadJumps bool // analyse other paths after an unresolvable jump, instead of stopping
 }
 
 type CfgCoverageStats struct {
@@ -649,8 +654,12 @@ func pushNewEdges(workList []edge, edges []edge) []edge {
 }
 
 func GenCfg(code []byte, anlyCounterLimit int, maxStackLen int, maxStackCount int, metrics *CfgMetrics) (cfg *Cfg, err error) {
+	return genCfg(context.Background(), code, anlyCounterLimit, maxStackLen, maxStackCount, metrics, false)
+}
+
+func genCfg(ctx context.Context, code []byte, anlyCounterLimit int, maxStackLen int, maxStackCount int, metrics *CfgMetrics, tolerateBadJumps bool) (cfg *Cfg, err error) {
 	program := toProgram(code)
-	cfg = &Cfg{Metrics: metrics}
+	cfg = &Cfg{Metrics: metrics, tolerateBadJumps: tolerateBadJumps}
 	cfg.BadJumps = make(map[int]bool)
 	cfg.Metrics = metrics
 	cfg.Program = program
@@ -677,6 +686,10 @@ func GenCfg(code []byte, anlyCounterLimit int, maxStackLen int, maxStackCount in
 			cfg.Metrics.AnlyCounterLimit = true
 			return cfg, errors.New("reached analysis counter limit")
 		}
+		if cfg.Metrics.AnlyCounter%1024 == 0 && ctx.Err() != nil {
+			cfg.Metrics.Timeout = true
+			return cfg, ctx.Err()
+		}
 
 		var e edge
 		e, workList = workList[0], workList[1:]
@@ -20,6 +20,7 @@
 package vm
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/holiman/uint256"
@@ -57,6 +58,39 @@ func TestJumpDestAnalysis(t *testing.T) {
 	}
 }
 
+func TestJumpDestCacheConcurrent(t *testing.T) {
+	t.Parallel()
+	// JUMPDEST at 3 is code, JUMPDEST at 1 is push data
+	code := []byte{byte(PUSH1), byte(JUMPDEST), byte(POP), byte(JUMPDEST)}
+	hash := crypto.Keccak256Hash(code)
+	c := NewJumpDestCache(16)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				contract := NewContract(dummyContractRef{}, common.Address{}, nil, 0, false /* skipAnalysis */, c)
+				contract.SetCallCode(&common.Address{}, hash, code)
+				if valid, _ := contract.validJumpdest(uint256.NewInt(3)); !valid {
+					t.Error("expected valid jumpdest at 3")
+				}
+				if valid, _ := contract.validJumpdest(uint256.NewInt(1)); valid {
+					t.Error("expected invalid jumpdest at 1")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if total := c.total.Load(); total != 800 {
+		t.Fatalf("expected 800 lookups (one per contract), got %d", total)
+	}
+	if hit := c.hit.Load(); hit < 800-8 {
+		t.Fatalf("expected at most one miss per goroutine, got %d hits", hit)
+	}
+}
+
 func BenchmarkJumpdestAnalysisEmpty_1200k(bench *testing.B) {
 	// 1.4 ms
 	code := make([]byte, 1200000)
@@ -20,12 +20,7 @@
 package vm
 
 import (
-	"fmt"
-
 	"github.com/erigontech/erigon-lib/common"
-	"github.com/erigontech/erigon-lib/common/dbg"
-	"github.com/erigontech/erigon-lib/log/v3"
-	"github.com/hashicorp/golang-lru/v2/simplelru"
 	"github.com/holiman/uint256"
 
 	"github.com/erigontech/erigon/core/tracing"
@@ -68,32 +63,16 @@ type Contract struct {
 
 	Gas   uint64
 	value *uint256.Int
-}
-
-type JumpDestCache struct {
-	*simplelru.LRU[common.Hash, bitvec]
-	hit, total int
-	trace      bool
-}
-
-var (
-	JumpDestCacheLimit = dbg.EnvInt("JD_LRU", 128)
-	jumpDestCacheTrace = dbg.EnvBool("JD_LRU_TRACE", false)
-)
 
-func NewJumpDestCache(limit int) *JumpDestCache {
-	c, err := simplelru.NewLRU[common.Hash, bitvec](limit, nil)
-	if err != nil {
-		panic(err)
-	}
-	return &JumpDestCache{LRU: c, trace: jumpDestCacheTrace}
+	container   *Container // EOF container of the code, Code is its code section being executed; nil for legacy code
+	codeSection uint16
+	returnStack []eofReturn // return points of CALLF
 }
 
-func (c *JumpDestCache) LogStats() {
-	if c == nil || !c.trace {
-		return
-	}
-	log.Warn("[dbg] JumpDestCache", "hit", c.hit, "total", c.total, "limit", JumpDestCacheLimit, "ratio", fmt.Sprintf("%.2f", float64(c.hit)/float64(c.total)))
+// eofReturn - code section and pc to continue from after RETF
+type eofReturn struct {
+	section uint16
+	pc      uint64
 }
 
 // NewContract returns a new contract environment for the execution of EVM.
@@ -131,23 +110,16 @@ func (c *Contract) validJumpdest(dest *uint256.Int) (bool, bool) {
 // isCode returns true if the provided PC location is an actual opcode, as
 // opposed to a data-segment following a PUSHN operation.
 func (c *Contract) isCode(udest uint64) bool {
+	if c.analysis != nil {
+		return c.analysis.codeSegment(udest)
+	}
 	// Do we have a contract hash already?
 	// If we do have a hash, that means it's a 'regular' contract. For regular
-	// contracts ( not temporary initcode), we store the analysis in a map
+	// contracts ( not temporary initcode), we store the analysis in a cache
+	// shared between contracts with the same code, also across transactions.
 	if c.CodeHash != (common.Hash{}) {
-		// Does parent context have the analysis?
-		c.jumpdests.total++
-		analysis, exist := c.jumpdests.Get(c.CodeHash)
-		if !exist {
-			// Do the analysis and save in parent context
-			// We do not need to store it in c.analysis
-			analysis = codeBitmap(c.Code)
-			c.jumpdests.Add(c.CodeHash, analysis)
-		} else {
-			c.jumpdests.hit++
-		}
 		// Also stash it in current contract for faster access
-		c.analysis = analysis
+		c.analysis = c.jumpdests.analysis(c.CodeHash, c.Code)
 		return c.analysis.codeSegment(udest)
 	}
 
@@ -155,10 +127,7 @@ func (c *Contract) isCode(udest uint64) bool {
 	// in state trie. In that case, we do an analysis, and save it locally, so
 	// we don't have to recalculate it for every JUMP instruction in the execution
 	// However, we don't save it within the parent context
-	if c.analysis == nil {
-		c.analysis = codeBitmap(c.Code)
-	}
-
+	c.analysis = codeBitmap(c.Code)
 	return c.analysis.codeSegment(udest)
 }
 
@@ -235,14 +204,32 @@ func (c *Contract) Value() *uint256.Int {
 // object
 func (c *Contract) SetCallCode(addr *common.Address, hash common.Hash, code []byte) {
 	c.Code = code
+	c.analysis = nil
 	c.CodeHash = hash
 	c.CodeAddr = addr
 }
 
+// IsEOF returns whether the contract runs EOF code
+func (c *Contract) IsEOF() bool {
+	return c.container != nil
+}
+
+// setContainer makes the contract run EOF container, starting from its first code section
+func (c *Contract) setContainer(container *Container) {
+	c.container = container
+	c.setCodeSection(0)
+}
+
+func (c *Contract) setCodeSection(section uint16) {
+	c.codeSection = section
+	c.Code = c.container.codeSections[section]
+}
+
 // SetCodeOptionalHash can be used to provide code, but it's optional to provide hash.
 // In case hash is not provided, the jumpdest analysis will not be saved to the parent context
 func (c *Contract) SetCodeOptionalHash(addr *common.Address, codeAndHash *codeAndHash) {
 	c.Code = codeAndHash.code
+	c.analysis = nil
 	c.CodeHash = codeAndHash.hash
 	c.CodeAddr = addr
 }
@@ -210,6 +210,10 @@ func init() {
 
 // ActivePrecompiles returns the precompiles enabled with the current configuration.
 func ActivePrecompiles(rules *chain.Rules) []common.Address {
+	return withCustomPrecompiles(rules, activeStandardPrecompiles(rules))
+}
+
+func activeStandardPrecompiles(rules *chain.Rules) []common.Address {
 	switch {
 	case rules.IsOsaka:
 		return PrecompiledAddressesOsaka
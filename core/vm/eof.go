@@ -0,0 +1,574 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/chain/params"
+)
+
+// EVM Object Format (EOF) v1, see EIP-7692 for the list of EIPs it consists of. Container layout (EIP-3540):
+//
+//	container := header, body
+//	header    := magic, version, kind_types, types_size, kind_code, num_code_sections, code_size+,
+//	             [kind_container, num_container_sections, container_size+,] kind_data, data_size, terminator
+//	body      := types_section, code_section+, container_section*, data_section
+//	types_section := (inputs, outputs, max_stack_increase)+
+const (
+	eofVersion1 = 0x01
+
+	eofKindTypes     = 0x01
+	eofKindCode      = 0x02
+	eofKindContainer = 0x03
+	eofKindData      = 0xff
+	eofTerminator    = 0x00
+
+	eofTypeSize        = 4    // inputs (1 byte), outputs (1 byte), max_stack_increase (2 bytes)
+	eofNonReturning    = 0x80 // outputs of a code section, which never returns to its caller
+	eofMaxIO           = 0x7f // max inputs and outputs of a code section
+	eofMaxStackHeight  = 1023
+	eofMaxCodeSections = 1024
+	eofMaxContainers   = 256
+	eofMaxDataSize     = 0xffff
+	eofReturnStackSize = 1024
+)
+
+var eofMagic = []byte{0xef, 0x00}
+
+var (
+	errEOFInvalidMagic          = errors.New("invalid EOF magic")
+	errEOFInvalidVersion        = errors.New("invalid EOF version")
+	errEOFIncompleteContainer   = errors.New("incomplete EOF container")
+	errEOFInvalidHeader         = errors.New("invalid EOF header")
+	errEOFInvalidTypes          = errors.New("invalid EOF types section")
+	errEOFInvalidSection0Type   = errors.New("invalid type of EOF code section 0")
+	errEOFTrailingBytes         = errors.New("trailing bytes after EOF container")
+	errEOFTruncatedData         = errors.New("truncated EOF data section")
+	errEOFUndefinedInstruction  = errors.New("undefined instruction")
+	errEOFTruncatedInstruction  = errors.New("truncated instruction")
+	errEOFInvalidJumpDest       = errors.New("invalid relative jump destination")
+	errEOFInvalidSectionIndex   = errors.New("invalid code section index")
+	errEOFInvalidContainerIndex = errors.New("invalid container section index")
+	errEOFInvalidDataIndex      = errors.New("invalid DATALOADN index")
+	errEOFCallfToNonReturning   = errors.New("CALLF to non-returning code section")
+	errEOFIncompatibleOutputs   = errors.New("JUMPF to code section with more outputs")
+	errEOFInvalidNonReturning   = errors.New("invalid non-returning flag of code section")
+	errEOFIncompatibleKind      = errors.New("instruction not allowed in container kind")
+	errEOFUnreachableSection    = errors.New("unreachable code section")
+	errEOFUnreachableCode       = errors.New("unreachable code")
+	errEOFNoTerminator          = errors.New("code section doesn't end with terminating instruction")
+	errEOFStackUnderflow        = errors.New("stack underflow")
+	errEOFStackOverflow         = errors.New("stack overflow")
+	errEOFStackHigherThanOutput = errors.New("stack higher than code section outputs")
+	errEOFBackwardJumpHeight    = errors.New("stack height mismatch at backward jump")
+	errEOFInvalidMaxStack       = errors.New("invalid max stack increase")
+	errEOFAmbiguousContainer    = errors.New("container is used both by EOFCREATE and RETURNCONTRACT")
+	errEOFOrphanContainer       = errors.New("container is not used by EOFCREATE or RETURNCONTRACT")
+	errEOFAuxDataSize           = errors.New("invalid size of data section with aux data")
+)
+
+// ContainerKind - how EOF container is used, some instructions are only allowed in one of the kinds
+type ContainerKind int
+
+const (
+	RuntimeContainer  ContainerKind = iota // deployed code, can't use RETURNCONTRACT
+	InitcodeContainer                      // code run by EOFCREATE, can't use RETURN and STOP
+)
+
+type eofCodeType struct {
+	inputs           uint8
+	outputs          uint8 // eofNonReturning if the code section never returns
+	maxStackIncrease uint16
+}
+
+// Container is a parsed EOF container. Its sections point into the raw container.
+type Container struct {
+	types         []eofCodeType
+	codeSections  [][]byte
+	subContainers [][]byte
+	data          []byte
+	dataSize      int // declared in the header, data of not yet deployed container can be shorter
+	dataSizePos   int // position of data_size in the header
+	raw           []byte
+}
+
+// HasEOFMagic returns whether code starts with EOF magic 0xEF00
+func HasEOFMagic(code []byte) bool {
+	return bytes.HasPrefix(code, eofMagic)
+}
+
+// ParseEOF parses EOF container and checks its header and types section, but not its code (see ValidateEOF).
+func ParseEOF(code []byte) (*Container, error) {
+	if !HasEOFMagic(code) {
+		return nil, errEOFInvalidMagic
+	}
+	if len(code) < 3 {
+		return nil, errEOFIncompleteContainer
+	}
+	if code[2] != eofVersion1 {
+		return nil, fmt.Errorf("%w: %d", errEOFInvalidVersion, code[2])
+	}
+	pos := 3
+	readSection := func(kind byte) (uint16, error) {
+		if pos+3 > len(code) {
+			return 0, errEOFIncompleteContainer
+		}
+		if code[pos] != kind {
+			return 0, fmt.Errorf("%w: section kind 0x%02x instead of 0x%02x at %d", errEOFInvalidHeader, code[pos], kind, pos)
+		}
+		pos += 3
+		return binary.BigEndian.Uint16(code[pos-2:]), nil
+	}
+	readSizes := func(num uint16, width int) ([]int, error) {
+		if pos+int(num)*width > len(code) {
+			return nil, errEOFIncompleteContainer
+		}
+		sizes := make([]int, num)
+		for i := range sizes {
+			if width == 2 {
+				sizes[i] = int(binary.BigEndian.Uint16(code[pos:]))
+			} else {
+				sizes[i] = int(binary.BigEndian.Uint32(code[pos:]))
+			}
+			if sizes[i] == 0 {
+				return nil, fmt.Errorf("%w: empty section at %d", errEOFInvalidHeader, pos)
+			}
+			pos += width
+		}
+		return sizes, nil
+	}
+
+	typesSize, err := readSection(eofKindTypes)
+	if err != nil {
+		return nil, err
+	}
+	if typesSize == 0 || typesSize%eofTypeSize != 0 {
+		return nil, fmt.Errorf("%w: types section size %d", errEOFInvalidHeader, typesSize)
+	}
+	numCodeSections, err := readSection(eofKindCode)
+	if err != nil {
+		return nil, err
+	}
+	if numCodeSections == 0 || numCodeSections > eofMaxCodeSections {
+		return nil, fmt.Errorf("%w: %d code sections", errEOFInvalidHeader, numCodeSections)
+	}
+	if int(numCodeSections)*eofTypeSize != int(typesSize) {
+		return nil, fmt.Errorf("%w: types section size %d for %d code sections", errEOFInvalidHeader, typesSize, numCodeSections)
+	}
+	codeSizes, err := readSizes(numCodeSections, 2)
+	if err != nil {
+		return nil, err
+	}
+	var containerSizes []int
+	if pos < len(code) && code[pos] == eofKindContainer {
+		numContainers, err := readSection(eofKindContainer)
+		if err != nil {
+			return nil, err
+		}
+		if numContainers == 0 || numContainers > eofMaxContainers {
+			return nil, fmt.Errorf("%w: %d container sections", errEOFInvalidHeader, numContainers)
+		}
+		if containerSizes, err = readSizes(numContainers, 4); err != nil {
+			return nil, err
+		}
+	}
+	dataSize, err := readSection(eofKindData)
+	if err != nil {
+		return nil, err
+	}
+	c := &Container{dataSize: int(dataSize), dataSizePos: pos - 2, raw: code}
+	if pos >= len(code) {
+		return nil, errEOFIncompleteContainer
+	}
+	if code[pos] != eofTerminator {
+		return nil, fmt.Errorf("%w: missing terminator", errEOFInvalidHeader)
+	}
+	pos++
+
+	// body
+	if pos+int(typesSize) > len(code) {
+		return nil, errEOFIncompleteContainer
+	}
+	c.types = make([]eofCodeType, numCodeSections)
+	for i := range c.types {
+		t := eofCodeType{inputs: code[pos], outputs: code[pos+1], maxStackIncrease: binary.BigEndian.Uint16(code[pos+2:])}
+		switch {
+		case t.inputs > eofMaxIO:
+			return nil, fmt.Errorf("%w: code section %d has %d inputs", errEOFInvalidTypes, i, t.inputs)
+		case t.outputs > eofMaxIO && t.outputs != eofNonReturning:
+			return nil, fmt.Errorf("%w: code section %d has %d outputs", errEOFInvalidTypes, i, t.outputs)
+		case int(t.inputs)+int(t.maxStackIncrease) > eofMaxStackHeight:
+			return nil, fmt.Errorf("%w: code section %d has max stack height %d", errEOFInvalidTypes, i, int(t.inputs)+int(t.maxStackIncrease))
+		case i == 0 && (t.inputs != 0 || t.outputs != eofNonReturning):
+			return nil, fmt.Errorf("%w: %d inputs, %d outputs", errEOFInvalidSection0Type, t.inputs, t.outputs)
+		}
+		c.types[i] = t
+		pos += eofTypeSize
+	}
+	c.codeSections = make([][]byte, numCodeSections)
+	for i, size := range codeSizes {
+		if pos+size > len(code) {
+			return nil, errEOFIncompleteContainer
+		}
+		c.codeSections[i] = code[pos : pos+size]
+		pos += size
+	}
+	if len(containerSizes) > 0 {
+		c.subContainers = make([][]byte, len(containerSizes))
+	}
+	for i, size := range containerSizes {
+		if pos+size > len(code) {
+			return nil, errEOFIncompleteContainer
+		}
+		c.subContainers[i] = code[pos : pos+size]
+		pos += size
+	}
+	c.data = code[pos:]
+	if len(c.data) > c.dataSize {
+		return nil, fmt.Errorf("%w: %d bytes of data, %d declared", errEOFTrailingBytes, len(c.data), c.dataSize)
+	}
+	return c, nil
+}
+
+// ValidateEOF parses EOF container of given kind and validates its code and all its subcontainers recursively. Only
+// valid containers can be deployed and executed.
+func ValidateEOF(code []byte, kind ContainerKind) (*Container, error) {
+	return validateEOF(code, kind, true)
+}
+
+func validateEOF(code []byte, kind ContainerKind, topLevel bool) (*Container, error) {
+	c, err := ParseEOF(code)
+	if err != nil {
+		return nil, err
+	}
+	// only data of containers deployed by RETURNCONTRACT can be completed by aux data
+	if len(c.data) < c.dataSize && (topLevel || kind == InitcodeContainer) {
+		return nil, fmt.Errorf("%w: %d bytes of data, %d declared", errEOFTruncatedData, len(c.data), c.dataSize)
+	}
+
+	refs := &eofRefs{
+		sections:       make([]bool, len(c.codeSections)),
+		eofCreate:      make([]bool, len(c.subContainers)),
+		returnContract: make([]bool, len(c.subContainers)),
+	}
+	refs.sections[0] = true
+	for queue := []int{0}; len(queue) > 0; queue = queue[1:] {
+		section := queue[0]
+		called, err := c.validateCode(section, kind, refs)
+		if err != nil {
+			return nil, fmt.Errorf("code section %d: %w", section, err)
+		}
+		for _, target := range called {
+			if !refs.sections[target] {
+				refs.sections[target] = true
+				queue = append(queue, target)
+			}
+		}
+	}
+	for section, reachable := range refs.sections {
+		if !reachable {
+			return nil, fmt.Errorf("%w: %d", errEOFUnreachableSection, section)
+		}
+	}
+
+	for i, sub := range c.subContainers {
+		var subKind ContainerKind
+		switch {
+		case refs.eofCreate[i] && refs.returnContract[i]:
+			return nil, fmt.Errorf("%w: %d", errEOFAmbiguousContainer, i)
+		case refs.eofCreate[i]:
+			subKind = InitcodeContainer
+		case refs.returnContract[i]:
+			subKind = RuntimeContainer
+		default:
+			return nil, fmt.Errorf("%w: %d", errEOFOrphanContainer, i)
+		}
+		if _, err := validateEOF(sub, subKind, false); err != nil {
+			return nil, fmt.Errorf("container section %d: %w", i, err)
+		}
+	}
+	return c, nil
+}
+
+// eofRefs - code sections and subcontainers referenced by already validated code
+type eofRefs struct {
+	sections       []bool
+	eofCreate      []bool
+	returnContract []bool
+}
+
+// eofImmediateSize - size of immediate arguments of the instruction at pos. Size of RJUMPV immediates depends on its
+// first immediate byte, so it's 1 for truncated RJUMPV.
+func eofImmediateSize(code []byte, pos int) int {
+	switch op := OpCode(code[pos]); op {
+	case RJUMP, RJUMPI, CALLF, JUMPF, DATALOADN:
+		return 2
+	case DUPN, SWAPN, EXCHANGE, EOFCREATE, RETURNCONTRACT:
+		return 1
+	case RJUMPV:
+		if pos+1 < len(code) {
+			return 1 + (int(code[pos+1])+1)*2
+		}
+		return 1
+	default:
+		if op >= PUSH1 && op <= PUSH32 {
+			return int(op-PUSH1) + 1
+		}
+		return 0
+	}
+}
+
+// eofJumpTargets - destinations of RJUMP, RJUMPI and RJUMPV at pos
+func eofJumpTargets(code []byte, pos int) []int {
+	switch OpCode(code[pos]) {
+	case RJUMP, RJUMPI:
+		return []int{pos + 3 + int(int16(binary.BigEndian.Uint16(code[pos+1:])))}
+	case RJUMPV:
+		count := int(code[pos+1]) + 1
+		end := pos + 2 + count*2
+		targets := make([]int, count)
+		for i := range targets {
+			targets[i] = end + int(int16(binary.BigEndian.Uint16(code[pos+2+i*2:])))
+		}
+		return targets
+	}
+	return nil
+}
+
+// validateCode checks instructions of the code section (EIP-3670, EIP-4200, EIP-4750, EIP-6206, EIP-7480, EIP-7620)
+// and its stack (EIP-5450). Returns code sections, which are called or jumped to by the section.
+func (c *Container) validateCode(section int, kind ContainerKind, refs *eofRefs) ([]int, error) {
+	code := c.codeSections[section]
+	typ := c.types[section]
+	immediates := make(bitvec, (len(code)+63)/64)
+	var (
+		called    []int
+		jumps     []int
+		returning bool
+	)
+	for pos := 0; pos < len(code); {
+		op := OpCode(code[pos])
+		if eofInstructionSet[op].undefined {
+			return nil, fmt.Errorf("%w: 0x%02x at %d", errEOFUndefinedInstruction, byte(op), pos)
+		}
+		size := eofImmediateSize(code, pos)
+		if pos+1+size > len(code) {
+			return nil, fmt.Errorf("%w: %v at %d", errEOFTruncatedInstruction, op, pos)
+		}
+		for i := pos + 1; i <= pos+size; i++ {
+			immediates.set1(uint64(i))
+		}
+		var imm uint16
+		if size >= 2 {
+			imm = binary.BigEndian.Uint16(code[pos+1:])
+		} else if size == 1 {
+			imm = uint16(code[pos+1])
+		}
+
+		switch op {
+		case RJUMP, RJUMPI, RJUMPV:
+			jumps = append(jumps, pos)
+		case CALLF:
+			if int(imm) >= len(c.codeSections) {
+				return nil, fmt.Errorf("%w: CALLF %d at %d", errEOFInvalidSectionIndex, imm, pos)
+			}
+			if c.types[imm].outputs == eofNonReturning {
+				return nil, fmt.Errorf("%w: %d at %d", errEOFCallfToNonReturning, imm, pos)
+			}
+			called = append(called, int(imm))
+		case JUMPF:
+			if int(imm) >= len(c.codeSections) {
+				return nil, fmt.Errorf("%w: JUMPF %d at %d", errEOFInvalidSectionIndex, imm, pos)
+			}
+			if target := c.types[imm]; target.outputs != eofNonReturning {
+				if typ.outputs == eofNonReturning || target.outputs > typ.outputs {
+					return nil, fmt.Errorf("%w: %d at %d", errEOFIncompatibleOutputs, imm, pos)
+				}
+				returning = true
+			}
+			called = append(called, int(imm))
+		case RETF:
+			if typ.outputs == eofNonReturning {
+				return nil, fmt.Errorf("%w: RETF at %d", errEOFInvalidNonReturning, pos)
+			}
+			returning = true
+		case DATALOADN:
+			if int(imm)+32 > c.dataSize {
+				return nil, fmt.Errorf("%w: %d at %d, data size %d", errEOFInvalidDataIndex, imm, pos, c.dataSize)
+			}
+		case EOFCREATE, RETURNCONTRACT:
+			if int(imm) >= len(c.subContainers) {
+				return nil, fmt.Errorf("%w: %v %d at %d", errEOFInvalidContainerIndex, op, imm, pos)
+			}
+			if op == EOFCREATE {
+				refs.eofCreate[imm] = true
+			} else {
+				if kind != InitcodeContainer {
+					return nil, fmt.Errorf("%w: %v at %d", errEOFIncompatibleKind, op, pos)
+				}
+				refs.returnContract[imm] = true
+			}
+		case RETURN, STOP:
+			if kind == InitcodeContainer {
+				return nil, fmt.Errorf("%w: %v at %d", errEOFIncompatibleKind, op, pos)
+			}
+		}
+		pos += 1 + size
+	}
+
+	for _, pos := range jumps {
+		for _, target := range eofJumpTargets(code, pos) {
+			if target < 0 || target >= len(code) || !immediates.codeSegment(uint64(target)) {
+				return nil, fmt.Errorf("%w: %d at %d", errEOFInvalidJumpDest, target, pos)
+			}
+		}
+	}
+	if typ.outputs != eofNonReturning && !returning {
+		return nil, fmt.Errorf("%w: returning code section without RETF or JUMPF to returning section", errEOFInvalidNonReturning)
+	}
+	if err := c.validateStack(section); err != nil {
+		return nil, err
+	}
+	return called, nil
+}
+
+type eofStackRange struct{ min, max int }
+
+// validateStack - EIP-5450 stack validation. Instructions are visited in order, tracking range of stack heights they
+// can be reached with: all of them must be reached by forward jumps or by preceding instructions, backward jumps
+// must not change the range.
+func (c *Container) validateStack(section int) error {
+	code := c.codeSections[section]
+	typ := c.types[section]
+	heights := make([]eofStackRange, len(code))
+	for i := range heights {
+		heights[i].min = -1
+	}
+	heights[0] = eofStackRange{int(typ.inputs), int(typ.inputs)}
+	maxHeight := int(typ.inputs)
+
+	for pos := 0; pos < len(code); {
+		op := OpCode(code[pos])
+		cur := heights[pos]
+		if cur.min < 0 {
+			return fmt.Errorf("%w: at %d", errEOFUnreachableCode, pos)
+		}
+		next := pos + 1 + eofImmediateSize(code, pos)
+
+		var required, change int
+		switch op {
+		case CALLF, JUMPF:
+			target := c.types[binary.BigEndian.Uint16(code[pos+1:])]
+			if cur.max+int(target.maxStackIncrease) > int(params.StackLimit) {
+				return fmt.Errorf("%w: %v at %d", errEOFStackOverflow, op, pos)
+			}
+			switch {
+			case op == CALLF:
+				required, change = int(target.inputs), int(target.outputs)-int(target.inputs)
+			case target.outputs == eofNonReturning:
+				required = int(target.inputs)
+			default:
+				required = int(typ.outputs) + int(target.inputs) - int(target.outputs)
+				if cur.max > required {
+					return fmt.Errorf("%w: JUMPF at %d", errEOFStackHigherThanOutput, pos)
+				}
+			}
+		case RETF:
+			required = int(typ.outputs)
+			if cur.max > required {
+				return fmt.Errorf("%w: RETF at %d", errEOFStackHigherThanOutput, pos)
+			}
+		case DUPN:
+			required, change = int(code[pos+1])+1, 1
+		case SWAPN:
+			required = int(code[pos+1]) + 2
+		case EXCHANGE:
+			required = int(code[pos+1]>>4) + int(code[pos+1]&0x0f) + 3
+		default:
+			operation := eofInstructionSet[op]
+			required, change = operation.numPop, operation.numPush-operation.numPop
+		}
+		if cur.min < required {
+			return fmt.Errorf("%w: %v at %d needs %d items, has %d", errEOFStackUnderflow, op, pos, required, cur.min)
+		}
+		after := eofStackRange{cur.min + change, cur.max + change}
+		maxHeight = max(maxHeight, after.max)
+
+		visit := func(target int) error {
+			if target <= pos {
+				if heights[target] != after {
+					return fmt.Errorf("%w: from %d to %d", errEOFBackwardJumpHeight, pos, target)
+				}
+				return nil
+			}
+			if target >= len(code) {
+				return errEOFNoTerminator
+			}
+			if heights[target].min < 0 {
+				heights[target] = after
+			} else {
+				heights[target] = eofStackRange{min(heights[target].min, after.min), max(heights[target].max, after.max)}
+			}
+			return nil
+		}
+		switch op {
+		case STOP, RETURN, REVERT, INVALID, RETF, JUMPF, RETURNCONTRACT:
+		case RJUMP:
+			if err := visit(eofJumpTargets(code, pos)[0]); err != nil {
+				return err
+			}
+		default:
+			if err := visit(next); err != nil {
+				return err
+			}
+			for _, target := range eofJumpTargets(code, pos) {
+				if err := visit(target); err != nil {
+					return err
+				}
+			}
+		}
+		pos = next
+	}
+
+	if maxHeight > eofMaxStackHeight {
+		return fmt.Errorf("%w: max stack height %d", errEOFStackOverflow, maxHeight)
+	}
+	if maxHeight-int(typ.inputs) != int(typ.maxStackIncrease) {
+		return fmt.Errorf("%w: %d declared, %d computed", errEOFInvalidMaxStack, typ.maxStackIncrease, maxHeight-int(typ.inputs))
+	}
+	return nil
+}
+
+// deployContainer - subcontainer to be deployed by RETURNCONTRACT with aux data appended to its data section
+func (c *Container) deployContainer(idx int, auxData []byte) ([]byte, error) {
+	sub, err := ParseEOF(c.subContainers[idx])
+	if err != nil {
+		return nil, err
+	}
+	dataSize := len(sub.data) + len(auxData)
+	if dataSize < sub.dataSize || dataSize > eofMaxDataSize {
+		return nil, fmt.Errorf("%w: %d, declared %d", errEOFAuxDataSize, dataSize, sub.dataSize)
+	}
+	deployed := make([]byte, 0, len(sub.raw)+len(auxData))
+	deployed = append(append(deployed, sub.raw...), auxData...)
+	binary.BigEndian.PutUint16(deployed[sub.dataSizePos:], uint16(dataSize))
+	return deployed, nil
+}
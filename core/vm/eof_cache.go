@@ -0,0 +1,74 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/dbg"
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+var (
+	EOFCacheLimit = dbg.EnvInt("EOF_LRU", 1024)
+
+	mxEOFCacheHit  = metrics.GetOrCreateCounter("evm_eof_cache_hit")
+	mxEOFCacheMiss = metrics.GetOrCreateCounter("evm_eof_cache_miss")
+)
+
+// EOFCache keeps parsed EOF containers by code hash, so that calls into EOF code don't parse its header
+// every time. It's safe for concurrent use, by default all EVMs of the process share one (see SharedEOFCache).
+// Cached containers are shared, they must not be modified.
+type EOFCache struct {
+	*lru.Cache[common.Hash, parsedEOF]
+}
+
+// parsedEOF - result of ParseEOF, errors are cached too: code deployed before EOF activation isn't validated
+type parsedEOF struct {
+	container *Container
+	err       error
+}
+
+func NewEOFCache(limit int) *EOFCache {
+	c, err := lru.New[common.Hash, parsedEOF](limit)
+	if err != nil {
+		panic(err)
+	}
+	return &EOFCache{Cache: c}
+}
+
+var sharedEOFCache = sync.OnceValue(func() *EOFCache { return NewEOFCache(EOFCacheLimit) })
+
+// SharedEOFCache returns the process-wide cache, used by EVMs created without an explicit one
+func SharedEOFCache() *EOFCache {
+	return sharedEOFCache()
+}
+
+// parse returns parsed container of the code with the given hash, parsing it on a miss
+func (c *EOFCache) parse(codeHash common.Hash, code []byte) (*Container, error) {
+	if p, ok := c.Get(codeHash); ok {
+		mxEOFCacheHit.Inc()
+		return p.container, p.err
+	}
+	container, err := ParseEOF(code)
+	c.Add(codeHash, parsedEOF{container: container, err: err})
+	mxEOFCacheMiss.Inc()
+	return container, err
+}
@@ -0,0 +1,500 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/common/math"
+	"github.com/erigontech/erigon-lib/crypto"
+
+	"github.com/erigontech/erigon/core/tracing"
+)
+
+var (
+	// eofMagicHash - code hash of EOF contracts as seen by legacy EXTCODEHASH
+	eofMagicHash = crypto.Keccak256Hash(eofMagic)
+
+	errInvalidEOFCallTarget = errors.New("EOF call target is not a valid address")
+)
+
+// enableEOF turns the instruction set into the one of EOF code (EIP-7692): removes instructions, which observe code
+// and gas or jump to dynamic destinations, adds the EOF instructions.
+func enableEOF(jt *JumpTable) {
+	for _, op := range []OpCode{CALLCODE, SELFDESTRUCT, JUMP, JUMPI, PC, CREATE, CREATE2, CODESIZE, CODECOPY,
+		EXTCODESIZE, EXTCODECOPY, EXTCODEHASH, GAS, CALL, DELEGATECALL, STATICCALL} {
+		jt[op] = &operation{execute: opUndefined, undefined: true}
+	}
+	// designated invalid instruction is valid in EOF code
+	jt[INVALID] = &operation{execute: opUndefined}
+	jt[RETURNDATACOPY].execute = opReturnDataCopyEOF
+
+	// EIP-4200: static relative jumps
+	jt[RJUMP] = &operation{execute: opRjump, constantGas: GasQuickStep}
+	jt[RJUMPI] = &operation{execute: opRjumpi, constantGas: GasFastishStep, numPop: 1}
+	jt[RJUMPV] = &operation{execute: opRjumpv, constantGas: GasFastishStep, numPop: 1}
+	// EIP-4750: functions, EIP-6206: JUMPF and non-returning functions
+	jt[CALLF] = &operation{execute: opCallf, constantGas: GasFastStep}
+	jt[RETF] = &operation{execute: opRetf, constantGas: GasFastestStep}
+	jt[JUMPF] = &operation{execute: opJumpf, constantGas: GasFastStep}
+	// EIP-663: SWAPN, DUPN and EXCHANGE
+	jt[DUPN] = &operation{execute: opDupN, constantGas: GasFastestStep, numPush: 1}
+	jt[SWAPN] = &operation{execute: opSwapN, constantGas: GasFastestStep}
+	jt[EXCHANGE] = &operation{execute: opExchange, constantGas: GasFastestStep}
+	// EIP-7480: data section access instructions
+	jt[DATALOAD] = &operation{execute: opDataLoad, constantGas: GasFastishStep, numPop: 1, numPush: 1}
+	jt[DATALOADN] = &operation{execute: opDataLoadN, constantGas: GasFastestStep, numPush: 1}
+	jt[DATASIZE] = &operation{execute: opDataSize, constantGas: GasQuickStep, numPush: 1}
+	jt[DATACOPY] = &operation{
+		execute:     opDataCopy,
+		constantGas: GasFastestStep,
+		dynamicGas:  memoryCopierGas(2),
+		numPop:      3,
+		memorySize:  memoryCallDataCopy,
+	}
+	// EIP-7069: revamped CALL instructions
+	jt[RETURNDATALOAD] = &operation{execute: opReturnDataLoad, constantGas: GasFastestStep, numPop: 1, numPush: 1}
+	jt[EXTCALL] = &operation{
+		execute:     opExtCall,
+		constantGas: params.WarmStorageReadCostEIP2929,
+		dynamicGas:  gasExtCall,
+		numPop:      4,
+		numPush:     1,
+		memorySize:  memoryExtCall,
+	}
+	jt[EXTDELEGATECALL] = &operation{
+		execute:     opExtDelegateCall,
+		constantGas: params.WarmStorageReadCostEIP2929,
+		dynamicGas:  gasExtDelegateCall,
+		numPop:      3,
+		numPush:     1,
+		memorySize:  memoryExtCall,
+	}
+	jt[EXTSTATICCALL] = &operation{
+		execute:     opExtStaticCall,
+		constantGas: params.WarmStorageReadCostEIP2929,
+		dynamicGas:  gasExtDelegateCall,
+		numPop:      3,
+		numPush:     1,
+		memorySize:  memoryExtCall,
+	}
+	// EIP-7620: EOF contract creation
+	jt[EOFCREATE] = &operation{
+		execute:     opEOFCreate,
+		constantGas: params.CreateGas,
+		dynamicGas:  pureMemoryGascost,
+		numPop:      4,
+		numPush:     1,
+		memorySize:  memoryEOFCreate,
+	}
+	jt[RETURNCONTRACT] = &operation{
+		execute:    opReturnContract,
+		dynamicGas: pureMemoryGascost,
+		numPop:     2,
+		memorySize: memoryReturn,
+	}
+}
+
+// enableEOFLegacy makes legacy code see EOF contracts as 0xEF00 code (EIP-3540)
+func enableEOFLegacy(jt *JumpTable) {
+	jt[EXTCODESIZE].execute = opExtCodeSizeEOF
+	jt[EXTCODECOPY].execute = opExtCodeCopyEOF
+	jt[EXTCODEHASH].execute = opExtCodeHashEOF
+}
+
+func opExtCodeSizeEOF(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	slot := scope.Stack.peek()
+	code, err := interpreter.evm.IntraBlockState().GetCode(slot.Bytes20())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntraBlockStateFailed, err)
+	}
+	if HasEOFMagic(code) {
+		slot.SetUint64(uint64(len(eofMagic)))
+	} else {
+		slot.SetUint64(uint64(len(code)))
+	}
+	return nil, nil
+}
+
+func opExtCodeCopyEOF(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		stack      = scope.Stack
+		a          = stack.pop()
+		memOffset  = stack.pop()
+		codeOffset = stack.pop()
+		length     = stack.pop()
+	)
+	code, err := interpreter.evm.IntraBlockState().GetCode(a.Bytes20())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntraBlockStateFailed, err)
+	}
+	if HasEOFMagic(code) {
+		code = eofMagic
+	}
+	scope.Memory.Set(memOffset.Uint64(), length.Uint64(), getDataBig(code, &codeOffset, length.Uint64()))
+	return nil, nil
+}
+
+func opExtCodeHashEOF(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	slot := scope.Stack.peek()
+	address := common.Address(slot.Bytes20())
+	ibs := interpreter.evm.IntraBlockState()
+	empty, err := ibs.Empty(address)
+	if err != nil {
+		return nil, err
+	}
+	if empty {
+		slot.Clear()
+		return nil, nil
+	}
+	code, err := ibs.GetCode(address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntraBlockStateFailed, err)
+	}
+	if HasEOFMagic(code) {
+		slot.SetBytes(eofMagicHash.Bytes())
+		return nil, nil
+	}
+	codeHash, err := ibs.GetCodeHash(address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntraBlockStateFailed, err)
+	}
+	slot.SetBytes(codeHash.Bytes())
+	return nil, nil
+}
+
+// opReturnDataCopyEOF - RETURNDATACOPY of EOF code pads out of bounds return data with zeros (EIP-7069)
+func opReturnDataCopyEOF(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		memOffset  = scope.Stack.pop()
+		dataOffset = scope.Stack.pop()
+		length     = scope.Stack.pop()
+	)
+	scope.Memory.Set(memOffset.Uint64(), length.Uint64(), getDataBig(interpreter.returnData, &dataOffset, length.Uint64()))
+	return nil, nil
+}
+
+// relativeJump - moves pc to the destination of relative jump, which ends at immediatesEnd. The interpreter loop
+// increments pc after the instruction, so it's set to the destination - 1.
+func relativeJump(pc *uint64, immediatesEnd uint64, offset []byte) {
+	*pc = uint64(int64(immediatesEnd)+int64(int16(binary.BigEndian.Uint16(offset)))) - 1
+}
+
+func opRjump(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	relativeJump(pc, *pc+3, scope.Contract.Code[*pc+1:])
+	return nil, nil
+}
+
+func opRjumpi(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	cond := scope.Stack.pop()
+	if cond.IsZero() {
+		*pc += 2
+		return nil, nil
+	}
+	relativeJump(pc, *pc+3, scope.Contract.Code[*pc+1:])
+	return nil, nil
+}
+
+func opRjumpv(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	code := scope.Contract.Code
+	maxIndex := uint64(code[*pc+1])
+	immediatesEnd := *pc + 2 + (maxIndex+1)*2
+	idx := scope.Stack.pop()
+	if i, overflow := idx.Uint64WithOverflow(); !overflow && i <= maxIndex {
+		relativeJump(pc, immediatesEnd, code[*pc+2+i*2:])
+	} else {
+		*pc = immediatesEnd - 1
+	}
+	return nil, nil
+}
+
+// checkSectionStack - stack must have room for max stack increase of the code section being called or jumped to
+func checkSectionStack(scope *ScopeContext, section uint16) error {
+	maxStackIncrease := int(scope.Contract.container.types[section].maxStackIncrease)
+	if scope.Stack.len()+maxStackIncrease > int(params.StackLimit) {
+		return &ErrStackOverflow{stackLen: scope.Stack.len(), limit: int(params.StackLimit) - maxStackIncrease}
+	}
+	return nil
+}
+
+func opCallf(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	section := binary.BigEndian.Uint16(scope.Contract.Code[*pc+1:])
+	if err := checkSectionStack(scope, section); err != nil {
+		return nil, err
+	}
+	if len(scope.Contract.returnStack) >= eofReturnStackSize {
+		return nil, ErrReturnStackExceeded
+	}
+	scope.Contract.returnStack = append(scope.Contract.returnStack, eofReturn{section: scope.Contract.codeSection, pc: *pc + 3})
+	scope.Contract.setCodeSection(section)
+	*pc = math.MaxUint64 // incremented to 0 by the interpreter loop
+	return nil, nil
+}
+
+func opRetf(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	returnStack := scope.Contract.returnStack
+	ret := returnStack[len(returnStack)-1]
+	scope.Contract.returnStack = returnStack[:len(returnStack)-1]
+	scope.Contract.setCodeSection(ret.section)
+	*pc = ret.pc - 1
+	return nil, nil
+}
+
+func opJumpf(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	section := binary.BigEndian.Uint16(scope.Contract.Code[*pc+1:])
+	if err := checkSectionStack(scope, section); err != nil {
+		return nil, err
+	}
+	scope.Contract.setCodeSection(section)
+	*pc = math.MaxUint64 // incremented to 0 by the interpreter loop
+	return nil, nil
+}
+
+func opDupN(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	scope.Stack.dup(int(scope.Contract.Code[*pc+1]) + 1)
+	*pc += 1
+	return nil, nil
+}
+
+func opSwapN(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	scope.Stack.swap(int(scope.Contract.Code[*pc+1]) + 1)
+	*pc += 1
+	return nil, nil
+}
+
+func opExchange(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	imm := scope.Contract.Code[*pc+1]
+	n, m := int(imm>>4)+1, int(imm&0x0f)+1
+	scope.Stack.exchange(n, n+m)
+	*pc += 1
+	return nil, nil
+}
+
+func opDataLoad(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	offset := scope.Stack.peek()
+	offset.SetBytes32(getDataBig(scope.Contract.container.data, offset, 32))
+	return nil, nil
+}
+
+func opDataLoadN(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	offset := binary.BigEndian.Uint16(scope.Contract.Code[*pc+1:])
+	scope.Stack.push(new(uint256.Int).SetBytes32(getData(scope.Contract.container.data, uint64(offset), 32)))
+	*pc += 2
+	return nil, nil
+}
+
+func opDataSize(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	scope.Stack.push(new(uint256.Int).SetUint64(uint64(len(scope.Contract.container.data))))
+	return nil, nil
+}
+
+func opDataCopy(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		memOffset  = scope.Stack.pop()
+		dataOffset = scope.Stack.pop()
+		length     = scope.Stack.pop()
+	)
+	scope.Memory.Set(memOffset.Uint64(), length.Uint64(), getDataBig(scope.Contract.container.data, &dataOffset, length.Uint64()))
+	return nil, nil
+}
+
+func opReturnDataLoad(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	offset := scope.Stack.peek()
+	offset.SetBytes32(getDataBig(interpreter.returnData, offset, 32))
+	return nil, nil
+}
+
+func memoryExtCall(stack *Stack) (uint64, bool) {
+	return calcMemSize64(stack.Back(1), stack.Back(2))
+}
+
+func memoryEOFCreate(stack *Stack) (uint64, bool) {
+	return calcMemSize64(stack.Back(2), stack.Back(3))
+}
+
+// gasExtDelegateCall - dynamic gas of EXTDELEGATECALL and EXTSTATICCALL: memory expansion and cold account access.
+// Gas of the callee is not known in advance, it's calculated by the instruction from the gas left.
+func gasExtDelegateCall(evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+	gas, err := memoryGasCost(mem, memorySize)
+	if err != nil {
+		return 0, err
+	}
+	if evm.IntraBlockState().AddAddressToAccessList(stack.Back(0).Bytes20()) {
+		var overflow bool
+		if gas, overflow = math.SafeAdd(gas, params.ColdAccountAccessCostEIP2929-params.WarmStorageReadCostEIP2929); overflow {
+			return 0, ErrGasUintOverflow
+		}
+	}
+	return gas, nil
+}
+
+// gasExtCall - dynamic gas of EXTDELEGATECALL plus value transfer and creation of the target account
+func gasExtCall(evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+	gas, err := gasExtDelegateCall(evm, contract, stack, mem, memorySize)
+	if err != nil || stack.Back(3).IsZero() {
+		return gas, err
+	}
+	gas += params.CallValueTransferGas
+	empty, err := evm.IntraBlockState().Empty(stack.Back(0).Bytes20())
+	if err != nil {
+		return 0, err
+	}
+	if empty {
+		gas += params.CallNewAccountGas
+	}
+	return gas, nil
+}
+
+func opExtCall(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	stack := scope.Stack
+	addr, inOffset, inSize, value := stack.pop(), stack.pop(), stack.pop(), stack.pop()
+	if !value.IsZero() && interpreter.readOnly {
+		return nil, ErrWriteProtection
+	}
+	return nil, extCall(EXTCALL, interpreter, scope, &addr, &inOffset, &inSize, &value)
+}
+
+func opExtDelegateCall(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	stack := scope.Stack
+	addr, inOffset, inSize := stack.pop(), stack.pop(), stack.pop()
+	return nil, extCall(EXTDELEGATECALL, interpreter, scope, &addr, &inOffset, &inSize, nil)
+}
+
+func opExtStaticCall(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	stack := scope.Stack
+	addr, inOffset, inSize := stack.pop(), stack.pop(), stack.pop()
+	return nil, extCall(EXTSTATICCALL, interpreter, scope, &addr, &inOffset, &inSize, new(uint256.Int))
+}
+
+// extCall implements EXTCALL, EXTDELEGATECALL and EXTSTATICCALL (EIP-7069). The callee gets all gas left but
+// max(1/64 of it, ExtCallMinRetainedGas). Pushes status: 0 - success, 1 - revert or light failure, which doesn't
+// consume gas (call depth, insufficient balance, too little gas for the callee, EXTDELEGATECALL to legacy code),
+// 2 - failure.
+func extCall(typ OpCode, interpreter *EVMInterpreter, scope *ScopeContext, addr, inOffset, inSize, value *uint256.Int) error {
+	if addr.BitLen() > 8*length.Addr {
+		return errInvalidEOFCallTarget
+	}
+	var (
+		evm    = interpreter.evm
+		tracer = evm.Config().Tracer
+		toAddr = common.Address(addr.Bytes20())
+		args   = scope.Memory.GetPtr(inOffset.Uint64(), inSize.Uint64())
+		gas    = scope.Contract.Gas
+		status = addr // reuse for the result
+	)
+	interpreter.returnData = nil
+	if retained := max(gas/64, params.ExtCallMinRetainedGas); gas > retained {
+		gas -= retained
+	} else {
+		gas = 0
+	}
+	lightFailure, err := extCallLightFailure(typ, interpreter, scope, toAddr, value, gas)
+	if err != nil {
+		return err
+	}
+	if lightFailure {
+		status.SetOne()
+		scope.Stack.push(status)
+		return nil
+	}
+
+	scope.Contract.UseGas(gas, tracer, tracing.GasChangeCallOpCode)
+	ret, returnGas, err := evm.call(typ, scope.Contract, toAddr, args, gas, value, false /* bailout */)
+	switch {
+	case err == nil:
+		status.Clear()
+	case errors.Is(err, ErrExecutionReverted):
+		status.SetOne()
+	default:
+		status.SetUint64(2)
+	}
+	scope.Stack.push(status)
+	scope.Contract.RefundGas(returnGas, tracer, tracing.GasChangeCallLeftOverRefunded)
+	interpreter.returnData = ret
+	return nil
+}
+
+func extCallLightFailure(typ OpCode, interpreter *EVMInterpreter, scope *ScopeContext, toAddr common.Address, value *uint256.Int, gas uint64) (bool, error) {
+	if gas < params.ExtCallMinCalleeGas || interpreter.depth > int(params.CallCreateDepth) {
+		return true, nil
+	}
+	ibs := interpreter.evm.IntraBlockState()
+	if value != nil && !value.IsZero() {
+		canTransfer, err := interpreter.evm.Context.CanTransfer(ibs, scope.Contract.Address(), value)
+		if err != nil || !canTransfer {
+			return true, err
+		}
+	}
+	if typ == EXTDELEGATECALL {
+		code, err := ibs.ResolveCode(toAddr)
+		if err != nil {
+			return false, fmt.Errorf("%w: %w", ErrIntraBlockStateFailed, err)
+		}
+		return !HasEOFMagic(code), nil
+	}
+	return false, nil
+}
+
+func opEOFCreate(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	if interpreter.readOnly {
+		return nil, ErrWriteProtection
+	}
+	var (
+		idx          = scope.Contract.Code[*pc+1]
+		value        = scope.Stack.pop()
+		salt         = scope.Stack.pop()
+		offset, size = scope.Stack.pop(), scope.Stack.peek()
+		input        = scope.Memory.GetCopy(offset.Uint64(), size.Uint64())
+		gas          = scope.Contract.Gas
+	)
+	*pc += 1
+
+	gas -= gas / 64
+	scope.Contract.UseGas(gas, interpreter.evm.Config().Tracer, tracing.GasChangeCallContractCreation2)
+	res, addr, returnGas, suberr := interpreter.evm.EOFCreate(scope.Contract, scope.Contract.container.subContainers[idx], input, gas, &value, &salt)
+	// reuse size for the result
+	if suberr != nil {
+		size.Clear()
+	} else {
+		size.SetBytes(addr.Bytes())
+	}
+	scope.Contract.RefundGas(returnGas, interpreter.evm.config.Tracer, tracing.GasChangeCallLeftOverRefunded)
+
+	if suberr == ErrExecutionReverted {
+		interpreter.returnData = res // set REVERT data to return data buffer
+		return nil, nil
+	}
+	interpreter.returnData = nil // clear dirty return data buffer
+	return nil, nil
+}
+
+func opReturnContract(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	idx := scope.Contract.Code[*pc+1]
+	offset, size := scope.Stack.pop(), scope.Stack.pop()
+	deployed, err := scope.Contract.container.deployContainer(int(idx), scope.Memory.GetPtr(offset.Uint64(), size.Uint64()))
+	if err != nil {
+		return nil, err
+	}
+	return deployed, errStopToken
+}
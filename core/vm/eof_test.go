@@ -0,0 +1,184 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/crypto"
+)
+
+type eofTestSection struct {
+	inputs, outputs  uint8
+	maxStackIncrease uint16
+	code             []byte
+}
+
+// makeEOF - EOF container with given sections, declared data size can differ from the size of data
+func makeEOF(sections []eofTestSection, containers [][]byte, data []byte, dataSize int) []byte {
+	b := []byte{0xef, 0x00, eofVersion1, eofKindTypes}
+	b = binary.BigEndian.AppendUint16(b, uint16(len(sections)*eofTypeSize))
+	b = append(b, eofKindCode)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(sections)))
+	for _, s := range sections {
+		b = binary.BigEndian.AppendUint16(b, uint16(len(s.code)))
+	}
+	if len(containers) > 0 {
+		b = append(b, eofKindContainer)
+		b = binary.BigEndian.AppendUint16(b, uint16(len(containers)))
+		for _, c := range containers {
+			b = binary.BigEndian.AppendUint32(b, uint32(len(c)))
+		}
+	}
+	b = append(b, eofKindData)
+	b = binary.BigEndian.AppendUint16(b, uint16(dataSize))
+	b = append(b, eofTerminator)
+	for _, s := range sections {
+		b = append(b, s.inputs, s.outputs)
+		b = binary.BigEndian.AppendUint16(b, s.maxStackIncrease)
+	}
+	for _, s := range sections {
+		b = append(b, s.code...)
+	}
+	for _, c := range containers {
+		b = append(b, c...)
+	}
+	return append(b, data...)
+}
+
+func eofCode(maxStackIncrease uint16, code ...byte) []eofTestSection {
+	return []eofTestSection{{outputs: eofNonReturning, maxStackIncrease: maxStackIncrease, code: code}}
+}
+
+func TestValidateEOF(t *testing.T) {
+	t.Parallel()
+	stop := makeEOF(eofCode(0, byte(STOP)), nil, nil, 0)
+	returnContract := makeEOF(eofCode(2, byte(PUSH0), byte(PUSH0), byte(RETURNCONTRACT), 0), [][]byte{stop}, nil, 0)
+
+	for _, tc := range []struct {
+		name string
+		code []byte
+		kind ContainerKind
+		err  error
+	}{
+		{name: "stop", code: stop},
+		{name: "stop in initcode", code: stop, kind: InitcodeContainer, err: errEOFIncompatibleKind},
+		{name: "no magic", code: []byte{0xef, 0x01, 0x01}, err: errEOFInvalidMagic},
+		{name: "version", code: append([]byte{0xef, 0x00, 0x02}, stop[3:]...), err: errEOFInvalidVersion},
+		{name: "truncated header", code: stop[:10], err: errEOFIncompleteContainer},
+		{name: "data", code: makeEOF(eofCode(0, byte(STOP)), nil, []byte{1, 2}, 2)},
+		{name: "trailing bytes", code: makeEOF(eofCode(0, byte(STOP)), nil, []byte{1, 2}, 1), err: errEOFTrailingBytes},
+		{name: "truncated data", code: makeEOF(eofCode(0, byte(STOP)), nil, []byte{1}, 2), err: errEOFTruncatedData},
+		{name: "section 0 returning", code: makeEOF([]eofTestSection{{code: []byte{byte(RETF)}}}, nil, nil, 0), err: errEOFInvalidSection0Type},
+		{name: "legacy jump", code: makeEOF(eofCode(1, byte(PUSH0), byte(JUMP)), nil, nil, 0), err: errEOFUndefinedInstruction},
+		{name: "undefined", code: makeEOF(eofCode(0, 0x0c), nil, nil, 0), err: errEOFUndefinedInstruction},
+		{name: "truncated push", code: makeEOF(eofCode(1, byte(PUSH2), 0), nil, nil, 0), err: errEOFTruncatedInstruction},
+		{name: "no terminator", code: makeEOF(eofCode(1, byte(PUSH0)), nil, nil, 0), err: errEOFNoTerminator},
+		{name: "unreachable", code: makeEOF(eofCode(0, byte(STOP), byte(STOP)), nil, nil, 0), err: errEOFUnreachableCode},
+		{name: "underflow", code: makeEOF(eofCode(1, byte(PUSH0), byte(ADD), byte(STOP)), nil, nil, 0), err: errEOFStackUnderflow},
+		{name: "max stack", code: makeEOF(eofCode(2, byte(PUSH0), byte(STOP)), nil, nil, 0), err: errEOFInvalidMaxStack},
+		{name: "rjumpi", code: makeEOF(eofCode(1, byte(PUSH0), byte(RJUMPI), 0, 1, byte(INVALID), byte(STOP)), nil, nil, 0)},
+		{name: "rjump into immediate", code: makeEOF(eofCode(1, byte(RJUMP), 0, 1, byte(PUSH1), 0, byte(STOP)), nil, nil, 0), err: errEOFInvalidJumpDest},
+		{name: "rjump out of code", code: makeEOF(eofCode(0, byte(RJUMP), 0, 1, byte(STOP)), nil, nil, 0), err: errEOFInvalidJumpDest},
+		{name: "loop", code: makeEOF(eofCode(1, byte(PUSH0), byte(RJUMPI), 0xff, 0xfc, byte(STOP)), nil, nil, 0)},
+		{name: "growing loop", code: makeEOF(eofCode(1, byte(PUSH0), byte(RJUMP), 0xff, 0xfc), nil, nil, 0), err: errEOFBackwardJumpHeight},
+		{name: "rjumpv", code: makeEOF(eofCode(1, byte(PUSH0), byte(RJUMPV), 1, 0, 0, 0, 1, byte(STOP), byte(STOP)), nil, nil, 0)},
+		{name: "callf", code: makeEOF([]eofTestSection{
+			{outputs: eofNonReturning, maxStackIncrease: 2, code: []byte{byte(PUSH0), byte(PUSH0), byte(CALLF), 0, 1, byte(POP), byte(STOP)}},
+			{inputs: 2, outputs: 1, code: []byte{byte(ADD), byte(RETF)}},
+		}, nil, nil, 0)},
+		{name: "callf to non-returning", code: makeEOF([]eofTestSection{
+			{outputs: eofNonReturning, code: []byte{byte(CALLF), 0, 1, byte(STOP)}},
+			{outputs: eofNonReturning, code: []byte{byte(STOP)}},
+		}, nil, nil, 0), err: errEOFCallfToNonReturning},
+		{name: "unreachable section", code: makeEOF([]eofTestSection{
+			{outputs: eofNonReturning, code: []byte{byte(STOP)}},
+			{outputs: eofNonReturning, code: []byte{byte(STOP)}},
+		}, nil, nil, 0), err: errEOFUnreachableSection},
+		{name: "jumpf to returning from non-returning", code: makeEOF([]eofTestSection{
+			{outputs: eofNonReturning, code: []byte{byte(JUMPF), 0, 1}},
+			{outputs: 0, code: []byte{byte(RETF)}},
+		}, nil, nil, 0), err: errEOFIncompatibleOutputs},
+		{name: "returning without retf", code: makeEOF([]eofTestSection{
+			{outputs: eofNonReturning, code: []byte{byte(CALLF), 0, 1, byte(STOP)}},
+			{outputs: 0, code: []byte{byte(STOP)}},
+		}, nil, nil, 0), err: errEOFInvalidNonReturning},
+		{name: "retf outputs", code: makeEOF([]eofTestSection{
+			{outputs: eofNonReturning, maxStackIncrease: 1, code: []byte{byte(CALLF), 0, 1, byte(STOP)}},
+			{outputs: 1, maxStackIncrease: 2, code: []byte{byte(PUSH0), byte(PUSH0), byte(RETF)}},
+		}, nil, nil, 0), err: errEOFStackHigherThanOutput},
+		{name: "dataloadn", code: makeEOF(eofCode(1, byte(DATALOADN), 0, 1, byte(STOP)), nil, make([]byte, 33), 33)},
+		{name: "dataloadn out of data", code: makeEOF(eofCode(1, byte(DATALOADN), 0, 2, byte(STOP)), nil, make([]byte, 33), 33), err: errEOFInvalidDataIndex},
+		{name: "eofcreate", code: makeEOF(eofCode(4, byte(PUSH0), byte(PUSH0), byte(PUSH0), byte(PUSH0), byte(EOFCREATE), 0, byte(STOP)), [][]byte{returnContract}, nil, 0)},
+		{name: "returncontract", code: returnContract, kind: InitcodeContainer},
+		{name: "returncontract in runtime", code: returnContract, err: errEOFIncompatibleKind},
+		{name: "eofcreate of runtime", code: makeEOF(eofCode(4, byte(PUSH0), byte(PUSH0), byte(PUSH0), byte(PUSH0), byte(EOFCREATE), 0, byte(STOP)), [][]byte{stop}, nil, 0), err: errEOFIncompatibleKind},
+		{name: "orphan container", code: makeEOF(eofCode(0, byte(STOP)), [][]byte{stop}, nil, 0), err: errEOFOrphanContainer},
+		{name: "truncated data of deployed container", code: makeEOF(eofCode(2, byte(PUSH0), byte(PUSH0), byte(RETURNCONTRACT), 0),
+			[][]byte{makeEOF(eofCode(0, byte(STOP)), nil, nil, 32)}, nil, 0), kind: InitcodeContainer},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ValidateEOF(tc.code, tc.kind)
+			if tc.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestDeployContainer(t *testing.T) {
+	t.Parallel()
+	sub := makeEOF(eofCode(0, byte(STOP)), nil, []byte{1}, 3)
+	c, err := ParseEOF(makeEOF(eofCode(2, byte(PUSH0), byte(PUSH0), byte(RETURNCONTRACT), 0), [][]byte{sub}, nil, 0))
+	require.NoError(t, err)
+
+	deployed, err := c.deployContainer(0, []byte{2, 3, 4})
+	require.NoError(t, err)
+	require.Equal(t, makeEOF(eofCode(0, byte(STOP)), nil, []byte{1, 2, 3, 4}, 4), deployed)
+	_, err = ValidateEOF(deployed, RuntimeContainer)
+	require.NoError(t, err)
+
+	_, err = c.deployContainer(0, []byte{2})
+	require.ErrorIs(t, err, errEOFAuxDataSize)
+}
+
+func TestEOFCache(t *testing.T) {
+	t.Parallel()
+	c := NewEOFCache(16)
+	code := makeEOF(eofCode(0, byte(STOP)), nil, nil, 0)
+	hash := crypto.Keccak256Hash(code)
+
+	first, err := c.parse(hash, code)
+	require.NoError(t, err)
+	second, err := c.parse(hash, code)
+	require.NoError(t, err)
+	require.Same(t, first, second)
+
+	// parse errors are cached too
+	invalid := []byte{0xEF, 0x00, 0x02}
+	invalidHash := crypto.Keccak256Hash(invalid)
+	_, err = c.parse(invalidHash, invalid)
+	require.ErrorIs(t, err, errEOFInvalidVersion)
+	_, err = c.parse(invalidHash, invalid)
+	require.ErrorIs(t, err, errEOFInvalidVersion)
+	require.Equal(t, 2, c.Len())
+}
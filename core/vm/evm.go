@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"sync/atomic"
+	"time"
 
 	"github.com/erigontech/erigon-lib/common/empty"
 	"github.com/erigontech/erigon/core/state"
@@ -59,8 +60,10 @@ func (evm *EVM) precompile(addr common.Address) (PrecompiledContract, bool) {
 	default:
 		precompiles = PrecompiledContractsHomestead
 	}
-	p, ok := precompiles[addr]
-	return p, ok
+	if p, ok := precompiles[addr]; ok {
+		return p, true
+	}
+	return customPrecompileAt(evm.chainRules, addr)
 }
 
 // EVM is the Ethereum Virtual Machine base object and provides
@@ -95,6 +98,9 @@ type EVM struct {
 	// available gas is calculated in gasCall* according to the 63/64 rule and later
 	// applied in opCall*.
 	callGasTemp uint64
+	// profiledTime is the total time of call frames and precompiles run under Config.Profiler,
+	// used to exclude the time of callees from the time of calling opcodes
+	profiledTime time.Duration
 }
 
 // NewEVM returns a new EVM. The returned EVM is not thread safe and should
@@ -114,7 +120,10 @@ func NewEVM(blockCtx evmtypes.BlockContext, txCtx evmtypes.TxContext, ibs *state
 		chainRules:      chainConfig.Rules(blockCtx.BlockNumber, blockCtx.Time),
 	}
 	if evm.config.JumpDestCache == nil {
-		evm.config.JumpDestCache = NewJumpDestCache(JumpDestCacheLimit)
+		evm.config.JumpDestCache = SharedJumpDestCache()
+	}
+	if evm.config.EOFCache == nil {
+		evm.config.EOFCache = SharedEOFCache()
 	}
 
 	evm.interpreter = NewEVMInterpreter(evm, vmConfig)
@@ -144,6 +153,9 @@ func (evm *EVM) ResetBetweenBlocks(blockCtx evmtypes.BlockContext, txCtx evmtype
 	if vmConfig.JumpDestCache == nil && evm.config.JumpDestCache != nil {
 		vmConfig.JumpDestCache = evm.config.JumpDestCache
 	}
+	if vmConfig.EOFCache == nil && evm.config.EOFCache != nil {
+		vmConfig.EOFCache = evm.config.EOFCache
+	}
 	evm.config = vmConfig
 	evm.chainRules = chainRules
 
@@ -190,9 +202,9 @@ func (evm *EVM) call(typ OpCode, caller ContractRef, addr common.Address, input
 	// Invoke tracer hooks that signal entering/exiting a call frame
 	if evm.Config().Tracer != nil {
 		v := value
-		if typ == STATICCALL {
+		if typ == STATICCALL || typ == EXTSTATICCALL {
 			v = nil
-		} else if typ == DELEGATECALL {
+		} else if typ == DELEGATECALL || typ == EXTDELEGATECALL {
 			// NOTE: caller must, at all times be a contract. It should never happen
 			// that caller is something other than a Contract.
 			parent := caller.(*Contract)
@@ -212,7 +224,7 @@ func (evm *EVM) call(typ OpCode, caller ContractRef, addr common.Address, input
 	if depth > int(params.CallCreateDepth) {
 		return nil, gas, ErrDepth
 	}
-	if typ == CALL || typ == CALLCODE {
+	if typ == CALL || typ == CALLCODE || typ == EXTCALL {
 		// Fail if we're trying to transfer more than the available balance
 		canTransfer, err := evm.Context.CanTransfer(evm.intraBlockState, caller.Address(), value)
 		if err != nil {
@@ -227,7 +239,7 @@ func (evm *EVM) call(typ OpCode, caller ContractRef, addr common.Address, input
 
 	snapshot := evm.intraBlockState.Snapshot()
 
-	if typ == CALL {
+	if typ == CALL || typ == EXTCALL {
 		exist, err := evm.intraBlockState.Exist(addr)
 		if err != nil {
 			return nil, 0, fmt.Errorf("%w: %w", ErrIntraBlockStateFailed, err)
@@ -239,7 +251,7 @@ func (evm *EVM) call(typ OpCode, caller ContractRef, addr common.Address, input
 			evm.intraBlockState.CreateAccount(addr, false)
 		}
 		evm.Context.Transfer(evm.intraBlockState, caller.Address(), addr, value, bailout)
-	} else if typ == STATICCALL {
+	} else if typ == STATICCALL || typ == EXTSTATICCALL {
 		// We do an AddBalance of zero here, just in order to trigger a touch.
 		// This doesn't matter on Mainnet, where all empties are gone at the time of Byzantium,
 		// but is the correct thing to do and matters on other networks, in tests, and potential
@@ -249,7 +261,7 @@ func (evm *EVM) call(typ OpCode, caller ContractRef, addr common.Address, input
 
 	// It is allowed to call precompiles, even via delegatecall
 	if isPrecompile {
-		ret, gas, err = RunPrecompiledContract(p, input, gas, evm.Config().Tracer)
+		ret, gas, err = evm.runPrecompile(typ, p, caller, addr, input, gas, value)
 	} else if len(code) == 0 {
 		// If the account has no code, we can abort here
 		// The depth-check is already done, and precompiles handled above
@@ -269,17 +281,23 @@ func (evm *EVM) call(typ OpCode, caller ContractRef, addr common.Address, input
 		var contract *Contract
 		if typ == CALLCODE {
 			contract = NewContract(caller, caller.Address(), value, gas, evm.config.SkipAnalysis, evm.config.JumpDestCache)
-		} else if typ == DELEGATECALL {
+		} else if typ == DELEGATECALL || typ == EXTDELEGATECALL {
 			contract = NewContract(caller, caller.Address(), value, gas, evm.config.SkipAnalysis, evm.config.JumpDestCache).AsDelegate()
 		} else {
 			contract = NewContract(caller, addrCopy, value, gas, evm.config.SkipAnalysis, evm.config.JumpDestCache)
 		}
 		contract.SetCallCode(&addrCopy, codeHash, code)
-		readOnly := false
-		if typ == STATICCALL {
-			readOnly = true
+		if evm.chainRules.IsEOF && HasEOFMagic(code) {
+			// EOF code is validated on deployment, only containers from genesis can fail here
+			var container *Container
+			if container, err = evm.config.EOFCache.parse(codeHash, code); err == nil {
+				contract.setContainer(container)
+			}
+		}
+		if err == nil {
+			readOnly := typ == STATICCALL || typ == EXTSTATICCALL
+			ret, err = evm.interpreter.Run(contract, input, readOnly)
 		}
-		ret, err = evm.interpreter.Run(contract, input, readOnly)
 		gas = contract.Gas
 	}
 	// When an error was returned by the EVM or when setting the creation code
@@ -353,11 +371,11 @@ func (c *codeAndHash) Hash() common.Hash {
 }
 
 func (evm *EVM) OverlayCreate(caller ContractRef, codeAndHash *codeAndHash, gas uint64, value *uint256.Int, address common.Address, typ OpCode, incrementNonce bool) ([]byte, common.Address, uint64, error) {
-	return evm.create(caller, codeAndHash, gas, value, address, typ, incrementNonce, false)
+	return evm.create(caller, codeAndHash, nil, gas, value, address, typ, incrementNonce, false)
 }
 
 // create creates a new contract using code as deployment code.
-func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gasRemaining uint64, value *uint256.Int, address common.Address, typ OpCode, incrementNonce bool, bailout bool) (ret []byte, createAddress common.Address, leftOverGas uint64, err error) {
+func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, input []byte, gasRemaining uint64, value *uint256.Int, address common.Address, typ OpCode, incrementNonce bool, bailout bool) (ret []byte, createAddress common.Address, leftOverGas uint64, err error) {
 	depth := evm.interpreter.Depth()
 
 	if evm.Config().Tracer != nil {
@@ -433,7 +451,16 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gasRemainin
 		return nil, address, gasRemaining, nil
 	}
 
-	ret, err = evm.interpreter.Run(contract, nil, false)
+	if typ == EOFCREATE {
+		// initcontainer has been validated together with the container of the creator
+		var container *Container
+		if container, err = evm.config.EOFCache.parse(codeAndHash.Hash(), codeAndHash.code); err == nil {
+			contract.setContainer(container)
+		}
+	}
+	if err == nil {
+		ret, err = evm.interpreter.Run(contract, input, false)
+	}
 
 	// EIP-170: Contract code size limit
 	if err == nil && evm.chainRules.IsSpuriousDragon && len(ret) > evm.maxCodeSize() {
@@ -444,8 +471,8 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gasRemainin
 		}
 	}
 
-	// Reject code starting with 0xEF if EIP-3541 is enabled.
-	if err == nil && evm.chainRules.IsLondon && len(ret) >= 1 && ret[0] == 0xEF {
+	// Reject code starting with 0xEF if EIP-3541 is enabled. EOF initcode returns EOF container by RETURNCONTRACT.
+	if err == nil && evm.chainRules.IsLondon && typ != EOFCREATE && len(ret) >= 1 && ret[0] == 0xEF {
 		err = ErrInvalidCode
 	}
 	// if the contract creation ran successfully and no errors were returned
@@ -489,7 +516,7 @@ func (evm *EVM) Create(caller ContractRef, code []byte, gasRemaining uint64, end
 		return nil, common.Address{}, 0, err
 	}
 	contractAddr = crypto.CreateAddress(caller.Address(), nonce)
-	return evm.create(caller, &codeAndHash{code: code}, gasRemaining, endowment, contractAddr, CREATE, true /* incrementNonce */, bailout)
+	return evm.create(caller, &codeAndHash{code: code}, nil, gasRemaining, endowment, contractAddr, CREATE, true /* incrementNonce */, bailout)
 }
 
 // Create2 creates a new contract using code as deployment code.
@@ -500,13 +527,22 @@ func (evm *EVM) Create(caller ContractRef, code []byte, gasRemaining uint64, end
 func (evm *EVM) Create2(caller ContractRef, code []byte, gasRemaining uint64, endowment *uint256.Int, salt *uint256.Int, bailout bool) (ret []byte, contractAddr common.Address, leftOverGas uint64, err error) {
 	codeAndHash := &codeAndHash{code: code}
 	contractAddr = crypto.CreateAddress2(caller.Address(), salt.Bytes32(), codeAndHash.Hash().Bytes())
-	return evm.create(caller, codeAndHash, gasRemaining, endowment, contractAddr, CREATE2, true /* incrementNonce */, bailout)
+	return evm.create(caller, codeAndHash, nil, gasRemaining, endowment, contractAddr, CREATE2, true /* incrementNonce */, bailout)
+}
+
+// EOFCreate creates a new contract running EOF initcontainer with input as calldata (EIP-7620). Its address is
+// keccak256(0xff ++ sender ++ salt)[12:], sender being left-padded to 32 bytes.
+func (evm *EVM) EOFCreate(caller ContractRef, initContainer []byte, input []byte, gasRemaining uint64, endowment *uint256.Int, salt *uint256.Int) (ret []byte, contractAddr common.Address, leftOverGas uint64, err error) {
+	sender := caller.Address()
+	saltBytes := salt.Bytes32()
+	contractAddr = common.BytesToAddress(crypto.Keccak256([]byte{0xff}, common.LeftPadBytes(sender[:], 32), saltBytes[:])[12:])
+	return evm.create(caller, &codeAndHash{code: initContainer}, input, gasRemaining, endowment, contractAddr, EOFCREATE, true /* incrementNonce */, false /* bailout */)
 }
 
 // SysCreate is a special (system) contract creation methods for genesis constructors.
 // Unlike the normal Create & Create2, it doesn't increment caller's nonce.
 func (evm *EVM) SysCreate(caller ContractRef, code []byte, gas uint64, endowment *uint256.Int, contractAddr common.Address) (ret []byte, leftOverGas uint64, err error) {
-	ret, _, leftOverGas, err = evm.create(caller, &codeAndHash{code: code}, gas, endowment, contractAddr, CREATE, false /* incrementNonce */, false)
+	ret, _, leftOverGas, err = evm.create(caller, &codeAndHash{code: code}, nil, gas, endowment, contractAddr, CREATE, false /* incrementNonce */, false)
 	return
 }
 
@@ -0,0 +1,174 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+)
+
+// GasRules override the gas schedule of the fork's jump table, to evaluate gas changes by re-executing history.
+// They are described in JSON, keyed by opcode name:
+//
+//	{
+//	  "SLOAD": {"constantGas": 800},
+//	  "EXP":   {"constantGas": 10, "dynamicGas": "gasExpFrontier"},
+//	  "SSTORE": {"dynamicGas": "gasSStoreEIP2200"}
+//	}
+//
+// dynamicGas is one of the gas functions of the interpreter, see DynamicGasFuncs. "none" removes dynamic gas,
+// but memory expansion is still charged for opcodes which access memory.
+type GasRules struct {
+	rules  map[OpCode]GasRule
+	tables sync.Map // *JumpTable of a fork -> *JumpTable with the rules applied
+}
+
+// GasRule - gas of an opcode. Empty fields keep the gas of the fork.
+type GasRule struct {
+	ConstantGas *uint64 `json:"constantGas,omitempty"`
+	DynamicGas  string  `json:"dynamicGas,omitempty"`
+}
+
+const noDynamicGas = "none"
+
+var dynamicGasFuncs = map[string]gasFunc{
+	"gasCall":                gasCall,
+	"gasCallCode":            gasCallCode,
+	"gasDelegateCall":        gasDelegateCall,
+	"gasStaticCall":          gasStaticCall,
+	"gasCallEIP2929":         gasCallEIP2929,
+	"gasCallCodeEIP2929":     gasCallCodeEIP2929,
+	"gasDelegateCallEIP2929": gasDelegateCallEIP2929,
+	"gasStaticCallEIP2929":   gasStaticCallEIP2929,
+	"gasCallEIP7702":         gasCallEIP7702,
+	"gasCallCodeEIP7702":     gasCallCodeEIP7702,
+	"gasDelegateCallEIP7702": gasDelegateCallEIP7702,
+	"gasStaticCallEIP7702":   gasStaticCallEIP7702,
+	"gasCreate":              gasCreate,
+	"gasCreate2":             gasCreate2,
+	"gasCreateEip3860":       gasCreateEip3860,
+	"gasCreate2Eip3860":      gasCreate2Eip3860,
+	"gasSelfdestruct":        gasSelfdestruct,
+	"gasSelfdestructEIP2929": gasSelfdestructEIP2929,
+	"gasSelfdestructEIP3529": gasSelfdestructEIP3529,
+	"gasSStore":              gasSStore,
+	"gasSStoreEIP2200":       gasSStoreEIP2200,
+	"gasSStoreEIP2929":       gasSStoreEIP2929,
+	"gasSStoreEIP3529":       gasSStoreEIP3529,
+	"gasSLoadEIP2929":        gasSLoadEIP2929,
+	"gasEip2929AccountCheck": gasEip2929AccountCheck,
+	"gasExtCodeCopy":         gasExtCodeCopy,
+	"gasExtCodeCopyEIP2929":  gasExtCodeCopyEIP2929,
+	"gasExpFrontier":         gasExpFrontier,
+	"gasExpEIP160":           gasExpEIP160,
+	"gasKeccak256":           gasKeccak256,
+	"gasCallDataCopy":        gasCallDataCopy,
+	"gasCodeCopy":            gasCodeCopy,
+	"gasReturnDataCopy":      gasReturnDataCopy,
+	"gasMcopy":               gasMcopy,
+	"gasLog0":                makeGasLog(0),
+	"gasLog1":                makeGasLog(1),
+	"gasLog2":                makeGasLog(2),
+	"gasLog3":                makeGasLog(3),
+	"gasLog4":                makeGasLog(4),
+	"pureMemoryGascost":      pureMemoryGascost,
+}
+
+// DynamicGasFuncs returns the names of the dynamic gas functions which GasRules can choose from
+func DynamicGasFuncs() []string {
+	names := make([]string, 0, len(dynamicGasFuncs)+1)
+	for name := range dynamicGasFuncs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return append(names, noDynamicGas)
+}
+
+// ParseGasRules parses and validates JSON gas rules
+func ParseGasRules(data []byte) (*GasRules, error) {
+	var byName map[string]GasRule
+	if err := json.Unmarshal(data, &byName); err != nil {
+		return nil, err
+	}
+	rules := make(map[OpCode]GasRule, len(byName))
+	for name, rule := range byName {
+		op, ok := stringToOp[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown opcode %q", name)
+		}
+		if _, ok := dynamicGasFuncs[rule.DynamicGas]; !ok && rule.DynamicGas != "" && rule.DynamicGas != noDynamicGas {
+			return nil, fmt.Errorf("opcode %s: unknown dynamic gas function %q", name, rule.DynamicGas)
+		}
+		rules[op] = rule
+	}
+	return &GasRules{rules: rules}, nil
+}
+
+// LoadGasRules reads gas rules from a JSON file
+func LoadGasRules(path string) (*GasRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := ParseGasRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// jumpTable returns a copy of the jump table of a fork with the rules applied. Copies are shared, because
+// the interpreter is re-created for every transaction.
+func (r *GasRules) jumpTable(jt *JumpTable) *JumpTable {
+	if jt == nil || len(r.rules) == 0 {
+		return jt
+	}
+	if applied, ok := r.tables.Load(jt); ok {
+		return applied.(*JumpTable)
+	}
+	applied := copyJumpTable(jt)
+	r.apply(applied)
+	actual, _ := r.tables.LoadOrStore(jt, applied)
+	return actual.(*JumpTable)
+}
+
+// apply modifies the jump table in place
+func (r *GasRules) apply(jt *JumpTable) {
+	for op, rule := range r.rules {
+		operation := jt[op]
+		if operation == nil || operation.undefined {
+			continue
+		}
+		if rule.ConstantGas != nil {
+			operation.constantGas = *rule.ConstantGas
+		}
+		switch rule.DynamicGas {
+		case "":
+		case noDynamicGas:
+			if operation.memorySize != nil {
+				operation.dynamicGas = pureMemoryGascost // memory is resized only when there is dynamic gas
+			} else {
+				operation.dynamicGas = nil
+			}
+		default:
+			operation.dynamicGas = dynamicGasFuncs[rule.DynamicGas]
+		}
+	}
+}
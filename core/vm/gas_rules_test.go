@@ -0,0 +1,58 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+)
+
+func TestGasRules(t *testing.T) {
+	t.Parallel()
+	_, err := ParseGasRules([]byte(`{"NOTANOP": {"constantGas": 1}}`))
+	require.ErrorContains(t, err, "unknown opcode")
+	_, err = ParseGasRules([]byte(`{"EXP": {"dynamicGas": "gasUnknown"}}`))
+	require.ErrorContains(t, err, "unknown dynamic gas function")
+
+	rules, err := ParseGasRules([]byte(`{"ADD": {"constantGas": 10}, "EXP": {"dynamicGas": "gasExpFrontier"}, "MSTORE": {"dynamicGas": "none"}}`))
+	require.NoError(t, err)
+
+	jt := rules.jumpTable(&cancunInstructionSet)
+	require.Same(t, jt, rules.jumpTable(&cancunInstructionSet))
+	require.Equal(t, uint64(10), jt[ADD].constantGas)
+	require.Equal(t, GasFastestStep, cancunInstructionSet[ADD].constantGas, "jump table of the fork is not modified")
+	require.NotNil(t, jt[MSTORE].dynamicGas, "memory expansion is still charged")
+
+	// PUSH1 1, PUSH1 2, ADD, PUSH0, MSTORE, STOP
+	code := []byte{byte(PUSH1), 1, byte(PUSH1), 2, byte(ADD), byte(PUSH0), byte(MSTORE), byte(STOP)}
+	run := func(rules *GasRules) uint64 {
+		evm := NewEVM(evmtypes.BlockContext{}, evmtypes.TxContext{}, nil, chain.AllProtocolChanges, Config{GasRules: rules})
+		contract := NewContract(dummyContractRef{}, common.Address{}, new(uint256.Int), 100, false /* skipAnalysis */, NewJumpDestCache(16))
+		contract.SetCallCode(&common.Address{}, crypto.Keccak256Hash(code), code)
+		_, err := evm.Interpreter().Run(contract, nil, false)
+		require.NoError(t, err)
+		return 100 - contract.Gas
+	}
+	require.Equal(t, run(nil)+10-GasFastestStep, run(rules))
+}
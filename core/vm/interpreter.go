@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"hash"
 	"slices"
+	"time"
 
 	"github.com/holiman/uint256"
 
@@ -39,6 +40,7 @@ import (
 type Config struct {
 	Tracer        *tracing.Hooks
 	JumpDestCache *JumpDestCache
+	EOFCache      *EOFCache
 	NoRecursion   bool // Disables call, callcode, delegate call and create
 	NoBaseFee     bool // Forces the EIP-1559 baseFee to 0 (needed for 0 price calls)
 	SkipAnalysis  bool // Whether we can skip jumpdest analysis based on the checked history
@@ -50,6 +52,9 @@ type Config struct {
 
 	ExtraEips []int // Additional EIPS that are to be enabled
 
+	Profiler         *Profiler // Aggregates gas and time per contract and opcode, and per precompile
+	FuseInstructions bool      // Execute common opcode sequences as superinstructions, see superinstructions.go
+	GasRules         *GasRules // Overrides the gas schedule of the fork, see gas_rules.go
 }
 
 func (vmConfig *Config) HasEip3860(rules *chain.Rules) bool {
@@ -136,6 +141,7 @@ type keccakState interface {
 type EVMInterpreter struct {
 	*VM
 	jt    *JumpTable // EVM instruction table
+	eofJt *JumpTable // instruction table of EOF code, nil before the EOF fork
 	depth int
 }
 
@@ -166,8 +172,10 @@ func copyJumpTable(jt *JumpTable) *JumpTable {
 
 // NewEVMInterpreter returns a new instance of the Interpreter.
 func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
-	var jt *JumpTable
+	var jt, eofJt *JumpTable
 	switch {
+	case evm.ChainRules().IsEOF:
+		jt, eofJt = &eofLegacyInstructionSet, &eofInstructionSet
 	case evm.ChainRules().IsPrague:
 		jt = &pragueInstructionSet
 	case evm.ChainRules().IsCancun:
@@ -205,13 +213,22 @@ func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
 			}
 		}
 	}
+	if cfg.GasRules != nil {
+		if len(cfg.ExtraEips) > 0 {
+			cfg.GasRules.apply(jt) // already a copy
+		} else {
+			jt = cfg.GasRules.jumpTable(jt)
+		}
+		eofJt = cfg.GasRules.jumpTable(eofJt)
+	}
 
 	return &EVMInterpreter{
 		VM: &VM{
 			evm: evm,
 			cfg: cfg,
 		},
-		jt: jt,
+		jt:    jt,
+		eofJt: eofJt,
 	}
 }
 
@@ -251,10 +268,24 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 		gasCopy uint64 // for Tracer to log gas remaining before execution
 		logged  bool   // deferred Tracer should ignore already logged steps
 		res     []byte // result of the opcode execution function
+		jt      = in.jt
 		debug   = in.cfg.Tracer != nil && (in.cfg.Tracer.OnOpcode != nil || in.cfg.Tracer.OnGasChange != nil || in.cfg.Tracer.OnFault != nil)
+		// profiling of the frame, see Config.Profiler
+		prof      *frameProfile
+		opStart   time.Time
+		opNested  time.Duration
+		opCallGas uint64
+		// superinstructions of the code, nil if fusion is off
+		fused    fusedCode
+		fusedOps int
 	)
 
 	contract.Input = input
+	if contract.IsEOF() {
+		jt = in.eofJt
+	} else if (in.cfg.FuseInstructions || FuseInstructions) && !debug && in.cfg.Profiler == nil && in.cfg.GasRules == nil && !dbg.TraceInstructions {
+		fused = fusedCodeOf(contract)
+	}
 
 	// Make sure the readOnly is only set if we aren't in readOnly yet.
 	// This makes also sure that the readOnly flag isn't removed for child calls.
@@ -264,6 +295,9 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 	}
 	// Increment the call depth which is restricted to 1024
 	in.depth++
+	if in.cfg.Profiler != nil {
+		prof = in.cfg.Profiler.enterFrame(contract, in.evm.profiledTime)
+	}
 	defer func() {
 		// first: capture data/memory/state/depth/etc... then clenup them
 		if debug && err != nil {
@@ -281,26 +315,45 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 			in.readOnly = false
 		}
 		in.depth--
+		if fusedOps > 0 {
+			mxFusedInstructions.AddInt(fusedOps)
+		}
+		if prof != nil {
+			in.evm.profiledTime = in.cfg.Profiler.exitFrame(prof)
+		}
 	}()
 
 	// The Interpreter main run loop (contextual). This loop runs until either an
 	// explicit STOP, RETURN or SELFDESTRUCT is executed, an error occurred during
 	// the execution of one of the operations or until the done flag is set by the
 	// parent context.
-	steps := 0
+	steps, nextCancelCheck := 0, 1000
 	for {
 		steps++
-		if steps%1000 == 0 && in.evm.Cancelled() {
-			break
+		if steps >= nextCancelCheck {
+			if in.evm.Cancelled() {
+				break
+			}
+			nextCancelCheck = steps + 1000
 		}
 		if debug {
 			// Capture pre-execution values for tracing.
 			logged, pcCopy, gasCopy = false, _pc, contract.Gas
 		}
+		if prof != nil {
+			opStart, opNested = time.Now(), in.evm.profiledTime
+		}
+		if _pc < uint64(len(fused)) && fused[_pc].kind != siNone {
+			if si := fused[_pc]; in.runFused(si, pc, jt, callContext) {
+				steps += int(si.ops) - 1
+				fusedOps += int(si.ops)
+				continue
+			}
+		}
 		// Get the operation from the jump table and validate the stack to ensure there are
 		// enough stack items available to perform the operation.
 		op = contract.GetOp(_pc)
-		operation := in.jt[op]
+		operation := jt[op]
 		cost = operation.constantGas // For tracing
 		// Validate stack
 		if sLen := locStack.len(); sLen < operation.numPop {
@@ -371,7 +424,13 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 		}
 
 		// execute the operation
+		if prof != nil {
+			opCallGas = in.evm.callGasTemp
+		}
 		res, err = operation.execute(pc, in, callContext)
+		if prof != nil {
+			prof.record(op, cost, opCallGas, time.Since(opStart)-(in.evm.profiledTime-opNested))
+		}
 
 		if err != nil {
 			break
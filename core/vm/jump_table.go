@@ -49,6 +49,8 @@ type operation struct {
 	isSwap  bool
 	isDup   bool
 	opNum   int // only for push, swap, dup
+	// undefined is set for opcodes, which are not defined at the fork: EOF code with them is invalid
+	undefined bool
 	// memorySize returns the memory size required for the operation
 	memorySize memorySizeFunc
 	string     stringer
@@ -68,6 +70,8 @@ var (
 	napoliInstructionSet           = newNapoliInstructionSet()
 	cancunInstructionSet           = newCancunInstructionSet()
 	pragueInstructionSet           = newPragueInstructionSet()
+	eofLegacyInstructionSet        = newEOFLegacyInstructionSet()
+	eofInstructionSet              = newEOFInstructionSet()
 )
 
 // JumpTable contains the EVM opcodes supported at a given fork.
@@ -91,6 +95,24 @@ func validateAndFillMaxStack(jt *JumpTable) {
 	}
 }
 
+// newEOFInstructionSet returns the instructions of EOF code (EIP-7692): prague instructions without the ones, which
+// are not allowed in EOF, and the EOF ones.
+func newEOFInstructionSet() JumpTable {
+	instructionSet := newEOFLegacyInstructionSet()
+	enableEOF(&instructionSet)
+	validateAndFillMaxStack(&instructionSet)
+	return instructionSet
+}
+
+// newEOFLegacyInstructionSet returns the instructions of legacy code at the EOF fork: prague instructions, which see
+// EOF code of other accounts as 0xEF00 (EIP-3540).
+func newEOFLegacyInstructionSet() JumpTable {
+	instructionSet := newPragueInstructionSet()
+	enableEOFLegacy(&instructionSet)
+	validateAndFillMaxStack(&instructionSet)
+	return instructionSet
+}
+
 // newPragueInstructionSet returns the frontier, homestead, byzantium,
 // constantinople, istanbul, petersburg, berlin, london, paris, shanghai,
 // cancun, and prague instructions.
@@ -1314,7 +1336,7 @@ func newFrontierInstructionSet() JumpTable {
 	// Fill all unassigned slots with opUndefined.
 	for i, entry := range tbl {
 		if entry == nil {
-			tbl[i] = &operation{execute: opUndefined}
+			tbl[i] = &operation{execute: opUndefined, undefined: true}
 		}
 	}
 
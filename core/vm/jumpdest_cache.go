@@ -0,0 +1,97 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/dbg"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+var (
+	JumpDestCacheLimit = dbg.EnvInt("JD_LRU", 4096)
+	jumpDestCacheTrace = dbg.EnvBool("JD_LRU_TRACE", false)
+
+	mxJumpDestCacheHit      = metrics.GetOrCreateCounter("evm_jumpdest_cache_hit")
+	mxJumpDestCacheMiss     = metrics.GetOrCreateCounter("evm_jumpdest_cache_miss")
+	mxJumpDestAnalysisSpent = metrics.GetOrCreateCounter("evm_jumpdest_analysis_seconds") // time spent on analysis of cache misses
+	mxJumpDestAnalysisSaved = metrics.GetOrCreateCounter("evm_jumpdest_analysis_saved_seconds")
+)
+
+// JumpDestCache keeps results of JUMPDEST analysis by code hash. It's safe for concurrent use,
+// by default all EVMs of the process share one (see SharedJumpDestCache).
+type JumpDestCache struct {
+	*lru.Cache[common.Hash, jumpDestAnalysis]
+	hit, total atomic.Uint64
+	trace      bool
+}
+
+// jumpDestAnalysis - code bitmap and time it took to compute it, which is saved by every cache hit
+type jumpDestAnalysis struct {
+	bitvec
+	took time.Duration
+}
+
+func NewJumpDestCache(limit int) *JumpDestCache {
+	c, err := lru.New[common.Hash, jumpDestAnalysis](limit)
+	if err != nil {
+		panic(err)
+	}
+	return &JumpDestCache{Cache: c, trace: jumpDestCacheTrace}
+}
+
+var sharedJumpDestCache = sync.OnceValue(func() *JumpDestCache { return NewJumpDestCache(JumpDestCacheLimit) })
+
+// SharedJumpDestCache returns the process-wide cache, used by EVMs created without an explicit one:
+// block execution, eth_call, tracing, etc.
+func SharedJumpDestCache() *JumpDestCache {
+	return sharedJumpDestCache()
+}
+
+// analysis returns code bitmap of the code with the given hash, computing it on a miss
+func (c *JumpDestCache) analysis(codeHash common.Hash, code []byte) bitvec {
+	c.total.Add(1)
+	if a, ok := c.Get(codeHash); ok {
+		c.hit.Add(1)
+		mxJumpDestCacheHit.Inc()
+		mxJumpDestAnalysisSaved.Add(a.took.Seconds())
+		return a.bitvec
+	}
+	start := time.Now()
+	bits := codeBitmap(code)
+	took := time.Since(start)
+	c.Add(codeHash, jumpDestAnalysis{bitvec: bits, took: took})
+	mxJumpDestCacheMiss.Inc()
+	mxJumpDestAnalysisSpent.Add(took.Seconds())
+	return bits
+}
+
+func (c *JumpDestCache) LogStats() {
+	if c == nil || !c.trace {
+		return
+	}
+	hit, total := c.hit.Load(), c.total.Load()
+	log.Warn("[dbg] JumpDestCache", "hit", hit, "total", total, "limit", JumpDestCacheLimit, "ratio", fmt.Sprintf("%.2f", float64(hit)/float64(total)))
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+)
+
+// StatefulPrecompiledContract is a precompiled contract which, in addition to its input,
+// has access to the state and to the context of the call. When a contract implements it,
+// RunStateful is used instead of Run.
+// Changes made to the state are reverted together with the rest of the call frame.
+type StatefulPrecompiledContract interface {
+	PrecompiledContract
+	RunStateful(env *PrecompileEnvironment, input []byte) ([]byte, error)
+}
+
+// PrecompileEnvironment is the context of a call to a stateful precompiled contract
+type PrecompileEnvironment struct {
+	EVM      *EVM
+	Caller   common.Address // msg.sender as seen by the precompile
+	Address  common.Address // the account whose storage the precompile acts on (the caller's for CALLCODE and DELEGATECALL)
+	Value    *uint256.Int
+	ReadOnly bool // state modifications must be rejected with ErrWriteProtection
+}
+
+type customPrecompile struct {
+	name     string
+	contract PrecompiledContract
+}
+
+// customPrecompiles are registered once during initialisation and only read afterwards
+var customPrecompiles = map[common.Address]customPrecompile{}
+
+// RegisterPrecompile makes a precompiled contract available at the given address. The contract
+// is only active on chains whose config schedules it under the given name
+// (see chain.Config.CustomPrecompiles), which keeps registration safe for builds serving public networks.
+// It is meant to be called from init functions of custom builds (like cmd/erigoncustom) and panics
+// if the name or the address is already taken, including by the standard precompiles.
+func RegisterPrecompile(name string, addr common.Address, p PrecompiledContract) {
+	if _, ok := PrecompiledContractsOsaka[addr]; ok {
+		panic(fmt.Sprintf("precompile address %x is taken by a standard precompile", addr))
+	}
+	if cp, ok := customPrecompiles[addr]; ok {
+		panic(fmt.Sprintf("precompile address %x is already registered for %s", addr, cp.name))
+	}
+	for _, cp := range customPrecompiles {
+		if cp.name == name {
+			panic("precompile " + name + " is already registered")
+		}
+	}
+	customPrecompiles[addr] = customPrecompile{name: name, contract: p}
+}
+
+// customPrecompileAt returns the custom precompiled contract at the given address if it is active under the rules
+func customPrecompileAt(rules *chain.Rules, addr common.Address) (PrecompiledContract, bool) {
+	if len(rules.CustomPrecompiles) == 0 {
+		return nil, false
+	}
+	cp, ok := customPrecompiles[addr]
+	if !ok || !rules.CustomPrecompiles[cp.name] {
+		return nil, false
+	}
+	return cp.contract, true
+}
+
+// withCustomPrecompiles appends the addresses of the custom precompiles active under the rules
+// to a copy of the standard precompile addresses
+func withCustomPrecompiles(rules *chain.Rules, addresses []common.Address) []common.Address {
+	if len(rules.CustomPrecompiles) == 0 {
+		return addresses
+	}
+	var custom []common.Address
+	for addr, cp := range customPrecompiles {
+		if rules.CustomPrecompiles[cp.name] {
+			custom = append(custom, addr)
+		}
+	}
+	if len(custom) == 0 {
+		return addresses
+	}
+	sort.Slice(custom, func(i, j int) bool { return custom[i].Cmp(custom[j]) < 0 })
+	return slices.Concat(addresses, custom)
+}
+
+// runPrecompile runs a precompiled contract called with the given call type.
+// Stateful precompiles see the call context the way a contract deployed at addr would,
+// so a DELEGATECALL runs them against the delegating contract with its caller and value.
+func (evm *EVM) runPrecompile(typ OpCode, p PrecompiledContract, caller ContractRef, addr common.Address, input []byte, gas uint64,
+	value *uint256.Int) (ret []byte, leftOverGas uint64, err error) {
+	if prof := evm.config.Profiler; prof != nil {
+		start := time.Now()
+		defer func() {
+			took := time.Since(start)
+			prof.recordPrecompile(addr, gas-leftOverGas, took)
+			evm.profiledTime += took
+		}()
+	}
+	sp, ok := p.(StatefulPrecompiledContract)
+	if !ok {
+		return RunPrecompiledContract(p, input, gas, evm.Config().Tracer)
+	}
+	env := &PrecompileEnvironment{EVM: evm, Caller: caller.Address(), Address: addr, Value: value,
+		ReadOnly: typ == STATICCALL || typ == EXTSTATICCALL}
+	switch typ {
+	case CALLCODE:
+		env.Address = caller.Address()
+	case DELEGATECALL, EXTDELEGATECALL:
+		parent := caller.(*Contract)
+		env.Caller, env.Address, env.Value = parent.CallerAddress, parent.Address(), parent.value
+	}
+	if in, ok := evm.interpreter.(*EVMInterpreter); ok && in.readOnly {
+		env.ReadOnly = true
+	}
+	return RunPrecompiledContract(statefulRunner{sp, env}, input, gas, evm.Config().Tracer)
+}
+
+// statefulRunner binds a stateful precompile to its environment, so that it can be run as a plain one
+type statefulRunner struct {
+	StatefulPrecompiledContract
+	env *PrecompileEnvironment
+}
+
+func (r statefulRunner) Run(input []byte) ([]byte, error) {
+	return r.RunStateful(r.env, input)
+}
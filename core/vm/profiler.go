@@ -0,0 +1,197 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/pprof/profile"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+)
+
+// ProfileStats - aggregated execution of an opcode or a precompile
+type ProfileStats struct {
+	Count uint64
+	Gas   uint64        // gas charged by the opcode itself: gas passed to callees is not included
+	Time  time.Duration // wall time of the opcode itself: time spent in callees is not included
+}
+
+func (s *ProfileStats) add(gas uint64, took time.Duration) {
+	s.Count++
+	s.Gas += gas
+	s.Time += took
+}
+
+// Profiler aggregates execution count, gas and wall time per code hash and opcode, and per precompile,
+// of all EVMs it's attached to by Config.Profiler. It's safe for concurrent use, so one profiler may be
+// shared by parallel execution workers.
+type Profiler struct {
+	mu          sync.Mutex
+	code        map[common.Hash]*[256]ProfileStats
+	precompiles map[common.Address]*ProfileStats
+}
+
+func NewProfiler() *Profiler {
+	return &Profiler{
+		code:        map[common.Hash]*[256]ProfileStats{},
+		precompiles: map[common.Address]*ProfileStats{},
+	}
+}
+
+// OpStats returns stats of the opcode executed in code with the given hash. Hash of initcode is keccak of the initcode.
+func (p *Profiler) OpStats(codeHash common.Hash, op OpCode) ProfileStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if ops, ok := p.code[codeHash]; ok {
+		return ops[op]
+	}
+	return ProfileStats{}
+}
+
+// PrecompileStats returns stats of runs of the precompile, gas is the gas it required
+func (p *Profiler) PrecompileStats(addr common.Address) ProfileStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if s, ok := p.precompiles[addr]; ok {
+		return *s
+	}
+	return ProfileStats{}
+}
+
+// frameProfile - stats of one call frame. They are merged into Profiler when the frame returns,
+// so that the interpreter loop doesn't take the lock.
+type frameProfile struct {
+	codeHash common.Hash
+	ops      [256]ProfileStats
+	start    time.Time
+	nested   time.Duration // EVM.profiledTime at the frame start
+}
+
+var frameProfilePool = sync.Pool{New: func() any { return new(frameProfile) }}
+
+func (p *Profiler) enterFrame(contract *Contract, profiledTime time.Duration) *frameProfile {
+	f := frameProfilePool.Get().(*frameProfile)
+	f.codeHash = contract.CodeHash
+	if f.codeHash == (common.Hash{}) {
+		f.codeHash = crypto.Keccak256Hash(contract.Code)
+	}
+	f.start, f.nested = time.Now(), profiledTime
+	return f
+}
+
+// record accounts an executed opcode. callGas is the gas passed to the callee by CALL-like opcodes,
+// which is included in their dynamic gas.
+func (f *frameProfile) record(op OpCode, cost, callGas uint64, took time.Duration) {
+	switch op {
+	case CALL, CALLCODE, DELEGATECALL, STATICCALL:
+		cost -= callGas
+	}
+	f.ops[op].add(cost, took)
+}
+
+// exitFrame merges stats of the frame and returns new EVM.profiledTime: whole time of the frame
+// is excluded from the self time of the opcode which has made the call
+func (p *Profiler) exitFrame(f *frameProfile) time.Duration {
+	profiledTime := f.nested + time.Since(f.start)
+	p.mu.Lock()
+	ops, ok := p.code[f.codeHash]
+	if !ok {
+		ops = new([256]ProfileStats)
+		p.code[f.codeHash] = ops
+	}
+	for op := range f.ops {
+		if f.ops[op].Count == 0 {
+			continue
+		}
+		ops[op].Count += f.ops[op].Count
+		ops[op].Gas += f.ops[op].Gas
+		ops[op].Time += f.ops[op].Time
+	}
+	p.mu.Unlock()
+	*f = frameProfile{}
+	frameProfilePool.Put(f)
+	return profiledTime
+}
+
+func (p *Profiler) recordPrecompile(addr common.Address, gas uint64, took time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s, ok := p.precompiles[addr]
+	if !ok {
+		s = &ProfileStats{}
+		p.precompiles[addr] = s
+	}
+	s.add(gas, took)
+}
+
+// WriteProfile writes collected stats as a gzipped pprof profile with sample types "calls", "gas" and "time".
+// Stack of an opcode sample is code hash -> opcode, of a precompile sample: "precompiles" -> address.
+// So `go tool pprof -top` shows totals per opcode and `-peek` or `-tree` their breakdown per contract.
+func (p *Profiler) WriteProfile(w io.Writer) error {
+	prof := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "calls", Unit: "count"},
+			{Type: "gas", Unit: "gas"},
+			{Type: "time", Unit: "nanoseconds"},
+		},
+		DefaultSampleType: "gas",
+		PeriodType:        &profile.ValueType{Type: "calls", Unit: "count"},
+		Period:            1,
+		TimeNanos:         time.Now().UnixNano(),
+	}
+	locations := map[string]*profile.Location{}
+	location := func(name string) *profile.Location {
+		if loc, ok := locations[name]; ok {
+			return loc
+		}
+		fn := &profile.Function{ID: uint64(len(prof.Function) + 1), Name: name, SystemName: name}
+		prof.Function = append(prof.Function, fn)
+		loc := &profile.Location{ID: uint64(len(prof.Location) + 1), Line: []profile.Line{{Function: fn}}}
+		prof.Location = append(prof.Location, loc)
+		locations[name] = loc
+		return loc
+	}
+	sample := func(s ProfileStats, stack ...*profile.Location) {
+		prof.Sample = append(prof.Sample, &profile.Sample{
+			Location: stack,
+			Value:    []int64{int64(s.Count), int64(s.Gas), int64(s.Time)},
+		})
+	}
+
+	p.mu.Lock()
+	for codeHash, ops := range p.code {
+		code := location(codeHash.Hex())
+		for op := range ops {
+			if ops[op].Count > 0 {
+				sample(ops[op], location(OpCode(op).String()), code)
+			}
+		}
+	}
+	if len(p.precompiles) > 0 {
+		precompiles := location("precompiles")
+		for addr, s := range p.precompiles {
+			sample(*s, location(addr.Hex()), precompiles)
+		}
+	}
+	p.mu.Unlock()
+
+	return prof.Write(w)
+}
@@ -20,6 +20,7 @@
 package runtime
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"fmt"
@@ -29,13 +30,16 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/pprof/profile"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 
 	"github.com/erigontech/erigon-lib/abi"
 	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/params"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/memdb"
 	"github.com/erigontech/erigon-lib/kv/rawdbv3"
@@ -792,3 +796,215 @@ func BenchmarkEVM_SWAP1(b *testing.B) {
 		}
 	})
 }
+
+// eofContainer - EOF container with code sections of given types (inputs, outputs, max stack increase)
+func eofContainer(types [][3]int, code [][]byte, containers [][]byte, data []byte, dataSize int) []byte {
+	b := []byte{0xef, 0x00, 0x01, 0x01}
+	b = binary.BigEndian.AppendUint16(b, uint16(len(types)*4))
+	b = append(b, 0x02)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(code)))
+	for _, c := range code {
+		b = binary.BigEndian.AppendUint16(b, uint16(len(c)))
+	}
+	if len(containers) > 0 {
+		b = append(b, 0x03)
+		b = binary.BigEndian.AppendUint16(b, uint16(len(containers)))
+		for _, c := range containers {
+			b = binary.BigEndian.AppendUint32(b, uint32(len(c)))
+		}
+	}
+	b = append(b, 0xff)
+	b = binary.BigEndian.AppendUint16(b, uint16(dataSize))
+	b = append(b, 0x00)
+	for _, t := range types {
+		b = append(b, byte(t[0]), byte(t[1]))
+		b = binary.BigEndian.AppendUint16(b, uint16(t[2]))
+	}
+	for _, c := range append(code, containers...) {
+		b = append(b, c...)
+	}
+	return append(b, data...)
+}
+
+func eofConfig() *Config {
+	cfg := new(Config)
+	setDefaults(cfg)
+	cfg.ChainConfig.EOFTime = new(big.Int)
+	return cfg
+}
+
+func TestEOFExecute(t *testing.T) {
+	t.Parallel()
+	// 3 + 4 by a function
+	code := eofContainer([][3]int{{0, 0x80, 2}, {2, 1, 0}}, [][]byte{{
+		byte(vm.PUSH1), 3,
+		byte(vm.PUSH1), 4,
+		byte(vm.CALLF), 0, 1,
+		byte(vm.PUSH0),
+		byte(vm.MSTORE),
+		byte(vm.PUSH1), 32,
+		byte(vm.PUSH0),
+		byte(vm.RETURN),
+	}, {
+		byte(vm.ADD),
+		byte(vm.RETF),
+	}}, nil, nil, 0)
+	_, err := vm.ValidateEOF(code, vm.RuntimeContainer)
+	require.NoError(t, err)
+	ret, _, err := Execute(code, nil, eofConfig(), t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), new(uint256.Int).SetBytes(ret).Uint64())
+
+	// before the EOF fork the container is legacy code, which fails on 0xEF
+	_, _, err = Execute(code, nil, nil, t.TempDir())
+	require.Error(t, err)
+
+	// runtime container returns its data, which is provided by initcode as aux data
+	runtimeContainer := eofContainer([][3]int{{0, 0x80, 2}}, [][]byte{{
+		byte(vm.DATALOADN), 0, 0,
+		byte(vm.PUSH0),
+		byte(vm.MSTORE),
+		byte(vm.PUSH1), 32,
+		byte(vm.PUSH0),
+		byte(vm.RETURN),
+	}}, nil, nil, 32)
+	initContainer := eofContainer([][3]int{{0, 0x80, 3}}, [][]byte{{
+		byte(vm.PUSH1), 32,
+		byte(vm.PUSH0),
+		byte(vm.PUSH0),
+		byte(vm.CALLDATACOPY),
+		byte(vm.PUSH1), 32,
+		byte(vm.PUSH0),
+		byte(vm.RETURNCONTRACT), 0,
+	}}, [][]byte{runtimeContainer}, nil, 0)
+	// creates contract with 42 as its data and calls it
+	code = eofContainer([][3]int{{0, 0x80, 5}}, [][]byte{{
+		byte(vm.PUSH1), 42,
+		byte(vm.PUSH0),
+		byte(vm.MSTORE),
+		byte(vm.PUSH1), 32, // input size
+		byte(vm.PUSH0), // input offset
+		byte(vm.PUSH0), // salt
+		byte(vm.PUSH0), // value
+		byte(vm.EOFCREATE), 0,
+		byte(vm.PUSH0),   // value
+		byte(vm.PUSH0),   // input size
+		byte(vm.PUSH0),   // input offset
+		byte(vm.DUPN), 3, // address
+		byte(vm.EXTCALL),
+		byte(vm.POP),
+		byte(vm.PUSH0),
+		byte(vm.RETURNDATALOAD),
+		byte(vm.PUSH0),
+		byte(vm.MSTORE),
+		byte(vm.PUSH1), 32,
+		byte(vm.PUSH0),
+		byte(vm.RETURN),
+	}}, [][]byte{initContainer}, nil, 0)
+	_, err = vm.ValidateEOF(code, vm.RuntimeContainer)
+	require.NoError(t, err)
+	ret, _, err = Execute(code, nil, eofConfig(), t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), new(uint256.Int).SetBytes(ret).Uint64())
+}
+
+var senderPrecompileAddress = common.HexToAddress("0x0300000000000000000000000000000000000001")
+
+// senderPrecompile stores its msg.sender in the first slot of the account it acts on and returns it
+type senderPrecompile struct{}
+
+func (p *senderPrecompile) RequiredGas(input []byte) uint64 { return 100 }
+
+func (p *senderPrecompile) Run(input []byte) ([]byte, error) { return nil, vm.ErrExecutionReverted }
+
+func (p *senderPrecompile) RunStateful(env *vm.PrecompileEnvironment, input []byte) ([]byte, error) {
+	if env.ReadOnly {
+		return nil, vm.ErrWriteProtection
+	}
+	var sender uint256.Int
+	sender.SetBytes(env.Caller[:])
+	if err := env.EVM.IntraBlockState().SetState(env.Address, common.Hash{}, sender); err != nil {
+		return nil, err
+	}
+	b := sender.Bytes32()
+	return b[:], nil
+}
+
+func init() {
+	vm.RegisterPrecompile("sender", senderPrecompileAddress, &senderPrecompile{})
+}
+
+func TestCustomPrecompile(t *testing.T) {
+	t.Parallel()
+	contract := common.BytesToAddress([]byte("contract"))
+	customConfig := func(block int64) *Config {
+		cfg := &Config{BlockNumber: big.NewInt(10), Origin: common.HexToAddress("0xc0ffee")}
+		setDefaults(cfg)
+		cfg.ChainConfig.CustomPrecompiles = map[string]chain.PrecompileActivation{"sender": {Block: big.NewInt(block)}}
+		return cfg
+	}
+
+	rules := customConfig(10).ChainConfig.Rules(10, 0)
+	require.Contains(t, vm.ActivePrecompiles(rules), senderPrecompileAddress)
+	require.NotContains(t, vm.ActivePrecompiles(customConfig(11).ChainConfig.Rules(10, 0)), senderPrecompileAddress)
+	require.NotContains(t, vm.ActivePrecompiles(&chain.Rules{IsOsaka: true}), senderPrecompileAddress)
+
+	call := program.New().Call(nil, senderPrecompileAddress, 0, 0, 0, 0, 32).Op(vm.POP).Return(0, 32).Bytes()
+	ret, state, err := Execute(call, nil, customConfig(10), t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, common.BytesToHash(contract[:]), common.BytesToHash(ret))
+	var stored uint256.Int
+	require.NoError(t, state.GetState(senderPrecompileAddress, common.Hash{}, &stored))
+	require.Equal(t, common.BytesToHash(contract[:]), common.Hash(stored.Bytes32()))
+
+	// not scheduled yet: a call to an empty account
+	ret, _, err = Execute(call, nil, customConfig(11), t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{}, common.BytesToHash(ret))
+
+	// delegatecall acts on the delegating contract on behalf of its caller
+	cfg := customConfig(10)
+	delegateCall := program.New().DelegateCall(nil, senderPrecompileAddress, 0, 0, 0, 32).Op(vm.POP).Return(0, 32).Bytes()
+	ret, state, err = Execute(delegateCall, nil, cfg, t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, common.BytesToHash(cfg.Origin[:]), common.BytesToHash(ret))
+	require.NoError(t, state.GetState(contract, common.Hash{}, &stored))
+	require.Equal(t, common.BytesToHash(cfg.Origin[:]), common.Hash(stored.Bytes32()))
+
+	// staticcall fails
+	staticCall := program.New().StaticCall(nil, senderPrecompileAddress, 0, 0, 0, 0).Push0().Op(vm.MSTORE).Return(0, 32).Bytes()
+	ret, _, err = Execute(staticCall, nil, customConfig(10), t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{}, common.BytesToHash(ret))
+}
+
+func TestProfiler(t *testing.T) {
+	t.Parallel()
+	identity := common.BytesToAddress([]byte{4})
+	code := program.New().StaticCall(nil, identity, 0, 0, 0, 0).Op(vm.POP).StaticCall(nil, identity, 0, 0, 0, 0).Op(vm.POP).Bytes()
+	cfg := &Config{BlockNumber: big.NewInt(1), EVMConfig: vm.Config{Profiler: vm.NewProfiler()}}
+	setDefaults(cfg)
+	_, _, err := Execute(code, nil, cfg, t.TempDir())
+	require.NoError(t, err)
+
+	codeHash := crypto.Keccak256Hash(code)
+	staticCall := cfg.EVMConfig.Profiler.OpStats(codeHash, vm.STATICCALL)
+	require.Equal(t, uint64(2), staticCall.Count)
+	require.Equal(t, 2*params.WarmStorageReadCostEIP2929, staticCall.Gas) // gas passed to the precompile is not included
+	pop := cfg.EVMConfig.Profiler.OpStats(codeHash, vm.POP)
+	require.Equal(t, uint64(2), pop.Count)
+	require.Equal(t, 2*vm.GasQuickStep, pop.Gas)
+	precompile := cfg.EVMConfig.Profiler.PrecompileStats(identity)
+	require.Equal(t, uint64(2), precompile.Count)
+	require.Equal(t, 2*params.IdentityBaseGas, precompile.Gas)
+
+	var buf bytes.Buffer
+	require.NoError(t, cfg.EVMConfig.Profiler.WriteProfile(&buf))
+	prof, err := profile.Parse(&buf)
+	require.NoError(t, err)
+	var gas int64
+	for _, s := range prof.Sample {
+		gas += s.Value[1]
+	}
+	require.Greater(t, gas, int64(2*(params.WarmStorageReadCostEIP2929+vm.GasQuickStep+params.IdentityBaseGas)))
+}
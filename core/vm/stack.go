@@ -112,6 +112,16 @@ func (st *Stack) swap16() {
 	st.data[st.len()-17], st.data[st.len()-1] = st.data[st.len()-1], st.data[st.len()-17]
 }
 
+// swap exchanges the top item with the n'th one below it
+func (st *Stack) swap(n int) {
+	st.data[st.len()-n-1], st.data[st.len()-1] = st.data[st.len()-1], st.data[st.len()-n-1]
+}
+
+// exchange exchanges the n'th and m'th items, counting from the top one as 0
+func (st *Stack) exchange(n, m int) {
+	st.data[st.len()-n-1], st.data[st.len()-m-1] = st.data[st.len()-m-1], st.data[st.len()-n-1]
+}
+
 func (st *Stack) dup(n int) {
 	st.data = append(st.data, st.data[len(st.data)-n])
 }
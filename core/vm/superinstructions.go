@@ -0,0 +1,208 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/dbg"
+	"github.com/erigontech/erigon-lib/metrics"
+
+	"github.com/erigontech/erigon/core/tracing"
+)
+
+// Superinstructions fuse common sequences of legacy opcodes, so that the interpreter loop charges gas,
+// validates the stack and dispatches once per sequence instead of once per opcode.
+// A superinstruction is executed only if all of its opcodes would succeed: when any of them would fail
+// (out of gas, stack limits, invalid jump destination) the interpreter executes the sequence opcode by
+// opcode, so that errors, gas and state are exactly the same as without fusion.
+// Fusion is disabled when a tracer observes single opcodes or gas changes.
+
+var (
+	// FuseInstructions enables superinstructions in all EVMs of the process, not only those with Config.FuseInstructions
+	FuseInstructions   = dbg.EnvBool("EVM_FUSE", false)
+	fusedCodeCacheSize = dbg.EnvInt("EVM_FUSE_LRU", 4096)
+
+	mxFusedInstructions = metrics.GetOrCreateCounter("evm_fused_instructions") // opcodes executed as part of superinstructions
+)
+
+type superInstruction uint8
+
+const (
+	siNone        superInstruction = iota
+	siPushJump                     // PUSHn dest, JUMP
+	siPushJumpi                    // PUSHn dest, JUMPI
+	siPush0Mstore                  // PUSH0, MSTORE
+	siStackOps                     // DUPn/SWAPn chain
+)
+
+// fusedOp - superinstruction starting at a pc and the number of opcodes it fuses
+type fusedOp struct {
+	kind superInstruction
+	ops  uint8
+}
+
+// fusedCode - superinstructions of a code by pc
+type fusedCode []fusedOp
+
+// fuseCode finds fusable sequences in legacy code. Every instruction start is considered, so when a chain
+// of DUP/SWAP can't be run fused, the rest of it still can after the first opcode is executed alone.
+func fuseCode(code []byte) fusedCode {
+	fused := make(fusedCode, len(code))
+	for pc := 0; pc < len(code); pc++ {
+		op := OpCode(code[pc])
+		switch {
+		case op >= PUSH1 && op <= PUSH32:
+			next := pc + 1 + int(op-PUSH1+1)
+			if next < len(code) {
+				switch OpCode(code[next]) {
+				case JUMP:
+					fused[pc] = fusedOp{kind: siPushJump, ops: 2}
+				case JUMPI:
+					fused[pc] = fusedOp{kind: siPushJumpi, ops: 2}
+				}
+			}
+			pc = next - 1 // skip push data
+		case op == PUSH0:
+			if pc+1 < len(code) && OpCode(code[pc+1]) == MSTORE {
+				fused[pc] = fusedOp{kind: siPush0Mstore, ops: 2}
+			}
+		case isStackOp(op):
+			n := 1
+			for pc+n < len(code) && n < math.MaxUint8 && isStackOp(OpCode(code[pc+n])) {
+				n++
+			}
+			if n > 1 {
+				fused[pc] = fusedOp{kind: siStackOps, ops: uint8(n)}
+			}
+		}
+	}
+	return fused
+}
+
+func isStackOp(op OpCode) bool {
+	return (op >= DUP1 && op <= DUP16) || (op >= SWAP1 && op <= SWAP16)
+}
+
+var fusedCodeCache = sync.OnceValue(func() *lru.Cache[common.Hash, fusedCode] {
+	c, err := lru.New[common.Hash, fusedCode](fusedCodeCacheSize)
+	if err != nil {
+		panic(err)
+	}
+	return c
+})
+
+// fusedCodeOf returns superinstructions of the contract code, shared by code hash across transactions
+func fusedCodeOf(contract *Contract) fusedCode {
+	if contract.CodeHash == (common.Hash{}) { // initcode: not worth fusing for a single run
+		return nil
+	}
+	cache := fusedCodeCache()
+	if fused, ok := cache.Get(contract.CodeHash); ok {
+		return fused
+	}
+	fused := fuseCode(contract.Code)
+	cache.Add(contract.CodeHash, fused)
+	return fused
+}
+
+// runFused executes the superinstruction at *pc and moves *pc to the next instruction.
+// It returns false, without touching the state, if any opcode of the sequence would fail.
+func (in *EVMInterpreter) runFused(si fusedOp, pc *uint64, jt *JumpTable, scope *ScopeContext) bool {
+	contract, stack, code := scope.Contract, scope.Stack, scope.Contract.Code
+	sLen := stack.len()
+	switch si.kind {
+	case siPushJump, siPushJumpi:
+		push := jt[code[*pc]]
+		size := uint64(code[*pc] - byte(PUSH1) + 1)
+		jumpPc := *pc + 1 + size
+		jump := jt[code[jumpPc]]
+		if sLen > push.maxStack || sLen+1 < jump.numPop {
+			return false
+		}
+		gas := push.constantGas + jump.constantGas
+		if contract.Gas < gas {
+			return false
+		}
+		var dest uint256.Int
+		dest.SetBytes(code[*pc+1 : jumpPc])
+		taken := si.kind == siPushJump || !stack.peek().IsZero()
+		if taken {
+			if valid, _ := contract.validJumpdest(&dest); !valid {
+				return false
+			}
+		}
+		contract.UseGas(gas, in.cfg.Tracer, tracing.GasChangeIgnored)
+		if si.kind == siPushJumpi {
+			stack.pop()
+		}
+		if taken {
+			*pc = dest.Uint64()
+		} else {
+			*pc = jumpPc + 1
+		}
+	case siPush0Mstore:
+		push, mstore := jt[PUSH0], jt[MSTORE]
+		if push.undefined || sLen > push.maxStack || sLen+1 < mstore.numPop {
+			return false
+		}
+		// offset is 0, so memory size is one word. Dynamic gas of MSTORE depends only on memory expansion.
+		memGas, err := mstore.dynamicGas(in.evm, contract, stack, scope.Memory, 32)
+		if err != nil {
+			return false
+		}
+		gas := push.constantGas + mstore.constantGas
+		if gas += memGas; gas < memGas || contract.Gas < gas {
+			return false
+		}
+		contract.UseGas(gas, in.cfg.Tracer, tracing.GasChangeIgnored)
+		scope.Memory.Resize(32)
+		val := stack.pop()
+		scope.Memory.Set32(0, &val)
+		*pc += 2
+	case siStackOps:
+		var gas uint64
+		for i := uint64(0); i < uint64(si.ops); i++ {
+			op := jt[code[*pc+i]]
+			if sLen < op.numPop || sLen > op.maxStack {
+				return false
+			}
+			sLen += op.numPush - op.numPop
+			gas += op.constantGas
+		}
+		if contract.Gas < gas {
+			return false
+		}
+		contract.UseGas(gas, in.cfg.Tracer, tracing.GasChangeIgnored)
+		for i := uint64(0); i < uint64(si.ops); i++ {
+			if op := OpCode(code[*pc+i]); op <= DUP16 {
+				stack.dup(int(op - DUP1 + 1))
+			} else {
+				stack.swap(int(op - SWAP1 + 1))
+			}
+		}
+		*pc += uint64(si.ops)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+)
+
+func TestFuseCode(t *testing.T) {
+	t.Parallel()
+	code := []byte{
+		byte(PUSH1), 4, byte(JUMP), // 0
+		byte(STOP),                          // 3
+		byte(JUMPDEST),                      // 4
+		byte(DUP1), byte(SWAP1), byte(DUP2), // 5
+		byte(PUSH0), byte(MSTORE), // 8
+		byte(PUSH2), byte(JUMP), byte(JUMPI), byte(JUMPI), // 10: push data looks like JUMP, PUSH2+JUMPI
+		byte(PUSH1), byte(JUMP), // 14: truncated push
+	}
+	expected := map[int]fusedOp{
+		0:  {kind: siPushJump, ops: 2},
+		5:  {kind: siStackOps, ops: 3},
+		6:  {kind: siStackOps, ops: 2},
+		8:  {kind: siPush0Mstore, ops: 2},
+		10: {kind: siPushJumpi, ops: 2},
+	}
+	fused := fuseCode(code)
+	for pc := range code {
+		if fused[pc] != expected[pc] {
+			t.Errorf("pc %d: expected %+v, got %+v", pc, expected[pc], fused[pc])
+		}
+	}
+}
+
+// fuzzResult - everything the fused and the plain interpreter must agree on
+type fuzzResult struct {
+	ret     []byte
+	gasLeft uint64
+	err     error
+	storage map[common.Hash]uint256.Int
+	logs    types.Logs
+}
+
+// storageRecorder collects storage written by IntraBlockState.FinalizeTx
+type storageRecorder struct {
+	*state.NoopWriter
+	storage map[common.Hash]uint256.Int
+}
+
+func (w *storageRecorder) WriteAccountStorage(_ common.Address, _ uint64, key common.Hash, _, value uint256.Int) error {
+	w.storage[key] = value
+	return nil
+}
+
+// runFuzzCode runs the code of a contract in a fresh EVM and state
+func runFuzzCode(t *testing.T, chainConfig *chain.Config, code []byte, gas uint64, fuse bool) fuzzResult {
+	addr := common.Address{1}
+	ibs := state.New(state.NewNoopReader())
+	if err := ibs.SetCode(addr, code); err != nil {
+		t.Fatal(err)
+	}
+	evm := NewEVM(evmtypes.BlockContext{}, evmtypes.TxContext{}, ibs, chainConfig, Config{FuseInstructions: fuse})
+	contract := NewContract(dummyContractRef{}, addr, new(uint256.Int), gas, false /* skipAnalysis */, NewJumpDestCache(16))
+	contract.SetCallCode(&addr, crypto.Keccak256Hash(code), code)
+	var res fuzzResult
+	res.ret, res.err = evm.Interpreter().Run(contract, nil, false)
+	res.gasLeft = contract.Gas
+	if res.err != nil {
+		// the caller reverts state of the failed frame, logs and storage are discarded
+		return res
+	}
+	w := &storageRecorder{NoopWriter: state.NewNoopWriter(), storage: map[common.Hash]uint256.Int{}}
+	if err := ibs.FinalizeTx(evm.ChainRules(), w); err != nil {
+		t.Fatal(err)
+	}
+	res.storage, res.logs = w.storage, ibs.Logs()
+	return res
+}
+
+// fuzzOps are opcodes which don't call other contracts, so that the code runs in a single frame
+var fuzzOps = []OpCode{
+	PUSH0, PUSH1, PUSH1, PUSH2, PUSH32, JUMP, JUMPI, JUMPDEST, JUMPDEST,
+	DUP1, DUP2, DUP3, DUP16, SWAP1, SWAP2, SWAP3, SWAP16,
+	MSTORE, MLOAD, MSIZE, ADD, SUB, LT, ISZERO, POP, PC, GAS, STOP,
+	SSTORE, SLOAD, LOG0, LOG1,
+}
+
+// fuzzCode turns arbitrary bytes into legacy code of fuzzOps, which returns the first 64 bytes of memory at the end
+func fuzzCode(data []byte) []byte {
+	var code []byte
+	for i := 0; i < len(data); i++ {
+		op := fuzzOps[int(data[i])%len(fuzzOps)]
+		code = append(code, byte(op))
+		if op >= PUSH1 && op <= PUSH32 {
+			n := int(op - PUSH1 + 1)
+			for j := 0; j < n; j++ {
+				var b byte
+				if i+1 < len(data) {
+					i++
+					b = data[i]
+				}
+				code = append(code, b)
+			}
+		}
+	}
+	return append(code, byte(PUSH1), 64, byte(PUSH0), byte(RETURN))
+}
+
+// FuzzSuperInstructions checks that fused execution gives the same result, gas, error, storage and logs
+// as the plain interpreter
+func FuzzSuperInstructions(f *testing.F) {
+	seeds := [][]byte{
+		{byte(PUSH1), 4, byte(JUMP), byte(STOP), byte(JUMPDEST), byte(PUSH1), 1, byte(PUSH0), byte(MSTORE)},
+		{byte(PUSH1), 1, byte(PUSH1), 7, byte(JUMPI), byte(STOP), byte(JUMPDEST), byte(DUP1), byte(SWAP1), byte(DUP2), byte(ADD)},
+		{byte(PUSH1), 0, byte(PUSH1), 7, byte(JUMPI), byte(DUP1), byte(DUP1), byte(SWAP16)},
+		{byte(PUSH1), 3, byte(JUMP)}, // invalid jump
+		{byte(DUP1), byte(DUP2), byte(SWAP1)},
+		{byte(JUMPDEST), byte(PUSH1), 1, byte(DUP1), byte(DUP1), byte(PUSH1), 0, byte(JUMP)}, // runs out of gas
+		{byte(PUSH1), 7, byte(PUSH1), 1, byte(SSTORE), byte(PUSH1), 1, byte(SLOAD), byte(PUSH0), byte(MSTORE), byte(PUSH1), 32, byte(PUSH0), byte(LOG0)},
+		{byte(PUSH1), 2, byte(DUP1), byte(SWAP1), byte(SSTORE), byte(PUSH1), 5, byte(PUSH1), 10, byte(JUMP), byte(JUMPDEST), byte(PUSH0), byte(PUSH0), byte(LOG1)},
+	}
+	for _, seed := range seeds {
+		for _, gas := range []uint64{0, 3, 5, 11, 100, 100_000} {
+			var input [8]byte
+			binary.BigEndian.PutUint64(input[:], gas)
+			f.Add(append(input[:], seed...))
+		}
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) < 8 {
+			return
+		}
+		gas := binary.BigEndian.Uint64(data) % 1_000_000
+		code := fuzzCode(data[8:])
+		for _, chainConfig := range []*chain.Config{chain.TestChainConfig, chain.AllProtocolChanges} {
+			plain := runFuzzCode(t, chainConfig, code, gas, false)
+			fused := runFuzzCode(t, chainConfig, code, gas, true)
+			if !bytes.Equal(plain.ret, fused.ret) || plain.gasLeft != fused.gasLeft || !sameError(plain.err, fused.err) {
+				t.Fatalf("code %x gas %d: plain (%x, %d, %v), fused (%x, %d, %v)", code, gas, plain.ret, plain.gasLeft, plain.err, fused.ret, fused.gasLeft, fused.err)
+			}
+			if !reflect.DeepEqual(plain.storage, fused.storage) {
+				t.Fatalf("code %x gas %d: plain storage %v, fused storage %v", code, gas, plain.storage, fused.storage)
+			}
+			if !reflect.DeepEqual(plain.logs, fused.logs) {
+				t.Fatalf("code %x gas %d: plain logs %v, fused logs %v", code, gas, plain.logs, fused.logs)
+			}
+		}
+	})
+}
+
+func sameError(a, b error) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return errors.Is(a, b) || a.Error() == b.Error()
+}
@@ -70,6 +70,15 @@ type Config struct {
 	PragueTime   *big.Int `json:"pragueTime,omitempty"`
 	OsakaTime    *big.Int `json:"osakaTime,omitempty"`
 
+	// EIP-7692: EVM Object Format (EOF) v1. Not scheduled for any mainnet fork, used by EOF devnets
+	EOFTime *big.Int `json:"eofTime,omitempty"`
+
+	// EIP-7928: Block-level access lists. Not scheduled for any mainnet fork, used by devnets
+	BlockAccessListTime *big.Int `json:"blockAccessListTime,omitempty"`
+
+	// EIP-7805: Fork-choice enforced inclusion lists (FOCIL). Not scheduled for any mainnet fork, used by devnets
+	FocilTime *big.Int `json:"focilTime,omitempty"`
+
 	// Optional EIP-4844 parameters (see also EIP-7691 & EIP-7840)
 	MinBlobGasPrice *uint64       `json:"minBlobGasPrice,omitempty"`
 	BlobSchedule    *BlobSchedule `json:"blobSchedule,omitempty"`
@@ -80,6 +89,10 @@ type Config struct {
 	// see https://github.com/gnosischain/specs/blob/master/network-upgrades/pectra.md#eip-4844-pectra.
 	BurntContract map[string]common.Address `json:"burntContract,omitempty"`
 
+	// (Optional) activation of custom precompiles registered by the node build, see vm.RegisterPrecompile.
+	// A key is the name the precompile was registered with.
+	CustomPrecompiles map[string]PrecompileActivation `json:"customPrecompiles,omitempty"`
+
 	// (Optional) deposit contract of PoS chains
 	// See also EIP-6110: Supply validator deposits on chain
 	DepositContract common.Address `json:"depositContractAddress,omitempty"`
@@ -365,6 +378,21 @@ func (c *Config) IsOsaka(time uint64) bool {
 	return isForked(c.OsakaTime, time)
 }
 
+// IsEOF returns whether time is either equal to the EOF activation time or greater.
+func (c *Config) IsEOF(time uint64) bool {
+	return isForked(c.EOFTime, time)
+}
+
+// IsBlockAccessList returns whether time is either equal to the block access list activation time or greater.
+func (c *Config) IsBlockAccessList(time uint64) bool {
+	return isForked(c.BlockAccessListTime, time)
+}
+
+// IsFocil returns whether time is either equal to the FOCIL activation time or greater.
+func (c *Config) IsFocil(time uint64) bool {
+	return isForked(c.FocilTime, time)
+}
+
 func (c *Config) GetBurntContract(num uint64) *common.Address {
 	if len(c.BurntContract) == 0 {
 		return nil
@@ -419,19 +447,23 @@ func (c *Config) SecondsPerSlot() uint64 {
 }
 
 // CheckCompatible checks whether scheduled fork transitions have been imported
-// with a mismatching chain configuration.
-func (c *Config) CheckCompatible(newcfg *Config, height uint64) *ConfigCompatError {
-	bhead := height
+// with a mismatching chain configuration. height and time are those of the head block.
+func (c *Config) CheckCompatible(newcfg *Config, height uint64, time uint64) *ConfigCompatError {
+	bhead, btime := height, time
 
 	// Iterate checkCompatible to find the lowest conflict.
 	var lasterr *ConfigCompatError
 	for {
-		err := c.checkCompatible(newcfg, bhead)
-		if err == nil || (lasterr != nil && err.RewindTo == lasterr.RewindTo) {
+		err := c.checkCompatible(newcfg, bhead, btime)
+		if err == nil || (lasterr != nil && err.RewindTo == lasterr.RewindTo && err.RewindToTime == lasterr.RewindToTime) {
 			break
 		}
 		lasterr = err
-		bhead = err.RewindTo
+		if err.RewindToTime > 0 {
+			btime = err.RewindToTime
+		} else {
+			bhead = err.RewindTo
+		}
 	}
 	return lasterr
 }
@@ -491,7 +523,7 @@ func (c *Config) CheckConfigForkOrder() error {
 	return nil
 }
 
-func (c *Config) checkCompatible(newcfg *Config, head uint64) *ConfigCompatError {
+func (c *Config) checkCompatible(newcfg *Config, head uint64, headTime uint64) *ConfigCompatError {
 	// returns true if a fork scheduled at s1 cannot be rescheduled to block s2 because head is already past the fork.
 	incompatible := func(s1, s2 *big.Int, head uint64) bool {
 		return (isForked(s1, head) || isForked(s2, head)) && !numEqual(s1, s2)
@@ -547,6 +579,22 @@ func (c *Config) checkCompatible(newcfg *Config, head uint64) *ConfigCompatError
 	if incompatible(c.MergeNetsplitBlock, newcfg.MergeNetsplitBlock, head) {
 		return newCompatError("Merge netsplit block", c.MergeNetsplitBlock, newcfg.MergeNetsplitBlock)
 	}
+	for name, a := range c.CustomPrecompiles {
+		if newBlock := newcfg.CustomPrecompiles[name].Block; incompatible(a.Block, newBlock, head) {
+			return newCompatError("custom precompile "+name+" block", a.Block, newBlock)
+		}
+		if newTime := newcfg.CustomPrecompiles[name].Time; incompatible(a.Time, newTime, headTime) {
+			return newTimestampCompatError("custom precompile "+name+" time", a.Time, newTime)
+		}
+	}
+	for name, a := range newcfg.CustomPrecompiles {
+		if _, ok := c.CustomPrecompiles[name]; !ok && isForked(a.Block, head) {
+			return newCompatError("custom precompile "+name+" block", nil, a.Block)
+		}
+		if _, ok := c.CustomPrecompiles[name]; !ok && isForked(a.Time, headTime) {
+			return newTimestampCompatError("custom precompile "+name+" time", nil, a.Time)
+		}
+	}
 
 	return nil
 }
@@ -565,30 +613,47 @@ func numEqual(x, y *big.Int) bool {
 // ChainConfig that would alter the past.
 type ConfigCompatError struct {
 	What string
-	// block numbers of the stored and new configurations
+	// block numbers (timestamps for time-based forks) of the stored and new configurations
 	StoredConfig, NewConfig *big.Int
 	// the block number to which the local chain must be rewound to correct the error
 	RewindTo uint64
+	// the timestamp to which the local chain must be rewound to correct the error of a time-based fork
+	RewindToTime uint64
 }
 
 func newCompatError(what string, storedblock, newblock *big.Int) *ConfigCompatError {
+	err := &ConfigCompatError{What: what, StoredConfig: storedblock, NewConfig: newblock}
+	err.RewindTo = rewindTo(storedblock, newblock)
+	return err
+}
+
+func newTimestampCompatError(what string, storedtime, newtime *big.Int) *ConfigCompatError {
+	err := &ConfigCompatError{What: what, StoredConfig: storedtime, NewConfig: newtime}
+	err.RewindToTime = rewindTo(storedtime, newtime)
+	return err
+}
+
+// rewindTo returns the block number (or timestamp) just before the earlier of the two fork points
+func rewindTo(stored, new *big.Int) uint64 {
 	var rew *big.Int
 	switch {
-	case storedblock == nil:
-		rew = newblock
-	case newblock == nil || storedblock.Cmp(newblock) < 0:
-		rew = storedblock
+	case stored == nil:
+		rew = new
+	case new == nil || stored.Cmp(new) < 0:
+		rew = stored
 	default:
-		rew = newblock
+		rew = new
 	}
-	err := &ConfigCompatError{what, storedblock, newblock, 0}
 	if rew != nil && rew.Sign() > 0 {
-		err.RewindTo = rew.Uint64() - 1
+		return rew.Uint64() - 1
 	}
-	return err
+	return 0
 }
 
 func (err *ConfigCompatError) Error() string {
+	if err.RewindToTime > 0 {
+		return fmt.Sprintf("mismatching %s in database (have timestamp %d, want timestamp %d, rewindto timestamp %d)", err.What, err.StoredConfig, err.NewConfig, err.RewindToTime)
+	}
 	return fmt.Sprintf("mismatching %s in database (have %d, want %d, rewindto %d)", err.What, err.StoredConfig, err.NewConfig, err.RewindTo)
 }
 
@@ -648,8 +713,9 @@ type Rules struct {
 	IsByzantium, IsConstantinople, IsPetersburg       bool
 	IsIstanbul, IsBerlin, IsLondon, IsShanghai        bool
 	IsCancun, IsNapoli                                bool
-	IsPrague, IsOsaka                                 bool
+	IsPrague, IsOsaka, IsEOF                          bool
 	IsAura                                            bool
+	CustomPrecompiles                                 map[string]bool // names of active custom precompiles, nil if none is configured
 }
 
 // Rules ensures c's ChainID is not nil and returns a new Rules instance
@@ -675,8 +741,39 @@ func (c *Config) Rules(num uint64, time uint64) *Rules {
 		IsNapoli:           c.IsNapoli(num),
 		IsPrague:           c.IsPrague(time),
 		IsOsaka:            c.IsOsaka(time),
+		IsEOF:              c.IsEOF(time),
 		IsAura:             c.Aura != nil,
+		CustomPrecompiles:  c.activeCustomPrecompiles(num, time),
+	}
+}
+
+// PrecompileActivation schedules a custom precompile either by block number or by block time.
+type PrecompileActivation struct {
+	Block *big.Int `json:"block,omitempty"`
+	Time  *big.Int `json:"time,omitempty"`
+}
+
+// IsCustomPrecompileActive returns whether the custom precompile registered under the given name is active
+// at the given block number and time.
+func (c *Config) IsCustomPrecompileActive(name string, num uint64, time uint64) bool {
+	a, ok := c.CustomPrecompiles[name]
+	if !ok {
+		return false
+	}
+	return isForked(a.Block, num) || isForked(a.Time, time)
+}
+
+func (c *Config) activeCustomPrecompiles(num uint64, time uint64) map[string]bool {
+	var active map[string]bool
+	for name := range c.CustomPrecompiles {
+		if c.IsCustomPrecompileActive(name, num, time) {
+			if active == nil {
+				active = make(map[string]bool, len(c.CustomPrecompiles))
+			}
+			active[name] = true
+		}
 	}
+	return active
 }
 
 // isForked returns whether a fork scheduled at block s is active at the given head block.
@@ -17,6 +17,7 @@
 package chain
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -82,3 +83,37 @@ func TestNilBlobSchedule(t *testing.T) {
 	assert.Equal(t, uint64(9), b.MaxBlobsPerBlock(isPrague))
 	assert.Equal(t, uint64(5007716), b.BaseFeeUpdateFraction(isPrague))
 }
+
+func TestCustomPrecompiles(t *testing.T) {
+	c := &Config{CustomPrecompiles: map[string]PrecompileActivation{
+		"allowlist": {Block: big.NewInt(10)},
+		"minter":    {Time: big.NewInt(1000)},
+	}}
+	assert.Nil(t, c.Rules(9, 999).CustomPrecompiles)
+	assert.Equal(t, map[string]bool{"allowlist": true}, c.Rules(10, 999).CustomPrecompiles)
+	assert.Equal(t, map[string]bool{"allowlist": true, "minter": true}, c.Rules(10, 1000).CustomPrecompiles)
+	assert.False(t, c.IsCustomPrecompileActive("unknown", 10, 1000))
+
+	rescheduled := &Config{CustomPrecompiles: map[string]PrecompileActivation{
+		"allowlist": {Block: big.NewInt(20)},
+	}}
+	assert.Nil(t, c.CheckCompatible(rescheduled, 9, 0))
+	assert.NotNil(t, c.CheckCompatible(rescheduled, 10, 0))
+	assert.NotNil(t, (&Config{}).CheckCompatible(rescheduled, 20, 0))
+
+	retimed := &Config{CustomPrecompiles: map[string]PrecompileActivation{
+		"allowlist": {Block: big.NewInt(10)},
+		"minter":    {Time: big.NewInt(2000)},
+	}}
+	assert.Nil(t, c.CheckCompatible(retimed, 100, 999))
+	err := c.CheckCompatible(retimed, 100, 1000)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "custom precompile minter time", err.What)
+		assert.Equal(t, uint64(999), err.RewindToTime)
+		assert.Equal(t, uint64(0), err.RewindTo)
+	}
+	err = (&Config{}).CheckCompatible(&Config{CustomPrecompiles: map[string]PrecompileActivation{"minter": {Time: big.NewInt(1000)}}}, 0, 1500)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, uint64(999), err.RewindToTime)
+	}
+}
@@ -190,6 +190,10 @@ const (
 	SetCodeMagicPrefix  = byte(0x05)
 	PerEmptyAccountCost = 25000
 	PerAuthBaseCost     = 12500
+
+	// EIP-7069: Revamped CALL instructions (EOF)
+	ExtCallMinRetainedGas uint64 = 5000 // Minimum gas kept by the caller of EXTCALL, EXTDELEGATECALL and EXTSTATICCALL
+	ExtCallMinCalleeGas   uint64 = 2300 // Calls, which would give less gas to the callee, fail without executing it
 )
 
 // EIP-7702: Set EOA account code
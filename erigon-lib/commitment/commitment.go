@@ -634,6 +634,37 @@ func (branchData BranchData) decodeCells() (touchMap, afterMap uint16, row [16]*
 	return
 }
 
+// DiffCellHashes returns nibbles of cells which are present only in one of branches or have different hashes.
+// Cells without hash on any side are compared only by presence.
+func (branchData BranchData) DiffCellHashes(other BranchData) ([]int, error) {
+	if len(branchData) < 4 || len(other) < 4 {
+		if len(branchData) == len(other) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("branch data too short: %d vs %d", len(branchData), len(other))
+	}
+	_, _, row1, err := branchData.decodeCells()
+	if err != nil {
+		return nil, err
+	}
+	_, _, row2, err := other.decodeCells()
+	if err != nil {
+		return nil, err
+	}
+	var diff []int
+	for nibble := range row1 {
+		c1, c2 := row1[nibble], row2[nibble]
+		switch {
+		case c1 == nil && c2 == nil:
+		case c1 == nil || c2 == nil:
+			diff = append(diff, nibble)
+		case c1.hashLen > 0 && c2.hashLen > 0 && !bytes.Equal(c1.hash[:c1.hashLen], c2.hash[:c2.hashLen]):
+			diff = append(diff, nibble)
+		}
+	}
+	return diff, nil
+}
+
 type BranchMerger struct {
 	buf []byte
 	num [4]byte
@@ -398,3 +398,49 @@ func TestUpdates_TouchPlainKey(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, len(uniqUpds), i)
 }
+
+func TestBranchData_DiffCellHashes(t *testing.T) {
+	t.Parallel()
+
+	row, bm := generateCellRow(t, 16)
+	encode := func() BranchData {
+		be := NewBranchEncoder(1024)
+		enc, _, err := be.EncodeBranch(bm, bm, bm, func(nibble int, skip bool) (*cell, error) {
+			return row[nibble], nil
+		})
+		require.NoError(t, err)
+		return common.Copy(enc)
+	}
+
+	original := encode()
+	diff, err := original.DiffCellHashes(original)
+	require.NoError(t, err)
+	require.Empty(t, diff)
+
+	row[3].hash[0] ^= 0xff
+	changedHash := encode()
+	diff, err = original.DiffCellHashes(changedHash)
+	require.NoError(t, err)
+	require.Equal(t, []int{3}, diff)
+
+	bm &^= 1 << 7
+	missingCell := encode()
+	diff, err = original.DiffCellHashes(missingCell)
+	require.NoError(t, err)
+	require.Equal(t, []int{3, 7}, diff)
+}
+
+func TestCompactBytesToHexNibbles(t *testing.T) {
+	t.Parallel()
+
+	for _, nibbles := range [][]byte{
+		{},
+		{0x1},
+		{0x1, 0x2},
+		{0xa, 0xb, 0xc},
+		{0x1, 0x2, 0x3, terminatorHexByte},
+		{0x1, 0x2, terminatorHexByte},
+	} {
+		require.Equal(t, nibbles, CompactBytesToHexNibbles(hexNibblesToCompactBytes(nibbles)), "nibbles %x", nibbles)
+	}
+}
@@ -70,6 +70,25 @@ func hexNibblesToCompactBytes(key []byte) []byte {
 	return buf
 }
 
+// CompactBytesToHexNibbles is the inverse of hexNibblesToCompactBytes: it expands compact key (as used for branch prefixes)
+// back into hex nibbles, appending terminator if compact key has one.
+func CompactBytesToHexNibbles(compact []byte) []byte {
+	if len(compact) == 0 {
+		return nil
+	}
+	nibbles := make([]byte, 0, len(compact)*2)
+	if compact[0]&0x10 != 0 { // odd length, first nibble is stored in the flag byte
+		nibbles = append(nibbles, compact[0]&0xf)
+	}
+	for _, b := range compact[1:] {
+		nibbles = append(nibbles, b>>4, b&0xf)
+	}
+	if compact[0]&0x20 != 0 {
+		nibbles = append(nibbles, terminatorHexByte)
+	}
+	return nibbles
+}
+
 // hasTerm returns whether a hex nibble key has the terminator flag.
 func hasTerm(s []byte) bool {
 	return len(s) > 0 && s[len(s)-1] == terminatorHexByte
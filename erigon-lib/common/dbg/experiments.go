@@ -71,6 +71,9 @@ var (
 	numWorkers    = runtime.NumCPU() / 2
 	Exec3Workers  = EnvInt("EXEC3_WORKERS", numWorkers)
 
+	// amount of goroutines splitting buckets of one recsplit index (see recsplit.RecSplitArgs.Workers)
+	RecsplitWorkers = EnvInt("RECSPLIT_WORKERS", runtime.GOMAXPROCS(0))
+
 	TraceAccounts        = EnvStrings("TRACE_ACCOUNTS", ",", nil)
 	TraceStateKeys       = EnvStrings("TRACE_STATE_KEYS", ",", nil)
 	TraceInstructions    = EnvBool("TRACE_INSTRUCTIONS", false)
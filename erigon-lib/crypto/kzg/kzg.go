@@ -25,12 +25,14 @@ import (
 	"os"
 	"sync"
 
+	goethkzg "github.com/crate-crypto/go-eth-kzg"
 	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
 )
 
 const (
 	BlobCommitmentVersionKZG uint8 = 0x01
 	PrecompileInputLength    int   = 192
+	CellsPerExtBlob          int   = goethkzg.CellsPerExtBlob // EIP-7594: number of cells (and cell proofs) of extended blob
 )
 
 type VersionedHash [32]byte
@@ -45,6 +47,9 @@ var (
 
 	gokzgCtx      *gokzg4844.Context
 	initCryptoCtx sync.Once
+
+	goethkzgCtx *goethkzg.Context
+	initCellCtx sync.Once
 )
 
 func init() {
@@ -94,6 +99,79 @@ func Ctx() *gokzg4844.Context {
 	return gokzgCtx
 }
 
+// CellCtx returns a context object to create and verify EIP-7594 (PeerDAS) cell proofs. Like Ctx,
+// it's expensive to initialize and uses trusted setup file of SetTrustedSetupFilePath if set.
+func CellCtx() *goethkzg.Context {
+	initCellCtx.Do(func() {
+		var err error
+		if trustedSetupFile != "" {
+			file, err := os.ReadFile(trustedSetupFile)
+			if err != nil {
+				panic(fmt.Sprintf("could not read file, err: %v", err))
+			}
+
+			setup := new(goethkzg.JSONTrustedSetup)
+			if err = json.Unmarshal(file, setup); err != nil {
+				panic(fmt.Sprintf("could not unmarshal, err: %v", err))
+			}
+
+			goethkzgCtx, err = goethkzg.NewContext4096(setup)
+			if err != nil {
+				panic(fmt.Sprintf("could not create KZG context, err: %v", err))
+			}
+		} else if goethkzgCtx, err = goethkzg.NewContext4096Secure(); err != nil {
+			panic(fmt.Sprintf("could not create context, err : %v", err))
+		}
+	})
+	return goethkzgCtx
+}
+
+// ComputeCellProofs computes CellsPerExtBlob cell proofs of blob (compute_cells_and_kzg_proofs from EIP-7594)
+func ComputeCellProofs(blob []byte) ([]gokzg4844.KZGProof, error) {
+	if len(blob) != len(goethkzg.Blob{}) {
+		return nil, errInvalidInputLength
+	}
+	_, proofs, err := CellCtx().ComputeCellsAndKZGProofs((*goethkzg.Blob)(blob), 0)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]gokzg4844.KZGProof, len(proofs))
+	for i := range proofs {
+		res[i] = gokzg4844.KZGProof(proofs[i])
+	}
+	return res, nil
+}
+
+// VerifyCellProofs verifies cell proofs of blobs: CellsPerExtBlob proofs per blob, in order of blobs and cells
+func VerifyCellProofs(blobs []gokzg4844.BlobRef, commitments []gokzg4844.KZGCommitment, cellProofs []gokzg4844.KZGProof) error {
+	if len(commitments) != len(blobs) || len(cellProofs) != len(blobs)*CellsPerExtBlob {
+		return errInvalidInputLength
+	}
+	ctx := CellCtx()
+	cellCommitments := make([]goethkzg.KZGCommitment, 0, len(cellProofs))
+	cellIndices := make([]uint64, 0, len(cellProofs))
+	cells := make([]*goethkzg.Cell, 0, len(cellProofs))
+	proofs := make([]goethkzg.KZGProof, len(cellProofs))
+	for i, blob := range blobs {
+		if len(blob) != len(goethkzg.Blob{}) {
+			return errInvalidInputLength
+		}
+		blobCells, err := ctx.ComputeCells((*goethkzg.Blob)(blob), 0)
+		if err != nil {
+			return err
+		}
+		for j, cell := range blobCells {
+			cellCommitments = append(cellCommitments, goethkzg.KZGCommitment(commitments[i]))
+			cellIndices = append(cellIndices, uint64(j))
+			cells = append(cells, cell)
+		}
+	}
+	for i := range cellProofs {
+		proofs[i] = goethkzg.KZGProof(cellProofs[i])
+	}
+	return ctx.VerifyCellKZGProofBatch(cellCommitments, cellIndices, cells, proofs)
+}
+
 // KZGToVersionedHash implements kzg_to_versioned_hash from EIP-4844
 func KZGToVersionedHash(kzg gokzg4844.KZGCommitment) VersionedHash {
 	h := sha256.Sum256(kzg[:])
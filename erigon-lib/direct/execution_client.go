@@ -53,6 +53,10 @@ func (s *ExecutionClientDirect) GetAssembledBlock(ctx context.Context, in *execu
 	return s.server.GetAssembledBlock(ctx, in)
 }
 
+func (s *ExecutionClientDirect) UpdateInclusionList(ctx context.Context, in *execution.UpdateInclusionListRequest, opts ...grpc.CallOption) (*execution.UpdateInclusionListResponse, error) {
+	return s.server.UpdateInclusionList(ctx, in)
+}
+
 // Chain Putters.
 func (s *ExecutionClientDirect) InsertBlocks(ctx context.Context, in *execution.InsertBlocksRequest, opts ...grpc.CallOption) (*execution.InsertionResult, error) {
 	return s.server.InsertBlocks(ctx, in)
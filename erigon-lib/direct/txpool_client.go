@@ -123,3 +123,146 @@ func (s *TxPoolClient) Nonce(ctx context.Context, in *txpool_proto.NonceRequest,
 func (s *TxPoolClient) GetBlobs(ctx context.Context, in *txpool_proto.GetBlobsRequest, opts ...grpc.CallOption) (*txpool_proto.GetBlobsReply, error) {
 	return s.server.GetBlobs(ctx, in)
 }
+
+func (s *TxPoolClient) TransactionStatus(ctx context.Context, in *txpool_proto.TransactionStatusRequest, opts ...grpc.CallOption) (*txpool_proto.TransactionStatusReply, error) {
+	return s.server.TransactionStatus(ctx, in)
+}
+
+// -- start Export
+
+func (s *TxPoolClient) Export(ctx context.Context, in *txpool_proto.ExportRequest, opts ...grpc.CallOption) (txpool_proto.Txpool_ExportClient, error) {
+	ch := make(chan *exportReply, 16)
+	streamServer := &TxPoolExportS{ch: ch, ctx: ctx}
+	go func() {
+		defer close(ch)
+		streamServer.Err(s.server.Export(in, streamServer))
+	}()
+	return &TxPoolExportC{ch: ch, ctx: ctx}, nil
+}
+
+type exportReply struct {
+	r   *txpool_proto.ExportReply
+	err error
+}
+
+type TxPoolExportS struct {
+	ch  chan *exportReply
+	ctx context.Context
+	grpc.ServerStream
+}
+
+func (s *TxPoolExportS) Send(m *txpool_proto.ExportReply) error {
+	select {
+	case s.ch <- &exportReply{r: m}:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+func (s *TxPoolExportS) Context() context.Context { return s.ctx }
+func (s *TxPoolExportS) Err(err error) {
+	if err == nil {
+		return
+	}
+	select {
+	case s.ch <- &exportReply{err: err}:
+	case <-s.ctx.Done():
+	}
+}
+
+type TxPoolExportC struct {
+	ch  chan *exportReply
+	ctx context.Context
+	grpc.ClientStream
+}
+
+func (c *TxPoolExportC) Recv() (*txpool_proto.ExportReply, error) {
+	m, ok := <-c.ch
+	if !ok || m == nil {
+		return nil, io.EOF
+	}
+	return m.r, m.err
+}
+func (c *TxPoolExportC) Context() context.Context { return c.ctx }
+
+// -- end Export
+
+// -- start Import
+
+func (s *TxPoolClient) Import(ctx context.Context, opts ...grpc.CallOption) (txpool_proto.Txpool_ImportClient, error) {
+	ch := make(chan *txpool_proto.ImportRequest, 16)
+	done := make(chan *importReply, 1)
+	streamServer := &TxPoolImportS{ch: ch, ctx: ctx}
+	go func() {
+		reply := &importReply{}
+		reply.err = s.server.Import(streamServer)
+		reply.r = streamServer.reply
+		done <- reply
+	}()
+	return &TxPoolImportC{ch: ch, done: done, ctx: ctx}, nil
+}
+
+type importReply struct {
+	r   *txpool_proto.ImportReply
+	err error
+}
+
+type TxPoolImportS struct {
+	ch    chan *txpool_proto.ImportRequest
+	reply *txpool_proto.ImportReply
+	ctx   context.Context
+	grpc.ServerStream
+}
+
+func (s *TxPoolImportS) Recv() (*txpool_proto.ImportRequest, error) {
+	select {
+	case m, ok := <-s.ch:
+		if !ok {
+			return nil, io.EOF
+		}
+		return m, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+func (s *TxPoolImportS) SendAndClose(m *txpool_proto.ImportReply) error {
+	s.reply = m
+	return nil
+}
+func (s *TxPoolImportS) Context() context.Context { return s.ctx }
+
+type TxPoolImportC struct {
+	ch     chan *txpool_proto.ImportRequest
+	done   chan *importReply
+	result *importReply
+	ctx    context.Context
+	grpc.ClientStream
+}
+
+func (c *TxPoolImportC) Send(m *txpool_proto.ImportRequest) error {
+	if c.result != nil {
+		return io.EOF
+	}
+	select {
+	case c.ch <- m:
+		return nil
+	case c.result = <-c.done: // server finished before reading all messages
+		return io.EOF
+	case <-c.ctx.Done():
+		return c.ctx.Err()
+	}
+}
+func (c *TxPoolImportC) CloseAndRecv() (*txpool_proto.ImportReply, error) {
+	if c.result == nil {
+		close(c.ch)
+		select {
+		case c.result = <-c.done:
+		case <-c.ctx.Done():
+			return nil, c.ctx.Err()
+		}
+	}
+	return c.result.r, c.result.err
+}
+func (c *TxPoolImportC) Context() context.Context { return c.ctx }
+
+// -- end Import
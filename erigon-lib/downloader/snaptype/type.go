@@ -53,14 +53,15 @@ func (f RangeExtractorFunc) Extract(ctx context.Context, blockFrom, blockTo uint
 	return f(ctx, blockFrom, blockTo, firstKey, db, chainConfig, collect, workers, lvl, logger)
 }
 
+// IndexBuilder builds indices of given segment. workers - amount of goroutines building one index (see recsplit.RecSplitArgs.Workers)
 type IndexBuilder interface {
-	Build(ctx context.Context, info FileInfo, salt uint32, chainConfig *chain.Config, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) error
+	Build(ctx context.Context, info FileInfo, salt uint32, chainConfig *chain.Config, tmpDir string, workers int, p *background.Progress, lvl log.Lvl, logger log.Logger) error
 }
 
-type IndexBuilderFunc func(ctx context.Context, info FileInfo, salt uint32, chainConfig *chain.Config, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) error
+type IndexBuilderFunc func(ctx context.Context, info FileInfo, salt uint32, chainConfig *chain.Config, tmpDir string, workers int, p *background.Progress, lvl log.Lvl, logger log.Logger) error
 
-func (f IndexBuilderFunc) Build(ctx context.Context, info FileInfo, salt uint32, chainConfig *chain.Config, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) error {
-	return f(ctx, info, salt, chainConfig, tmpDir, p, lvl, logger)
+func (f IndexBuilderFunc) Build(ctx context.Context, info FileInfo, salt uint32, chainConfig *chain.Config, tmpDir string, workers int, p *background.Progress, lvl log.Lvl, logger log.Logger) error {
+	return f(ctx, info, salt, chainConfig, tmpDir, workers, p, lvl, logger)
 }
 
 var saltMap = map[string]uint32{}
@@ -173,7 +174,7 @@ type Type interface {
 	IdxFileNames(version Version, from uint64, to uint64) []string
 	Indexes() []Index
 	HasIndexFiles(info FileInfo, logger log.Logger) bool
-	BuildIndexes(ctx context.Context, info FileInfo, indexBuilder IndexBuilder, chainConfig *chain.Config, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) error
+	BuildIndexes(ctx context.Context, info FileInfo, indexBuilder IndexBuilder, chainConfig *chain.Config, tmpDir string, workers int, p *background.Progress, lvl log.Lvl, logger log.Logger) error
 	ExtractRange(ctx context.Context, info FileInfo, rangeExtractor RangeExtractor, indexBuilder IndexBuilder, firstKeyGetter FirstKeyGetter, db kv.RoDB, chainConfig *chain.Config, tmpDir string, workers int, lvl log.Lvl, logger log.Logger) (uint64, error)
 
 	RangeExtractor() RangeExtractor
@@ -255,7 +256,7 @@ func (s snapType) Indexes() []Index {
 	return s.indexes
 }
 
-func (s snapType) BuildIndexes(ctx context.Context, info FileInfo, indexBuilder IndexBuilder, chainConfig *chain.Config, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) error {
+func (s snapType) BuildIndexes(ctx context.Context, info FileInfo, indexBuilder IndexBuilder, chainConfig *chain.Config, tmpDir string, workers int, p *background.Progress, lvl log.Lvl, logger log.Logger) error {
 	salt, err := GetIndexSalt(info.Dir())
 
 	if err != nil {
@@ -266,7 +267,7 @@ func (s snapType) BuildIndexes(ctx context.Context, info FileInfo, indexBuilder
 		indexBuilder = s.indexBuilder
 	}
 
-	return indexBuilder.Build(ctx, info, salt, chainConfig, tmpDir, p, lvl, logger)
+	return indexBuilder.Build(ctx, info, salt, chainConfig, tmpDir, workers, p, lvl, logger)
 }
 
 func (s snapType) HasIndexFiles(info FileInfo, logger log.Logger) bool {
@@ -387,8 +388,8 @@ func (e Enum) HasIndexFiles(info FileInfo, logger log.Logger) bool {
 	return e.Type().HasIndexFiles(info, logger)
 }
 
-func (e Enum) BuildIndexes(ctx context.Context, info FileInfo, indexBuilder IndexBuilder, chainConfig *chain.Config, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) error {
-	return e.Type().BuildIndexes(ctx, info, indexBuilder, chainConfig, tmpDir, p, lvl, logger)
+func (e Enum) BuildIndexes(ctx context.Context, info FileInfo, indexBuilder IndexBuilder, chainConfig *chain.Config, tmpDir string, workers int, p *background.Progress, lvl log.Lvl, logger log.Logger) error {
+	return e.Type().BuildIndexes(ctx, info, indexBuilder, chainConfig, tmpDir, workers, p, lvl, logger)
 }
 
 func ParseEnum(s string) (Enum, bool) {
@@ -558,7 +559,7 @@ func ExtractRange(ctx context.Context, f FileInfo, extractor RangeExtractor, ind
 
 	p := &background.Progress{}
 
-	if err := f.Type.BuildIndexes(ctx, f, indexBuilder, chainConfig, tmpDir, p, lvl, logger); err != nil {
+	if err := f.Type.BuildIndexes(ctx, f, indexBuilder, chainConfig, tmpDir, workers, p, lvl, logger); err != nil {
 		return lastKeyValue, err
 	}
 
@@ -1343,6 +1343,110 @@ func (x *GetAssembledBlockResponse) GetBusy() bool {
 	return false
 }
 
+type UpdateInclusionListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	InclusionList [][]byte               `protobuf:"bytes,2,rep,name=inclusion_list,json=inclusionList,proto3" json:"inclusion_list,omitempty"` // EIP-7805: transactions which must be included into assembled block
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateInclusionListRequest) Reset() {
+	*x = UpdateInclusionListRequest{}
+	mi := &file_execution_execution_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateInclusionListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateInclusionListRequest) ProtoMessage() {}
+
+func (x *UpdateInclusionListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_execution_execution_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateInclusionListRequest.ProtoReflect.Descriptor instead.
+func (*UpdateInclusionListRequest) Descriptor() ([]byte, []int) {
+	return file_execution_execution_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateInclusionListRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateInclusionListRequest) GetInclusionList() [][]byte {
+	if x != nil {
+		return x.InclusionList
+	}
+	return nil
+}
+
+type UpdateInclusionListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"` // block with given id is being assembled
+	Busy          bool                   `protobuf:"varint,2,opt,name=busy,proto3" json:"busy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateInclusionListResponse) Reset() {
+	*x = UpdateInclusionListResponse{}
+	mi := &file_execution_execution_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateInclusionListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateInclusionListResponse) ProtoMessage() {}
+
+func (x *UpdateInclusionListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_execution_execution_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateInclusionListResponse.ProtoReflect.Descriptor instead.
+func (*UpdateInclusionListResponse) Descriptor() ([]byte, []int) {
+	return file_execution_execution_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateInclusionListResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *UpdateInclusionListResponse) GetBusy() bool {
+	if x != nil {
+		return x.Busy
+	}
+	return false
+}
+
 type GetBodiesBatchResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Bodies        []*BlockBody           `protobuf:"bytes,1,rep,name=bodies,proto3" json:"bodies,omitempty"`
@@ -1352,7 +1456,7 @@ type GetBodiesBatchResponse struct {
 
 func (x *GetBodiesBatchResponse) Reset() {
 	*x = GetBodiesBatchResponse{}
-	mi := &file_execution_execution_proto_msgTypes[20]
+	mi := &file_execution_execution_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1364,7 +1468,7 @@ func (x *GetBodiesBatchResponse) String() string {
 func (*GetBodiesBatchResponse) ProtoMessage() {}
 
 func (x *GetBodiesBatchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_execution_execution_proto_msgTypes[20]
+	mi := &file_execution_execution_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1377,7 +1481,7 @@ func (x *GetBodiesBatchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBodiesBatchResponse.ProtoReflect.Descriptor instead.
 func (*GetBodiesBatchResponse) Descriptor() ([]byte, []int) {
-	return file_execution_execution_proto_rawDescGZIP(), []int{20}
+	return file_execution_execution_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetBodiesBatchResponse) GetBodies() []*BlockBody {
@@ -1396,7 +1500,7 @@ type GetBodiesByHashesRequest struct {
 
 func (x *GetBodiesByHashesRequest) Reset() {
 	*x = GetBodiesByHashesRequest{}
-	mi := &file_execution_execution_proto_msgTypes[21]
+	mi := &file_execution_execution_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1408,7 +1512,7 @@ func (x *GetBodiesByHashesRequest) String() string {
 func (*GetBodiesByHashesRequest) ProtoMessage() {}
 
 func (x *GetBodiesByHashesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_execution_execution_proto_msgTypes[21]
+	mi := &file_execution_execution_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1421,7 +1525,7 @@ func (x *GetBodiesByHashesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBodiesByHashesRequest.ProtoReflect.Descriptor instead.
 func (*GetBodiesByHashesRequest) Descriptor() ([]byte, []int) {
-	return file_execution_execution_proto_rawDescGZIP(), []int{21}
+	return file_execution_execution_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GetBodiesByHashesRequest) GetHashes() []*typesproto.H256 {
@@ -1441,7 +1545,7 @@ type GetBodiesByRangeRequest struct {
 
 func (x *GetBodiesByRangeRequest) Reset() {
 	*x = GetBodiesByRangeRequest{}
-	mi := &file_execution_execution_proto_msgTypes[22]
+	mi := &file_execution_execution_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1453,7 +1557,7 @@ func (x *GetBodiesByRangeRequest) String() string {
 func (*GetBodiesByRangeRequest) ProtoMessage() {}
 
 func (x *GetBodiesByRangeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_execution_execution_proto_msgTypes[22]
+	mi := &file_execution_execution_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1466,7 +1570,7 @@ func (x *GetBodiesByRangeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBodiesByRangeRequest.ProtoReflect.Descriptor instead.
 func (*GetBodiesByRangeRequest) Descriptor() ([]byte, []int) {
-	return file_execution_execution_proto_rawDescGZIP(), []int{22}
+	return file_execution_execution_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GetBodiesByRangeRequest) GetStart() uint64 {
@@ -1492,7 +1596,7 @@ type ReadyResponse struct {
 
 func (x *ReadyResponse) Reset() {
 	*x = ReadyResponse{}
-	mi := &file_execution_execution_proto_msgTypes[23]
+	mi := &file_execution_execution_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1504,7 +1608,7 @@ func (x *ReadyResponse) String() string {
 func (*ReadyResponse) ProtoMessage() {}
 
 func (x *ReadyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_execution_execution_proto_msgTypes[23]
+	mi := &file_execution_execution_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1517,7 +1621,7 @@ func (x *ReadyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReadyResponse.ProtoReflect.Descriptor instead.
 func (*ReadyResponse) Descriptor() ([]byte, []int) {
-	return file_execution_execution_proto_rawDescGZIP(), []int{23}
+	return file_execution_execution_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *ReadyResponse) GetReady() bool {
@@ -1537,7 +1641,7 @@ type FrozenBlocksResponse struct {
 
 func (x *FrozenBlocksResponse) Reset() {
 	*x = FrozenBlocksResponse{}
-	mi := &file_execution_execution_proto_msgTypes[24]
+	mi := &file_execution_execution_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1549,7 +1653,7 @@ func (x *FrozenBlocksResponse) String() string {
 func (*FrozenBlocksResponse) ProtoMessage() {}
 
 func (x *FrozenBlocksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_execution_execution_proto_msgTypes[24]
+	mi := &file_execution_execution_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1562,7 +1666,7 @@ func (x *FrozenBlocksResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FrozenBlocksResponse.ProtoReflect.Descriptor instead.
 func (*FrozenBlocksResponse) Descriptor() ([]byte, []int) {
-	return file_execution_execution_proto_rawDescGZIP(), []int{24}
+	return file_execution_execution_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *FrozenBlocksResponse) GetFrozenBlocks() uint64 {
@@ -1588,7 +1692,7 @@ type HasBlockResponse struct {
 
 func (x *HasBlockResponse) Reset() {
 	*x = HasBlockResponse{}
-	mi := &file_execution_execution_proto_msgTypes[25]
+	mi := &file_execution_execution_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1600,7 +1704,7 @@ func (x *HasBlockResponse) String() string {
 func (*HasBlockResponse) ProtoMessage() {}
 
 func (x *HasBlockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_execution_execution_proto_msgTypes[25]
+	mi := &file_execution_execution_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1613,7 +1717,7 @@ func (x *HasBlockResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HasBlockResponse.ProtoReflect.Descriptor instead.
 func (*HasBlockResponse) Descriptor() ([]byte, []int) {
-	return file_execution_execution_proto_rawDescGZIP(), []int{25}
+	return file_execution_execution_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *HasBlockResponse) GetHasBlock() bool {
@@ -1750,7 +1854,13 @@ const file_execution_execution_proto_rawDesc = "" +
 	"\x19GetAssembledBlockResponse\x126\n" +
 	"\x04data\x18\x01 \x01(\v2\x1d.execution.AssembledBlockDataH\x00R\x04data\x88\x01\x01\x12\x12\n" +
 	"\x04busy\x18\x02 \x01(\bR\x04busyB\a\n" +
-	"\x05_data\"F\n" +
+	"\x05_data\"S\n" +
+	"\x1aUpdateInclusionListRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12%\n" +
+	"\x0einclusion_list\x18\x02 \x03(\fR\rinclusionList\"G\n" +
+	"\x1bUpdateInclusionListResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12\x12\n" +
+	"\x04busy\x18\x02 \x01(\bR\x04busy\"F\n" +
 	"\x16GetBodiesBatchResponse\x12,\n" +
 	"\x06bodies\x18\x01 \x03(\v2\x14.execution.BlockBodyR\x06bodies\"?\n" +
 	"\x18GetBodiesByHashesRequest\x12#\n" +
@@ -1772,14 +1882,15 @@ const file_execution_execution_proto_rawDesc = "" +
 	"TooFarAway\x10\x02\x12\x12\n" +
 	"\x0eMissingSegment\x10\x03\x12\x15\n" +
 	"\x11InvalidForkchoice\x10\x04\x12\b\n" +
-	"\x04Busy\x10\x052\x86\n" +
+	"\x04Busy\x10\x052\xec\n" +
 	"\n" +
 	"\tExecution\x12J\n" +
 	"\fInsertBlocks\x12\x1e.execution.InsertBlocksRequest\x1a\x1a.execution.InsertionResult\x12K\n" +
 	"\rValidateChain\x12\x1c.execution.ValidationRequest\x1a\x1c.execution.ValidationReceipt\x12G\n" +
 	"\x10UpdateForkChoice\x12\x15.execution.ForkChoice\x1a\x1c.execution.ForkChoiceReceipt\x12R\n" +
 	"\rAssembleBlock\x12\x1f.execution.AssembleBlockRequest\x1a .execution.AssembleBlockResponse\x12^\n" +
-	"\x11GetAssembledBlock\x12#.execution.GetAssembledBlockRequest\x1a$.execution.GetAssembledBlockResponse\x12E\n" +
+	"\x11GetAssembledBlock\x12#.execution.GetAssembledBlockRequest\x1a$.execution.GetAssembledBlockResponse\x12d\n" +
+	"\x13UpdateInclusionList\x12%.execution.UpdateInclusionListRequest\x1a&.execution.UpdateInclusionListResponse\x12E\n" +
 	"\rCurrentHeader\x12\x16.google.protobuf.Empty\x1a\x1c.execution.GetHeaderResponse\x12?\n" +
 	"\x05GetTD\x12\x1c.execution.GetSegmentRequest\x1a\x18.execution.GetTDResponse\x12G\n" +
 	"\tGetHeader\x12\x1c.execution.GetSegmentRequest\x1a\x1c.execution.GetHeaderResponse\x12C\n" +
@@ -1806,7 +1917,7 @@ func file_execution_execution_proto_rawDescGZIP() []byte {
 }
 
 var file_execution_execution_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_execution_execution_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_execution_execution_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_execution_execution_proto_goTypes = []any{
 	(ExecutionStatus)(0),                // 0: execution.ExecutionStatus
 	(*ForkChoiceReceipt)(nil),           // 1: execution.ForkChoiceReceipt
@@ -1829,103 +1940,107 @@ var file_execution_execution_proto_goTypes = []any{
 	(*GetAssembledBlockRequest)(nil),    // 18: execution.GetAssembledBlockRequest
 	(*AssembledBlockData)(nil),          // 19: execution.AssembledBlockData
 	(*GetAssembledBlockResponse)(nil),   // 20: execution.GetAssembledBlockResponse
-	(*GetBodiesBatchResponse)(nil),      // 21: execution.GetBodiesBatchResponse
-	(*GetBodiesByHashesRequest)(nil),    // 22: execution.GetBodiesByHashesRequest
-	(*GetBodiesByRangeRequest)(nil),     // 23: execution.GetBodiesByRangeRequest
-	(*ReadyResponse)(nil),               // 24: execution.ReadyResponse
-	(*FrozenBlocksResponse)(nil),        // 25: execution.FrozenBlocksResponse
-	(*HasBlockResponse)(nil),            // 26: execution.HasBlockResponse
-	(*typesproto.H256)(nil),             // 27: types.H256
-	(*typesproto.H160)(nil),             // 28: types.H160
-	(*typesproto.H2048)(nil),            // 29: types.H2048
-	(*typesproto.Withdrawal)(nil),       // 30: types.Withdrawal
-	(*typesproto.ExecutionPayload)(nil), // 31: types.ExecutionPayload
-	(*typesproto.BlobsBundleV1)(nil),    // 32: types.BlobsBundleV1
-	(*typesproto.RequestsBundle)(nil),   // 33: types.RequestsBundle
-	(*emptypb.Empty)(nil),               // 34: google.protobuf.Empty
+	(*UpdateInclusionListRequest)(nil),  // 21: execution.UpdateInclusionListRequest
+	(*UpdateInclusionListResponse)(nil), // 22: execution.UpdateInclusionListResponse
+	(*GetBodiesBatchResponse)(nil),      // 23: execution.GetBodiesBatchResponse
+	(*GetBodiesByHashesRequest)(nil),    // 24: execution.GetBodiesByHashesRequest
+	(*GetBodiesByRangeRequest)(nil),     // 25: execution.GetBodiesByRangeRequest
+	(*ReadyResponse)(nil),               // 26: execution.ReadyResponse
+	(*FrozenBlocksResponse)(nil),        // 27: execution.FrozenBlocksResponse
+	(*HasBlockResponse)(nil),            // 28: execution.HasBlockResponse
+	(*typesproto.H256)(nil),             // 29: types.H256
+	(*typesproto.H160)(nil),             // 30: types.H160
+	(*typesproto.H2048)(nil),            // 31: types.H2048
+	(*typesproto.Withdrawal)(nil),       // 32: types.Withdrawal
+	(*typesproto.ExecutionPayload)(nil), // 33: types.ExecutionPayload
+	(*typesproto.BlobsBundleV1)(nil),    // 34: types.BlobsBundleV1
+	(*typesproto.RequestsBundle)(nil),   // 35: types.RequestsBundle
+	(*emptypb.Empty)(nil),               // 36: google.protobuf.Empty
 }
 var file_execution_execution_proto_depIdxs = []int32{
 	0,  // 0: execution.ForkChoiceReceipt.status:type_name -> execution.ExecutionStatus
-	27, // 1: execution.ForkChoiceReceipt.latest_valid_hash:type_name -> types.H256
+	29, // 1: execution.ForkChoiceReceipt.latest_valid_hash:type_name -> types.H256
 	0,  // 2: execution.ValidationReceipt.validation_status:type_name -> execution.ExecutionStatus
-	27, // 3: execution.ValidationReceipt.latest_valid_hash:type_name -> types.H256
-	27, // 4: execution.Header.parent_hash:type_name -> types.H256
-	28, // 5: execution.Header.coinbase:type_name -> types.H160
-	27, // 6: execution.Header.state_root:type_name -> types.H256
-	27, // 7: execution.Header.receipt_root:type_name -> types.H256
-	29, // 8: execution.Header.logs_bloom:type_name -> types.H2048
-	27, // 9: execution.Header.prev_randao:type_name -> types.H256
-	27, // 10: execution.Header.difficulty:type_name -> types.H256
-	27, // 11: execution.Header.block_hash:type_name -> types.H256
-	27, // 12: execution.Header.ommer_hash:type_name -> types.H256
-	27, // 13: execution.Header.transaction_hash:type_name -> types.H256
-	27, // 14: execution.Header.base_fee_per_gas:type_name -> types.H256
-	27, // 15: execution.Header.withdrawal_hash:type_name -> types.H256
-	27, // 16: execution.Header.parent_beacon_block_root:type_name -> types.H256
-	27, // 17: execution.Header.requests_hash:type_name -> types.H256
-	27, // 18: execution.BlockBody.block_hash:type_name -> types.H256
+	29, // 3: execution.ValidationReceipt.latest_valid_hash:type_name -> types.H256
+	29, // 4: execution.Header.parent_hash:type_name -> types.H256
+	30, // 5: execution.Header.coinbase:type_name -> types.H160
+	29, // 6: execution.Header.state_root:type_name -> types.H256
+	29, // 7: execution.Header.receipt_root:type_name -> types.H256
+	31, // 8: execution.Header.logs_bloom:type_name -> types.H2048
+	29, // 9: execution.Header.prev_randao:type_name -> types.H256
+	29, // 10: execution.Header.difficulty:type_name -> types.H256
+	29, // 11: execution.Header.block_hash:type_name -> types.H256
+	29, // 12: execution.Header.ommer_hash:type_name -> types.H256
+	29, // 13: execution.Header.transaction_hash:type_name -> types.H256
+	29, // 14: execution.Header.base_fee_per_gas:type_name -> types.H256
+	29, // 15: execution.Header.withdrawal_hash:type_name -> types.H256
+	29, // 16: execution.Header.parent_beacon_block_root:type_name -> types.H256
+	29, // 17: execution.Header.requests_hash:type_name -> types.H256
+	29, // 18: execution.BlockBody.block_hash:type_name -> types.H256
 	4,  // 19: execution.BlockBody.uncles:type_name -> execution.Header
-	30, // 20: execution.BlockBody.withdrawals:type_name -> types.Withdrawal
+	32, // 20: execution.BlockBody.withdrawals:type_name -> types.Withdrawal
 	4,  // 21: execution.Block.header:type_name -> execution.Header
 	5,  // 22: execution.Block.body:type_name -> execution.BlockBody
 	4,  // 23: execution.GetHeaderResponse.header:type_name -> execution.Header
-	27, // 24: execution.GetTDResponse.td:type_name -> types.H256
+	29, // 24: execution.GetTDResponse.td:type_name -> types.H256
 	5,  // 25: execution.GetBodyResponse.body:type_name -> execution.BlockBody
-	27, // 26: execution.GetSegmentRequest.block_hash:type_name -> types.H256
+	29, // 26: execution.GetSegmentRequest.block_hash:type_name -> types.H256
 	6,  // 27: execution.InsertBlocksRequest.blocks:type_name -> execution.Block
-	27, // 28: execution.ForkChoice.head_block_hash:type_name -> types.H256
-	27, // 29: execution.ForkChoice.finalized_block_hash:type_name -> types.H256
-	27, // 30: execution.ForkChoice.safe_block_hash:type_name -> types.H256
+	29, // 28: execution.ForkChoice.head_block_hash:type_name -> types.H256
+	29, // 29: execution.ForkChoice.finalized_block_hash:type_name -> types.H256
+	29, // 30: execution.ForkChoice.safe_block_hash:type_name -> types.H256
 	0,  // 31: execution.InsertionResult.result:type_name -> execution.ExecutionStatus
-	27, // 32: execution.ValidationRequest.hash:type_name -> types.H256
-	27, // 33: execution.AssembleBlockRequest.parent_hash:type_name -> types.H256
-	27, // 34: execution.AssembleBlockRequest.prev_randao:type_name -> types.H256
-	28, // 35: execution.AssembleBlockRequest.suggested_fee_recipient:type_name -> types.H160
-	30, // 36: execution.AssembleBlockRequest.withdrawals:type_name -> types.Withdrawal
-	27, // 37: execution.AssembleBlockRequest.parent_beacon_block_root:type_name -> types.H256
-	31, // 38: execution.AssembledBlockData.execution_payload:type_name -> types.ExecutionPayload
-	27, // 39: execution.AssembledBlockData.block_value:type_name -> types.H256
-	32, // 40: execution.AssembledBlockData.blobs_bundle:type_name -> types.BlobsBundleV1
-	33, // 41: execution.AssembledBlockData.requests:type_name -> types.RequestsBundle
+	29, // 32: execution.ValidationRequest.hash:type_name -> types.H256
+	29, // 33: execution.AssembleBlockRequest.parent_hash:type_name -> types.H256
+	29, // 34: execution.AssembleBlockRequest.prev_randao:type_name -> types.H256
+	30, // 35: execution.AssembleBlockRequest.suggested_fee_recipient:type_name -> types.H160
+	32, // 36: execution.AssembleBlockRequest.withdrawals:type_name -> types.Withdrawal
+	29, // 37: execution.AssembleBlockRequest.parent_beacon_block_root:type_name -> types.H256
+	33, // 38: execution.AssembledBlockData.execution_payload:type_name -> types.ExecutionPayload
+	29, // 39: execution.AssembledBlockData.block_value:type_name -> types.H256
+	34, // 40: execution.AssembledBlockData.blobs_bundle:type_name -> types.BlobsBundleV1
+	35, // 41: execution.AssembledBlockData.requests:type_name -> types.RequestsBundle
 	19, // 42: execution.GetAssembledBlockResponse.data:type_name -> execution.AssembledBlockData
 	5,  // 43: execution.GetBodiesBatchResponse.bodies:type_name -> execution.BlockBody
-	27, // 44: execution.GetBodiesByHashesRequest.hashes:type_name -> types.H256
+	29, // 44: execution.GetBodiesByHashesRequest.hashes:type_name -> types.H256
 	12, // 45: execution.Execution.InsertBlocks:input_type -> execution.InsertBlocksRequest
 	15, // 46: execution.Execution.ValidateChain:input_type -> execution.ValidationRequest
 	13, // 47: execution.Execution.UpdateForkChoice:input_type -> execution.ForkChoice
 	16, // 48: execution.Execution.AssembleBlock:input_type -> execution.AssembleBlockRequest
 	18, // 49: execution.Execution.GetAssembledBlock:input_type -> execution.GetAssembledBlockRequest
-	34, // 50: execution.Execution.CurrentHeader:input_type -> google.protobuf.Empty
-	11, // 51: execution.Execution.GetTD:input_type -> execution.GetSegmentRequest
-	11, // 52: execution.Execution.GetHeader:input_type -> execution.GetSegmentRequest
-	11, // 53: execution.Execution.GetBody:input_type -> execution.GetSegmentRequest
-	11, // 54: execution.Execution.HasBlock:input_type -> execution.GetSegmentRequest
-	23, // 55: execution.Execution.GetBodiesByRange:input_type -> execution.GetBodiesByRangeRequest
-	22, // 56: execution.Execution.GetBodiesByHashes:input_type -> execution.GetBodiesByHashesRequest
-	27, // 57: execution.Execution.IsCanonicalHash:input_type -> types.H256
-	27, // 58: execution.Execution.GetHeaderHashNumber:input_type -> types.H256
-	34, // 59: execution.Execution.GetForkChoice:input_type -> google.protobuf.Empty
-	34, // 60: execution.Execution.Ready:input_type -> google.protobuf.Empty
-	34, // 61: execution.Execution.FrozenBlocks:input_type -> google.protobuf.Empty
-	14, // 62: execution.Execution.InsertBlocks:output_type -> execution.InsertionResult
-	2,  // 63: execution.Execution.ValidateChain:output_type -> execution.ValidationReceipt
-	1,  // 64: execution.Execution.UpdateForkChoice:output_type -> execution.ForkChoiceReceipt
-	17, // 65: execution.Execution.AssembleBlock:output_type -> execution.AssembleBlockResponse
-	20, // 66: execution.Execution.GetAssembledBlock:output_type -> execution.GetAssembledBlockResponse
-	7,  // 67: execution.Execution.CurrentHeader:output_type -> execution.GetHeaderResponse
-	8,  // 68: execution.Execution.GetTD:output_type -> execution.GetTDResponse
-	7,  // 69: execution.Execution.GetHeader:output_type -> execution.GetHeaderResponse
-	9,  // 70: execution.Execution.GetBody:output_type -> execution.GetBodyResponse
-	26, // 71: execution.Execution.HasBlock:output_type -> execution.HasBlockResponse
-	21, // 72: execution.Execution.GetBodiesByRange:output_type -> execution.GetBodiesBatchResponse
-	21, // 73: execution.Execution.GetBodiesByHashes:output_type -> execution.GetBodiesBatchResponse
-	3,  // 74: execution.Execution.IsCanonicalHash:output_type -> execution.IsCanonicalResponse
-	10, // 75: execution.Execution.GetHeaderHashNumber:output_type -> execution.GetHeaderHashNumberResponse
-	13, // 76: execution.Execution.GetForkChoice:output_type -> execution.ForkChoice
-	24, // 77: execution.Execution.Ready:output_type -> execution.ReadyResponse
-	25, // 78: execution.Execution.FrozenBlocks:output_type -> execution.FrozenBlocksResponse
-	62, // [62:79] is the sub-list for method output_type
-	45, // [45:62] is the sub-list for method input_type
+	21, // 50: execution.Execution.UpdateInclusionList:input_type -> execution.UpdateInclusionListRequest
+	36, // 51: execution.Execution.CurrentHeader:input_type -> google.protobuf.Empty
+	11, // 52: execution.Execution.GetTD:input_type -> execution.GetSegmentRequest
+	11, // 53: execution.Execution.GetHeader:input_type -> execution.GetSegmentRequest
+	11, // 54: execution.Execution.GetBody:input_type -> execution.GetSegmentRequest
+	11, // 55: execution.Execution.HasBlock:input_type -> execution.GetSegmentRequest
+	25, // 56: execution.Execution.GetBodiesByRange:input_type -> execution.GetBodiesByRangeRequest
+	24, // 57: execution.Execution.GetBodiesByHashes:input_type -> execution.GetBodiesByHashesRequest
+	29, // 58: execution.Execution.IsCanonicalHash:input_type -> types.H256
+	29, // 59: execution.Execution.GetHeaderHashNumber:input_type -> types.H256
+	36, // 60: execution.Execution.GetForkChoice:input_type -> google.protobuf.Empty
+	36, // 61: execution.Execution.Ready:input_type -> google.protobuf.Empty
+	36, // 62: execution.Execution.FrozenBlocks:input_type -> google.protobuf.Empty
+	14, // 63: execution.Execution.InsertBlocks:output_type -> execution.InsertionResult
+	2,  // 64: execution.Execution.ValidateChain:output_type -> execution.ValidationReceipt
+	1,  // 65: execution.Execution.UpdateForkChoice:output_type -> execution.ForkChoiceReceipt
+	17, // 66: execution.Execution.AssembleBlock:output_type -> execution.AssembleBlockResponse
+	20, // 67: execution.Execution.GetAssembledBlock:output_type -> execution.GetAssembledBlockResponse
+	22, // 68: execution.Execution.UpdateInclusionList:output_type -> execution.UpdateInclusionListResponse
+	7,  // 69: execution.Execution.CurrentHeader:output_type -> execution.GetHeaderResponse
+	8,  // 70: execution.Execution.GetTD:output_type -> execution.GetTDResponse
+	7,  // 71: execution.Execution.GetHeader:output_type -> execution.GetHeaderResponse
+	9,  // 72: execution.Execution.GetBody:output_type -> execution.GetBodyResponse
+	28, // 73: execution.Execution.HasBlock:output_type -> execution.HasBlockResponse
+	23, // 74: execution.Execution.GetBodiesByRange:output_type -> execution.GetBodiesBatchResponse
+	23, // 75: execution.Execution.GetBodiesByHashes:output_type -> execution.GetBodiesBatchResponse
+	3,  // 76: execution.Execution.IsCanonicalHash:output_type -> execution.IsCanonicalResponse
+	10, // 77: execution.Execution.GetHeaderHashNumber:output_type -> execution.GetHeaderHashNumberResponse
+	13, // 78: execution.Execution.GetForkChoice:output_type -> execution.ForkChoice
+	26, // 79: execution.Execution.Ready:output_type -> execution.ReadyResponse
+	27, // 80: execution.Execution.FrozenBlocks:output_type -> execution.FrozenBlocksResponse
+	63, // [63:81] is the sub-list for method output_type
+	45, // [45:63] is the sub-list for method input_type
 	45, // [45:45] is the sub-list for extension type_name
 	45, // [45:45] is the sub-list for extension extendee
 	0,  // [0:45] is the sub-list for field type_name
@@ -1951,7 +2066,7 @@ func file_execution_execution_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_execution_execution_proto_rawDesc), len(file_execution_execution_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   26,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,6 +26,7 @@ const (
 	Execution_UpdateForkChoice_FullMethodName    = "/execution.Execution/UpdateForkChoice"
 	Execution_AssembleBlock_FullMethodName       = "/execution.Execution/AssembleBlock"
 	Execution_GetAssembledBlock_FullMethodName   = "/execution.Execution/GetAssembledBlock"
+	Execution_UpdateInclusionList_FullMethodName = "/execution.Execution/UpdateInclusionList"
 	Execution_CurrentHeader_FullMethodName       = "/execution.Execution/CurrentHeader"
 	Execution_GetTD_FullMethodName               = "/execution.Execution/GetTD"
 	Execution_GetHeader_FullMethodName           = "/execution.Execution/GetHeader"
@@ -53,6 +54,8 @@ type ExecutionClient interface {
 	// EAGAIN design here, AssembleBlock initiates the asynchronous request, and GetAssembleBlock just return it if ready.
 	AssembleBlock(ctx context.Context, in *AssembleBlockRequest, opts ...grpc.CallOption) (*AssembleBlockResponse, error)
 	GetAssembledBlock(ctx context.Context, in *GetAssembledBlockRequest, opts ...grpc.CallOption) (*GetAssembledBlockResponse, error)
+	// UpdateInclusionList rebuilds block being assembled with transactions of inclusion list.
+	UpdateInclusionList(ctx context.Context, in *UpdateInclusionListRequest, opts ...grpc.CallOption) (*UpdateInclusionListResponse, error)
 	// Chain Getters.
 	CurrentHeader(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetHeaderResponse, error)
 	GetTD(ctx context.Context, in *GetSegmentRequest, opts ...grpc.CallOption) (*GetTDResponse, error)
@@ -131,6 +134,16 @@ func (c *executionClient) GetAssembledBlock(ctx context.Context, in *GetAssemble
 	return out, nil
 }
 
+func (c *executionClient) UpdateInclusionList(ctx context.Context, in *UpdateInclusionListRequest, opts ...grpc.CallOption) (*UpdateInclusionListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateInclusionListResponse)
+	err := c.cc.Invoke(ctx, Execution_UpdateInclusionList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *executionClient) CurrentHeader(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetHeaderResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetHeaderResponse)
@@ -264,6 +277,8 @@ type ExecutionServer interface {
 	// EAGAIN design here, AssembleBlock initiates the asynchronous request, and GetAssembleBlock just return it if ready.
 	AssembleBlock(context.Context, *AssembleBlockRequest) (*AssembleBlockResponse, error)
 	GetAssembledBlock(context.Context, *GetAssembledBlockRequest) (*GetAssembledBlockResponse, error)
+	// UpdateInclusionList rebuilds block being assembled with transactions of inclusion list.
+	UpdateInclusionList(context.Context, *UpdateInclusionListRequest) (*UpdateInclusionListResponse, error)
 	// Chain Getters.
 	CurrentHeader(context.Context, *emptypb.Empty) (*GetHeaderResponse, error)
 	GetTD(context.Context, *GetSegmentRequest) (*GetTDResponse, error)
@@ -307,6 +322,9 @@ func (UnimplementedExecutionServer) AssembleBlock(context.Context, *AssembleBloc
 func (UnimplementedExecutionServer) GetAssembledBlock(context.Context, *GetAssembledBlockRequest) (*GetAssembledBlockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAssembledBlock not implemented")
 }
+func (UnimplementedExecutionServer) UpdateInclusionList(context.Context, *UpdateInclusionListRequest) (*UpdateInclusionListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateInclusionList not implemented")
+}
 func (UnimplementedExecutionServer) CurrentHeader(context.Context, *emptypb.Empty) (*GetHeaderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CurrentHeader not implemented")
 }
@@ -454,6 +472,24 @@ func _Execution_GetAssembledBlock_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Execution_UpdateInclusionList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateInclusionListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionServer).UpdateInclusionList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Execution_UpdateInclusionList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionServer).UpdateInclusionList(ctx, req.(*UpdateInclusionListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Execution_CurrentHeader_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -697,6 +733,10 @@ var Execution_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAssembledBlock",
 			Handler:    _Execution_GetAssembledBlock_Handler,
 		},
+		{
+			MethodName: "UpdateInclusionList",
+			Handler:    _Execution_UpdateInclusionList_Handler,
+		},
 		{
 			MethodName: "CurrentHeader",
 			Handler:    _Execution_CurrentHeader_Handler,
@@ -1940,6 +1940,178 @@ func (x *HasPrefixReply) GetHasPrefix() bool {
 	return false
 }
 
+type ChangesReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromTxNum     uint64                 `protobuf:"varint,1,opt,name=from_tx_num,json=fromTxNum,proto3" json:"from_tx_num,omitempty"`
+	ToTxNum       int64                  `protobuf:"zigzag64,2,opt,name=to_tx_num,json=toTxNum,proto3" json:"to_tx_num,omitempty"` // -1 means follow chain tip
+	PageSize      uint64                 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`  // amount of txNums which changes are sent in one reply. 0 means server will choose
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangesReq) Reset() {
+	*x = ChangesReq{}
+	mi := &file_remote_kv_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangesReq) ProtoMessage() {}
+
+func (x *ChangesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_kv_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangesReq.ProtoReflect.Descriptor instead.
+func (*ChangesReq) Descriptor() ([]byte, []int) {
+	return file_remote_kv_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ChangesReq) GetFromTxNum() uint64 {
+	if x != nil {
+		return x.FromTxNum
+	}
+	return 0
+}
+
+func (x *ChangesReq) GetToTxNum() int64 {
+	if x != nil {
+		return x.ToTxNum
+	}
+	return 0
+}
+
+func (x *ChangesReq) GetPageSize() uint64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type DomainChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxNum         uint64                 `protobuf:"varint,1,opt,name=tx_num,json=txNum,proto3" json:"tx_num,omitempty"`
+	Domain        string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	Key           []byte                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Value         []byte                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"` // value after change. empty means deletion
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DomainChange) Reset() {
+	*x = DomainChange{}
+	mi := &file_remote_kv_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DomainChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainChange) ProtoMessage() {}
+
+func (x *DomainChange) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_kv_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainChange.ProtoReflect.Descriptor instead.
+func (*DomainChange) Descriptor() ([]byte, []int) {
+	return file_remote_kv_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DomainChange) GetTxNum() uint64 {
+	if x != nil {
+		return x.TxNum
+	}
+	return 0
+}
+
+func (x *DomainChange) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *DomainChange) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *DomainChange) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type ChangesReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*DomainChange        `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"` // ordered by (tx_num, domain, key)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangesReply) Reset() {
+	*x = ChangesReply{}
+	mi := &file_remote_kv_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangesReply) ProtoMessage() {}
+
+func (x *ChangesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_kv_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangesReply.ProtoReflect.Descriptor instead.
+func (*ChangesReply) Descriptor() ([]byte, []int) {
+	return file_remote_kv_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ChangesReply) GetChanges() []*DomainChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
 var File_remote_kv_proto protoreflect.FileDescriptor
 
 const file_remote_kv_proto_rawDesc = "" +
@@ -2083,7 +2255,19 @@ const file_remote_kv_proto_rawDesc = "" +
 	"\tfirst_key\x18\x01 \x01(\fR\bfirstKey\x12\x1b\n" +
 	"\tfirst_val\x18\x02 \x01(\fR\bfirstVal\x12\x1d\n" +
 	"\n" +
-	"has_prefix\x18\x03 \x01(\bR\thasPrefix*\xfb\x01\n" +
+	"has_prefix\x18\x03 \x01(\bR\thasPrefix\"e\n" +
+	"\n" +
+	"ChangesReq\x12\x1e\n" +
+	"\vfrom_tx_num\x18\x01 \x01(\x04R\tfromTxNum\x12\x1a\n" +
+	"\tto_tx_num\x18\x02 \x01(\x12R\atoTxNum\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x04R\bpageSize\"e\n" +
+	"\fDomainChange\x12\x15\n" +
+	"\x06tx_num\x18\x01 \x01(\x04R\x05txNum\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\fR\x03key\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\fR\x05value\">\n" +
+	"\fChangesReply\x12.\n" +
+	"\achanges\x18\x01 \x03(\v2\x14.remote.DomainChangeR\achanges*\xfb\x01\n" +
 	"\x02Op\x12\t\n" +
 	"\x05FIRST\x10\x00\x12\r\n" +
 	"\tFIRST_DUP\x10\x01\x12\b\n" +
@@ -2129,7 +2313,9 @@ const file_remote_kv_proto_rawDesc = "" +
 	"IndexRange\x12\x15.remote.IndexRangeReq\x1a\x17.remote.IndexRangeReply\x126\n" +
 	"\fHistoryRange\x12\x17.remote.HistoryRangeReq\x1a\r.remote.Pairs\x120\n" +
 	"\tRangeAsOf\x12\x14.remote.RangeAsOfReq\x1a\r.remote.Pairs\x129\n" +
-	"\tHasPrefix\x12\x14.remote.HasPrefixReq\x1a\x16.remote.HasPrefixReplyB\x16Z\x14./remote;remoteprotob\x06proto3"
+	"\tHasPrefix\x12\x14.remote.HasPrefixReq\x1a\x16.remote.HasPrefixReply2E\n" +
+	"\fChangeStream\x125\n" +
+	"\aChanges\x12\x12.remote.ChangesReq\x1a\x14.remote.ChangesReply0\x01B\x16Z\x14./remote;remoteprotob\x06proto3"
 
 var (
 	file_remote_kv_proto_rawDescOnce sync.Once
@@ -2144,7 +2330,7 @@ func file_remote_kv_proto_rawDescGZIP() []byte {
 }
 
 var file_remote_kv_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_remote_kv_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_remote_kv_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_remote_kv_proto_goTypes = []any{
 	(Op)(0),                         // 0: remote.Op
 	(Action)(0),                     // 1: remote.Action
@@ -2174,50 +2360,56 @@ var file_remote_kv_proto_goTypes = []any{
 	(*IndexPagination)(nil),         // 25: remote.IndexPagination
 	(*HasPrefixReq)(nil),            // 26: remote.HasPrefixReq
 	(*HasPrefixReply)(nil),          // 27: remote.HasPrefixReply
-	(*typesproto.H256)(nil),         // 28: types.H256
-	(*typesproto.H160)(nil),         // 29: types.H160
-	(*emptypb.Empty)(nil),           // 30: google.protobuf.Empty
-	(*typesproto.VersionReply)(nil), // 31: types.VersionReply
+	(*ChangesReq)(nil),              // 28: remote.ChangesReq
+	(*DomainChange)(nil),            // 29: remote.DomainChange
+	(*ChangesReply)(nil),            // 30: remote.ChangesReply
+	(*typesproto.H256)(nil),         // 31: types.H256
+	(*typesproto.H160)(nil),         // 32: types.H160
+	(*emptypb.Empty)(nil),           // 33: google.protobuf.Empty
+	(*typesproto.VersionReply)(nil), // 34: types.VersionReply
 }
 var file_remote_kv_proto_depIdxs = []int32{
 	0,  // 0: remote.Cursor.op:type_name -> remote.Op
-	28, // 1: remote.StorageChange.location:type_name -> types.H256
-	29, // 2: remote.AccountChange.address:type_name -> types.H160
+	31, // 1: remote.StorageChange.location:type_name -> types.H256
+	32, // 2: remote.AccountChange.address:type_name -> types.H160
 	1,  // 3: remote.AccountChange.action:type_name -> remote.Action
 	5,  // 4: remote.AccountChange.storage_changes:type_name -> remote.StorageChange
 	8,  // 5: remote.StateChangeBatch.change_batch:type_name -> remote.StateChange
 	2,  // 6: remote.StateChange.direction:type_name -> remote.Direction
-	28, // 7: remote.StateChange.block_hash:type_name -> types.H256
+	31, // 7: remote.StateChange.block_hash:type_name -> types.H256
 	6,  // 8: remote.StateChange.changes:type_name -> remote.AccountChange
-	30, // 9: remote.KV.Version:input_type -> google.protobuf.Empty
-	3,  // 10: remote.KV.Tx:input_type -> remote.Cursor
-	9,  // 11: remote.KV.StateChanges:input_type -> remote.StateChangeRequest
-	10, // 12: remote.KV.Snapshots:input_type -> remote.SnapshotsRequest
-	12, // 13: remote.KV.Range:input_type -> remote.RangeReq
-	13, // 14: remote.KV.Sequence:input_type -> remote.SequenceReq
-	15, // 15: remote.KV.GetLatest:input_type -> remote.GetLatestReq
-	17, // 16: remote.KV.HistorySeek:input_type -> remote.HistorySeekReq
-	19, // 17: remote.KV.IndexRange:input_type -> remote.IndexRangeReq
-	21, // 18: remote.KV.HistoryRange:input_type -> remote.HistoryRangeReq
-	22, // 19: remote.KV.RangeAsOf:input_type -> remote.RangeAsOfReq
-	26, // 20: remote.KV.HasPrefix:input_type -> remote.HasPrefixReq
-	31, // 21: remote.KV.Version:output_type -> types.VersionReply
-	4,  // 22: remote.KV.Tx:output_type -> remote.Pair
-	7,  // 23: remote.KV.StateChanges:output_type -> remote.StateChangeBatch
-	11, // 24: remote.KV.Snapshots:output_type -> remote.SnapshotsReply
-	23, // 25: remote.KV.Range:output_type -> remote.Pairs
-	14, // 26: remote.KV.Sequence:output_type -> remote.SequenceReply
-	16, // 27: remote.KV.GetLatest:output_type -> remote.GetLatestReply
-	18, // 28: remote.KV.HistorySeek:output_type -> remote.HistorySeekReply
-	20, // 29: remote.KV.IndexRange:output_type -> remote.IndexRangeReply
-	23, // 30: remote.KV.HistoryRange:output_type -> remote.Pairs
-	23, // 31: remote.KV.RangeAsOf:output_type -> remote.Pairs
-	27, // 32: remote.KV.HasPrefix:output_type -> remote.HasPrefixReply
-	21, // [21:33] is the sub-list for method output_type
-	9,  // [9:21] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	29, // 9: remote.ChangesReply.changes:type_name -> remote.DomainChange
+	33, // 10: remote.KV.Version:input_type -> google.protobuf.Empty
+	3,  // 11: remote.KV.Tx:input_type -> remote.Cursor
+	9,  // 12: remote.KV.StateChanges:input_type -> remote.StateChangeRequest
+	10, // 13: remote.KV.Snapshots:input_type -> remote.SnapshotsRequest
+	12, // 14: remote.KV.Range:input_type -> remote.RangeReq
+	13, // 15: remote.KV.Sequence:input_type -> remote.SequenceReq
+	15, // 16: remote.KV.GetLatest:input_type -> remote.GetLatestReq
+	17, // 17: remote.KV.HistorySeek:input_type -> remote.HistorySeekReq
+	19, // 18: remote.KV.IndexRange:input_type -> remote.IndexRangeReq
+	21, // 19: remote.KV.HistoryRange:input_type -> remote.HistoryRangeReq
+	22, // 20: remote.KV.RangeAsOf:input_type -> remote.RangeAsOfReq
+	26, // 21: remote.KV.HasPrefix:input_type -> remote.HasPrefixReq
+	28, // 22: remote.ChangeStream.Changes:input_type -> remote.ChangesReq
+	34, // 23: remote.KV.Version:output_type -> types.VersionReply
+	4,  // 24: remote.KV.Tx:output_type -> remote.Pair
+	7,  // 25: remote.KV.StateChanges:output_type -> remote.StateChangeBatch
+	11, // 26: remote.KV.Snapshots:output_type -> remote.SnapshotsReply
+	23, // 27: remote.KV.Range:output_type -> remote.Pairs
+	14, // 28: remote.KV.Sequence:output_type -> remote.SequenceReply
+	16, // 29: remote.KV.GetLatest:output_type -> remote.GetLatestReply
+	18, // 30: remote.KV.HistorySeek:output_type -> remote.HistorySeekReply
+	20, // 31: remote.KV.IndexRange:output_type -> remote.IndexRangeReply
+	23, // 32: remote.KV.HistoryRange:output_type -> remote.Pairs
+	23, // 33: remote.KV.RangeAsOf:output_type -> remote.Pairs
+	27, // 34: remote.KV.HasPrefix:output_type -> remote.HasPrefixReply
+	30, // 35: remote.ChangeStream.Changes:output_type -> remote.ChangesReply
+	23, // [23:36] is the sub-list for method output_type
+	10, // [10:23] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_remote_kv_proto_init() }
@@ -2231,9 +2423,9 @@ func file_remote_kv_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_remote_kv_proto_rawDesc), len(file_remote_kv_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   25,
+			NumMessages:   28,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_remote_kv_proto_goTypes,
 		DependencyIndexes: file_remote_kv_proto_depIdxs,
@@ -565,3 +565,116 @@ var KV_ServiceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "remote/kv.proto",
 }
+
+const (
+	ChangeStream_Changes_FullMethodName = "/remote.ChangeStream/Changes"
+)
+
+// ChangeStreamClient is the client API for ChangeStream service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Domain-level change data capture for external consumers (indexers, analytics)
+type ChangeStreamClient interface {
+	// Changes replays account/storage/code changes from given TxNum in TxNum order, then follows the chain tip.
+	// Unwind below already sent TxNum closes stream with ABORTED status.
+	Changes(ctx context.Context, in *ChangesReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangesReply], error)
+}
+
+type changeStreamClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChangeStreamClient(cc grpc.ClientConnInterface) ChangeStreamClient {
+	return &changeStreamClient{cc}
+}
+
+func (c *changeStreamClient) Changes(ctx context.Context, in *ChangesReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangesReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChangeStream_ServiceDesc.Streams[0], ChangeStream_Changes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ChangesReq, ChangesReply]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChangeStream_ChangesClient = grpc.ServerStreamingClient[ChangesReply]
+
+// ChangeStreamServer is the server API for ChangeStream service.
+// All implementations must embed UnimplementedChangeStreamServer
+// for forward compatibility.
+//
+// Domain-level change data capture for external consumers (indexers, analytics)
+type ChangeStreamServer interface {
+	// Changes replays account/storage/code changes from given TxNum in TxNum order, then follows the chain tip.
+	// Unwind below already sent TxNum closes stream with ABORTED status.
+	Changes(*ChangesReq, grpc.ServerStreamingServer[ChangesReply]) error
+	mustEmbedUnimplementedChangeStreamServer()
+}
+
+// UnimplementedChangeStreamServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChangeStreamServer struct{}
+
+func (UnimplementedChangeStreamServer) Changes(*ChangesReq, grpc.ServerStreamingServer[ChangesReply]) error {
+	return status.Errorf(codes.Unimplemented, "method Changes not implemented")
+}
+func (UnimplementedChangeStreamServer) mustEmbedUnimplementedChangeStreamServer() {}
+func (UnimplementedChangeStreamServer) testEmbeddedByValue()                      {}
+
+// UnsafeChangeStreamServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChangeStreamServer will
+// result in compilation errors.
+type UnsafeChangeStreamServer interface {
+	mustEmbedUnimplementedChangeStreamServer()
+}
+
+func RegisterChangeStreamServer(s grpc.ServiceRegistrar, srv ChangeStreamServer) {
+	// If the following call pancis, it indicates UnimplementedChangeStreamServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ChangeStream_ServiceDesc, srv)
+}
+
+func _ChangeStream_Changes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ChangesReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChangeStreamServer).Changes(m, &grpc.GenericServerStream[ChangesReq, ChangesReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChangeStream_ChangesServer = grpc.ServerStreamingServer[ChangesReply]
+
+// ChangeStream_ServiceDesc is the grpc.ServiceDesc for ChangeStream service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ChangeStream_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "remote.ChangeStream",
+	HandlerType: (*ChangeStreamServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Changes",
+			Handler:       _ChangeStream_Changes_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "remote/kv.proto",
+}
@@ -130,6 +130,61 @@ func (AllReply_TxnType) EnumDescriptor() ([]byte, []int) {
 	return file_txpool_txpool_proto_rawDescGZIP(), []int{8, 0}
 }
 
+type TransactionStatusReply_Status int32
+
+const (
+	TransactionStatusReply_UNKNOWN   TransactionStatusReply_Status = 0 // Not in pool and not in history of recently discarded transactions
+	TransactionStatusReply_PENDING   TransactionStatusReply_Status = 1
+	TransactionStatusReply_QUEUED    TransactionStatusReply_Status = 2
+	TransactionStatusReply_BASE_FEE  TransactionStatusReply_Status = 3
+	TransactionStatusReply_DISCARDED TransactionStatusReply_Status = 4 // Discarded recently: see discard_reason
+)
+
+// Enum value maps for TransactionStatusReply_Status.
+var (
+	TransactionStatusReply_Status_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "PENDING",
+		2: "QUEUED",
+		3: "BASE_FEE",
+		4: "DISCARDED",
+	}
+	TransactionStatusReply_Status_value = map[string]int32{
+		"UNKNOWN":   0,
+		"PENDING":   1,
+		"QUEUED":    2,
+		"BASE_FEE":  3,
+		"DISCARDED": 4,
+	}
+)
+
+func (x TransactionStatusReply_Status) Enum() *TransactionStatusReply_Status {
+	p := new(TransactionStatusReply_Status)
+	*p = x
+	return p
+}
+
+func (x TransactionStatusReply_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransactionStatusReply_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_txpool_txpool_proto_enumTypes[2].Descriptor()
+}
+
+func (TransactionStatusReply_Status) Type() protoreflect.EnumType {
+	return &file_txpool_txpool_proto_enumTypes[2]
+}
+
+func (x TransactionStatusReply_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransactionStatusReply_Status.Descriptor instead.
+func (TransactionStatusReply_Status) EnumDescriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{17, 0}
+}
+
 type TxHashes struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Hashes        []*typesproto.H256     `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
@@ -175,10 +230,12 @@ func (x *TxHashes) GetHashes() []*typesproto.H256 {
 }
 
 type AddRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RlpTxs        [][]byte               `protobuf:"bytes,1,rep,name=rlp_txs,json=rlpTxs,proto3" json:"rlp_txs,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RlpTxs         [][]byte               `protobuf:"bytes,1,rep,name=rlp_txs,json=rlpTxs,proto3" json:"rlp_txs,omitempty"`
+	Private        bool                   `protobuf:"varint,2,opt,name=private,proto3" json:"private,omitempty"`                                       // private transactions are never propagated to peers
+	MaxBlockNumber uint64                 `protobuf:"varint,3,opt,name=max_block_number,json=maxBlockNumber,proto3" json:"max_block_number,omitempty"` // private transactions not included until this block are dropped. 0 - no limit
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *AddRequest) Reset() {
@@ -218,6 +275,20 @@ func (x *AddRequest) GetRlpTxs() [][]byte {
 	return nil
 }
 
+func (x *AddRequest) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+func (x *AddRequest) GetMaxBlockNumber() uint64 {
+	if x != nil {
+		return x.MaxBlockNumber
+	}
+	return 0
+}
+
 type AddReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Imported      []ImportResult         `protobuf:"varint,1,rep,packed,name=imported,proto3,enum=txpool.ImportResult" json:"imported,omitempty"`
@@ -757,6 +828,7 @@ func (x *NonceReply) GetNonce() uint64 {
 type GetBlobsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BlobHashes    []*typesproto.H256     `protobuf:"bytes,1,rep,name=blob_hashes,json=blobHashes,proto3" json:"blob_hashes,omitempty"`
+	CellProofs    bool                   `protobuf:"varint,2,opt,name=cell_proofs,json=cellProofs,proto3" json:"cell_proofs,omitempty"` // EIP-7594: reply with cell proofs instead of blob proofs
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -798,10 +870,17 @@ func (x *GetBlobsRequest) GetBlobHashes() []*typesproto.H256 {
 	return nil
 }
 
+func (x *GetBlobsRequest) GetCellProofs() bool {
+	if x != nil {
+		return x.CellProofs
+	}
+	return false
+}
+
 type GetBlobsReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Blobs         [][]byte               `protobuf:"bytes,1,rep,name=blobs,proto3" json:"blobs,omitempty"`
-	Proofs        [][]byte               `protobuf:"bytes,2,rep,name=proofs,proto3" json:"proofs,omitempty"`
+	Proofs        [][]byte               `protobuf:"bytes,2,rep,name=proofs,proto3" json:"proofs,omitempty"` // blob proof, or concatenated cell proofs of blob if cell_proofs. Empty if blob is not known
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -850,18 +929,339 @@ func (x *GetBlobsReply) GetProofs() [][]byte {
 	return nil
 }
 
+type TransactionStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          *typesproto.H256       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionStatusRequest) Reset() {
+	*x = TransactionStatusRequest{}
+	mi := &file_txpool_txpool_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionStatusRequest) ProtoMessage() {}
+
+func (x *TransactionStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionStatusRequest.ProtoReflect.Descriptor instead.
+func (*TransactionStatusRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TransactionStatusRequest) GetHash() *typesproto.H256 {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+type TransactionStatusReply struct {
+	state         protoimpl.MessageState        `protogen:"open.v1"`
+	Status        TransactionStatusReply_Status `protobuf:"varint,1,opt,name=status,proto3,enum=txpool.TransactionStatusReply_Status" json:"status,omitempty"`
+	Private       bool                          `protobuf:"varint,2,opt,name=private,proto3" json:"private,omitempty"`                                 // submitted as private transaction
+	DiscardReason string                        `protobuf:"bytes,3,opt,name=discard_reason,json=discardReason,proto3" json:"discard_reason,omitempty"` // set if DISCARDED
+	DiscardTime   uint64                        `protobuf:"varint,4,opt,name=discard_time,json=discardTime,proto3" json:"discard_time,omitempty"`      // unix seconds, set if DISCARDED
+	ReplacedBy    *typesproto.H256              `protobuf:"bytes,5,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`          // replacement transaction, if discarded as replaced by higher tip
+	MinedBlock    uint64                        `protobuf:"varint,6,opt,name=mined_block,json=minedBlock,proto3" json:"mined_block,omitempty"`         // block which included the transaction, 0 if not seen mined
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionStatusReply) Reset() {
+	*x = TransactionStatusReply{}
+	mi := &file_txpool_txpool_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionStatusReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionStatusReply) ProtoMessage() {}
+
+func (x *TransactionStatusReply) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionStatusReply.ProtoReflect.Descriptor instead.
+func (*TransactionStatusReply) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TransactionStatusReply) GetStatus() TransactionStatusReply_Status {
+	if x != nil {
+		return x.Status
+	}
+	return TransactionStatusReply_UNKNOWN
+}
+
+func (x *TransactionStatusReply) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+func (x *TransactionStatusReply) GetDiscardReason() string {
+	if x != nil {
+		return x.DiscardReason
+	}
+	return ""
+}
+
+func (x *TransactionStatusReply) GetDiscardTime() uint64 {
+	if x != nil {
+		return x.DiscardTime
+	}
+	return 0
+}
+
+func (x *TransactionStatusReply) GetReplacedBy() *typesproto.H256 {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return nil
+}
+
+func (x *TransactionStatusReply) GetMinedBlock() uint64 {
+	if x != nil {
+		return x.MinedBlock
+	}
+	return 0
+}
+
+type ExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
+	mi := &file_txpool_txpool_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRequest) ProtoMessage() {}
+
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{18}
+}
+
+type ExportReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunk         []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`  // next part of dump
+	Count         uint64                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"` // amount of exported transactions, set in the last message
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportReply) Reset() {
+	*x = ExportReply{}
+	mi := &file_txpool_txpool_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportReply) ProtoMessage() {}
+
+func (x *ExportReply) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportReply.ProtoReflect.Descriptor instead.
+func (*ExportReply) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ExportReply) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *ExportReply) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ImportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunk         []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"` // next part of dump streamed by Export
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRequest) Reset() {
+	*x = ImportRequest{}
+	mi := &file_txpool_txpool_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRequest) ProtoMessage() {}
+
+func (x *ImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRequest.ProtoReflect.Descriptor instead.
+func (*ImportRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ImportRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+type ImportReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Added         uint64                 `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`         // local and private transactions accepted by pool
+	Discarded     uint64                 `protobuf:"varint,2,opt,name=discarded,proto3" json:"discarded,omitempty"` // local and private transactions rejected by pool
+	Queued        uint64                 `protobuf:"varint,3,opt,name=queued,proto3" json:"queued,omitempty"`       // remote transactions: queued for usual validation
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportReply) Reset() {
+	*x = ImportReply{}
+	mi := &file_txpool_txpool_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportReply) ProtoMessage() {}
+
+func (x *ImportReply) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportReply.ProtoReflect.Descriptor instead.
+func (*ImportReply) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ImportReply) GetAdded() uint64 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+func (x *ImportReply) GetDiscarded() uint64 {
+	if x != nil {
+		return x.Discarded
+	}
+	return 0
+}
+
+func (x *ImportReply) GetQueued() uint64 {
+	if x != nil {
+		return x.Queued
+	}
+	return 0
+}
+
 type AllReply_Tx struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TxnType       AllReply_TxnType       `protobuf:"varint,1,opt,name=txn_type,json=txnType,proto3,enum=txpool.AllReply_TxnType" json:"txn_type,omitempty"`
 	Sender        *typesproto.H160       `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
 	RlpTx         []byte                 `protobuf:"bytes,3,opt,name=rlp_tx,json=rlpTx,proto3" json:"rlp_tx,omitempty"`
+	Private       bool                   `protobuf:"varint,4,opt,name=private,proto3" json:"private,omitempty"` // submitted as private transaction
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AllReply_Tx) Reset() {
 	*x = AllReply_Tx{}
-	mi := &file_txpool_txpool_proto_msgTypes[16]
+	mi := &file_txpool_txpool_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -873,7 +1273,7 @@ func (x *AllReply_Tx) String() string {
 func (*AllReply_Tx) ProtoMessage() {}
 
 func (x *AllReply_Tx) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[16]
+	mi := &file_txpool_txpool_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -910,6 +1310,13 @@ func (x *AllReply_Tx) GetRlpTx() []byte {
 	return nil
 }
 
+func (x *AllReply_Tx) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
 type PendingReply_Tx struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Sender        *typesproto.H160       `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
@@ -921,7 +1328,7 @@ type PendingReply_Tx struct {
 
 func (x *PendingReply_Tx) Reset() {
 	*x = PendingReply_Tx{}
-	mi := &file_txpool_txpool_proto_msgTypes[17]
+	mi := &file_txpool_txpool_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -933,7 +1340,7 @@ func (x *PendingReply_Tx) String() string {
 func (*PendingReply_Tx) ProtoMessage() {}
 
 func (x *PendingReply_Tx) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[17]
+	mi := &file_txpool_txpool_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -976,10 +1383,12 @@ const file_txpool_txpool_proto_rawDesc = "" +
 	"\n" +
 	"\x13txpool/txpool.proto\x12\x06txpool\x1a\x1bgoogle/protobuf/empty.proto\x1a\x11types/types.proto\"/\n" +
 	"\bTxHashes\x12#\n" +
-	"\x06hashes\x18\x01 \x03(\v2\v.types.H256R\x06hashes\"%\n" +
+	"\x06hashes\x18\x01 \x03(\v2\v.types.H256R\x06hashes\"i\n" +
 	"\n" +
 	"AddRequest\x12\x17\n" +
-	"\arlp_txs\x18\x01 \x03(\fR\x06rlpTxs\"T\n" +
+	"\arlp_txs\x18\x01 \x03(\fR\x06rlpTxs\x12\x18\n" +
+	"\aprivate\x18\x02 \x01(\bR\aprivate\x12(\n" +
+	"\x10max_block_number\x18\x03 \x01(\x04R\x0emaxBlockNumber\"T\n" +
 	"\bAddReply\x120\n" +
 	"\bimported\x18\x01 \x03(\x0e2\x14.txpool.ImportResultR\bimported\x12\x16\n" +
 	"\x06errors\x18\x02 \x03(\tR\x06errors\":\n" +
@@ -992,13 +1401,14 @@ const file_txpool_txpool_proto_rawDesc = "" +
 	"OnAddReply\x12\x17\n" +
 	"\arpl_txs\x18\x01 \x03(\fR\x06rplTxs\"\f\n" +
 	"\n" +
-	"AllRequest\"\xda\x01\n" +
+	"AllRequest\"\xf5\x01\n" +
 	"\bAllReply\x12%\n" +
-	"\x03txs\x18\x01 \x03(\v2\x13.txpool.AllReply.TxR\x03txs\x1au\n" +
+	"\x03txs\x18\x01 \x03(\v2\x13.txpool.AllReply.TxR\x03txs\x1a\x8f\x01\n" +
 	"\x02Tx\x123\n" +
 	"\btxn_type\x18\x01 \x01(\x0e2\x18.txpool.AllReply.TxnTypeR\atxnType\x12#\n" +
 	"\x06sender\x18\x02 \x01(\v2\v.types.H160R\x06sender\x12\x15\n" +
-	"\x06rlp_tx\x18\x03 \x01(\fR\x05rlpTx\"0\n" +
+	"\x06rlp_tx\x18\x03 \x01(\fR\x05rlpTx\x12\x18\n" +
+	"\aprivate\x18\x04 \x01(\bR\aprivate\"0\n" +
 	"\aTxnType\x12\v\n" +
 	"\aPENDING\x10\x00\x12\n" +
 	"\n" +
@@ -1020,20 +1430,50 @@ const file_txpool_txpool_proto_rawDesc = "" +
 	"\n" +
 	"NonceReply\x12\x14\n" +
 	"\x05found\x18\x01 \x01(\bR\x05found\x12\x14\n" +
-	"\x05nonce\x18\x02 \x01(\x04R\x05nonce\"?\n" +
+	"\x05nonce\x18\x02 \x01(\x04R\x05nonce\"`\n" +
 	"\x0fGetBlobsRequest\x12,\n" +
 	"\vblob_hashes\x18\x01 \x03(\v2\v.types.H256R\n" +
-	"blobHashes\"=\n" +
+	"blobHashes\x12\x1f\n" +
+	"\vcell_proofs\x18\x02 \x01(\bR\n" +
+	"cellProofs\"=\n" +
 	"\rGetBlobsReply\x12\x14\n" +
 	"\x05blobs\x18\x01 \x03(\fR\x05blobs\x12\x16\n" +
-	"\x06proofs\x18\x02 \x03(\fR\x06proofs*l\n" +
+	"\x06proofs\x18\x02 \x03(\fR\x06proofs\";\n" +
+	"\x18TransactionStatusRequest\x12\x1f\n" +
+	"\x04hash\x18\x01 \x01(\v2\v.types.H256R\x04hash\"\xd7\x02\n" +
+	"\x16TransactionStatusReply\x12=\n" +
+	"\x06status\x18\x01 \x01(\x0e2%.txpool.TransactionStatusReply.StatusR\x06status\x12\x18\n" +
+	"\aprivate\x18\x02 \x01(\bR\aprivate\x12%\n" +
+	"\x0ediscard_reason\x18\x03 \x01(\tR\rdiscardReason\x12!\n" +
+	"\fdiscard_time\x18\x04 \x01(\x04R\vdiscardTime\x12,\n" +
+	"\vreplaced_by\x18\x05 \x01(\v2\v.types.H256R\n" +
+	"replacedBy\x12\x1f\n" +
+	"\vmined_block\x18\x06 \x01(\x04R\n" +
+	"minedBlock\"K\n" +
+	"\x06Status\x12\v\n" +
+	"\aUNKNOWN\x10\x00\x12\v\n" +
+	"\aPENDING\x10\x01\x12\n" +
+	"\n" +
+	"\x06QUEUED\x10\x02\x12\f\n" +
+	"\bBASE_FEE\x10\x03\x12\r\n" +
+	"\tDISCARDED\x10\x04\"\x0f\n" +
+	"\rExportRequest\"9\n" +
+	"\vExportReply\x12\x14\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x04R\x05count\"%\n" +
+	"\rImportRequest\x12\x14\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk\"Y\n" +
+	"\vImportReply\x12\x14\n" +
+	"\x05added\x18\x01 \x01(\x04R\x05added\x12\x1c\n" +
+	"\tdiscarded\x18\x02 \x01(\x04R\tdiscarded\x12\x16\n" +
+	"\x06queued\x18\x03 \x01(\x04R\x06queued*l\n" +
 	"\fImportResult\x12\v\n" +
 	"\aSUCCESS\x10\x00\x12\x12\n" +
 	"\x0eALREADY_EXISTS\x10\x01\x12\x0f\n" +
 	"\vFEE_TOO_LOW\x10\x02\x12\t\n" +
 	"\x05STALE\x10\x03\x12\v\n" +
 	"\aINVALID\x10\x04\x12\x12\n" +
-	"\x0eINTERNAL_ERROR\x10\x052\xa8\x04\n" +
+	"\x0eINTERNAL_ERROR\x10\x052\xef\x05\n" +
 	"\x06Txpool\x126\n" +
 	"\aVersion\x12\x16.google.protobuf.Empty\x1a\x13.types.VersionReply\x121\n" +
 	"\vFindUnknown\x12\x10.txpool.TxHashes\x1a\x10.txpool.TxHashes\x12+\n" +
@@ -1044,7 +1484,10 @@ const file_txpool_txpool_proto_rawDesc = "" +
 	"\x05OnAdd\x12\x14.txpool.OnAddRequest\x1a\x12.txpool.OnAddReply0\x01\x124\n" +
 	"\x06Status\x12\x15.txpool.StatusRequest\x1a\x13.txpool.StatusReply\x121\n" +
 	"\x05Nonce\x12\x14.txpool.NonceRequest\x1a\x12.txpool.NonceReply\x12:\n" +
-	"\bGetBlobs\x12\x17.txpool.GetBlobsRequest\x1a\x15.txpool.GetBlobsReplyB\x16Z\x14./txpool;txpoolprotob\x06proto3"
+	"\bGetBlobs\x12\x17.txpool.GetBlobsRequest\x1a\x15.txpool.GetBlobsReply\x12U\n" +
+	"\x11TransactionStatus\x12 .txpool.TransactionStatusRequest\x1a\x1e.txpool.TransactionStatusReply\x126\n" +
+	"\x06Export\x12\x15.txpool.ExportRequest\x1a\x13.txpool.ExportReply0\x01\x126\n" +
+	"\x06Import\x12\x15.txpool.ImportRequest\x1a\x13.txpool.ImportReply(\x01B\x16Z\x14./txpool;txpoolprotob\x06proto3"
 
 var (
 	file_txpool_txpool_proto_rawDescOnce sync.Once
@@ -1058,70 +1501,86 @@ func file_txpool_txpool_proto_rawDescGZIP() []byte {
 	return file_txpool_txpool_proto_rawDescData
 }
 
-var file_txpool_txpool_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_txpool_txpool_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_txpool_txpool_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_txpool_txpool_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_txpool_txpool_proto_goTypes = []any{
-	(ImportResult)(0),               // 0: txpool.ImportResult
-	(AllReply_TxnType)(0),           // 1: txpool.AllReply.TxnType
-	(*TxHashes)(nil),                // 2: txpool.TxHashes
-	(*AddRequest)(nil),              // 3: txpool.AddRequest
-	(*AddReply)(nil),                // 4: txpool.AddReply
-	(*TransactionsRequest)(nil),     // 5: txpool.TransactionsRequest
-	(*TransactionsReply)(nil),       // 6: txpool.TransactionsReply
-	(*OnAddRequest)(nil),            // 7: txpool.OnAddRequest
-	(*OnAddReply)(nil),              // 8: txpool.OnAddReply
-	(*AllRequest)(nil),              // 9: txpool.AllRequest
-	(*AllReply)(nil),                // 10: txpool.AllReply
-	(*PendingReply)(nil),            // 11: txpool.PendingReply
-	(*StatusRequest)(nil),           // 12: txpool.StatusRequest
-	(*StatusReply)(nil),             // 13: txpool.StatusReply
-	(*NonceRequest)(nil),            // 14: txpool.NonceRequest
-	(*NonceReply)(nil),              // 15: txpool.NonceReply
-	(*GetBlobsRequest)(nil),         // 16: txpool.GetBlobsRequest
-	(*GetBlobsReply)(nil),           // 17: txpool.GetBlobsReply
-	(*AllReply_Tx)(nil),             // 18: txpool.AllReply.Tx
-	(*PendingReply_Tx)(nil),         // 19: txpool.PendingReply.Tx
-	(*typesproto.H256)(nil),         // 20: types.H256
-	(*typesproto.H160)(nil),         // 21: types.H160
-	(*emptypb.Empty)(nil),           // 22: google.protobuf.Empty
-	(*typesproto.VersionReply)(nil), // 23: types.VersionReply
+	(ImportResult)(0),                  // 0: txpool.ImportResult
+	(AllReply_TxnType)(0),              // 1: txpool.AllReply.TxnType
+	(TransactionStatusReply_Status)(0), // 2: txpool.TransactionStatusReply.Status
+	(*TxHashes)(nil),                   // 3: txpool.TxHashes
+	(*AddRequest)(nil),                 // 4: txpool.AddRequest
+	(*AddReply)(nil),                   // 5: txpool.AddReply
+	(*TransactionsRequest)(nil),        // 6: txpool.TransactionsRequest
+	(*TransactionsReply)(nil),          // 7: txpool.TransactionsReply
+	(*OnAddRequest)(nil),               // 8: txpool.OnAddRequest
+	(*OnAddReply)(nil),                 // 9: txpool.OnAddReply
+	(*AllRequest)(nil),                 // 10: txpool.AllRequest
+	(*AllReply)(nil),                   // 11: txpool.AllReply
+	(*PendingReply)(nil),               // 12: txpool.PendingReply
+	(*StatusRequest)(nil),              // 13: txpool.StatusRequest
+	(*StatusReply)(nil),                // 14: txpool.StatusReply
+	(*NonceRequest)(nil),               // 15: txpool.NonceRequest
+	(*NonceReply)(nil),                 // 16: txpool.NonceReply
+	(*GetBlobsRequest)(nil),            // 17: txpool.GetBlobsRequest
+	(*GetBlobsReply)(nil),              // 18: txpool.GetBlobsReply
+	(*TransactionStatusRequest)(nil),   // 19: txpool.TransactionStatusRequest
+	(*TransactionStatusReply)(nil),     // 20: txpool.TransactionStatusReply
+	(*ExportRequest)(nil),              // 21: txpool.ExportRequest
+	(*ExportReply)(nil),                // 22: txpool.ExportReply
+	(*ImportRequest)(nil),              // 23: txpool.ImportRequest
+	(*ImportReply)(nil),                // 24: txpool.ImportReply
+	(*AllReply_Tx)(nil),                // 25: txpool.AllReply.Tx
+	(*PendingReply_Tx)(nil),            // 26: txpool.PendingReply.Tx
+	(*typesproto.H256)(nil),            // 27: types.H256
+	(*typesproto.H160)(nil),            // 28: types.H160
+	(*emptypb.Empty)(nil),              // 29: google.protobuf.Empty
+	(*typesproto.VersionReply)(nil),    // 30: types.VersionReply
 }
 var file_txpool_txpool_proto_depIdxs = []int32{
-	20, // 0: txpool.TxHashes.hashes:type_name -> types.H256
+	27, // 0: txpool.TxHashes.hashes:type_name -> types.H256
 	0,  // 1: txpool.AddReply.imported:type_name -> txpool.ImportResult
-	20, // 2: txpool.TransactionsRequest.hashes:type_name -> types.H256
-	18, // 3: txpool.AllReply.txs:type_name -> txpool.AllReply.Tx
-	19, // 4: txpool.PendingReply.txs:type_name -> txpool.PendingReply.Tx
-	21, // 5: txpool.NonceRequest.address:type_name -> types.H160
-	20, // 6: txpool.GetBlobsRequest.blob_hashes:type_name -> types.H256
-	1,  // 7: txpool.AllReply.Tx.txn_type:type_name -> txpool.AllReply.TxnType
-	21, // 8: txpool.AllReply.Tx.sender:type_name -> types.H160
-	21, // 9: txpool.PendingReply.Tx.sender:type_name -> types.H160
-	22, // 10: txpool.Txpool.Version:input_type -> google.protobuf.Empty
-	2,  // 11: txpool.Txpool.FindUnknown:input_type -> txpool.TxHashes
-	3,  // 12: txpool.Txpool.Add:input_type -> txpool.AddRequest
-	5,  // 13: txpool.Txpool.Transactions:input_type -> txpool.TransactionsRequest
-	9,  // 14: txpool.Txpool.All:input_type -> txpool.AllRequest
-	22, // 15: txpool.Txpool.Pending:input_type -> google.protobuf.Empty
-	7,  // 16: txpool.Txpool.OnAdd:input_type -> txpool.OnAddRequest
-	12, // 17: txpool.Txpool.Status:input_type -> txpool.StatusRequest
-	14, // 18: txpool.Txpool.Nonce:input_type -> txpool.NonceRequest
-	16, // 19: txpool.Txpool.GetBlobs:input_type -> txpool.GetBlobsRequest
-	23, // 20: txpool.Txpool.Version:output_type -> types.VersionReply
-	2,  // 21: txpool.Txpool.FindUnknown:output_type -> txpool.TxHashes
-	4,  // 22: txpool.Txpool.Add:output_type -> txpool.AddReply
-	6,  // 23: txpool.Txpool.Transactions:output_type -> txpool.TransactionsReply
-	10, // 24: txpool.Txpool.All:output_type -> txpool.AllReply
-	11, // 25: txpool.Txpool.Pending:output_type -> txpool.PendingReply
-	8,  // 26: txpool.Txpool.OnAdd:output_type -> txpool.OnAddReply
-	13, // 27: txpool.Txpool.Status:output_type -> txpool.StatusReply
-	15, // 28: txpool.Txpool.Nonce:output_type -> txpool.NonceReply
-	17, // 29: txpool.Txpool.GetBlobs:output_type -> txpool.GetBlobsReply
-	20, // [20:30] is the sub-list for method output_type
-	10, // [10:20] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	27, // 2: txpool.TransactionsRequest.hashes:type_name -> types.H256
+	25, // 3: txpool.AllReply.txs:type_name -> txpool.AllReply.Tx
+	26, // 4: txpool.PendingReply.txs:type_name -> txpool.PendingReply.Tx
+	28, // 5: txpool.NonceRequest.address:type_name -> types.H160
+	27, // 6: txpool.GetBlobsRequest.blob_hashes:type_name -> types.H256
+	27, // 7: txpool.TransactionStatusRequest.hash:type_name -> types.H256
+	2,  // 8: txpool.TransactionStatusReply.status:type_name -> txpool.TransactionStatusReply.Status
+	27, // 9: txpool.TransactionStatusReply.replaced_by:type_name -> types.H256
+	1,  // 10: txpool.AllReply.Tx.txn_type:type_name -> txpool.AllReply.TxnType
+	28, // 11: txpool.AllReply.Tx.sender:type_name -> types.H160
+	28, // 12: txpool.PendingReply.Tx.sender:type_name -> types.H160
+	29, // 13: txpool.Txpool.Version:input_type -> google.protobuf.Empty
+	3,  // 14: txpool.Txpool.FindUnknown:input_type -> txpool.TxHashes
+	4,  // 15: txpool.Txpool.Add:input_type -> txpool.AddRequest
+	6,  // 16: txpool.Txpool.Transactions:input_type -> txpool.TransactionsRequest
+	10, // 17: txpool.Txpool.All:input_type -> txpool.AllRequest
+	29, // 18: txpool.Txpool.Pending:input_type -> google.protobuf.Empty
+	8,  // 19: txpool.Txpool.OnAdd:input_type -> txpool.OnAddRequest
+	13, // 20: txpool.Txpool.Status:input_type -> txpool.StatusRequest
+	15, // 21: txpool.Txpool.Nonce:input_type -> txpool.NonceRequest
+	17, // 22: txpool.Txpool.GetBlobs:input_type -> txpool.GetBlobsRequest
+	19, // 23: txpool.Txpool.TransactionStatus:input_type -> txpool.TransactionStatusRequest
+	21, // 24: txpool.Txpool.Export:input_type -> txpool.ExportRequest
+	23, // 25: txpool.Txpool.Import:input_type -> txpool.ImportRequest
+	30, // 26: txpool.Txpool.Version:output_type -> types.VersionReply
+	3,  // 27: txpool.Txpool.FindUnknown:output_type -> txpool.TxHashes
+	5,  // 28: txpool.Txpool.Add:output_type -> txpool.AddReply
+	7,  // 29: txpool.Txpool.Transactions:output_type -> txpool.TransactionsReply
+	11, // 30: txpool.Txpool.All:output_type -> txpool.AllReply
+	12, // 31: txpool.Txpool.Pending:output_type -> txpool.PendingReply
+	9,  // 32: txpool.Txpool.OnAdd:output_type -> txpool.OnAddReply
+	14, // 33: txpool.Txpool.Status:output_type -> txpool.StatusReply
+	16, // 34: txpool.Txpool.Nonce:output_type -> txpool.NonceReply
+	18, // 35: txpool.Txpool.GetBlobs:output_type -> txpool.GetBlobsReply
+	20, // 36: txpool.Txpool.TransactionStatus:output_type -> txpool.TransactionStatusReply
+	22, // 37: txpool.Txpool.Export:output_type -> txpool.ExportReply
+	24, // 38: txpool.Txpool.Import:output_type -> txpool.ImportReply
+	26, // [26:39] is the sub-list for method output_type
+	13, // [13:26] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_txpool_txpool_proto_init() }
@@ -1134,8 +1593,8 @@ func file_txpool_txpool_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_txpool_txpool_proto_rawDesc), len(file_txpool_txpool_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   18,
+			NumEnums:      3,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,16 +21,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Txpool_Version_FullMethodName      = "/txpool.Txpool/Version"
-	Txpool_FindUnknown_FullMethodName  = "/txpool.Txpool/FindUnknown"
-	Txpool_Add_FullMethodName          = "/txpool.Txpool/Add"
-	Txpool_Transactions_FullMethodName = "/txpool.Txpool/Transactions"
-	Txpool_All_FullMethodName          = "/txpool.Txpool/All"
-	Txpool_Pending_FullMethodName      = "/txpool.Txpool/Pending"
-	Txpool_OnAdd_FullMethodName        = "/txpool.Txpool/OnAdd"
-	Txpool_Status_FullMethodName       = "/txpool.Txpool/Status"
-	Txpool_Nonce_FullMethodName        = "/txpool.Txpool/Nonce"
-	Txpool_GetBlobs_FullMethodName     = "/txpool.Txpool/GetBlobs"
+	Txpool_Version_FullMethodName           = "/txpool.Txpool/Version"
+	Txpool_FindUnknown_FullMethodName       = "/txpool.Txpool/FindUnknown"
+	Txpool_Add_FullMethodName               = "/txpool.Txpool/Add"
+	Txpool_Transactions_FullMethodName      = "/txpool.Txpool/Transactions"
+	Txpool_All_FullMethodName               = "/txpool.Txpool/All"
+	Txpool_Pending_FullMethodName           = "/txpool.Txpool/Pending"
+	Txpool_OnAdd_FullMethodName             = "/txpool.Txpool/OnAdd"
+	Txpool_Status_FullMethodName            = "/txpool.Txpool/Status"
+	Txpool_Nonce_FullMethodName             = "/txpool.Txpool/Nonce"
+	Txpool_GetBlobs_FullMethodName          = "/txpool.Txpool/GetBlobs"
+	Txpool_TransactionStatus_FullMethodName = "/txpool.Txpool/TransactionStatus"
+	Txpool_Export_FullMethodName            = "/txpool.Txpool/Export"
+	Txpool_Import_FullMethodName            = "/txpool.Txpool/Import"
 )
 
 // TxpoolClient is the client API for Txpool service.
@@ -58,6 +61,12 @@ type TxpoolClient interface {
 	Nonce(ctx context.Context, in *NonceRequest, opts ...grpc.CallOption) (*NonceReply, error)
 	// returns the list of blobs and proofs for a given list of blob hashes
 	GetBlobs(ctx context.Context, in *GetBlobsRequest, opts ...grpc.CallOption) (*GetBlobsReply, error)
+	// returns status of transaction in pool, or why it was recently discarded
+	TransactionStatus(ctx context.Context, in *TransactionStatusRequest, opts ...grpc.CallOption) (*TransactionStatusReply, error)
+	// streams dump of all transactions of pool (with blob sidecars)
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportReply], error)
+	// adds transactions from dump streamed by Export
+	Import(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportRequest, ImportReply], error)
 }
 
 type txpoolClient struct {
@@ -177,6 +186,48 @@ func (c *txpoolClient) GetBlobs(ctx context.Context, in *GetBlobsRequest, opts .
 	return out, nil
 }
 
+func (c *txpoolClient) TransactionStatus(ctx context.Context, in *TransactionStatusRequest, opts ...grpc.CallOption) (*TransactionStatusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionStatusReply)
+	err := c.cc.Invoke(ctx, Txpool_TransactionStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txpoolClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Txpool_ServiceDesc.Streams[1], Txpool_Export_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportRequest, ExportReply]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Txpool_ExportClient = grpc.ServerStreamingClient[ExportReply]
+
+func (c *txpoolClient) Import(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportRequest, ImportReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Txpool_ServiceDesc.Streams[2], Txpool_Import_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportRequest, ImportReply]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Txpool_ImportClient = grpc.ClientStreamingClient[ImportRequest, ImportReply]
+
 // TxpoolServer is the server API for Txpool service.
 // All implementations must embed UnimplementedTxpoolServer
 // for forward compatibility.
@@ -202,6 +253,12 @@ type TxpoolServer interface {
 	Nonce(context.Context, *NonceRequest) (*NonceReply, error)
 	// returns the list of blobs and proofs for a given list of blob hashes
 	GetBlobs(context.Context, *GetBlobsRequest) (*GetBlobsReply, error)
+	// returns status of transaction in pool, or why it was recently discarded
+	TransactionStatus(context.Context, *TransactionStatusRequest) (*TransactionStatusReply, error)
+	// streams dump of all transactions of pool (with blob sidecars)
+	Export(*ExportRequest, grpc.ServerStreamingServer[ExportReply]) error
+	// adds transactions from dump streamed by Export
+	Import(grpc.ClientStreamingServer[ImportRequest, ImportReply]) error
 	mustEmbedUnimplementedTxpoolServer()
 }
 
@@ -242,6 +299,15 @@ func (UnimplementedTxpoolServer) Nonce(context.Context, *NonceRequest) (*NonceRe
 func (UnimplementedTxpoolServer) GetBlobs(context.Context, *GetBlobsRequest) (*GetBlobsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlobs not implemented")
 }
+func (UnimplementedTxpoolServer) TransactionStatus(context.Context, *TransactionStatusRequest) (*TransactionStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransactionStatus not implemented")
+}
+func (UnimplementedTxpoolServer) Export(*ExportRequest, grpc.ServerStreamingServer[ExportReply]) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (UnimplementedTxpoolServer) Import(grpc.ClientStreamingServer[ImportRequest, ImportReply]) error {
+	return status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
 func (UnimplementedTxpoolServer) mustEmbedUnimplementedTxpoolServer() {}
 func (UnimplementedTxpoolServer) testEmbeddedByValue()                {}
 
@@ -436,6 +502,42 @@ func _Txpool_GetBlobs_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Txpool_TransactionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).TransactionStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_TransactionStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).TransactionStatus(ctx, req.(*TransactionStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Txpool_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TxpoolServer).Export(m, &grpc.GenericServerStream[ExportRequest, ExportReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Txpool_ExportServer = grpc.ServerStreamingServer[ExportReply]
+
+func _Txpool_Import_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TxpoolServer).Import(&grpc.GenericServerStream[ImportRequest, ImportReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Txpool_ImportServer = grpc.ClientStreamingServer[ImportRequest, ImportReply]
+
 // Txpool_ServiceDesc is the grpc.ServiceDesc for Txpool service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -479,6 +581,10 @@ var Txpool_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlobs",
 			Handler:    _Txpool_GetBlobs_Handler,
 		},
+		{
+			MethodName: "TransactionStatus",
+			Handler:    _Txpool_TransactionStatus_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -486,6 +592,16 @@ var Txpool_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Txpool_OnAdd_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Export",
+			Handler:       _Txpool_Export_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Import",
+			Handler:       _Txpool_Import_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "txpool/txpool.proto",
 }
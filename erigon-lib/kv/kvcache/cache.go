@@ -32,6 +32,7 @@ import (
 	"golang.org/x/crypto/sha3"
 
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
 	"github.com/erigontech/erigon-lib/kv"
@@ -39,13 +40,14 @@ import (
 )
 
 type CacheValidationResult struct {
-	RequestCancelled   bool
-	Enabled            bool
-	LatestStateBehind  bool
-	CacheCleared       bool
-	LatestStateID      uint64
-	StateKeysOutOfSync [][]byte
-	CodeKeysOutOfSync  [][]byte
+	RequestCancelled     bool
+	Enabled              bool
+	LatestStateBehind    bool
+	CacheCleared         bool
+	LatestStateID        uint64
+	StateKeysOutOfSync   [][]byte
+	CodeKeysOutOfSync    [][]byte
+	StorageKeysOutOfSync [][]byte
 }
 
 type Cache interface {
@@ -99,20 +101,29 @@ type CacheView interface {
 // Rules of filling cache.stateEvict:
 //   - changes in Canonical View SHOULD reflect in stateEvict
 //   - changes in Non-Canonical View SHOULD NOT reflect in stateEvict
+//
+// Storage slots are cached separately from accounts (see StorageEvictionList): per-contract LRU with pinning of hot contracts,
+// same rules of filling cache.storageEvict apply.
 type Coherent struct {
 	hasher               hash.Hash
 	codeEvictLen         metrics.Gauge
 	codeKeys             metrics.Gauge
 	keys                 metrics.Gauge
 	evict                metrics.Gauge
+	storageKeys          metrics.Gauge
+	storageEvictLen      metrics.Gauge
+	storageContracts     metrics.Gauge
 	latestStateView      *CoherentRoot
 	codeMiss             metrics.Counter
 	timeout              metrics.Counter
 	hits                 metrics.Counter
 	codeHits             metrics.Counter
+	storageHits          metrics.Counter
+	storageMiss          metrics.Counter
 	roots                map[uint64]*CoherentRoot
 	stateEvict           *ThreadSafeEvictionList
 	codeEvict            *ThreadSafeEvictionList
+	storageEvict         *StorageEvictionList
 	miss                 metrics.Counter
 	cfg                  CoherentConfig
 	latestStateVersionID uint64
@@ -123,6 +134,7 @@ type Coherent struct {
 type CoherentRoot struct {
 	cache           *btree2.BTreeG[*Element]
 	codeCache       *btree2.BTreeG[*Element]
+	storageCache    *btree2.BTreeG[*Element]
 	ready           chan struct{} // close when ready
 	readyChanClosed atomic.Bool   // quick check if ready channel is closed
 	closeOnce       sync.Once     // protecting `ready` field from double-close
@@ -154,25 +166,30 @@ const (
 )
 
 type CoherentConfig struct {
-	CacheSize       datasize.ByteSize
-	CodeCacheSize   datasize.ByteSize
-	WaitForNewBlock bool // should we wait 10ms for a new block message to arrive when calling View?
-	WithStorage     bool
-	MetricsLabel    string
-	NewBlockWait    time.Duration // how long wait
-	KeepViews       uint64        // keep in memory up to this amount of views, evict older
-	StateV3         bool
+	CacheSize               datasize.ByteSize
+	CodeCacheSize           datasize.ByteSize
+	StorageCacheSize        datasize.ByteSize
+	StorageSlotsPerContract int              // max amount of cached slots of not-pinned contract. 0 - unlimited
+	PinnedContracts         []common.Address // storage of this contracts evicted only if there is nothing else to evict
+	WaitForNewBlock         bool             // should we wait 10ms for a new block message to arrive when calling View?
+	WithStorage             bool
+	MetricsLabel            string
+	NewBlockWait            time.Duration // how long wait
+	KeepViews               uint64        // keep in memory up to this amount of views, evict older
+	StateV3                 bool
 }
 
 var DefaultCoherentConfig = CoherentConfig{
-	KeepViews:       5,
-	NewBlockWait:    5 * time.Millisecond,
-	CacheSize:       2 * datasize.GB,
-	CodeCacheSize:   2 * datasize.GB,
-	MetricsLabel:    "default",
-	WithStorage:     true,
-	WaitForNewBlock: true,
-	StateV3:         true,
+	KeepViews:               5,
+	NewBlockWait:            5 * time.Millisecond,
+	CacheSize:               1 * datasize.GB,
+	CodeCacheSize:           2 * datasize.GB,
+	StorageCacheSize:        1 * datasize.GB,
+	StorageSlotsPerContract: 100_000,
+	MetricsLabel:            "default",
+	WithStorage:             true,
+	WaitForNewBlock:         true,
+	StateV3:                 true,
 }
 
 func New(cfg CoherentConfig) *Coherent {
@@ -181,20 +198,26 @@ func New(cfg CoherentConfig) *Coherent {
 	}
 
 	return &Coherent{
-		roots:        map[uint64]*CoherentRoot{},
-		stateEvict:   &ThreadSafeEvictionList{l: NewList()},
-		codeEvict:    &ThreadSafeEvictionList{l: NewList()},
-		hasher:       sha3.NewLegacyKeccak256(),
-		cfg:          cfg,
-		miss:         metrics.GetOrCreateCounter(fmt.Sprintf(`cache_total{result="miss",name="%s"}`, cfg.MetricsLabel)),
-		hits:         metrics.GetOrCreateCounter(fmt.Sprintf(`cache_total{result="hit",name="%s"}`, cfg.MetricsLabel)),
-		timeout:      metrics.GetOrCreateCounter(fmt.Sprintf(`cache_timeout_total{name="%s"}`, cfg.MetricsLabel)),
-		keys:         metrics.GetOrCreateGauge(fmt.Sprintf(`cache_keys_total{name="%s"}`, cfg.MetricsLabel)),
-		evict:        metrics.GetOrCreateGauge(fmt.Sprintf(`cache_list_total{name="%s"}`, cfg.MetricsLabel)),
-		codeMiss:     metrics.GetOrCreateCounter(fmt.Sprintf(`cache_code_total{result="miss",name="%s"}`, cfg.MetricsLabel)),
-		codeHits:     metrics.GetOrCreateCounter(fmt.Sprintf(`cache_code_total{result="hit",name="%s"}`, cfg.MetricsLabel)),
-		codeKeys:     metrics.GetOrCreateGauge(fmt.Sprintf(`cache_code_keys_total{name="%s"}`, cfg.MetricsLabel)),
-		codeEvictLen: metrics.GetOrCreateGauge(fmt.Sprintf(`cache_code_list_total{name="%s"}`, cfg.MetricsLabel)),
+		roots:            map[uint64]*CoherentRoot{},
+		stateEvict:       &ThreadSafeEvictionList{l: NewList()},
+		codeEvict:        &ThreadSafeEvictionList{l: NewList()},
+		storageEvict:     NewStorageEvictionList(cfg.StorageSlotsPerContract, cfg.PinnedContracts),
+		hasher:           sha3.NewLegacyKeccak256(),
+		cfg:              cfg,
+		miss:             metrics.GetOrCreateCounter(fmt.Sprintf(`cache_total{result="miss",name="%s"}`, cfg.MetricsLabel)),
+		hits:             metrics.GetOrCreateCounter(fmt.Sprintf(`cache_total{result="hit",name="%s"}`, cfg.MetricsLabel)),
+		timeout:          metrics.GetOrCreateCounter(fmt.Sprintf(`cache_timeout_total{name="%s"}`, cfg.MetricsLabel)),
+		keys:             metrics.GetOrCreateGauge(fmt.Sprintf(`cache_keys_total{name="%s"}`, cfg.MetricsLabel)),
+		evict:            metrics.GetOrCreateGauge(fmt.Sprintf(`cache_list_total{name="%s"}`, cfg.MetricsLabel)),
+		codeMiss:         metrics.GetOrCreateCounter(fmt.Sprintf(`cache_code_total{result="miss",name="%s"}`, cfg.MetricsLabel)),
+		codeHits:         metrics.GetOrCreateCounter(fmt.Sprintf(`cache_code_total{result="hit",name="%s"}`, cfg.MetricsLabel)),
+		codeKeys:         metrics.GetOrCreateGauge(fmt.Sprintf(`cache_code_keys_total{name="%s"}`, cfg.MetricsLabel)),
+		codeEvictLen:     metrics.GetOrCreateGauge(fmt.Sprintf(`cache_code_list_total{name="%s"}`, cfg.MetricsLabel)),
+		storageMiss:      metrics.GetOrCreateCounter(fmt.Sprintf(`cache_storage_total{result="miss",name="%s"}`, cfg.MetricsLabel)),
+		storageHits:      metrics.GetOrCreateCounter(fmt.Sprintf(`cache_storage_total{result="hit",name="%s"}`, cfg.MetricsLabel)),
+		storageKeys:      metrics.GetOrCreateGauge(fmt.Sprintf(`cache_storage_keys_total{name="%s"}`, cfg.MetricsLabel)),
+		storageEvictLen:  metrics.GetOrCreateGauge(fmt.Sprintf(`cache_storage_list_total{name="%s"}`, cfg.MetricsLabel)),
+		storageContracts: metrics.GetOrCreateGauge(fmt.Sprintf(`cache_storage_contracts_total{name="%s"}`, cfg.MetricsLabel)),
 	}
 }
 
@@ -208,9 +231,10 @@ func (c *Coherent) selectOrCreateRoot(versionID uint64) *CoherentRoot {
 	}
 
 	r = &CoherentRoot{
-		ready:     make(chan struct{}),
-		cache:     btree2.NewBTreeG[*Element](Less),
-		codeCache: btree2.NewBTreeG[*Element](Less),
+		ready:        make(chan struct{}),
+		cache:        btree2.NewBTreeG[*Element](Less),
+		codeCache:    btree2.NewBTreeG[*Element](Less),
+		storageCache: btree2.NewBTreeG[*Element](Less),
 	}
 	c.roots[versionID] = r
 	return r
@@ -234,13 +258,16 @@ func (c *Coherent) advanceRoot(stateVersionID uint64) (r *CoherentRoot) {
 		//log.Info("advance: clone", "from", viewID-1, "to", viewID)
 		r.cache = prevView.cache.Copy()
 		r.codeCache = prevView.codeCache.Copy()
+		r.storageCache = prevView.storageCache.Copy()
 	} else {
 		c.stateEvict.Init()
 		c.codeEvict.Init()
+		c.storageEvict.Init()
 		if r.cache == nil {
 			//log.Info("advance: new", "to", viewID)
 			r.cache = btree2.NewBTreeG[*Element](Less)
 			r.codeCache = btree2.NewBTreeG[*Element](Less)
+			r.storageCache = btree2.NewBTreeG[*Element](Less)
 		} else {
 			r.cache.Walk(func(items []*Element) bool {
 				for _, i := range items {
@@ -254,6 +281,14 @@ func (c *Coherent) advanceRoot(stateVersionID uint64) (r *CoherentRoot) {
 				}
 				return true
 			})
+			r.storageCache.Walk(func(items []*Element) bool {
+				for _, i := range items {
+					for _, evicted := range c.storageEvict.PushFront(i) {
+						r.storageCache.Delete(evicted)
+					}
+				}
+				return true
+			})
 		}
 	}
 	r.isCanonical = true
@@ -266,6 +301,9 @@ func (c *Coherent) advanceRoot(stateVersionID uint64) (r *CoherentRoot) {
 	c.codeKeys.SetInt(c.latestStateView.codeCache.Len())
 	c.evict.SetInt(c.stateEvict.Len())
 	c.codeEvictLen.SetInt(c.codeEvict.Len())
+	c.storageKeys.SetInt(c.latestStateView.storageCache.Len())
+	c.storageEvictLen.SetInt(c.storageEvict.Len())
+	c.storageContracts.SetInt(c.storageEvict.Contracts())
 	return r
 }
 
@@ -287,6 +325,7 @@ func (c *Coherent) OnNewBlock(stateChanges *remote.StateChangeBatch) {
 				addr := gointerfaces.ConvertH160toAddress(sc.Changes[i].Address)
 				v := sc.Changes[i].Data
 				c.add(addr[:], v, r, id)
+				c.removeStorageOf(addr, r, id) // contract re-created - storage starts from scratch
 				c.hasher.Reset()
 				c.hasher.Write(sc.Changes[i].Code)
 				k := make([]byte, 32)
@@ -295,6 +334,7 @@ func (c *Coherent) OnNewBlock(stateChanges *remote.StateChangeBatch) {
 			case remote.Action_REMOVE:
 				addr := gointerfaces.ConvertH160toAddress(sc.Changes[i].Address)
 				c.add(addr[:], nil, r, id)
+				c.removeStorageOf(addr, r, id)
 			case remote.Action_STORAGE:
 				//skip, will check later
 			case remote.Action_CODE:
@@ -310,11 +350,7 @@ func (c *Coherent) OnNewBlock(stateChanges *remote.StateChangeBatch) {
 				addr := gointerfaces.ConvertH160toAddress(sc.Changes[i].Address)
 				for _, change := range sc.Changes[i].StorageChanges {
 					loc := gointerfaces.ConvertH256ToHash(change.Location)
-					k := make([]byte, 20+8+32)
-					copy(k, addr[:])
-					binary.BigEndian.PutUint64(k[20:], sc.Changes[i].Incarnation)
-					copy(k[20+8:], loc[:])
-					c.add(k, change.Data, r, id)
+					c.addStorage(c.storageKey(addr, sc.Changes[i].Incarnation, loc), change.Data, r, id)
 				}
 			}
 		}
@@ -378,7 +414,25 @@ func (c *Coherent) getFromCache(k []byte, id uint64, code bool) (*Element, *Cohe
 	}
 	return it, r, nil
 }
+func (c *Coherent) getStorageFromCache(k []byte, id uint64) (*Element, *CoherentRoot, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	r, ok := c.roots[id]
+	if !ok {
+		return nil, r, fmt.Errorf("too old ViewID: %d, latestStateVersionID=%d", id, c.latestStateVersionID)
+	}
+	it, _ := r.storageCache.Get(&Element{K: k})
+	if it != nil && c.latestStateVersionID == id {
+		c.storageEvict.MoveToFront(it)
+	}
+	return it, r, nil
+}
+
 func (c *Coherent) Get(k []byte, tx kv.TemporalTx, id uint64) (v []byte, err error) {
+	if len(k) != length.Addr {
+		return c.GetStorage(k, tx, id)
+	}
 	it, r, err := c.getFromCache(k, id, false)
 	if err != nil {
 		return nil, err
@@ -393,11 +447,7 @@ func (c *Coherent) Get(k []byte, tx kv.TemporalTx, id uint64) (v []byte, err err
 	c.miss.Inc()
 
 	if c.cfg.StateV3 {
-		if len(k) == 20 {
-			v, _, err = tx.GetLatest(kv.AccountsDomain, k)
-		} else {
-			v, _, err = tx.GetLatest(kv.StorageDomain, k)
-		}
+		v, _, err = tx.GetLatest(kv.AccountsDomain, k)
 	} else {
 		v, err = tx.GetOne(kv.PlainState, k)
 	}
@@ -416,6 +466,56 @@ func (c *Coherent) Get(k []byte, tx kv.TemporalTx, id uint64) (v []byte, err err
 	return v, nil
 }
 
+// GetStorage - k is addr+location (or addr+incarnation+location if !StateV3).
+// Unlike accounts - absence of slot is cached too, because most of eth_call reads are for empty slots.
+func (c *Coherent) GetStorage(k []byte, tx kv.TemporalTx, id uint64) (v []byte, err error) {
+	if !c.cfg.WithStorage { // storage changes are not applied to cache - can't cache reads
+		return c.readStorage(k, tx)
+	}
+	it, r, err := c.getStorageFromCache(k, id)
+	if err != nil {
+		return nil, err
+	}
+	if it != nil {
+		c.storageHits.Inc()
+		return it.V, nil
+	}
+	c.storageMiss.Inc()
+
+	v, err = c.readStorage(k, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	v = c.addStorage(common.Copy(k), common.Copy(v), r, id).V
+	return v, nil
+}
+
+func (c *Coherent) readStorage(k []byte, tx kv.TemporalTx) (v []byte, err error) {
+	if c.cfg.StateV3 {
+		v, _, err = tx.GetLatest(kv.StorageDomain, k)
+	} else {
+		v, err = tx.GetOne(kv.PlainState, k)
+	}
+	return v, err
+}
+
+func (c *Coherent) storageKey(addr common.Address, incarnation uint64, loc common.Hash) []byte {
+	if c.cfg.StateV3 {
+		k := make([]byte, length.Addr+length.Hash)
+		copy(k, addr[:])
+		copy(k[length.Addr:], loc[:])
+		return k
+	}
+	k := make([]byte, length.Addr+length.Incarnation+length.Hash)
+	copy(k, addr[:])
+	binary.BigEndian.PutUint64(k[length.Addr:], incarnation)
+	copy(k[length.Addr+length.Incarnation:], loc[:])
+	return k
+}
+
 func (c *Coherent) GetCode(k []byte, tx kv.TemporalTx, id uint64) (v []byte, err error) {
 	it, r, err := c.getFromCache(k, id, true)
 	if err != nil {
@@ -458,6 +558,57 @@ func (c *Coherent) removeOldestCode(r *CoherentRoot) {
 		r.codeCache.Delete(e)
 	}
 }
+func (c *Coherent) removeOldestStorage(r *CoherentRoot) bool {
+	e := c.storageEvict.Oldest()
+	if e == nil {
+		return false
+	}
+	c.storageEvict.Remove(e)
+	r.storageCache.Delete(e)
+	return true
+}
+func (c *Coherent) addStorage(k, v []byte, r *CoherentRoot, id uint64) *Element {
+	it := &Element{K: k, V: v}
+	replaced, _ := r.storageCache.Set(it)
+	if c.latestStateVersionID != id {
+		return it
+	}
+	if replaced != nil {
+		c.storageEvict.Remove(replaced)
+	}
+	for _, evicted := range c.storageEvict.PushFront(it) {
+		r.storageCache.Delete(evicted)
+	}
+
+	for c.storageEvict.Size() > int(c.cfg.StorageCacheSize.Bytes()) {
+		if !c.removeOldestStorage(r) {
+			break
+		}
+	}
+	return it
+}
+
+// removeStorageOf - drops all cached slots of contract. Slots which are not in eviction list
+// (added to non-latest view) are dropped by prefix scan.
+func (c *Coherent) removeStorageOf(addr common.Address, r *CoherentRoot, id uint64) {
+	if c.latestStateVersionID == id {
+		for _, e := range c.storageEvict.RemoveContract(addr) {
+			r.storageCache.Delete(e)
+		}
+	}
+	var toDel []*Element
+	r.storageCache.Ascend(&Element{K: addr[:]}, func(e *Element) bool {
+		if !bytes.HasPrefix(e.K, addr[:]) {
+			return false
+		}
+		toDel = append(toDel, e)
+		return true
+	})
+	for _, e := range toDel {
+		r.storageCache.Delete(e)
+	}
+}
+
 func (c *Coherent) add(k, v []byte, r *CoherentRoot, id uint64) *Element {
 	it := &Element{K: k, V: v}
 
@@ -543,7 +694,7 @@ func (c *Coherent) ValidateCurrentRoot(ctx context.Context, tx kv.Tx) (*CacheVal
 
 	clearCache := false
 
-	compare := func(cache *btree2.BTreeG[*Element], bucket string) (bool, [][]byte, error) {
+	compare := func(cache *btree2.BTreeG[*Element], get func(k []byte) ([]byte, error)) (bool, [][]byte, error) {
 		keys := make([][]byte, 0)
 
 		for {
@@ -553,7 +704,7 @@ func (c *Coherent) ValidateCurrentRoot(ctx context.Context, tx kv.Tx) (*CacheVal
 			}
 
 			// check the db
-			inDb, err := tx.GetOne(bucket, val.K)
+			inDb, err := get(val.K)
 			if err != nil {
 				return false, keys, err
 			}
@@ -573,9 +724,20 @@ func (c *Coherent) ValidateCurrentRoot(ctx context.Context, tx kv.Tx) (*CacheVal
 		return false, keys, nil
 	}
 
-	cache, codeCache := c.cloneCaches(root)
+	getter := func(bucket string) func(k []byte) ([]byte, error) {
+		return func(k []byte) ([]byte, error) { return tx.GetOne(bucket, k) }
+	}
+	getStorage := getter(kv.PlainState)
+	if ttx, ok := tx.(kv.TemporalTx); ok && c.cfg.StateV3 {
+		getStorage = func(k []byte) ([]byte, error) {
+			v, _, err := ttx.GetLatest(kv.StorageDomain, k)
+			return v, err
+		}
+	}
 
-	cancelled, keys, err := compare(cache, kv.PlainState)
+	cache, codeCache, storageCache := c.cloneCaches(root)
+
+	cancelled, keys, err := compare(cache, getter(kv.PlainState))
 	if err != nil {
 		return nil, err
 	}
@@ -585,7 +747,7 @@ func (c *Coherent) ValidateCurrentRoot(ctx context.Context, tx kv.Tx) (*CacheVal
 		return result, nil
 	}
 
-	cancelled, keys, err = compare(codeCache, kv.Code)
+	cancelled, keys, err = compare(codeCache, getter(kv.Code))
 	if err != nil {
 		return nil, err
 	}
@@ -595,6 +757,16 @@ func (c *Coherent) ValidateCurrentRoot(ctx context.Context, tx kv.Tx) (*CacheVal
 		return result, nil
 	}
 
+	cancelled, keys, err = compare(storageCache, getStorage)
+	if err != nil {
+		return nil, err
+	}
+	result.StorageKeysOutOfSync = keys
+	if cancelled {
+		result.RequestCancelled = true
+		return result, nil
+	}
+
 	if clearCache {
 		c.clearCaches(root)
 	}
@@ -603,12 +775,13 @@ func (c *Coherent) ValidateCurrentRoot(ctx context.Context, tx kv.Tx) (*CacheVal
 	return result, nil
 }
 
-func (c *Coherent) cloneCaches(r *CoherentRoot) (cache *btree2.BTreeG[*Element], codeCache *btree2.BTreeG[*Element]) {
+func (c *Coherent) cloneCaches(r *CoherentRoot) (cache, codeCache, storageCache *btree2.BTreeG[*Element]) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 	cache = r.cache.Copy()
 	codeCache = r.codeCache.Copy()
-	return cache, codeCache
+	storageCache = r.storageCache.Copy()
+	return cache, codeCache, storageCache
 }
 
 func (c *Coherent) clearCaches(r *CoherentRoot) {
@@ -616,6 +789,8 @@ func (c *Coherent) clearCaches(r *CoherentRoot) {
 	defer c.lock.Unlock()
 	r.cache.Clear()
 	r.codeCache.Clear()
+	r.storageCache.Clear()
+	c.storageEvict.Init()
 }
 
 type Stat struct {
@@ -494,3 +494,93 @@ func TestCode(t *testing.T) {
 		return nil
 	})
 }
+
+func TestStorage(t *testing.T) {
+	require, ctx := require.New(t), context.Background()
+	cfg := DefaultCoherentConfig
+	cfg.NewBlockWait = 0
+	c := New(cfg)
+	db := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	addr := common.Address{1}
+	loc1, loc2 := common.Hash{1}, common.Hash{2}
+	k1, k2 := append(addr[:], loc1[:]...), append(addr[:], loc2[:]...)
+
+	c.OnNewBlock(&remote.StateChangeBatch{
+		StateVersionId: 1,
+		ChangeBatch: []*remote.StateChange{{
+			Direction: remote.Direction_FORWARD,
+			Changes: []*remote.AccountChange{{
+				Action:  remote.Action_STORAGE,
+				Address: gointerfaces.ConvertAddressToH160(addr),
+				StorageChanges: []*remote.StorageChange{
+					{Location: gointerfaces.ConvertHashToH256(loc1), Data: []byte{1}},
+				},
+			}},
+		}},
+	})
+	require.Equal(1, c.storageEvict.Len())
+	require.Equal(1, c.storageEvict.Contracts())
+
+	_ = db.ViewTemporal(ctx, func(tx kv.TemporalTx) error {
+		v, err := c.Get(k1, tx, 1)
+		require.NoError(err)
+		require.Equal([]byte{1}, v)
+		v, err = c.Get(k2, tx, 1) // miss: empty slot is cached too
+		require.NoError(err)
+		require.Empty(v)
+		return nil
+	})
+	require.Equal(2, c.storageEvict.Len())
+	require.Equal(2, c.roots[1].storageCache.Len())
+	require.Zero(c.roots[1].cache.Len())
+
+	// selfdestruct drops all slots of contract
+	c.OnNewBlock(&remote.StateChangeBatch{
+		StateVersionId: 2,
+		ChangeBatch: []*remote.StateChange{{
+			Direction: remote.Direction_FORWARD,
+			Changes: []*remote.AccountChange{{
+				Action:  remote.Action_REMOVE,
+				Address: gointerfaces.ConvertAddressToH160(addr),
+			}},
+		}},
+	})
+	require.Zero(c.storageEvict.Len())
+	require.Zero(c.storageEvict.Contracts())
+	require.Zero(c.roots[2].storageCache.Len())
+	require.Equal(2, c.roots[1].storageCache.Len()) // older view is immutable
+}
+
+func TestStorageEviction(t *testing.T) {
+	require := require.New(t)
+	hot, cold1, cold2 := common.Address{1}, common.Address{2}, common.Address{3}
+	slot := func(addr common.Address, loc byte) *Element {
+		return &Element{K: append(common.Copy(addr[:]), common.Hash{loc}.Bytes()...), V: []byte{loc}}
+	}
+
+	l := NewStorageEvictionList(2, []common.Address{hot})
+	for i := byte(0); i < 3; i++ {
+		require.Empty(l.PushFront(slot(hot, i))) // pinned contracts are not limited by slotsPerContract
+	}
+	require.Empty(l.PushFront(slot(cold1, 0)))
+	require.Empty(l.PushFront(slot(cold1, 1)))
+	evicted := l.PushFront(slot(cold1, 2))
+	require.Len(evicted, 1)
+	require.Equal(slot(cold1, 0).K, evicted[0].K)
+	require.Equal(5, l.Len())
+
+	e := slot(cold2, 0)
+	l.PushFront(e)
+	// cold1 is least-recently-used not-pinned contract
+	require.Equal(slot(cold1, 1).K, l.Oldest().K)
+	l.Remove(l.Oldest())
+	l.Remove(l.Oldest())
+	require.Equal(e.K, l.Oldest().K)
+	l.Remove(e)
+	require.Equal(1, l.Contracts())
+	// only pinned slots are left
+	require.Equal(slot(hot, 0).K, l.Oldest().K)
+	require.Len(l.RemoveContract(hot), 3)
+	require.Nil(l.Oldest())
+	require.Zero(l.Size())
+}
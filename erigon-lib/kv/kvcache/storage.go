@@ -0,0 +1,210 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package kvcache
+
+import (
+	"sync"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
+)
+
+// contractSlots - LRU of cached storage slots of one contract
+type contractSlots struct {
+	slots  *List
+	order  *Element // position of contract in StorageEvictionList.order or StorageEvictionList.pinnedOrder
+	pinned bool
+}
+
+// StorageEvictionList - eviction order of storage slots.
+//
+// Every contract has own LRU of slots (bounded by slotsPerContract), and contracts themselves are ordered by last access.
+// When cache is over its size limit - the oldest slot of the least-recently-used contract is evicted first.
+// Pinned contracts (hot routers, tokens, etc...) are not limited by slotsPerContract and their slots are evicted
+// only when there are no slots of not-pinned contracts left.
+type StorageEvictionList struct {
+	contracts        map[common.Address]*contractSlots
+	pinned           map[common.Address]struct{}
+	order            *List // not-pinned contracts, Element.K is contract address
+	pinnedOrder      *List // pinned contracts, Element.K is contract address
+	slotsPerContract int
+	len, size        int
+	lock             sync.Mutex
+}
+
+func NewStorageEvictionList(slotsPerContract int, pinned []common.Address) *StorageEvictionList {
+	l := &StorageEvictionList{
+		slotsPerContract: slotsPerContract,
+		pinned:           make(map[common.Address]struct{}, len(pinned)),
+	}
+	for _, addr := range pinned {
+		l.pinned[addr] = struct{}{}
+	}
+	l.init()
+	return l
+}
+
+func (l *StorageEvictionList) init() {
+	l.contracts = map[common.Address]*contractSlots{}
+	l.order = NewList()
+	l.pinnedOrder = NewList()
+	l.len, l.size = 0, 0
+}
+
+func (l *StorageEvictionList) Init() {
+	l.lock.Lock()
+	l.init()
+	l.lock.Unlock()
+}
+
+func (l *StorageEvictionList) touch(addr common.Address) *contractSlots {
+	cs, ok := l.contracts[addr]
+	if !ok {
+		_, pinned := l.pinned[addr]
+		cs = &contractSlots{slots: NewList(), order: &Element{K: common.Copy(addr[:])}, pinned: pinned}
+		l.contracts[addr] = cs
+		if pinned {
+			l.pinnedOrder.PushFront(cs.order)
+		} else {
+			l.order.PushFront(cs.order)
+		}
+		return cs
+	}
+	if cs.pinned {
+		l.pinnedOrder.MoveToFront(cs.order)
+	} else {
+		l.order.MoveToFront(cs.order)
+	}
+	return cs
+}
+
+func (l *StorageEvictionList) forget(addr common.Address, cs *contractSlots) {
+	if cs.pinned {
+		l.pinnedOrder.Remove(cs.order)
+	} else {
+		l.order.Remove(cs.order)
+	}
+	delete(l.contracts, addr)
+}
+
+// PushFront - adds slot to the front of its contract LRU and returns slots which must be evicted
+// because contract exceeded slotsPerContract limit
+func (l *StorageEvictionList) PushFront(e *Element) (evicted []*Element) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	addr := common.BytesToAddress(e.K[:length.Addr])
+	cs := l.touch(addr)
+	cs.slots.PushFront(e)
+	l.len++
+	l.size += e.Size()
+	if cs.pinned || l.slotsPerContract <= 0 {
+		return nil
+	}
+	for cs.slots.Len() > l.slotsPerContract {
+		oldest := cs.slots.Back()
+		cs.slots.Remove(oldest)
+		l.len--
+		l.size -= oldest.Size()
+		evicted = append(evicted, oldest)
+	}
+	return evicted
+}
+
+func (l *StorageEvictionList) MoveToFront(e *Element) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	cs, ok := l.contracts[common.BytesToAddress(e.K[:length.Addr])]
+	if !ok || e.list != cs.slots {
+		return
+	}
+	cs.slots.MoveToFront(e)
+	l.touch(common.BytesToAddress(e.K[:length.Addr]))
+}
+
+func (l *StorageEvictionList) Remove(e *Element) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.remove(e)
+}
+
+func (l *StorageEvictionList) remove(e *Element) {
+	addr := common.BytesToAddress(e.K[:length.Addr])
+	cs, ok := l.contracts[addr]
+	if !ok || e.list != cs.slots {
+		return
+	}
+	cs.slots.Remove(e)
+	l.len--
+	l.size -= e.Size()
+	if cs.slots.Len() == 0 {
+		l.forget(addr, cs)
+	}
+}
+
+// RemoveContract - forgets all slots of given contract (selfdestruct, re-creation) and returns them
+func (l *StorageEvictionList) RemoveContract(addr common.Address) (removed []*Element) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	cs, ok := l.contracts[addr]
+	if !ok {
+		return nil
+	}
+	for e := cs.slots.Front(); e != nil; e = cs.slots.Front() {
+		cs.slots.Remove(e)
+		l.len--
+		l.size -= e.Size()
+		removed = append(removed, e)
+	}
+	l.forget(addr, cs)
+	return removed
+}
+
+// Oldest - returns the oldest slot of least-recently-used not-pinned contract,
+// or the oldest slot of least-recently-used pinned contract if there are no others
+func (l *StorageEvictionList) Oldest() *Element {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	order := l.order.Back()
+	if order == nil {
+		order = l.pinnedOrder.Back()
+	}
+	if order == nil {
+		return nil
+	}
+	return l.contracts[common.BytesToAddress(order.K)].slots.Back()
+}
+
+// Len - amount of slots
+func (l *StorageEvictionList) Len() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.len
+}
+
+// Size - size of slots in bytes
+func (l *StorageEvictionList) Size() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.size
+}
+
+// Contracts - amount of contracts which have at least 1 slot in cache
+func (l *StorageEvictionList) Contracts() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return len(l.contracts)
+}
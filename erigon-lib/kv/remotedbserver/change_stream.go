@@ -0,0 +1,136 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package remotedbserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/temporal"
+)
+
+// ChangeStreamServer - domain-level change data capture for external consumers (indexers, analytics).
+// Replays account/storage/code changes from given txNum in txNum order, then follows the chain tip.
+// Unwind below already sent txNum closes stream with codes.Aborted.
+type ChangeStreamServer struct {
+	remote.UnimplementedChangeStreamServer // must be embedded to have forward compatible implementations.
+
+	kv       *KvServer
+	progress func(tx kv.Tx) (uint64, error)
+}
+
+// NewChangeStreamServer - progress returns first txNum which is not executed yet. New blocks are awaited by
+// subscription to state changes of kvServer.
+func NewChangeStreamServer(kvServer *KvServer, progress func(tx kv.Tx) (uint64, error)) *ChangeStreamServer {
+	return &ChangeStreamServer{kv: kvServer, progress: progress}
+}
+
+func (s *ChangeStreamServer) Changes(req *remote.ChangesReq, server grpc.ServerStreamingServer[remote.ChangesReply]) error {
+	db, ok := s.kv.kv.(kv.TemporalRoDB)
+	if !ok {
+		return errors.New("server DB doesn't implement kv.Temporal interface")
+	}
+
+	// subscribe before first read: notification about new block can't be lost
+	newBlocks, remove := s.kv.stateChangeStreams.Sub()
+	defer remove()
+	waitNew := func(ctx context.Context) error {
+		select {
+		case _, ok := <-newBlocks:
+			if !ok {
+				return errors.New("state changes subscription closed")
+			}
+			return nil
+		case <-s.kv.ctx.Done():
+			return s.kv.ctx.Err()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	changes := temporal.NewChangeStream(db, req.FromTxNum, s.progress, waitNew)
+	if req.ToTxNum >= 0 {
+		changes.SetEnd(uint64(req.ToTxNum))
+	}
+	if req.PageSize > 0 {
+		changes.SetWindow(req.PageSize)
+	}
+	for {
+		batch, err := changes.NextBatch(server.Context())
+		if err != nil {
+			switch {
+			case errors.Is(err, io.EOF):
+				return nil
+			case errors.Is(err, temporal.ErrChangesUnwound):
+				return status.Error(codes.Aborted, err.Error())
+			case errors.Is(err, context.Canceled), errors.Is(err, s.kv.ctx.Err()):
+				return nil
+			}
+			return err
+		}
+		reply := &remote.ChangesReply{Changes: make([]*remote.DomainChange, len(batch))}
+		for i, c := range batch {
+			reply.Changes[i] = &remote.DomainChange{TxNum: c.TxNum, Domain: c.Domain.String(), Key: c.Key, Value: c.Value}
+		}
+		if err := server.Send(reply); err != nil {
+			return err
+		}
+	}
+}
+
+// ChangesClient - reads ChangeStream service of remote Erigon
+type ChangesClient struct {
+	stream grpc.ServerStreamingClient[remote.ChangesReply]
+}
+
+// NewChangesClient - subscribes to changes of txNums [fromTxNum, toTxNum). toTxNum=-1 means follow chain tip.
+func NewChangesClient(ctx context.Context, client remote.ChangeStreamClient, fromTxNum uint64, toTxNum int64, opts ...grpc.CallOption) (*ChangesClient, error) {
+	stream, err := client.Changes(ctx, &remote.ChangesReq{FromTxNum: fromTxNum, ToTxNum: toTxNum}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ChangesClient{stream: stream}, nil
+}
+
+// NextBatch - blocks until next batch of changes. Returns io.EOF when toTxNum reached
+// and temporal.ErrChangesUnwound on unwind.
+func (c *ChangesClient) NextBatch() ([]temporal.Change, error) {
+	reply, err := c.stream.Recv()
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.Aborted {
+			return nil, fmt.Errorf("%w: %s", temporal.ErrChangesUnwound, st.Message())
+		}
+		return nil, err
+	}
+	batch := make([]temporal.Change, len(reply.Changes))
+	for i, c := range reply.Changes {
+		domain, err := kv.String2Domain(c.Domain)
+		if err != nil {
+			return nil, err
+		}
+		batch[i] = temporal.Change{TxNum: c.TxNum, Domain: domain, Key: c.Key, Value: c.Value}
+	}
+	return batch, nil
+}
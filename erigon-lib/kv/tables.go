@@ -460,12 +460,14 @@ const (
 	RecentLocalTransaction = "RecentLocalTransaction" // sequence_u64 -> tx_hash
 	PoolTransaction        = "PoolTransaction"        // txHash -> sender+tx_rlp
 	PoolInfo               = "PoolInfo"               // option_key -> option_value
+	PoolTxnStatus          = "PoolTxnStatus"          // txHash -> discard_reason+discard_time+discard_block+mined_block+replaced_by
 )
 
 var TxPoolTables = []string{
 	RecentLocalTransaction,
 	PoolTransaction,
 	PoolInfo,
+	PoolTxnStatus,
 }
 var SentryTables = []string{
 	Inodes,
@@ -0,0 +1,284 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package temporal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+)
+
+// ChangeDomains - domains which changes are emitted by ChangeStream, in order of emission inside one txNum
+var ChangeDomains = []kv.Domain{kv.AccountsDomain, kv.StorageDomain, kv.CodeDomain}
+
+// DefaultChangesWindow - amount of txNums which changes are collected and sorted in memory at once
+const DefaultChangesWindow = 10_000
+
+// unwindCheckDepth - amount of last blocks of chain which canonical hashes are checked to detect unwind
+// below already emitted txNum. Older blocks are final.
+const unwindCheckDepth = 128
+
+// ErrChangesUnwound - chain was unwound below already emitted txNum. Consumer must revert own state
+// to TxNum from error message (changes of txNums >= TxNum are not valid anymore) and re-subscribe from there.
+var ErrChangesUnwound = errors.New("changes stream: unwind below emitted txNum")
+
+// Change - one change of state domain made by transaction txNum
+type Change struct {
+	TxNum  uint64
+	Domain kv.Domain
+	Key    []byte
+	Value  []byte // value after change. empty means deletion
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("txNum=%d %s %x => %x", c.TxNum, c.Domain, c.Key, c.Value)
+}
+
+// ChangesRange - all changes of ChangeDomains made by transactions [fromTxNum, toTxNum), ordered by (txNum, domain, key).
+// Changes are read from history inverted indices of files and DB.
+func ChangesRange(tx kv.TemporalTx, fromTxNum, toTxNum uint64) ([]Change, error) {
+	for _, d := range ChangeDomains {
+		if start := tx.HistoryStartFrom(d); fromTxNum < start {
+			return nil, fmt.Errorf("changes of %s before txNum %d are pruned, requested from %d", d, start, fromTxNum)
+		}
+	}
+	var changes []Change
+	for _, d := range ChangeDomains {
+		keys, err := tx.HistoryRange(d, int(fromTxNum), int(toTxNum), order.Asc, kv.Unlim)
+		if err != nil {
+			return nil, err
+		}
+		for keys.HasNext() {
+			k, _, err := keys.Next()
+			if err != nil {
+				keys.Close()
+				return nil, err
+			}
+			k = common.Copy(k)
+			txNums, err := tx.IndexRange(domainHistoryIdx(d), k, int(fromTxNum), int(toTxNum), order.Asc, kv.Unlim)
+			if err != nil {
+				keys.Close()
+				return nil, err
+			}
+			for txNums.HasNext() {
+				txNum, err := txNums.Next()
+				if err != nil {
+					txNums.Close()
+					keys.Close()
+					return nil, err
+				}
+				changes = append(changes, Change{TxNum: txNum, Domain: d, Key: k})
+			}
+			txNums.Close()
+		}
+		keys.Close()
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].TxNum != changes[j].TxNum {
+			return changes[i].TxNum < changes[j].TxNum
+		}
+		if changes[i].Domain != changes[j].Domain {
+			return changes[i].Domain < changes[j].Domain
+		}
+		return bytes.Compare(changes[i].Key, changes[j].Key) < 0
+	})
+	for i := range changes {
+		c := &changes[i]
+		v, _, err := tx.GetAsOf(c.Domain, c.Key, c.TxNum+1)
+		if err != nil {
+			return nil, err
+		}
+		c.Value = common.Copy(v)
+	}
+	return changes, nil
+}
+
+func domainHistoryIdx(d kv.Domain) kv.InvertedIdx {
+	switch d {
+	case kv.AccountsDomain:
+		return kv.AccountsHistoryIdx
+	case kv.StorageDomain:
+		return kv.StorageHistoryIdx
+	case kv.CodeDomain:
+		return kv.CodeHistoryIdx
+	case kv.CommitmentDomain:
+		return kv.CommitmentHistoryIdx
+	case kv.ReceiptDomain:
+		return kv.ReceiptHistoryIdx
+	case kv.RCacheDomain:
+		return kv.RCacheHistoryIdx
+	default:
+		panic(fmt.Sprintf("unexpected domain: %s", d))
+	}
+}
+
+// ChangeStream - replays changes of state from given txNum in txNum order and then follows the chain tip ("live" mode).
+//
+//	s := NewChangeStream(db, fromTxNum, executedTxNum, waitForNewBlock)
+//	for {
+//		c, err := s.Next(ctx)
+//		if err != nil {
+//			return err
+//		}
+//	}
+type ChangeStream struct {
+	db       kv.TemporalRoDB
+	next     uint64 // first txNum which changes are not read yet
+	end      uint64 // stream finishes by io.EOF when reached it
+	window   uint64
+	progress func(tx kv.Tx) (uint64, error)
+	waitNew  func(ctx context.Context) error
+
+	buf     []Change
+	emitted []emittedBlock // recent canonical blocks which changes are (partially) emitted, ascending
+}
+
+type emittedBlock struct {
+	num        uint64
+	hash       common.Hash
+	firstTxNum uint64
+}
+
+// NewChangeStream - progress returns first txNum which is not available yet (first txNum after executed block).
+// waitNew blocks until new data is committed (new block, unwind) or ctx is canceled.
+func NewChangeStream(db kv.TemporalRoDB, fromTxNum uint64, progress func(tx kv.Tx) (uint64, error), waitNew func(ctx context.Context) error) *ChangeStream {
+	return &ChangeStream{db: db, next: fromTxNum, end: math.MaxUint64, window: DefaultChangesWindow, progress: progress, waitNew: waitNew}
+}
+
+// SetEnd - stream returns io.EOF after all changes of txNums < toTxNum emitted (instead of following chain tip)
+func (s *ChangeStream) SetEnd(toTxNum uint64) { s.end = toTxNum }
+
+// SetWindow - amount of txNums which changes are read in one read transaction
+func (s *ChangeStream) SetWindow(window uint64) { s.window = max(window, 1) }
+
+// NextTxNum - first txNum which changes are not emitted yet (except already buffered ones)
+func (s *ChangeStream) NextTxNum() uint64 { return s.next }
+
+// Next - returns next change. Blocks in live mode until new changes are available or ctx is done.
+func (s *ChangeStream) Next(ctx context.Context) (Change, error) {
+	for len(s.buf) == 0 {
+		if err := s.fill(ctx); err != nil {
+			return Change{}, err
+		}
+	}
+	c := s.buf[0]
+	s.buf = s.buf[1:]
+	return c, nil
+}
+
+// NextBatch - returns all buffered changes (at least one). Changes of one txNum may be split between batches.
+func (s *ChangeStream) NextBatch(ctx context.Context) ([]Change, error) {
+	for len(s.buf) == 0 {
+		if err := s.fill(ctx); err != nil {
+			return nil, err
+		}
+	}
+	batch := s.buf
+	s.buf = nil
+	return batch, nil
+}
+
+// fill - reads next window of changes. If there is nothing new - waits for new data.
+func (s *ChangeStream) fill(ctx context.Context) error {
+	if s.next >= s.end {
+		return io.EOF
+	}
+	var caughtUp bool
+	if err := s.db.ViewTemporal(ctx, func(tx kv.TemporalTx) error {
+		if err := s.checkCanonical(tx); err != nil {
+			return err
+		}
+		to, err := s.progress(tx)
+		if err != nil {
+			return err
+		}
+		if to < s.next {
+			return fmt.Errorf("%w: TxNum=%d, emitted up to %d", ErrChangesUnwound, to, s.next)
+		}
+		if to == s.next {
+			caughtUp = true
+			return nil
+		}
+		to = min(to, s.next+s.window, s.end)
+		if s.buf, err = ChangesRange(tx, s.next, to); err != nil {
+			return err
+		}
+		s.next = to
+		return s.trackEmitted(tx)
+	}); err != nil {
+		return err
+	}
+	if caughtUp {
+		return s.waitNew(ctx)
+	}
+	return nil
+}
+
+// checkCanonical - detects unwind by canonical hashes of emitted blocks: unwind with re-execution of other fork
+// may happen between reads, then progress alone is not enough to notice it.
+func (s *ChangeStream) checkCanonical(tx kv.Tx) error {
+	for _, b := range s.emitted {
+		hash, err := tx.GetOne(kv.HeaderCanonical, hexutil.EncodeTs(b.num))
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(hash, b.hash[:]) {
+			return fmt.Errorf("%w: TxNum=%d, block %d is not canonical anymore, emitted up to %d", ErrChangesUnwound, b.firstTxNum, b.num, s.next)
+		}
+	}
+	return nil
+}
+
+// trackEmitted - remembers canonical hashes of last blocks of chain which changes are emitted
+func (s *ChangeStream) trackEmitted(tx kv.Tx) error {
+	s.emitted = s.emitted[:0]
+	lastBlock, _, err := rawdbv3.TxNums.Last(tx)
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < unwindCheckDepth && i <= lastBlock; i++ {
+		num := lastBlock - i
+		firstTxNum, err := rawdbv3.TxNums.Min(tx, num)
+		if err != nil {
+			return err
+		}
+		if firstTxNum >= s.next { // not emitted yet
+			continue
+		}
+		hash, err := tx.GetOne(kv.HeaderCanonical, hexutil.EncodeTs(num))
+		if err != nil {
+			return err
+		}
+		if len(hash) == 0 { // no headers: nothing to check
+			break
+		}
+		s.emitted = append(s.emitted, emittedBlock{num: num, hash: common.BytesToHash(hash), firstTxNum: firstTxNum})
+	}
+	slices.Reverse(s.emitted)
+	return nil
+}
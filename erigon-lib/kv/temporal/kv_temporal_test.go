@@ -3,6 +3,8 @@ package temporal
 import (
 	"context"
 	"encoding/binary"
+	"errors"
+	"io"
 	"testing"
 	"time"
 
@@ -10,11 +12,14 @@ import (
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/memdb"
 	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon-lib/types/accounts"
 )
 
 func TestTemporalTx_HasPrefix_StorageDomain(t *testing.T) {
@@ -352,3 +357,114 @@ func TestTemporalTx_RangeAsOf_StorageDomain(t *testing.T) {
 	require.Equal(t, []byte{3}, v)
 	require.False(t, it5.HasNext())
 }
+
+func TestChangeStream(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	logger := log.New()
+	logger.SetHandler(log.LvlFilterHandler(log.LvlCrit, log.StderrHandler))
+
+	mdbxDb := memdb.NewTestDB(t, kv.ChainDB)
+	dirs := datadir.New(t.TempDir())
+	_, err := state.GetStateIndicesSalt(dirs, true /* genNew */, logger) // gen salt needed by aggregator
+	require.NoError(t, err)
+	agg, err := state.NewAggregator(ctx, dirs, 1, mdbxDb, logger)
+	require.NoError(t, err)
+	t.Cleanup(agg.Close)
+	temporalDb, err := New(mdbxDb, agg)
+	require.NoError(t, err)
+	t.Cleanup(temporalDb.Close)
+
+	acc1 := common.HexToAddress("0x1234567890123456789012345678901234567890").Bytes()
+	storageK1 := append(common.Copy(acc1), common.HexToHash("0x01").Bytes()...)
+	storageK2 := append(common.Copy(acc1), common.HexToHash("0x02").Bytes()...)
+	encAcc1 := accounts.SerialiseV3(&accounts.Account{Nonce: 1, Incarnation: 1})
+
+	rwTx, err := temporalDb.BeginTemporalRw(ctx)
+	require.NoError(t, err)
+	t.Cleanup(rwTx.Rollback)
+	sd, err := state.NewSharedDomains(rwTx, logger)
+	require.NoError(t, err)
+	t.Cleanup(sd.Close)
+	require.NoError(t, sd.DomainPut(kv.StorageDomain, rwTx, storageK2, []byte{2}, 1, nil, 0))
+	require.NoError(t, sd.DomainPut(kv.StorageDomain, rwTx, storageK1, []byte{1}, 1, nil, 0))
+	require.NoError(t, sd.DomainPut(kv.AccountsDomain, rwTx, acc1, encAcc1, 1, nil, 0))
+	require.NoError(t, sd.DomainPut(kv.CodeDomain, rwTx, acc1, []byte{0x60}, 2, nil, 0))
+	require.NoError(t, sd.DomainDel(kv.StorageDomain, rwTx, storageK1, 3, nil, 0))
+	require.NoError(t, sd.DomainPut(kv.StorageDomain, rwTx, storageK1, []byte{3}, 4, nil, 0))
+	require.NoError(t, sd.Flush(ctx, rwTx))
+	require.NoError(t, rwTx.Commit())
+
+	expect := []Change{
+		{TxNum: 1, Domain: kv.AccountsDomain, Key: acc1, Value: encAcc1},
+		{TxNum: 1, Domain: kv.StorageDomain, Key: storageK1, Value: []byte{1}},
+		{TxNum: 1, Domain: kv.StorageDomain, Key: storageK2, Value: []byte{2}},
+		{TxNum: 2, Domain: kv.CodeDomain, Key: acc1, Value: []byte{0x60}},
+		{TxNum: 3, Domain: kv.StorageDomain, Key: storageK1, Value: nil},
+		{TxNum: 4, Domain: kv.StorageDomain, Key: storageK1, Value: []byte{3}},
+	}
+
+	// replay: window smaller than range
+	progress := uint64(5)
+	s := NewChangeStream(temporalDb, 0, func(kv.Tx) (uint64, error) { return progress, nil }, func(context.Context) error {
+		t.Fatal("replay must not wait")
+		return nil
+	})
+	s.SetWindow(2)
+	s.SetEnd(5)
+	var got []Change
+	for {
+		c, err := s.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		got = append(got, c)
+	}
+	require.Equal(t, expect, got)
+
+	// live: waits for progress, then reports unwind
+	waits := 0
+	s = NewChangeStream(temporalDb, 3, func(kv.Tx) (uint64, error) { return progress, nil }, func(context.Context) error {
+		waits++
+		progress = 2 // unwind
+		return nil
+	})
+	progress = 3
+	_, err = s.Next(ctx)
+	require.ErrorIs(t, err, ErrChangesUnwound)
+	require.Equal(t, 1, waits)
+
+	// live: unwind of block 1 and execution of other fork between reads is detected by canonical hash
+	setCanonical := func(blockNum uint64, hash common.Hash) {
+		require.NoError(t, temporalDb.Update(ctx, func(tx kv.RwTx) error {
+			return tx.Put(kv.HeaderCanonical, hexutil.EncodeTs(blockNum), hash[:])
+		}))
+	}
+	require.NoError(t, temporalDb.Update(ctx, func(tx kv.RwTx) error {
+		for blockNum, maxTxNum := range []uint64{1, 3, 5} {
+			if err := rawdbv3.TxNums.Append(tx, uint64(blockNum), maxTxNum); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	for blockNum := uint64(0); blockNum < 3; blockNum++ {
+		setCanonical(blockNum, common.Hash{byte(blockNum)})
+	}
+	progress = 5
+	s = NewChangeStream(temporalDb, 0, func(kv.Tx) (uint64, error) { return progress, nil }, func(context.Context) error {
+		setCanonical(1, common.Hash{0xf1})
+		progress = 6
+		return nil
+	})
+	batch, err := s.NextBatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expect, batch)
+	_, err = s.Next(ctx)
+	require.ErrorIs(t, err, ErrChangesUnwound)
+	require.Contains(t, err.Error(), "TxNum=2")
+}
@@ -34,6 +34,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/assert"
+	"github.com/erigontech/erigon-lib/common/dbg"
 	"github.com/erigontech/erigon-lib/etl"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/recsplit/eliasfano16"
@@ -83,12 +84,14 @@ type RecSplit struct {
 	bucketPosAcc      []uint64   // Accumulator for position of every bucket in the encoding of the hash function
 	startSeed         []uint64
 	count             []uint16
-	currentBucket     []uint64 // 64-bit fingerprints of keys in the current bucket accumulated before the recsplit is performed for that bucket
-	currentBucketOffs []uint64 // Index offsets for the current bucket
-	offsetBuffer      []uint64
-	buffer            []uint64
-	golombRice        []uint32
-	bucketSizeAcc     []uint64 // Bucket size accumulator
+	currentBucket     []uint64        // 64-bit fingerprints of keys in the current bucket accumulated before the recsplit is performed for that bucket
+	currentBucketOffs []uint64        // Index offsets for the current bucket
+	bucketSizeAcc     []uint64        // Bucket size accumulator
+	splitter          *bucketSplitter // Used by single-threaded build. Its golombRice table is written to the index
+	bucketBuf         bucket
+	dispatchBucket    func() error // Sends current bucket to workers, set only during parallel build
+	workers           int
+	workersMemLimit   datasize.ByteSize
 	// Helper object to encode the sequence of cumulative number of keys in the buckets
 	// and the sequence of cumulative bit offsets of buckets in the Golomb-Rice code.
 	ef                 eliasfano16.DoubleEliasFano
@@ -138,8 +141,15 @@ type RecSplitArgs struct {
 	LeafSize    uint16
 
 	NoFsync bool // fsync is enabled by default, but tests can manually disable
+
+	// Buckets are independent after ETL sort - so they can be split in parallel. Produced index is byte-identical to single-threaded build.
+	Workers         int               // 0 - use RECSPLIT_WORKERS env variable (default GOMAXPROCS)
+	WorkersMemLimit datasize.ByteSize // Limit of RAM used by buckets which are not written to index yet. 0 - DefaultWorkersMemLimit
 }
 
+// DefaultWorkersMemLimit - buckets are small (100-2000 keys), so it's enough to keep all workers busy
+const DefaultWorkersMemLimit = 256 * datasize.MB
+
 // DefaultLeafSize - LeafSize=8 and BucketSize=100, use abount 1.8 bits per key. Increasing the leaf and bucket
 // sizes gives more compact structures (1.56 bits per key), at the	price of a slower construction time
 const DefaultLeafSize = 8
@@ -225,7 +235,15 @@ func NewRecSplit(args RecSplitArgs, logger log.Logger) (*RecSplit, error) {
 		rs.secondaryAggrBound = rs.primaryAggrBound * uint16(math.Ceil(0.21*float64(rs.leafSize)+9./10.))
 	}
 	rs.startSeed = args.StartSeed
-	rs.count = make([]uint16, rs.secondaryAggrBound)
+	rs.splitter = rs.newSplitter()
+	rs.workers = args.Workers
+	if rs.workers <= 0 {
+		rs.workers = dbg.RecsplitWorkers
+	}
+	rs.workersMemLimit = args.WorkersMemLimit
+	if rs.workersMemLimit == 0 {
+		rs.workersMemLimit = DefaultWorkersMemLimit
+	}
 	if args.NoFsync {
 		rs.DisableFsync()
 	}
@@ -258,6 +276,7 @@ func (rs *RecSplit) LogLvl(lvl log.Lvl) { rs.lvl = lvl }
 
 func (rs *RecSplit) SetTrace(trace bool) {
 	rs.trace = trace
+	rs.splitter.trace = trace
 }
 
 // remap converts the number x which is assumed to be uniformly distributed over the range [0..2^64) to the number that is uniformly
@@ -348,24 +367,6 @@ func computeGolombRice(m uint16, table []uint32, leafSize, primaryAggrBound, sec
 	table[m] |= nodes << 16
 }
 
-// golombParam returns the optimal Golomb parameter to use for encoding
-// salt for the part of the hash function separating m elements. It is based on
-// calculations with assumptions that we draw hash functions at random
-func (rs *RecSplit) golombParam(m uint16) int {
-	for s := uint16(len(rs.golombRice)); m >= s; s++ {
-		rs.golombRice = append(rs.golombRice, 0)
-		// For the case where bucket is larger than planned
-		if s == 0 {
-			rs.golombRice[0] = (bijMemo[0] << 27) | bijMemo[0]
-		} else if s <= rs.leafSize {
-			rs.golombRice[s] = (bijMemo[s] << 27) | (uint32(1) << 16) | bijMemo[s]
-		} else {
-			computeGolombRice(s, rs.golombRice, rs.leafSize, rs.primaryAggrBound, rs.secondaryAggrBound)
-		}
-	}
-	return int(rs.golombRice[m] >> 27)
-}
-
 // Add key to the RecSplit. There can be many more keys than what fits in RAM, and RecSplit
 // spills data onto disk to accomodate that. The key gets copied by the collector, therefore
 // the slice underlying key is not getting accessed by RecSplit after this invocation.
@@ -422,157 +423,56 @@ func (rs *RecSplit) AddOffset(offset uint64) error {
 }
 
 func (rs *RecSplit) recsplitCurrentBucket() error {
-	// Extend rs.bucketSizeAcc to accomodate current bucket index + 1
-	for len(rs.bucketSizeAcc) <= int(rs.currentBucketIdx)+1 {
-		rs.bucketSizeAcc = append(rs.bucketSizeAcc, rs.bucketSizeAcc[len(rs.bucketSizeAcc)-1])
+	if rs.dispatchBucket != nil {
+		return rs.dispatchBucket()
 	}
-	rs.bucketSizeAcc[int(rs.currentBucketIdx)+1] += uint64(len(rs.currentBucket))
-	// Sets of size 0 and 1 are not further processed, just write them to index
-	if len(rs.currentBucket) > 1 {
-		for i, key := range rs.currentBucket[1:] {
-			if key == rs.currentBucket[i] {
-				rs.collision = true
-				return fmt.Errorf("%w: %x", ErrCollision, key)
-			}
-		}
-		bitPos := rs.gr.bitCount
-		if rs.buffer == nil {
-			rs.buffer = make([]uint64, len(rs.currentBucket))
-			rs.offsetBuffer = make([]uint64, len(rs.currentBucketOffs))
-		} else {
-			for len(rs.buffer) < len(rs.currentBucket) {
-				rs.buffer = append(rs.buffer, 0)
-				rs.offsetBuffer = append(rs.offsetBuffer, 0)
-			}
-		}
-		unary, err := rs.recsplit(0 /* level */, rs.currentBucket, rs.currentBucketOffs, nil /* unary */)
-		if err != nil {
-			return err
-		}
-		rs.gr.appendUnaryAll(unary)
-		if rs.trace {
-			fmt.Printf("recsplitBucket(%d, %d, bitsize = %d)\n", rs.currentBucketIdx, len(rs.currentBucket), rs.gr.bitCount-bitPos)
-		}
-	} else {
-		for _, offset := range rs.currentBucketOffs {
-			binary.BigEndian.PutUint64(rs.numBuf[:], offset)
-			if _, err := rs.indexW.Write(rs.numBuf[8-rs.bytesPerRec:]); err != nil {
-				return err
-			}
-		}
+	b := &rs.bucketBuf
+	b.reset(rs.currentBucketIdx)
+	b.keys, b.offsets = rs.currentBucket, rs.currentBucketOffs
+	if err := rs.splitter.split(b); err != nil {
+		return err
 	}
-	// Extend rs.bucketPosAcc to accomodate current bucket index + 1
-	for len(rs.bucketPosAcc) <= int(rs.currentBucketIdx)+1 {
-		rs.bucketPosAcc = append(rs.bucketPosAcc, rs.bucketPosAcc[len(rs.bucketPosAcc)-1])
+	if err := rs.writeBucket(b); err != nil {
+		return err
 	}
-	rs.bucketPosAcc[int(rs.currentBucketIdx)+1] = uint64(rs.gr.Bits())
 	// clear for the next buckey
 	rs.currentBucket = rs.currentBucket[:0]
 	rs.currentBucketOffs = rs.currentBucketOffs[:0]
+	b.keys, b.offsets = nil, nil
 	return nil
 }
 
-// recsplit applies recSplit algorithm to the given bucket
-func (rs *RecSplit) recsplit(level int, bucket []uint64, offsets []uint64, unary []uint64) ([]uint64, error) {
-	if rs.trace {
-		fmt.Printf("recsplit(%d, %d, %x)\n", level, len(bucket), bucket)
-	}
-	// Pick initial salt for this level of recursive split
-	salt := rs.startSeed[level]
-	m := uint16(len(bucket))
-	if m <= rs.leafSize {
-		// No need to build aggregation levels - just find bijection
-		var mask uint32
-		for {
-			mask = 0
-			var fail bool
-			for i := uint16(0); !fail && i < m; i++ {
-				bit := uint32(1) << remap16(remix(bucket[i]+salt), m)
-				if mask&bit != 0 {
-					fail = true
-				} else {
-					mask |= bit
-				}
-			}
-			if !fail {
-				break
-			}
-			salt++
-		}
-		for i := uint16(0); i < m; i++ {
-			j := remap16(remix(bucket[i]+salt), m)
-			rs.offsetBuffer[j] = offsets[i]
-		}
-		for _, offset := range rs.offsetBuffer[:m] {
-			binary.BigEndian.PutUint64(rs.numBuf[:], offset)
-			if _, err := rs.indexW.Write(rs.numBuf[8-rs.bytesPerRec:]); err != nil {
-				return nil, err
-			}
-		}
-		salt -= rs.startSeed[level]
-		log2golomb := rs.golombParam(m)
-		if rs.trace {
-			fmt.Printf("encode bij %d with log2golomn %d at p = %d\n", salt, log2golomb, rs.gr.bitCount)
-		}
-		rs.gr.appendFixed(salt, log2golomb)
-		unary = append(unary, salt>>log2golomb)
-	} else {
-		fanout, unit := splitParams(m, rs.leafSize, rs.primaryAggrBound, rs.secondaryAggrBound)
-		count := rs.count
-		for {
-			for i := uint16(0); i < fanout-1; i++ {
-				count[i] = 0
-			}
-			var fail bool
-			for i := uint16(0); i < m; i++ {
-				count[remap16(remix(bucket[i]+salt), m)/unit]++
-			}
-			for i := uint16(0); i < fanout-1; i++ {
-				fail = fail || (count[i] != unit)
-			}
-			if !fail {
-				break
-			}
-			salt++
-		}
-		for i, c := uint16(0), uint16(0); i < fanout; i++ {
-			count[i] = c
-			c += unit
-		}
-		for i := uint16(0); i < m; i++ {
-			j := remap16(remix(bucket[i]+salt), m) / unit
-			rs.buffer[count[j]] = bucket[i]
-			rs.offsetBuffer[count[j]] = offsets[i]
-			count[j]++
+// writeBucket appends encoding of bucket to the index, buckets must be written in order
+func (rs *RecSplit) writeBucket(b *bucket) error {
+	// Extend rs.bucketSizeAcc to accomodate current bucket index + 1
+	for len(rs.bucketSizeAcc) <= int(b.idx)+1 {
+		rs.bucketSizeAcc = append(rs.bucketSizeAcc, rs.bucketSizeAcc[len(rs.bucketSizeAcc)-1])
+	}
+	rs.bucketSizeAcc[int(b.idx)+1] += uint64(len(b.keys))
+	if len(b.keys) > 1 {
+		// workers have own golomb-rice tables, grow the one written to index same way as single-threaded build does
+		rs.splitter.golombParam(uint16(len(b.keys)))
+		bitPos := rs.gr.bitCount
+		for _, c := range b.fixed {
+			rs.gr.appendFixed(c.v, c.log2golomb)
 		}
-		copy(bucket, rs.buffer)
-		copy(offsets, rs.offsetBuffer)
-		salt -= rs.startSeed[level]
-		log2golomb := rs.golombParam(m)
+		rs.gr.appendUnaryAll(b.unary)
 		if rs.trace {
-			fmt.Printf("encode fanout %d: %d with log2golomn %d at p = %d\n", fanout, salt, log2golomb, rs.gr.bitCount)
-		}
-		rs.gr.appendFixed(salt, log2golomb)
-		unary = append(unary, salt>>log2golomb)
-		var err error
-		var i uint16
-		for i = 0; i < m-unit; i += unit {
-			if unary, err = rs.recsplit(level+1, bucket[i:i+unit], offsets[i:i+unit], unary); err != nil {
-				return nil, err
-			}
+			fmt.Printf("writeBucket(%d, %d, bitsize = %d)\n", b.idx, len(b.keys), rs.gr.bitCount-bitPos)
 		}
-		if m-i > 1 {
-			if unary, err = rs.recsplit(level+1, bucket[i:], offsets[i:], unary); err != nil {
-				return nil, err
-			}
-		} else if m-i == 1 {
-			binary.BigEndian.PutUint64(rs.numBuf[:], offsets[i])
-			if _, err := rs.indexW.Write(rs.numBuf[8-rs.bytesPerRec:]); err != nil {
-				return nil, err
-			}
+	}
+	for _, offset := range b.idxOrder {
+		binary.BigEndian.PutUint64(rs.numBuf[:], offset)
+		if _, err := rs.indexW.Write(rs.numBuf[8-rs.bytesPerRec:]); err != nil {
+			return err
 		}
 	}
-	return unary, nil
+	// Extend rs.bucketPosAcc to accomodate current bucket index + 1
+	for len(rs.bucketPosAcc) <= int(b.idx)+1 {
+		rs.bucketPosAcc = append(rs.bucketPosAcc, rs.bucketPosAcc[len(rs.bucketPosAcc)-1])
+	}
+	rs.bucketPosAcc[int(b.idx)+1] = uint64(rs.gr.Bits())
+	return nil
 }
 
 // loadFuncBucket is required to satisfy the type etl.LoadFunc type, to use with collector.Load
@@ -640,13 +540,27 @@ func (rs *RecSplit) Build(ctx context.Context) error {
 	if rs.lvl < log.LvlTrace {
 		log.Log(rs.lvl, "[index] calculating", "file", rs.indexFileName)
 	}
-	if err := rs.bucketCollector.Load(nil, "", rs.loadFuncBucket, etl.TransformArgs{Quit: ctx.Done()}); err != nil {
-		return err
-	}
-	if len(rs.currentBucket) > 0 {
-		if err := rs.recsplitCurrentBucket(); err != nil {
+	loadBuckets := func(ctx context.Context) error {
+		if err := rs.bucketCollector.Load(nil, "", rs.loadFuncBucket, etl.TransformArgs{Quit: ctx.Done()}); err != nil {
 			return err
 		}
+		if len(rs.currentBucket) > 0 {
+			if err := rs.recsplitCurrentBucket(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if rs.workers > 1 {
+		err = rs.buildBucketsParallel(ctx, loadBuckets)
+	} else {
+		err = loadBuckets(ctx)
+	}
+	if err != nil {
+		if errors.Is(err, ErrCollision) {
+			rs.collision = true
+		}
+		return err
 	}
 
 	if assert.Enable {
@@ -722,7 +636,7 @@ func (rs *RecSplit) Build(ctx context.Context) error {
 		return err
 	}
 	// Write out the size of golomb rice params
-	binary.BigEndian.PutUint16(rs.numBuf[:], uint16(len(rs.golombRice)))
+	binary.BigEndian.PutUint16(rs.numBuf[:], uint16(len(rs.splitter.golombRice)))
 	if _, err := rs.indexW.Write(rs.numBuf[:4]); err != nil {
 		return fmt.Errorf("writing golomb rice param size: %w", err)
 	}
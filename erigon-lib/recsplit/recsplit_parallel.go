@@ -0,0 +1,294 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package recsplit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+)
+
+// fixedCode - fixed (truncated binary) part of Golomb-Rice code of one node of the splitting tree
+type fixedCode struct {
+	v          uint64
+	log2golomb int
+}
+
+// bucket - keys of one bucket and its encoding. Buckets are independent after ETL sort, so they
+// can be split by any worker, but encodings must be appended to the index strictly in bucket order.
+type bucket struct {
+	seq     uint64 // order of bucket in the ETL stream
+	idx     uint64
+	keys    []uint64
+	offsets []uint64
+
+	fixed     []fixedCode
+	unary     []uint64
+	idxOrder  []uint64 // offsets in the order they must be written to the index
+	splitErr  error
+	memWeight int64
+}
+
+func (b *bucket) reset(idx uint64) {
+	b.idx = idx
+	b.keys, b.offsets = b.keys[:0], b.offsets[:0]
+	b.fixed, b.unary, b.idxOrder = b.fixed[:0], b.unary[:0], b.idxOrder[:0]
+	b.splitErr = nil
+}
+
+// bucketSplitter - state of recursive split which can't be shared between goroutines
+type bucketSplitter struct {
+	buffer       []uint64
+	offsetBuffer []uint64
+	count        []uint16
+	golombRice   []uint32
+
+	startSeed                                      []uint64
+	leafSize, primaryAggrBound, secondaryAggrBound uint16
+	trace                                          bool
+}
+
+func (rs *RecSplit) newSplitter() *bucketSplitter {
+	return &bucketSplitter{
+		count:              make([]uint16, rs.secondaryAggrBound),
+		startSeed:          rs.startSeed,
+		leafSize:           rs.leafSize,
+		primaryAggrBound:   rs.primaryAggrBound,
+		secondaryAggrBound: rs.secondaryAggrBound,
+		trace:              rs.trace,
+	}
+}
+
+// buildBucketsParallel - loads buckets from ETL and splits them by rs.workers goroutines.
+// Amount of not-yet-written buckets is bounded by rs.workersMemLimit.
+// Result is byte-identical to single-threaded build.
+func (rs *RecSplit) buildBucketsParallel(ctx context.Context, load func(ctx context.Context) error) error {
+	g, gctx := errgroup.WithContext(ctx)
+	memLimit := int64(rs.workersMemLimit.Bytes())
+	mem := semaphore.NewWeighted(memLimit)
+	jobs := make(chan *bucket, rs.workers)
+	results := make(chan *bucket, rs.workers)
+	free := sync.Pool{New: func() any { return &bucket{} }}
+
+	var workersWg sync.WaitGroup
+	for i := 0; i < rs.workers; i++ {
+		bs := rs.newSplitter()
+		workersWg.Add(1)
+		g.Go(func() error {
+			defer workersWg.Done()
+			for b := range jobs {
+				b.splitErr = bs.split(b)
+				select {
+				case results <- b:
+				case <-gctx.Done():
+					return gctx.Err()
+				}
+			}
+			return nil
+		})
+	}
+	g.Go(func() error {
+		workersWg.Wait()
+		close(results)
+		return nil
+	})
+	g.Go(func() error {
+		pending := map[uint64]*bucket{}
+		var next uint64
+		for b := range results {
+			pending[b.seq] = b
+			for b, ok := pending[next]; ok; b, ok = pending[next] {
+				delete(pending, next)
+				if b.splitErr != nil {
+					return b.splitErr
+				}
+				if err := rs.writeBucket(b); err != nil {
+					return err
+				}
+				mem.Release(b.memWeight)
+				free.Put(b)
+				next++
+			}
+		}
+		return nil
+	})
+
+	var seq uint64
+	rs.dispatchBucket = func() error {
+		b := free.Get().(*bucket)
+		b.reset(rs.currentBucketIdx)
+		b.seq = seq
+		b.keys = append(b.keys, rs.currentBucket...)
+		b.offsets = append(b.offsets, rs.currentBucketOffs...)
+		// keys, offsets, idxOrder and splitter buffers
+		b.memWeight = min(int64(len(b.keys))*8*4, memLimit)
+		if err := mem.Acquire(gctx, b.memWeight); err != nil {
+			return err
+		}
+		select {
+		case jobs <- b:
+		case <-gctx.Done():
+			mem.Release(b.memWeight)
+			return gctx.Err()
+		}
+		seq++
+		rs.currentBucket = rs.currentBucket[:0]
+		rs.currentBucketOffs = rs.currentBucketOffs[:0]
+		return nil
+	}
+	defer func() { rs.dispatchBucket = nil }()
+
+	loadErr := load(gctx)
+	close(jobs)
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	return loadErr
+}
+
+// split applies recSplit algorithm to the given bucket
+func (bs *bucketSplitter) split(b *bucket) error {
+	// Sets of size 0 and 1 are not further processed, just write them to index
+	if len(b.keys) <= 1 {
+		b.idxOrder = append(b.idxOrder, b.offsets...)
+		return nil
+	}
+	for i, key := range b.keys[1:] {
+		if key == b.keys[i] {
+			return fmt.Errorf("%w: %x", ErrCollision, key)
+		}
+	}
+	for len(bs.buffer) < len(b.keys) {
+		bs.buffer = append(bs.buffer, 0)
+		bs.offsetBuffer = append(bs.offsetBuffer, 0)
+	}
+	bs.recsplit(0 /* level */, b.keys, b.offsets, b)
+	if bs.trace {
+		fmt.Printf("recsplitBucket(%d, %d, fixed codes = %d)\n", b.idx, len(b.keys), len(b.fixed))
+	}
+	return nil
+}
+
+// recsplit applies recSplit algorithm to the given part of bucket
+func (bs *bucketSplitter) recsplit(level int, keys []uint64, offsets []uint64, b *bucket) {
+	if bs.trace {
+		fmt.Printf("recsplit(%d, %d, %x)\n", level, len(keys), keys)
+	}
+	// Pick initial salt for this level of recursive split
+	salt := bs.startSeed[level]
+	m := uint16(len(keys))
+	if m <= bs.leafSize {
+		// No need to build aggregation levels - just find bijection
+		var mask uint32
+		for {
+			mask = 0
+			var fail bool
+			for i := uint16(0); !fail && i < m; i++ {
+				bit := uint32(1) << remap16(remix(keys[i]+salt), m)
+				if mask&bit != 0 {
+					fail = true
+				} else {
+					mask |= bit
+				}
+			}
+			if !fail {
+				break
+			}
+			salt++
+		}
+		for i := uint16(0); i < m; i++ {
+			j := remap16(remix(keys[i]+salt), m)
+			bs.offsetBuffer[j] = offsets[i]
+		}
+		b.idxOrder = append(b.idxOrder, bs.offsetBuffer[:m]...)
+		salt -= bs.startSeed[level]
+		log2golomb := bs.golombParam(m)
+		if bs.trace {
+			fmt.Printf("encode bij %d with log2golomn %d\n", salt, log2golomb)
+		}
+		b.fixed = append(b.fixed, fixedCode{v: salt, log2golomb: log2golomb})
+		b.unary = append(b.unary, salt>>log2golomb)
+	} else {
+		fanout, unit := splitParams(m, bs.leafSize, bs.primaryAggrBound, bs.secondaryAggrBound)
+		count := bs.count
+		for {
+			for i := uint16(0); i < fanout-1; i++ {
+				count[i] = 0
+			}
+			var fail bool
+			for i := uint16(0); i < m; i++ {
+				count[remap16(remix(keys[i]+salt), m)/unit]++
+			}
+			for i := uint16(0); i < fanout-1; i++ {
+				fail = fail || (count[i] != unit)
+			}
+			if !fail {
+				break
+			}
+			salt++
+		}
+		for i, c := uint16(0), uint16(0); i < fanout; i++ {
+			count[i] = c
+			c += unit
+		}
+		for i := uint16(0); i < m; i++ {
+			j := remap16(remix(keys[i]+salt), m) / unit
+			bs.buffer[count[j]] = keys[i]
+			bs.offsetBuffer[count[j]] = offsets[i]
+			count[j]++
+		}
+		copy(keys, bs.buffer)
+		copy(offsets, bs.offsetBuffer)
+		salt -= bs.startSeed[level]
+		log2golomb := bs.golombParam(m)
+		if bs.trace {
+			fmt.Printf("encode fanout %d: %d with log2golomn %d\n", fanout, salt, log2golomb)
+		}
+		b.fixed = append(b.fixed, fixedCode{v: salt, log2golomb: log2golomb})
+		b.unary = append(b.unary, salt>>log2golomb)
+		var i uint16
+		for i = 0; i < m-unit; i += unit {
+			bs.recsplit(level+1, keys[i:i+unit], offsets[i:i+unit], b)
+		}
+		if m-i > 1 {
+			bs.recsplit(level+1, keys[i:], offsets[i:], b)
+		} else if m-i == 1 {
+			b.idxOrder = append(b.idxOrder, offsets[i])
+		}
+	}
+}
+
+// golombParam returns the optimal Golomb parameter to use for encoding
+// salt for the part of the hash function separating m elements. It is based on
+// calculations with assumptions that we draw hash functions at random
+func (bs *bucketSplitter) golombParam(m uint16) int {
+	for s := uint16(len(bs.golombRice)); m >= s; s++ {
+		bs.golombRice = append(bs.golombRice, 0)
+		// For the case where bucket is larger than planned
+		if s == 0 {
+			bs.golombRice[0] = (bijMemo[0] << 27) | bijMemo[0]
+		} else if s <= bs.leafSize {
+			bs.golombRice[s] = (bijMemo[s] << 27) | (uint32(1) << 16) | bijMemo[s]
+		} else {
+			computeGolombRice(s, bs.golombRice, bs.leafSize, bs.primaryAggrBound, bs.secondaryAggrBound)
+		}
+	}
+	return int(bs.golombRice[m] >> 27)
+}
@@ -19,6 +19,7 @@ package recsplit
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -189,3 +190,49 @@ func TestTwoLayerIndex(t *testing.T) {
 		}
 	}
 }
+
+func TestParallelBuildIsByteIdentical(t *testing.T) {
+	logger := log.New()
+	tmpDir := t.TempDir()
+	salt := uint32(1)
+	N := 10_000
+	build := func(workers int, enums bool) []byte {
+		indexFile := filepath.Join(tmpDir, fmt.Sprintf("index_%d_%t", workers, enums))
+		rs, err := NewRecSplit(RecSplitArgs{
+			KeyCount:           N,
+			BucketSize:         100,
+			Salt:               &salt,
+			TmpDir:             tmpDir,
+			IndexFile:          indexFile,
+			LeafSize:           8,
+			Enums:              enums,
+			LessFalsePositives: enums,
+			NoFsync:            true,
+
+			Workers:         workers,
+			WorkersMemLimit: 4 * 1024, // a few buckets in-flight
+		}, logger)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rs.Close()
+		for i := 0; i < N; i++ {
+			if err = rs.AddKey([]byte(fmt.Sprintf("key %d", i)), uint64(i*17)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := rs.Build(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(indexFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	for _, enums := range []bool{false, true} {
+		expect := build(1, enums)
+		assert.Equal(t, expect, build(4, enums))
+		assert.Equal(t, expect, build(16, enums))
+	}
+}
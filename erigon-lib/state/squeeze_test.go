@@ -5,6 +5,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/erigontech/erigon-lib/commitment"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/empty"
 	"github.com/erigontech/erigon-lib/common/length"
@@ -144,3 +145,61 @@ func TestAggregator_SqueezeCommitment(t *testing.T) {
 	require.Equal(t, latestRoot, root)
 	require.NotEqual(t, empty.RootHash.Bytes(), root)
 }
+
+func TestRecomputeCommitment(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	cfgd := &testAggConfig{stepSize: 10, disableCommitmentBranchTransform: true}
+	_db, agg := testDbAggregatorWithFiles(t, cfgd)
+	db := wrapDbWithCtx(_db, agg)
+
+	tx, err := db.BeginTemporalRo(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	toTxNum := AggTx(tx).TxNumsInFiles(kv.StateDomains...)
+	end, err := AggTx(tx).StateFilesEnd(toTxNum)
+	require.NoError(t, err)
+	require.Equal(t, toTxNum, end)
+	latestRoot, commitmentTxNum, _, err := CommitmentStateInFiles(tx, toTxNum)
+	require.NoError(t, err)
+	require.Equal(t, toTxNum-1, commitmentTxNum)
+	for _, variant := range []commitment.TrieVariant{commitment.VariantHexPatriciaTrie, commitment.VariantConcurrentHexPatricia} {
+		rc, err := RecomputeCommitment(context.Background(), tx, toTxNum, variant, t.TempDir(), log.New())
+		require.NoError(t, err)
+		require.Equal(t, latestRoot, rc.Root, variant)
+
+		mismatch, err := rc.FirstMismatch(tx)
+		require.NoError(t, err)
+		require.Nil(t, mismatch, variant)
+	}
+
+	// step 3 is inside of merged files of steps 0-16: state is verified at the end of the files
+	stepEnd, err := AggTx(tx).StateFilesEnd(4 * agg.StepSize())
+	require.NoError(t, err)
+	require.Equal(t, 16*agg.StepSize(), stepEnd)
+	_, _, _, err = CommitmentStateInFiles(tx, 4*agg.StepSize())
+	require.Error(t, err)
+	_, err = RecomputeCommitment(context.Background(), tx, 4*agg.StepSize(), commitment.VariantHexPatriciaTrie, t.TempDir(), log.New())
+	require.Error(t, err)
+
+	mergedRoot, commitmentTxNum, _, err := CommitmentStateInFiles(tx, stepEnd)
+	require.NoError(t, err)
+	require.Equal(t, stepEnd-1, commitmentTxNum)
+	require.NotEqual(t, latestRoot, mergedRoot)
+	rc, err := RecomputeCommitment(context.Background(), tx, stepEnd, commitment.VariantHexPatriciaTrie, t.TempDir(), log.New())
+	require.NoError(t, err)
+	require.Equal(t, mergedRoot, rc.Root)
+	mismatch, err := rc.FirstMismatch(tx)
+	require.NoError(t, err)
+	require.Nil(t, mismatch)
+
+	// branches of older state differ from the last ones in files
+	rc.ToTxNum = toTxNum
+	mismatch, err = rc.FirstMismatch(tx)
+	require.NoError(t, err)
+	require.NotNil(t, mismatch)
+	require.NotEmpty(t, mismatch.Nibbles)
+}
@@ -0,0 +1,311 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/commitment"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/empty"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/stream"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types/accounts"
+)
+
+// recomputeBatchSize - amount of keys touched before each trie.Process. After first batch
+// concurrent trie is able to switch to parallel processing.
+const recomputeBatchSize = 1_000_000
+
+// RecomputedCommitment - commitment trie built from scratch out of accounts and storage domain files
+type RecomputedCommitment struct {
+	Root     []byte
+	ToTxNum  uint64 // files with endTxNum <= ToTxNum were used
+	Keys     uint64
+	branches map[string][]byte
+}
+
+// recomputeContext - PatriciaContext which keeps branches in memory and reads state only from files
+// which end not later than toTxNum. Guarded by mutex because concurrent trie reads it from several goroutines.
+type recomputeContext struct {
+	mu       sync.Mutex
+	at       *AggregatorRoTx
+	toTxNum  uint64
+	branches map[string][]byte
+}
+
+func (rc *recomputeContext) Branch(prefix []byte) ([]byte, uint64, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.branches[string(prefix)], 0, nil
+}
+
+func (rc *recomputeContext) PutBranch(prefix []byte, data []byte, prevData []byte, prevStep uint64) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.branches[string(prefix)] = common.Copy(data)
+	return nil
+}
+
+func (rc *recomputeContext) read(d kv.Domain, plainKey []byte) ([]byte, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	v, _, _, _, err := rc.at.DebugGetLatestFromFiles(d, plainKey, rc.toTxNum)
+	if err != nil {
+		return nil, fmt.Errorf("recompute commitment: read %s %x from files up to txNum %d: %w", d, plainKey, rc.toTxNum, err)
+	}
+	return v, nil
+}
+
+func (rc *recomputeContext) Account(plainKey []byte) (*commitment.Update, error) {
+	encAccount, err := rc.read(kv.AccountsDomain, plainKey)
+	if err != nil {
+		return nil, err
+	}
+	u := &commitment.Update{CodeHash: empty.CodeHash}
+	if len(encAccount) == 0 {
+		u.Flags = commitment.DeleteUpdate
+		return u, nil
+	}
+	acc := new(accounts.Account)
+	if err = accounts.DeserialiseV3(acc, encAccount); err != nil {
+		return nil, err
+	}
+	u.Flags |= commitment.NonceUpdate | commitment.BalanceUpdate
+	u.Nonce = acc.Nonce
+	u.Balance.Set(&acc.Balance)
+	if ch := acc.CodeHash.Bytes(); len(ch) > 0 {
+		u.Flags |= commitment.CodeUpdate
+		copy(u.CodeHash[:], ch)
+	}
+	return u, nil
+}
+
+func (rc *recomputeContext) Storage(plainKey []byte) (*commitment.Update, error) {
+	enc, err := rc.read(kv.StorageDomain, plainKey)
+	if err != nil {
+		return nil, err
+	}
+	u := &commitment.Update{Flags: commitment.DeleteUpdate, StorageLen: len(enc)}
+	if u.StorageLen > 0 {
+		u.Flags = commitment.StorageUpdate
+		copy(u.Storage[:u.StorageLen], enc)
+	}
+	return u, nil
+}
+
+// StateFilesEnd returns end txNum of visible accounts, storage and commitment files which cover txNum-1.
+// Domain files keep only the latest values of their range, so state inside of merged file is not available:
+// it can be verified only at the end of the file.
+func (at *AggregatorRoTx) StateFilesEnd(txNum uint64) (uint64, error) {
+	var end uint64
+	for _, d := range []kv.Domain{kv.AccountsDomain, kv.StorageDomain, kv.CommitmentDomain} {
+		var found bool
+		for _, f := range at.d[d].files {
+			if f.startTxNum < txNum && txNum <= f.endTxNum {
+				if end != 0 && f.endTxNum != end {
+					return 0, fmt.Errorf("%s file %d-%d is not aligned with files of other domains, which end at txNum %d", d, f.startTxNum, f.endTxNum, end)
+				}
+				end, found = f.endTxNum, true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("no %s file covers txNum %d", d, txNum-1)
+		}
+	}
+	return end, nil
+}
+
+func (at *AggregatorRoTx) checkStateFilesEnd(toTxNum uint64) error {
+	end, err := at.StateFilesEnd(toTxNum)
+	if err != nil {
+		return err
+	}
+	if end != toTxNum {
+		return fmt.Errorf("txNum %d is not end of state files: state is in files ending at txNum %d", toTxNum, end)
+	}
+	return nil
+}
+
+// filesKeyStream - sorted unique keys of all visible files of domain which end not later than toTxNum
+func (at *AggregatorRoTx) filesKeyStream(d kv.Domain, toTxNum uint64) (stream.KV, error) {
+	var it stream.KV
+	for _, f := range at.d[d].files { // files are sorted by txNum, newest file wins in union
+		if f.endTxNum > toTxNum {
+			break
+		}
+		fileStream, err := at.FileStream(d, f.startTxNum, f.endTxNum)
+		if err != nil {
+			return nil, err
+		}
+		it = stream.UnionKV(fileStream, it, -1)
+	}
+	if it == nil {
+		return nil, fmt.Errorf("no %s files found up to txNum %d", d, toTxNum)
+	}
+	return it, nil
+}
+
+// RecomputeCommitment builds commitment trie from scratch out of state at toTxNum. Keys and values are read only
+// from accounts and storage files which end not later than toTxNum (commitment files, history and DB are not used),
+// so toTxNum must be end of files (see StateFilesEnd).
+func RecomputeCommitment(ctx context.Context, tx kv.TemporalTx, toTxNum uint64, variant commitment.TrieVariant, tmpDir string, logger log.Logger) (*RecomputedCommitment, error) {
+	at := AggTx(tx)
+	if at == nil {
+		return nil, errors.New("recompute commitment: tx has no aggregator")
+	}
+	if err := at.checkStateFilesEnd(toTxNum); err != nil {
+		return nil, err
+	}
+	accs, err := at.filesKeyStream(kv.AccountsDomain, toTxNum)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := at.filesKeyStream(kv.StorageDomain, toTxNum)
+	if err != nil {
+		return nil, err
+	}
+	keys := stream.UnionKV(accs, storage, -1)
+	defer keys.Close()
+
+	rc := &recomputeContext{at: at, toTxNum: toTxNum, branches: map[string][]byte{}}
+	trie, updates := commitment.InitializeTrieAndUpdates(variant, commitment.ModeDirect, tmpDir)
+	defer updates.Close()
+	trie.ResetContext(rc)
+
+	logEvery := time.NewTicker(30 * time.Second)
+	defer logEvery.Stop()
+
+	res := &RecomputedCommitment{ToTxNum: toTxNum, branches: rc.branches}
+	var batch int
+	for keys.HasNext() {
+		k, _, err := keys.Next()
+		if err != nil {
+			return nil, err
+		}
+		d := kv.AccountsDomain
+		if len(k) > length.Addr {
+			d = kv.StorageDomain
+		}
+		v, err := rc.read(d, k)
+		if err != nil {
+			return nil, err
+		}
+		if len(v) == 0 { // deleted at toTxNum
+			continue
+		}
+		updates.TouchPlainKey(string(k), nil, nil)
+		res.Keys++
+		batch++
+
+		if batch >= recomputeBatchSize {
+			if res.Root, err = trie.Process(ctx, updates, "recompute"); err != nil {
+				return nil, err
+			}
+			batch = 0
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-logEvery.C:
+			logger.Info("[recompute commitment] progress", "keys", common.PrettyCounter(res.Keys), "branches", len(rc.branches), "key", fmt.Sprintf("%x", k))
+		default:
+		}
+	}
+	if batch > 0 || res.Root == nil {
+		if res.Root, err = trie.Process(ctx, updates, "recompute"); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// CommitmentStateInFiles returns root hash, txNum and blockNum of the last commitment stored in commitment files
+// which end not later than toTxNum. toTxNum must be end of files (see StateFilesEnd).
+func CommitmentStateInFiles(tx kv.TemporalTx, toTxNum uint64) (root []byte, txNum, blockNum uint64, err error) {
+	at := AggTx(tx)
+	if at == nil {
+		return nil, 0, 0, errors.New("commitment state: tx has no aggregator")
+	}
+	if err := at.checkStateFilesEnd(toTxNum); err != nil {
+		return nil, 0, 0, err
+	}
+	state, found, _, _, err := at.DebugGetLatestFromFiles(kv.CommitmentDomain, keyCommitmentState, toTxNum)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if !found || len(state) < 16 {
+		return nil, 0, 0, fmt.Errorf("commitment state not found in files up to txNum %d", toTxNum)
+	}
+	txNum, blockNum = _decodeTxBlockNums(state)
+	if root, err = commitment.HexTrieExtractStateRoot(state); err != nil {
+		return nil, 0, 0, err
+	}
+	return root, txNum, blockNum, nil
+}
+
+// CommitmentMismatch - shallowest branch which differs from branch stored in commitment files
+type CommitmentMismatch struct {
+	Prefix   []byte // hex nibbles of branch
+	Nibbles  []int  // mismatching cells of branch
+	Stored   commitment.BranchData
+	Computed commitment.BranchData
+}
+
+// FirstMismatch compares recomputed branches with branches in commitment files (ending not later than ToTxNum),
+// from the shortest prefix to the longest. Returns nil if all recomputed branches are equal to stored ones.
+func (rc *RecomputedCommitment) FirstMismatch(tx kv.TemporalTx) (*CommitmentMismatch, error) {
+	at := AggTx(tx)
+	if at == nil {
+		return nil, errors.New("first mismatch: tx has no aggregator")
+	}
+	type branchKey struct{ nibbles, compact []byte }
+	prefixes := make([]branchKey, 0, len(rc.branches))
+	for k := range rc.branches {
+		prefixes = append(prefixes, branchKey{nibbles: commitment.CompactBytesToHexNibbles([]byte(k)), compact: []byte(k)})
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		if len(prefixes[i].nibbles) != len(prefixes[j].nibbles) {
+			return len(prefixes[i].nibbles) < len(prefixes[j].nibbles)
+		}
+		return bytes.Compare(prefixes[i].nibbles, prefixes[j].nibbles) < 0
+	})
+	for _, p := range prefixes {
+		computed := commitment.BranchData(rc.branches[string(p.compact)])
+		stored, _, _, _, err := at.DebugGetLatestFromFiles(kv.CommitmentDomain, p.compact, rc.ToTxNum)
+		if err != nil {
+			return nil, err
+		}
+		diff, err := computed.DiffCellHashes(stored)
+		if err != nil {
+			return nil, fmt.Errorf("branch %x: %w", p.nibbles, err)
+		}
+		if len(diff) > 0 {
+			return &CommitmentMismatch{Prefix: p.nibbles, Nibbles: diff, Stored: stored, Computed: computed}, nil
+		}
+	}
+	return nil, nil
+}
@@ -36,6 +36,10 @@ import (
 
 const (
 	LEN_48 = 48 // KZGCommitment & KZGProof sizes
+
+	// BlobWrapperVersion1 - EIP-7594 network wrapper: [tx_payload_body, wrapper_version, blobs, commitments, cell_proofs],
+	// with CellsPerExtBlob cell proofs per blob instead of one blob proof
+	BlobWrapperVersion1 byte = 1
 )
 
 type KZGCommitment [LEN_48]byte // Compressed BLS12-381 G1 element
@@ -47,10 +51,11 @@ type KZGProofs []KZGProof
 type Blobs []Blob
 
 type BlobTxWrapper struct {
-	Tx          BlobTx
-	Commitments BlobKzgs
-	Blobs       Blobs
-	Proofs      KZGProofs
+	Tx             BlobTx
+	WrapperVersion byte // 0 - EIP-4844 wrapper without version, BlobWrapperVersion1 - Proofs are cell proofs
+	Commitments    BlobKzgs
+	Blobs          Blobs
+	Proofs         KZGProofs
 }
 
 /* Blob methods */
@@ -279,11 +284,23 @@ func (txw *BlobTxWrapper) ValidateBlobTransactionWrapper() error {
 	l2 := len(txw.Commitments)
 	l3 := len(txw.Blobs)
 	l4 := len(txw.Proofs)
+	if txw.WrapperVersion == BlobWrapperVersion1 {
+		l4 /= libkzg.CellsPerExtBlob
+		if len(txw.Proofs) != l4*libkzg.CellsPerExtBlob {
+			return fmt.Errorf("number of cell proofs %d is not multiple of %d", len(txw.Proofs), libkzg.CellsPerExtBlob)
+		}
+	} else if txw.WrapperVersion != 0 {
+		return fmt.Errorf("unsupported blob wrapper version %d", txw.WrapperVersion)
+	}
 	if l1 != l2 || l1 != l3 || l1 != l4 {
 		return fmt.Errorf("lengths don't match %v %v %v %v", l1, l2, l3, l4)
 	}
-	kzgCtx := libkzg.Ctx()
-	err := kzgCtx.VerifyBlobKZGProofBatch(toBlobs(txw.Blobs), toComms(txw.Commitments), toProofs(txw.Proofs))
+	var err error
+	if txw.WrapperVersion == BlobWrapperVersion1 {
+		err = libkzg.VerifyCellProofs(toBlobs(txw.Blobs), toComms(txw.Commitments), toProofs(txw.Proofs))
+	} else {
+		err = libkzg.Ctx().VerifyBlobKZGProofBatch(toBlobs(txw.Blobs), toComms(txw.Commitments), toProofs(txw.Proofs))
+	}
 	if err != nil {
 		return fmt.Errorf("error during proof verification: %w", err)
 	}
@@ -357,6 +374,21 @@ func (txw *BlobTxWrapper) DecodeRLP(s *rlp.Stream) error {
 		return err
 	}
 
+	kind, _, err := s.Kind()
+	if err != nil {
+		return err
+	}
+	if kind != rlp.List { // EIP-7594 wrapper version
+		version, err := s.Uint()
+		if err != nil {
+			return fmt.Errorf("blob wrapper version: %w", err)
+		}
+		if version != uint64(BlobWrapperVersion1) {
+			return fmt.Errorf("unsupported blob wrapper version %d", version)
+		}
+		txw.WrapperVersion = BlobWrapperVersion1
+	}
+
 	if err := txw.Blobs.DecodeRLP(s); err != nil {
 		return err
 	}
@@ -380,6 +412,9 @@ func (txw *BlobTxWrapper) EncodingSize() int {
 func (txw *BlobTxWrapper) payloadSize() (payloadSize int) {
 	l, _, _, _, _ := txw.Tx.payloadSize()
 	payloadSize += l + rlp.ListPrefixLen(l)
+	if txw.WrapperVersion != 0 {
+		payloadSize += rlp.U64Len(uint64(txw.WrapperVersion))
+	}
 	l = txw.Blobs.payloadSize()
 	payloadSize += l + rlp.ListPrefixLen(l)
 	l = txw.Commitments.payloadSize()
@@ -408,6 +443,11 @@ func (txw *BlobTxWrapper) MarshalBinaryWrapped(w io.Writer) error {
 	if _, err := w.Write(bw.Bytes()[1:]); err != nil {
 		return err
 	}
+	if txw.WrapperVersion != 0 {
+		if err := rlp.EncodeInt(uint64(txw.WrapperVersion), w, b[:]); err != nil {
+			return err
+		}
+	}
 
 	if err := txw.Blobs.encodePayload(w, b[:], txw.Blobs.payloadSize()); err != nil {
 		return err
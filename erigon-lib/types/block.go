@@ -1419,10 +1419,11 @@ func CopyTxs(in Transactions) Transactions {
 			blobTx := out[i].(*BlobTx)
 			out[i] = &BlobTxWrapper{
 				// it's ok to copy here - because it's constructor of object - no parallel access yet
-				Tx:          *blobTx, //nolint
-				Commitments: txWrapper.Commitments.copy(),
-				Blobs:       txWrapper.Blobs.copy(),
-				Proofs:      txWrapper.Proofs.copy(),
+				Tx:             *blobTx, //nolint
+				WrapperVersion: txWrapper.WrapperVersion,
+				Commitments:    txWrapper.Commitments.copy(),
+				Blobs:          txWrapper.Blobs.copy(),
+				Proofs:         txWrapper.Proofs.copy(),
 			}
 		}
 	}
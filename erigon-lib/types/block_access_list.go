@@ -0,0 +1,136 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+)
+
+// BlockAccessList - accounts and storage slots accessed by a block together with their post-values
+// after every change (EIP-7928). Changes are keyed by block access index: 0 is for the system calls
+// before the transactions, i+1 is for transaction i and len(txs)+1 is for withdrawals and
+// the system calls after the transactions.
+type BlockAccessList []*AccountChanges
+
+type AccountChanges struct {
+	Address        common.Address   `json:"address"`
+	StorageChanges []*SlotChanges   `json:"storageChanges"`
+	StorageReads   []common.Hash    `json:"storageReads"` // slots which were read, but not changed
+	BalanceChanges []*BalanceChange `json:"balanceChanges"`
+	NonceChanges   []*NonceChange   `json:"nonceChanges"`
+	CodeChanges    []*CodeChange    `json:"codeChanges"`
+}
+
+type SlotChanges struct {
+	Slot    common.Hash      `json:"slot"`
+	Changes []*StorageChange `json:"slotChanges"`
+}
+
+type StorageChange struct {
+	Index hexutil.Uint64 `json:"blockAccessIndex"`
+	Value common.Hash    `json:"postValue"`
+}
+
+type BalanceChange struct {
+	Index   hexutil.Uint64 `json:"blockAccessIndex"`
+	Balance uint256.Int    `json:"postBalance"`
+}
+
+type NonceChange struct {
+	Index hexutil.Uint64 `json:"blockAccessIndex"`
+	Nonce hexutil.Uint64 `json:"postNonce"`
+}
+
+type CodeChange struct {
+	Index hexutil.Uint64 `json:"blockAccessIndex"`
+	Code  hexutil.Bytes  `json:"newCode"`
+}
+
+// Hash - keccak256 of RLP encoding of the list
+func (bal BlockAccessList) Hash() common.Hash {
+	return rlpHash(bal)
+}
+
+// Validate checks that the list is canonical for a block with numTxs transactions:
+// addresses and slots are sorted and unique, changes are ordered by block access index
+// and the indices are in range, changed slots are not listed as reads.
+func (bal BlockAccessList) Validate(numTxs int) error {
+	maxIndex := hexutil.Uint64(numTxs + 1)
+	for i, account := range bal {
+		if account == nil {
+			return fmt.Errorf("nil account at %d", i)
+		}
+		if i > 0 && bytes.Compare(bal[i-1].Address[:], account.Address[:]) >= 0 {
+			return fmt.Errorf("accounts are not sorted or not unique: %x after %x", account.Address, bal[i-1].Address)
+		}
+		changed := make(map[common.Hash]struct{}, len(account.StorageChanges))
+		for j, slot := range account.StorageChanges {
+			if slot == nil {
+				return fmt.Errorf("account %x: nil slot at %d", account.Address, j)
+			}
+			if j > 0 && bytes.Compare(account.StorageChanges[j-1].Slot[:], slot.Slot[:]) >= 0 {
+				return fmt.Errorf("account %x: slots are not sorted or not unique: %x after %x", account.Address, slot.Slot, account.StorageChanges[j-1].Slot)
+			}
+			if len(slot.Changes) == 0 {
+				return fmt.Errorf("account %x: slot %x has no changes", account.Address, slot.Slot)
+			}
+			if err := validIndices(slot.Changes, maxIndex, func(c *StorageChange) hexutil.Uint64 { return c.Index }); err != nil {
+				return fmt.Errorf("account %x, slot %x: %w", account.Address, slot.Slot, err)
+			}
+			changed[slot.Slot] = struct{}{}
+		}
+		for j, slot := range account.StorageReads {
+			if j > 0 && bytes.Compare(account.StorageReads[j-1][:], slot[:]) >= 0 {
+				return fmt.Errorf("account %x: read slots are not sorted or not unique: %x after %x", account.Address, slot, account.StorageReads[j-1])
+			}
+			if _, ok := changed[slot]; ok {
+				return fmt.Errorf("account %x: slot %x is both read and changed", account.Address, slot)
+			}
+		}
+		if err := validIndices(account.BalanceChanges, maxIndex, func(c *BalanceChange) hexutil.Uint64 { return c.Index }); err != nil {
+			return fmt.Errorf("account %x, balance: %w", account.Address, err)
+		}
+		if err := validIndices(account.NonceChanges, maxIndex, func(c *NonceChange) hexutil.Uint64 { return c.Index }); err != nil {
+			return fmt.Errorf("account %x, nonce: %w", account.Address, err)
+		}
+		if err := validIndices(account.CodeChanges, maxIndex, func(c *CodeChange) hexutil.Uint64 { return c.Index }); err != nil {
+			return fmt.Errorf("account %x, code: %w", account.Address, err)
+		}
+	}
+	return nil
+}
+
+func validIndices[T any](changes []*T, maxIndex hexutil.Uint64, index func(*T) hexutil.Uint64) error {
+	for i, c := range changes {
+		if c == nil {
+			return fmt.Errorf("nil change at %d", i)
+		}
+		if index(c) > maxIndex {
+			return fmt.Errorf("block access index %d out of range [0, %d]", index(c), maxIndex)
+		}
+		if i > 0 && index(c) <= index(changes[i-1]) {
+			return fmt.Errorf("block access indices are not strictly increasing: %d after %d", index(c), index(changes[i-1]))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+)
+
+func testBlockAccessList() BlockAccessList {
+	return BlockAccessList{
+		{
+			Address:        common.Address{1},
+			StorageChanges: []*SlotChanges{{Slot: common.Hash{1}, Changes: []*StorageChange{{Index: 1, Value: common.Hash{31: 1}}, {Index: 3, Value: common.Hash{}}}}},
+			StorageReads:   []common.Hash{{0}, {2}},
+			BalanceChanges: []*BalanceChange{{Index: 0, Balance: *uint256.NewInt(1000)}},
+			NonceChanges:   []*NonceChange{{Index: 2, Nonce: 1}},
+			CodeChanges:    []*CodeChange{{Index: 2, Code: []byte{0x60, 0x00}}},
+		},
+		{Address: common.Address{2}},
+	}
+}
+
+func TestBlockAccessListValidate(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, testBlockAccessList().Validate(2))
+	require.ErrorContains(t, testBlockAccessList().Validate(1), "out of range")
+
+	bal := testBlockAccessList()
+	bal[0], bal[1] = bal[1], bal[0]
+	require.ErrorContains(t, bal.Validate(2), "accounts are not sorted")
+
+	bal = testBlockAccessList()
+	bal[0].StorageReads = []common.Hash{{0}, {1}, {2}}
+	require.ErrorContains(t, bal.Validate(2), "both read and changed")
+
+	bal = testBlockAccessList()
+	bal[0].NonceChanges = append(bal[0].NonceChanges, &NonceChange{Index: 2, Nonce: 2})
+	require.ErrorContains(t, bal.Validate(2), "not strictly increasing")
+}
+
+func TestBlockAccessListJSON(t *testing.T) {
+	t.Parallel()
+	bal := testBlockAccessList()
+	enc, err := json.Marshal(bal)
+	require.NoError(t, err)
+	require.Contains(t, string(enc), `"blockAccessIndex":"0x2","postNonce":"0x1"`)
+
+	var dec BlockAccessList
+	require.NoError(t, json.Unmarshal(enc, &dec))
+	require.Equal(t, bal.Hash(), dec.Hash())
+	require.NotEqual(t, bal.Hash(), dec[:1].Hash())
+}
@@ -61,6 +61,8 @@ type txJSON struct {
 	Blobs       Blobs     `json:"blobs,omitempty"`
 	Commitments BlobKzgs  `json:"commitments,omitempty"`
 	Proofs      KZGProofs `json:"proofs,omitempty"`
+	// 0 (omitted) - EIP-4844 blob proofs, BlobWrapperVersion1 - EIP-7594 cell proofs
+	WrapperVersion hexutil.Uint64 `json:"wrapperVersion,omitempty"`
 
 	// Only used for encoding:
 	Hash common.Hash `json:"hash"`
@@ -205,6 +207,7 @@ func (tx *BlobTxWrapper) MarshalJSON() ([]byte, error) {
 	enc.Blobs = tx.Blobs
 	enc.Commitments = tx.Commitments
 	enc.Proofs = tx.Proofs
+	enc.WrapperVersion = hexutil.Uint64(tx.WrapperVersion)
 
 	return json.Marshal(enc)
 }
@@ -618,6 +621,13 @@ func UnmarshalBlobTxJSON(input []byte) (Transaction, error) {
 		Blobs:       dec.Blobs,
 		Proofs:      dec.Proofs,
 	}
+	switch dec.WrapperVersion {
+	case 0:
+	case hexutil.Uint64(BlobWrapperVersion1):
+		btx.WrapperVersion = BlobWrapperVersion1
+	default:
+		return nil, fmt.Errorf("unsupported blob wrapper version %d", dec.WrapperVersion)
+	}
 	err := btx.ValidateBlobTransactionWrapper()
 	if err != nil {
 		return nil, err
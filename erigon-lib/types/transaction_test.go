@@ -32,13 +32,16 @@ import (
 	"testing"
 	"time"
 
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/erigontech/erigon-lib/chain/params"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/u256"
 	"github.com/erigontech/erigon-lib/crypto"
+	libkzg "github.com/erigontech/erigon-lib/crypto/kzg"
 	"github.com/erigontech/erigon-lib/rlp"
 )
 
@@ -729,6 +732,44 @@ func TestBlobTxEncodeDecode(t *testing.T) {
 	}
 }
 
+func TestBlobTxWrapperJSON(t *testing.T) {
+	blobTxRlp, _ := MakeBlobTxnRlp()
+	wrapper := BlobTxWrapper{}
+	require.NoError(t, wrapper.DecodeRLP(rlp.NewStream(bytes.NewReader(blobTxRlp[1:]), 0)))
+	for i, commitment := range wrapper.Commitments {
+		wrapper.Tx.BlobVersionedHashes[i] = common.Hash(libkzg.KZGToVersionedHash(gokzg4844.KZGCommitment(commitment)))
+	}
+
+	// blob proofs: no wrapper version in json
+	enc, err := json.Marshal(&wrapper)
+	require.NoError(t, err)
+	require.NotContains(t, string(enc), "wrapperVersion")
+	decoded, err := UnmarshalBlobTxJSON(enc)
+	require.NoError(t, err)
+	require.Equal(t, byte(0), decoded.(*BlobTxWrapper).WrapperVersion)
+
+	// cell proofs: version is explicit, not guessed by number of proofs
+	wrapper.WrapperVersion = BlobWrapperVersion1
+	wrapper.Proofs = nil
+	for _, blob := range wrapper.Blobs {
+		cellProofs, err := libkzg.ComputeCellProofs(blob[:])
+		require.NoError(t, err)
+		for _, proof := range cellProofs {
+			wrapper.Proofs = append(wrapper.Proofs, KZGProof(proof))
+		}
+	}
+	enc, err = json.Marshal(&wrapper)
+	require.NoError(t, err)
+	require.Contains(t, string(enc), `"wrapperVersion":"0x1"`)
+	decoded, err = UnmarshalBlobTxJSON(enc)
+	require.NoError(t, err)
+	require.Equal(t, BlobWrapperVersion1, decoded.(*BlobTxWrapper).WrapperVersion)
+	require.Equal(t, wrapper.Proofs, decoded.(*BlobTxWrapper).Proofs)
+
+	_, err = UnmarshalBlobTxJSON(bytes.Replace(enc, []byte(`"wrapperVersion":"0x1"`), []byte(`"wrapperVersion":"0x2"`), 1))
+	require.ErrorContains(t, err, "unsupported blob wrapper version 2")
+}
+
 func TestShortUnwrap(t *testing.T) {
 	blobTxRlp, _ := MakeBlobTxnRlp()
 	shortRlp, err := UnwrapTxPlayloadRlp(blobTxRlp)
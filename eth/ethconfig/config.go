@@ -91,9 +91,10 @@ var Defaults = Config{
 	NetworkID: 1,
 	Prune:     prune.DefaultMode,
 	Miner: params.MiningConfig{
-		GasLimit: 36_000_000,
-		GasPrice: big.NewInt(common.GWei),
-		Recommit: 3 * time.Second,
+		GasLimit:       36_000_000,
+		GasPrice:       big.NewInt(common.GWei),
+		Recommit:       3 * time.Second,
+		PayloadRebuild: 2 * time.Second,
 	},
 	TxPool:      txpoolcfg.DefaultConfig,
 	RPCGasCap:   50000000,
@@ -143,6 +144,7 @@ type BlocksFreezing struct {
 	DisableDownloadE3 bool // disable download state snapshots
 	DownloaderAddr    string
 	ChainName         string
+	RecsplitWorkers   int // amount of goroutines building one index of block files, 0 - dbg.RecsplitWorkers
 }
 
 func (s BlocksFreezing) String() string {
@@ -264,6 +266,10 @@ type Config struct {
 
 	// Account Abstraction
 	AllowAA bool
+
+	// Post-merge dev chain: blocks are produced by in-process simulated beacon every DevPeriod (0 - only by dev_mine)
+	DevSimulatedBeacon bool
+	DevPeriod          time.Duration
 }
 
 type Sync struct {
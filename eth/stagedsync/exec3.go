@@ -341,6 +341,7 @@ func ExecV3(ctx context.Context,
 	defer applyWorker.LogLRUStats()
 
 	applyWorker.ResetState(rs, accumulator)
+	applyWorker.SetProfiler(cfg.vmConfig.Profiler)
 
 	commitThreshold := cfg.batchSize.Bytes()
 
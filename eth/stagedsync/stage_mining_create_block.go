@@ -50,6 +50,7 @@ type MiningBlock struct {
 	Receipts         types.Receipts
 	Withdrawals      []*types.Withdrawal
 	PreparedTxns     types.Transactions
+	InclusionList    types.Transactions // EIP-7805: included ahead of txpool transactions
 	Requests         types.FlatRequests
 }
 
@@ -215,6 +216,7 @@ func SpawnMiningCreateBlockStage(s *StageState, txc wrap.TxContainer, cfg Mining
 		current.Header = header
 		current.Uncles = nil
 		current.Withdrawals = cfg.blockBuilderParameters.Withdrawals
+		current.InclusionList = cfg.blockBuilderParameters.InclusionList
 		return nil
 	}
 
@@ -153,8 +153,24 @@ func SpawnMiningExecStage(s *StageState, txc wrap.TxContainer, cfg MiningExecCfg
 			return err
 		}
 
+		stop := false
+		if len(current.InclusionList) > 0 {
+			inclusionList, err := inclusionListTransactions(cfg, chainID, current, executionAt, yielded, simStateReader, simStateWriter, logger)
+			if err != nil {
+				return err
+			}
+			if len(inclusionList) > 0 {
+				var logs types.Logs
+				logs, stop, err = addTransactionsToMiningBlock(ctx, logPrefix, current, cfg.chainConfig, cfg.vmConfig, getHeader, cfg.engine, inclusionList, cfg.miningState.MiningConfig.Etherbase, ibs, cfg.interrupt, cfg.payloadId, logger)
+				if err != nil {
+					return err
+				}
+				NotifyPendingLogs(logPrefix, cfg.notifier, logs, logger)
+			}
+		}
+
 		const amount = 50
-		for {
+		for !stop {
 			txns, err := getNextTransactions(ctx, cfg, chainID, current.Header, amount, executionAt, yielded, simStateReader, simStateWriter, logger)
 			if err != nil {
 				return err
@@ -293,6 +309,34 @@ func getNextTransactions(
 	return txns, nil
 }
 
+// inclusionListTransactions - transactions of inclusion list (EIP-7805) which can be included into block, in order of
+// inclusion list. They are marked as yielded: txpool doesn't provide them again
+func inclusionListTransactions(
+	cfg MiningExecCfg,
+	chainID *uint256.Int,
+	current *MiningBlock,
+	executionAt uint64,
+	yielded mapset.Set[[32]byte],
+	simStateReader state.StateReader,
+	simStateWriter state.StateWriter,
+	logger log.Logger,
+) ([]types.Transaction, error) {
+	header := current.Header
+	signer := types.MakeSigner(&cfg.chainConfig, header.Number.Uint64(), header.Time)
+	txns := make([]types.Transaction, 0, len(current.InclusionList))
+	for _, txn := range current.InclusionList {
+		if txn.Type() == types.BlobTxType {
+			continue // blobs are not propagated with inclusion list
+		}
+		if _, err := txn.Sender(*signer); err != nil {
+			continue
+		}
+		yielded.Add(txn.Hash())
+		txns = append(txns, txn)
+	}
+	return filterBadTransactions(txns, chainID, cfg.chainConfig, executionAt+1, header, simStateReader, simStateWriter, logger)
+}
+
 func filterBadTransactions(transactions []types.Transaction, chainID *uint256.Int, config chain.Config, blockNumber uint64, header *types.Header, simStateReader state.StateReader, simStateWriter state.StateWriter, logger log.Logger) ([]types.Transaction, error) {
 	initialCnt := len(transactions)
 	var filtered []types.Transaction
@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
 )
 
 // SyncStage represents the stages of syncronisation in the Mode.StagedSync mode
@@ -81,6 +82,26 @@ func GetStageProgress(db kv.Getter, stage SyncStage) (uint64, error) {
 	return unmarshalData(v)
 }
 
+// ExecutedTxNum - first txNum after the last executed block (end of available state history)
+func ExecutedTxNum(tx kv.Tx) (uint64, error) {
+	v, err := tx.GetOne(kv.SyncStageProgress, []byte(Execution))
+	if err != nil {
+		return 0, err
+	}
+	if len(v) == 0 {
+		return 0, nil
+	}
+	executed, err := unmarshalData(v)
+	if err != nil {
+		return 0, err
+	}
+	maxTxNum, err := rawdbv3.TxNums.Max(tx, executed)
+	if err != nil {
+		return 0, err
+	}
+	return maxTxNum + 1, nil
+}
+
 func SaveStageProgress(db kv.Putter, stage SyncStage, progress uint64) error {
 	if m, ok := SyncMetrics[stage]; ok {
 		m.SetUint64(progress)
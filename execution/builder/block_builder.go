@@ -21,52 +21,153 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/metrics"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/core"
 )
 
+var (
+	candidateBuildTimer     = metrics.NewSummary(`builder_candidate_build`)
+	candidatesCounter       = metrics.GetOrCreateCounter(`builder_candidates`)
+	candidatesImproved      = metrics.GetOrCreateCounter(`builder_candidates_improved`)
+	candidateValueGauge     = metrics.GetOrCreateGauge(`builder_candidate_value_gwei`)
+	candidateTxnsGauge      = metrics.GetOrCreateGauge(`builder_candidate_txs`)
+	bestCandidateValueGauge = metrics.GetOrCreateGauge(`builder_best_candidate_value_gwei`)
+)
+
 type BlockBuilderFunc func(param *core.BlockBuilderParameters, interrupt *int32) (*types.BlockWithReceipts, error)
 
-// BlockBuilder wraps a goroutine that builds Proof-of-Stake payloads (PoS "mining")
+// BlockBuilder - builds candidates of payload until Stop: every rebuildInterval builds new candidate with fresh
+// pool contents and keeps the most valuable one. Rebuilding stops at timestamp of the payload - when it's expected to
+// be requested by getPayload. With zero rebuildInterval builds one candidate. SetInclusionList triggers rebuild at any time.
 type BlockBuilder struct {
-	interrupt int32
-	syncCond  *sync.Cond
-	result    *types.BlockWithReceipts
-	err       error
+	interrupt     int32
+	stop          chan struct{}
+	stopOnce      sync.Once
+	rebuild       chan struct{}
+	syncCond      *sync.Cond
+	param         core.BlockBuilderParameters // parameters of the next candidate
+	paramVersion  int                         // incremented on every change of param
+	result        *types.BlockWithReceipts    // the most valuable candidate
+	resultVersion int                         // paramVersion of result
+	value         *uint256.Int
+	err           error
+	done          bool
 }
 
-func NewBlockBuilder(build BlockBuilderFunc, param *core.BlockBuilderParameters) *BlockBuilder {
+func NewBlockBuilder(build BlockBuilderFunc, param *core.BlockBuilderParameters, rebuildInterval time.Duration) *BlockBuilder {
 	builder := new(BlockBuilder)
 	builder.syncCond = sync.NewCond(new(sync.Mutex))
+	builder.stop = make(chan struct{})
+	builder.rebuild = make(chan struct{}, 1)
+	builder.param = *param
+	rebuildUntil := time.Unix(int64(param.Timestamp), 0)
 
 	go func() {
-		log.Info("Building block...")
-		t := time.Now()
-		result, err := build(param, &builder.interrupt)
-		if err != nil {
-			log.Warn("Failed to build a block", "err", err)
-		} else {
+		defer func() {
+			builder.syncCond.L.Lock()
+			defer builder.syncCond.L.Unlock()
+			builder.done = true
+			builder.syncCond.Broadcast()
+		}()
+
+		for candidate := 0; ; candidate++ {
+			if candidate == 0 {
+				log.Info("Building block...")
+			} else {
+				log.Debug("Rebuilding block...", "candidate", candidate)
+			}
+			builder.syncCond.L.Lock()
+			param, paramVersion := builder.param, builder.paramVersion
+			builder.syncCond.L.Unlock()
+			t := time.Now()
+			result, err := build(&param, &builder.interrupt)
+			if err != nil {
+				log.Warn("Failed to build a block", "candidate", candidate, "err", err)
+				builder.syncCond.L.Lock()
+				if builder.result == nil {
+					builder.err = err
+				}
+				builder.syncCond.L.Unlock()
+				return
+			}
+			candidateBuildTimer.ObserveDuration(t)
 			block := result.Block
-			log.Info("Built block", "hash", block.Hash(), "height", block.NumberU64(), "txs", len(block.Transactions()), "executionRequests", len(result.Requests), "gas used %", 100*float64(block.GasUsed())/float64(block.GasLimit()), "time", time.Since(t))
-		}
+			baseFee := new(uint256.Int)
+			if block.BaseFee() != nil {
+				baseFee.SetFromBig(block.BaseFee())
+			}
+			value := BlockValue(result, baseFee)
+			candidatesCounter.Inc()
+			candidateValueGauge.Set(value.Float64() / common.GWei)
+			candidateTxnsGauge.SetInt(len(block.Transactions()))
 
-		builder.syncCond.L.Lock()
-		defer builder.syncCond.L.Unlock()
-		builder.result = result
-		builder.err = err
-		builder.syncCond.Broadcast()
+			builder.syncCond.L.Lock()
+			// candidates built with outdated parameters (e.g. without inclusion list) are replaced regardless of value
+			improved := builder.result == nil || paramVersion > builder.resultVersion || value.Gt(builder.value)
+			if improved {
+				builder.result, builder.value, builder.resultVersion = result, value, paramVersion
+				bestCandidateValueGauge.Set(value.Float64() / common.GWei)
+				if candidate > 0 {
+					candidatesImproved.Inc()
+				}
+			}
+			builder.syncCond.L.Unlock()
+			if candidate == 0 {
+				log.Info("Built block", "hash", block.Hash(), "height", block.NumberU64(), "txs", len(block.Transactions()), "executionRequests", len(result.Requests), "gas used %", 100*float64(block.GasUsed())/float64(block.GasLimit()), "value", value, "time", time.Since(t))
+			} else {
+				log.Debug("Rebuilt block", "candidate", candidate, "hash", block.Hash(), "txs", len(block.Transactions()), "value", value, "improved", improved, "time", time.Since(t))
+			}
+
+			if atomic.LoadInt32(&builder.interrupt) != 0 {
+				return
+			}
+			var nextRebuild <-chan time.Time // nil - only SetInclusionList triggers rebuild
+			if rebuildInterval > 0 && time.Now().Add(rebuildInterval).Before(rebuildUntil) {
+				nextRebuild = time.After(rebuildInterval)
+			}
+			select {
+			case <-nextRebuild:
+			case <-builder.rebuild:
+			case <-builder.stop:
+				return
+			}
+		}
 	}()
 
 	return builder
 }
 
-func (b *BlockBuilder) Stop() (*types.BlockWithReceipts, error) {
+// SetInclusionList - rebuilds payload with transactions of inclusion list (EIP-7805) as soon as current candidate is built.
+// Candidates built without it are not returned once candidate with it is built
+func (b *BlockBuilder) SetInclusionList(inclusionList types.Transactions) {
+	b.syncCond.L.Lock()
+	b.param.InclusionList = inclusionList
+	b.paramVersion++
+	b.syncCond.L.Unlock()
+	select {
+	case b.rebuild <- struct{}{}:
+	default: // rebuild is already pending
+	}
+}
+
+// Interrupt - stops building without waiting for result
+func (b *BlockBuilder) Interrupt() {
 	atomic.StoreInt32(&b.interrupt, 1)
+	b.stopOnce.Do(func() { close(b.stop) })
+}
+
+// Stop - stops building and returns the most valuable candidate
+func (b *BlockBuilder) Stop() (*types.BlockWithReceipts, error) {
+	b.Interrupt()
 
 	b.syncCond.L.Lock()
 	defer b.syncCond.L.Unlock()
-	for b.result == nil && b.err == nil {
+	for !b.done {
 		b.syncCond.Wait()
 	}
 
@@ -82,3 +183,16 @@ func (b *BlockBuilder) Block() *types.Block {
 	}
 	return b.result.Block
 }
+
+// BlockValue - the expected value to be received by the feeRecipient in wei
+func BlockValue(br *types.BlockWithReceipts, baseFee *uint256.Int) *uint256.Int {
+	blockValue := uint256.NewInt(0)
+	txs := br.Block.Transactions()
+	for i := range txs {
+		gas := new(uint256.Int).SetUint64(br.Receipts[i].GasUsed)
+		effectiveTip := txs[i].GetEffectiveGasTip(baseFee)
+		txValue := new(uint256.Int).Mul(gas, effectiveTip)
+		blockValue.Add(blockValue, txValue)
+	}
+	return blockValue
+}
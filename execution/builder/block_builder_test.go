@@ -0,0 +1,146 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package builder
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+)
+
+func candidateWithTip(tip uint64) *types.BlockWithReceipts {
+	txn := types.NewTransaction(0, common.Address{}, uint256.NewInt(0), 21_000, uint256.NewInt(tip), nil)
+	receipts := types.Receipts{{GasUsed: 21_000}}
+	block := types.NewBlock(&types.Header{GasLimit: 30_000_000}, []types.Transaction{txn}, nil, receipts, nil)
+	return &types.BlockWithReceipts{Block: block, Receipts: receipts}
+}
+
+func TestBlockBuilderKeepsMostValuableCandidate(t *testing.T) {
+	t.Parallel()
+	tips := []uint64{1, 3, 2}
+	var built atomic.Int32
+	allBuilt := make(chan struct{})
+	build := func(param *core.BlockBuilderParameters, interrupt *int32) (*types.BlockWithReceipts, error) {
+		n := int(built.Add(1)) - 1
+		if n == len(tips)-1 {
+			close(allBuilt)
+		}
+		if n >= len(tips) {
+			return candidateWithTip(0), nil
+		}
+		return candidateWithTip(tips[n]), nil
+	}
+	param := &core.BlockBuilderParameters{Timestamp: uint64(time.Now().Add(time.Hour).Unix())}
+	b := NewBlockBuilder(build, param, time.Millisecond)
+	<-allBuilt
+
+	result, err := b.Stop()
+	require.NoError(t, err)
+	require.Equal(t, uint256.NewInt(3*21_000), BlockValue(result, uint256.NewInt(0)))
+	require.GreaterOrEqual(t, int(built.Load()), len(tips))
+}
+
+func TestBlockBuilderBuildsOnce(t *testing.T) {
+	t.Parallel()
+	var built atomic.Int32
+	build := func(param *core.BlockBuilderParameters, interrupt *int32) (*types.BlockWithReceipts, error) {
+		built.Add(1)
+		return candidateWithTip(1), nil
+	}
+
+	// zero interval
+	param := &core.BlockBuilderParameters{Timestamp: uint64(time.Now().Add(time.Hour).Unix())}
+	result, err := NewBlockBuilder(build, param, 0).Stop()
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, int32(1), built.Load())
+
+	// timestamp of payload is reached before next candidate
+	built.Store(0)
+	param = &core.BlockBuilderParameters{Timestamp: uint64(time.Now().Unix())}
+	b := NewBlockBuilder(build, param, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	result, err = b.Stop()
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, int32(1), built.Load())
+}
+
+func TestBlockBuilderError(t *testing.T) {
+	t.Parallel()
+	errBuild := errors.New("build failed")
+	var built atomic.Int32
+	build := func(param *core.BlockBuilderParameters, interrupt *int32) (*types.BlockWithReceipts, error) {
+		if built.Add(1) == 1 {
+			return candidateWithTip(1), nil
+		}
+		return nil, errBuild
+	}
+	param := &core.BlockBuilderParameters{Timestamp: uint64(time.Now().Add(time.Hour).Unix())}
+	b := NewBlockBuilder(build, param, time.Millisecond)
+	for built.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// failed rebuild doesn't discard already built candidate
+	result, err := b.Stop()
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	_, err = NewBlockBuilder(func(*core.BlockBuilderParameters, *int32) (*types.BlockWithReceipts, error) {
+		return nil, errBuild
+	}, param, time.Millisecond).Stop()
+	require.ErrorIs(t, err, errBuild)
+}
+
+func TestBlockBuilderInclusionList(t *testing.T) {
+	t.Parallel()
+	ilTxn := types.NewTransaction(1, common.Address{1}, uint256.NewInt(0), 21_000, uint256.NewInt(1), nil)
+	var built atomic.Int32
+	withInclusionList := make(chan struct{})
+	build := func(param *core.BlockBuilderParameters, interrupt *int32) (*types.BlockWithReceipts, error) {
+		built.Add(1)
+		if len(param.InclusionList) == 0 {
+			return candidateWithTip(3), nil
+		}
+		defer close(withInclusionList)
+		return candidateWithTip(1), nil // less valuable than candidate without inclusion list
+	}
+
+	// zero interval: only inclusion list triggers rebuild
+	param := &core.BlockBuilderParameters{Timestamp: uint64(time.Now().Add(time.Hour).Unix())}
+	b := NewBlockBuilder(build, param, 0)
+	for b.Block() == nil {
+		time.Sleep(time.Millisecond)
+	}
+	b.SetInclusionList(types.Transactions{ilTxn})
+	<-withInclusionList
+
+	result, err := b.Stop()
+	require.NoError(t, err)
+	require.Equal(t, uint256.NewInt(21_000), BlockValue(result, uint256.NewInt(0)))
+	require.Equal(t, int32(2), built.Load())
+	require.Empty(t, param.InclusionList)
+}
@@ -50,6 +50,7 @@ func (e *EthereumExecutionModule) evictOldBuilders() {
 
 	// remove old builders so that at most MaxBuilders - 1 remain
 	for i := 0; i <= len(e.builders)-engine_helpers.MaxBuilders; i++ {
+		e.builders[ids[i]].Interrupt()
 		delete(e.builders, ids[i])
 	}
 }
@@ -99,7 +100,7 @@ func (e *EthereumExecutionModule) AssembleBlock(ctx context.Context, req *execut
 	param.PayloadId = e.nextPayloadId
 	e.lastParameters = &param
 
-	e.builders[e.nextPayloadId] = builder.NewBlockBuilder(e.builderFunc, &param)
+	e.builders[e.nextPayloadId] = builder.NewBlockBuilder(e.builderFunc, &param, e.builderRebuildInterval)
 	e.logger.Info("[ForkChoiceUpdated] BlockBuilder added", "payload", e.nextPayloadId)
 
 	return &execution.AssembleBlockResponse{
@@ -108,17 +109,37 @@ func (e *EthereumExecutionModule) AssembleBlock(ctx context.Context, req *execut
 	}, nil
 }
 
-// The expected value to be received by the feeRecipient in wei
-func blockValue(br *types.BlockWithReceipts, baseFee *uint256.Int) *uint256.Int {
-	blockValue := uint256.NewInt(0)
-	txs := br.Block.Transactions()
-	for i := range txs {
-		gas := new(uint256.Int).SetUint64(br.Receipts[i].GasUsed)
-		effectiveTip := txs[i].GetEffectiveGasTip(baseFee)
-		txValue := new(uint256.Int).Mul(gas, effectiveTip)
-		blockValue.Add(blockValue, txValue)
-	}
-	return blockValue
+// UpdateInclusionList - block being assembled is rebuilt with transactions of inclusion list (EIP-7805) ahead of txpool ones.
+// Transactions which can't be decoded are ignored
+func (e *EthereumExecutionModule) UpdateInclusionList(ctx context.Context, req *execution.UpdateInclusionListRequest) (*execution.UpdateInclusionListResponse, error) {
+	if !e.semaphore.TryAcquire(1) {
+		return &execution.UpdateInclusionListResponse{
+			Busy: true,
+		}, nil
+	}
+	defer e.semaphore.Release(1)
+	blockBuilder, ok := e.builders[req.Id]
+	if !ok {
+		return &execution.UpdateInclusionListResponse{
+			Found: false,
+		}, nil
+	}
+
+	inclusionList := make(types.Transactions, 0, len(req.InclusionList))
+	for _, encoded := range req.InclusionList {
+		txn, err := types.DecodeTransaction(encoded)
+		if err != nil {
+			e.logger.Debug("[UpdateInclusionList] skipping invalid transaction", "payload", req.Id, "err", err)
+			continue
+		}
+		inclusionList = append(inclusionList, txn)
+	}
+	blockBuilder.SetInclusionList(inclusionList)
+	e.logger.Debug("[UpdateInclusionList] rebuilding block", "payload", req.Id, "txs", len(inclusionList))
+
+	return &execution.UpdateInclusionListResponse{
+		Found: true,
+	}, nil
 }
 
 func (e *EthereumExecutionModule) GetAssembledBlock(ctx context.Context, req *execution.GetAssembledBlockRequest) (*execution.GetAssembledBlockResponse, error) {
@@ -129,14 +150,14 @@ func (e *EthereumExecutionModule) GetAssembledBlock(ctx context.Context, req *ex
 	}
 	defer e.semaphore.Release(1)
 	payloadId := req.Id
-	builder, ok := e.builders[payloadId]
+	blockBuilder, ok := e.builders[payloadId]
 	if !ok {
 		return &execution.GetAssembledBlockResponse{
 			Busy: false,
 		}, nil
 	}
 
-	blockWithReceipts, err := builder.Stop()
+	blockWithReceipts, err := blockBuilder.Stop()
 	if err != nil {
 		e.logger.Error("Failed to build PoS block", "err", err)
 		return nil, err
@@ -180,7 +201,7 @@ func (e *EthereumExecutionModule) GetAssembledBlock(ctx context.Context, req *ex
 		payload.ExcessBlobGas = header.ExcessBlobGas
 	}
 
-	blockValue := blockValue(blockWithReceipts, baseFee)
+	blockValue := builder.BlockValue(blockWithReceipts, baseFee)
 
 	blobsBundle := &types2.BlobsBundleV1{}
 	for i, txn := range block.Transactions() {
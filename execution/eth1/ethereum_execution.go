@@ -22,6 +22,7 @@ import (
 	"math/big"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"golang.org/x/sync/semaphore"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -104,10 +105,11 @@ type EthereumExecutionModule struct {
 
 	logger log.Logger
 	// Block building
-	nextPayloadId  uint64
-	lastParameters *core.BlockBuilderParameters
-	builderFunc    builder.BlockBuilderFunc
-	builders       map[uint64]*builder.BlockBuilder
+	nextPayloadId          uint64
+	lastParameters         *core.BlockBuilderParameters
+	builderFunc            builder.BlockBuilderFunc
+	builderRebuildInterval time.Duration // 0 - payload is built once
+	builders               map[uint64]*builder.BlockBuilder
 
 	// Changes accumulator
 	hook                *stages.Hook
@@ -131,7 +133,7 @@ type EthereumExecutionModule struct {
 
 func NewEthereumExecutionModule(blockReader services.FullBlockReader, db kv.TemporalRwDB,
 	executionPipeline *stagedsync.Sync, forkValidator *engine_helpers.ForkValidator,
-	config *chain.Config, builderFunc builder.BlockBuilderFunc,
+	config *chain.Config, builderFunc builder.BlockBuilderFunc, builderRebuildInterval time.Duration,
 	hook *stages.Hook, accumulator *shards.Accumulator,
 	recentLogs *shards.RecentLogs,
 	stateChangeConsumer shards.StateChangeConsumer,
@@ -140,22 +142,23 @@ func NewEthereumExecutionModule(blockReader services.FullBlockReader, db kv.Temp
 	ctx context.Context,
 ) *EthereumExecutionModule {
 	return &EthereumExecutionModule{
-		blockReader:         blockReader,
-		db:                  db,
-		executionPipeline:   executionPipeline,
-		logger:              logger,
-		forkValidator:       forkValidator,
-		builders:            make(map[uint64]*builder.BlockBuilder),
-		builderFunc:         builderFunc,
-		config:              config,
-		semaphore:           semaphore.NewWeighted(1),
-		hook:                hook,
-		accumulator:         accumulator,
-		recentLogs:          recentLogs,
-		stateChangeConsumer: stateChangeConsumer,
-		engine:              engine,
-		syncCfg:             syncCfg,
-		bacgroundCtx:        ctx,
+		blockReader:            blockReader,
+		db:                     db,
+		executionPipeline:      executionPipeline,
+		logger:                 logger,
+		forkValidator:          forkValidator,
+		builders:               make(map[uint64]*builder.BlockBuilder),
+		builderFunc:            builderFunc,
+		builderRebuildInterval: builderRebuildInterval,
+		config:                 config,
+		semaphore:              semaphore.NewWeighted(1),
+		hook:                   hook,
+		accumulator:            accumulator,
+		recentLogs:             recentLogs,
+		stateChangeConsumer:    stateChangeConsumer,
+		engine:                 engine,
+		syncCfg:                syncCfg,
+		bacgroundCtx:           ctx,
 	}
 }
 
@@ -123,6 +123,11 @@ func (rw *Worker) ResetState(rs *state.ParallelExecutionState, accumulator *shar
 	rw.stateWriter = state.NewWriter(rs.TemporalPutDel(), accumulator, 0)
 }
 
+// SetProfiler attaches EVM profiler to the transactions executed by the worker, nil detaches it
+func (rw *Worker) SetProfiler(profiler *vm.Profiler) {
+	rw.vmCfg.Profiler = profiler
+}
+
 func (rw *Worker) SetGaspool(gp *core.GasPool) {
 	rw.taskGasPool = gp
 }
@@ -311,6 +316,7 @@ func (rw *Worker) RunTxTaskNoLock(txTask *state.TxTask, isMining, skipPostEvalua
 			}
 		} else {
 			txTask.Failed = applyRes.Failed()
+			txTask.ExecutionErr = applyRes.Err
 			txTask.GasUsed = applyRes.GasUsed
 			// Update the state with pending changes
 			ibs.SoftFinalise()
@@ -413,7 +419,7 @@ func (rw *Worker) execAATxn(txTask *state.TxTask) {
 	log.Info("🚀[aa] executed AA bundle transaction", "txIndex", txTask.TxIndex, "status", status, "gasUsed", gasUsed)
 }
 
-func NewWorkersPool(lock sync.Locker, accumulator *shards.Accumulator, logger log.Logger, hooks *tracing.Hooks, ctx context.Context, background bool, chainDb kv.RoDB, rs *state.ParallelExecutionState, in *state.QueueWithRetry, blockReader services.FullBlockReader, chainConfig *chain.Config, genesis *types.Genesis, engine consensus.Engine, workerCount int, dirs datadir.Dirs, isMining bool) (reconWorkers []*Worker, applyWorker *Worker, rws *state.ResultsQueue, clear func(), wait func()) {
+func NewWorkersPool(lock sync.Locker, accumulator *shards.Accumulator, logger log.Logger, hooks *tracing.Hooks, profiler *vm.Profiler, ctx context.Context, background bool, chainDb kv.RoDB, rs *state.ParallelExecutionState, in *state.QueueWithRetry, blockReader services.FullBlockReader, chainConfig *chain.Config, genesis *types.Genesis, engine consensus.Engine, workerCount int, dirs datadir.Dirs, isMining bool) (reconWorkers []*Worker, applyWorker *Worker, rws *state.ResultsQueue, clear func(), wait func()) {
 	reconWorkers = make([]*Worker, workerCount)
 
 	resultChSize := workerCount * 8
@@ -426,6 +432,7 @@ func NewWorkersPool(lock sync.Locker, accumulator *shards.Accumulator, logger lo
 		for i := 0; i < workerCount; i++ {
 			reconWorkers[i] = NewWorker(lock, logger, hooks, ctx, background, chainDb, in, blockReader, chainConfig, genesis, rws, engine, dirs, isMining)
 			reconWorkers[i].ResetState(rs, accumulator)
+			reconWorkers[i].SetProfiler(profiler)
 		}
 		if background {
 			for i := 0; i < workerCount; i++ {
@@ -160,7 +160,7 @@ var (
 		EventRangeExtractor{},
 		[]snaptype.Index{Indexes.BorTxnHash},
 		snaptype.IndexBuilderFunc(
-			func(ctx context.Context, sn snaptype.FileInfo, salt uint32, chainConfig *chain.Config, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
+			func(ctx context.Context, sn snaptype.FileInfo, salt uint32, chainConfig *chain.Config, tmpDir string, workers int, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
 				defer func() {
 					if rec := recover(); rec != nil {
 						err = fmt.Errorf("BorEventsIdx: at=%d-%d, %v, %s", sn.From, sn.To, rec, dbg.Stack())
@@ -203,6 +203,7 @@ var (
 					TmpDir:     tmpDir,
 					IndexFile:  filepath.Join(sn.Dir(), snaptype.IdxFileName(sn.Version, sn.From, sn.To, Enums.Events.String())),
 					BaseDataID: baseEventId,
+					Workers:    workers,
 				}, logger)
 				if err != nil {
 					return err
@@ -263,7 +264,7 @@ var (
 			}),
 		[]snaptype.Index{Indexes.BorSpanId},
 		snaptype.IndexBuilderFunc(
-			func(ctx context.Context, sn snaptype.FileInfo, salt uint32, _ *chain.Config, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
+			func(ctx context.Context, sn snaptype.FileInfo, salt uint32, _ *chain.Config, tmpDir string, workers int, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
 				d, err := seg.NewDecompressor(sn.Path)
 
 				if err != nil {
@@ -273,7 +274,7 @@ var (
 
 				baseSpanId := uint64(SpanIdAt(sn.From))
 
-				return buildValueIndex(ctx, sn, salt, d, baseSpanId, tmpDir, p, lvl, logger)
+				return buildValueIndex(ctx, sn, salt, d, baseSpanId, tmpDir, workers, p, lvl, logger)
 			}),
 	)
 
@@ -312,7 +313,7 @@ var (
 			}),
 		[]snaptype.Index{Indexes.BorCheckpointId},
 		snaptype.IndexBuilderFunc(
-			func(ctx context.Context, sn snaptype.FileInfo, salt uint32, _ *chain.Config, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
+			func(ctx context.Context, sn snaptype.FileInfo, salt uint32, _ *chain.Config, tmpDir string, workers int, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
 				d, err := seg.NewDecompressor(sn.Path)
 
 				if err != nil {
@@ -335,7 +336,7 @@ var (
 					firstCheckpointId = uint64(firstCheckpoint.Id)
 				}
 
-				return buildValueIndex(ctx, sn, salt, d, firstCheckpointId, tmpDir, p, lvl, logger)
+				return buildValueIndex(ctx, sn, salt, d, firstCheckpointId, tmpDir, workers, p, lvl, logger)
 			}),
 	)
 
@@ -374,7 +375,7 @@ var (
 			}),
 		[]snaptype.Index{Indexes.BorMilestoneId},
 		snaptype.IndexBuilderFunc(
-			func(ctx context.Context, sn snaptype.FileInfo, salt uint32, _ *chain.Config, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
+			func(ctx context.Context, sn snaptype.FileInfo, salt uint32, _ *chain.Config, tmpDir string, workers int, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
 				d, err := seg.NewDecompressor(sn.Path)
 
 				if err != nil {
@@ -397,7 +398,7 @@ var (
 					}
 				}
 
-				return buildValueIndex(ctx, sn, salt, d, firstMilestoneId, tmpDir, p, lvl, logger)
+				return buildValueIndex(ctx, sn, salt, d, firstMilestoneId, tmpDir, workers, p, lvl, logger)
 			}),
 	)
 )
@@ -470,7 +471,7 @@ func extractValueRange(ctx context.Context, table string, valueFrom, valueTo uin
 	return valueTo, nil
 }
 
-func buildValueIndex(ctx context.Context, sn snaptype.FileInfo, salt uint32, d *seg.Decompressor, baseId uint64, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
+func buildValueIndex(ctx context.Context, sn snaptype.FileInfo, salt uint32, d *seg.Decompressor, baseId uint64, tmpDir string, workers int, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
 	defer func() {
 		if rec := recover(); rec != nil {
 			err = fmt.Errorf("BorSpansIdx: at=%d-%d, %v, %s", sn.From, sn.To, rec, dbg.Stack())
@@ -486,6 +487,7 @@ func buildValueIndex(ctx context.Context, sn snaptype.FileInfo, salt uint32, d *
 		IndexFile:  filepath.Join(sn.Dir(), sn.Type.IdxFileName(sn.Version, sn.From, sn.To)),
 		BaseDataID: baseId,
 		Salt:       &salt,
+		Workers:    workers,
 	}, logger)
 	if err != nil {
 		return err
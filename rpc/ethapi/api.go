@@ -483,6 +483,7 @@ type RPCTransaction struct {
 	YParity              *hexutil.Big               `json:"yParity,omitempty"`
 	R                    *hexutil.Big               `json:"r"`
 	S                    *hexutil.Big               `json:"s"`
+	Private              bool                       `json:"private,omitempty"` // txpool_content: submitted by eth_sendPrivateTransaction
 }
 
 // NewRPCTransaction returns a transaction that will serialize to the RPC
@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	jsoniter "github.com/json-iterator/go"
+	"golang.org/x/sync/semaphore"
 
 	"github.com/erigontech/erigon-db/rawdb"
 	"github.com/erigontech/erigon-lib/common"
@@ -34,6 +35,7 @@ import (
 	"github.com/erigontech/erigon-lib/rlp"
 	"github.com/erigontech/erigon-lib/types/accounts"
 	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/eth/stagedsync/stages"
 	tracersConfig "github.com/erigontech/erigon/eth/tracers/config"
 	"github.com/erigontech/erigon/rpc"
@@ -66,6 +68,7 @@ type PrivateDebugAPI interface {
 	GetRawReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]hexutil.Bytes, error)
 	GetBadBlocks(ctx context.Context) ([]map[string]interface{}, error)
 	GetRawTransaction(ctx context.Context, hash common.Hash) (hexutil.Bytes, error)
+	GetContractCFG(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*ContractCFGResult, error)
 }
 
 // PrivateDebugAPIImpl is implementation of the PrivateDebugAPI interface based on remote Db access
@@ -73,14 +76,19 @@ type PrivateDebugAPIImpl struct {
 	*BaseAPI
 	db     kv.TemporalRoDB
 	GasCap uint64
+	cfgSem *semaphore.Weighted // limits concurrent debug_getContractCFG analyses
 }
 
+// maxConcurrentCfgAnalysis - CFG recovery is CPU-heavy, don't let RPC clients occupy all cores
+const maxConcurrentCfgAnalysis = 2
+
 // NewPrivateDebugAPI returns PrivateDebugAPIImpl instance
 func NewPrivateDebugAPI(base *BaseAPI, db kv.TemporalRoDB, gascap uint64) *PrivateDebugAPIImpl {
 	return &PrivateDebugAPIImpl{
 		BaseAPI: base,
 		db:      db,
 		GasCap:  gascap,
+		cfgSem:  semaphore.NewWeighted(maxConcurrentCfgAnalysis),
 	}
 }
 
@@ -536,3 +544,45 @@ func (api *PrivateDebugAPIImpl) GetRawTransaction(ctx context.Context, txnHash c
 
 	return nil, nil
 }
+
+// ContractCFGResult - control flow graph of contract code, also rendered in Graphviz format
+type ContractCFGResult struct {
+	*vm.ContractCfg
+	Dot string `json:"dot"`
+}
+
+// GetContractCFG implements debug_getContractCFG - recovers control flow graph of the code of the account at the given block
+// by abstract interpretation. Jumps which the analysis couldn't resolve are listed and highlighted.
+func (api *PrivateDebugAPIImpl) GetContractCFG(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*ContractCFGResult, error) {
+	code, err := api.readCode(ctx, address, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if err := api.cfgSem.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+	defer api.cfgSem.Release(1)
+	cfg := vm.AnalyzeCfg(ctx, code, vm.RpcCfgLimits)
+	return &ContractCFGResult{ContractCfg: cfg, Dot: cfg.Dot()}, nil
+}
+
+func (api *PrivateDebugAPIImpl) readCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) ([]byte, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := rpchelper.CreateStateReader(ctx, tx, api._blockReader, blockNrOrHash, 0, api.filters, api.stateCache, chainConfig.ChainName)
+	if err != nil {
+		return nil, err
+	}
+	acc, err := reader.ReadAccountData(address)
+	if acc == nil || err != nil {
+		return nil, err
+	}
+	return reader.ReadAccountCode(address)
+}
@@ -23,6 +23,7 @@ import (
 	"math/big"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	jsoniter "github.com/json-iterator/go"
@@ -310,6 +311,33 @@ func TestStorageRangeAt(t *testing.T) {
 
 }
 
+func TestGetContractCFG(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	result, err := api.GetContractCFG(m.Ctx, common.HexToAddress("0x537e697c7ab75a26f9ecf0ce810e3154dfcaaf44"), latest)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Blocks)
+	require.Equal(t, 0, result.Blocks[0].Entry)
+	require.NotEmpty(t, result.Edges)
+	require.Contains(t, result.Dot, "digraph")
+
+	// account without code
+	result, err = api.GetContractCFG(m.Ctx, common.HexToAddress("0x71562b71999873db5b286df957af199ec94617f7"), latest)
+	require.NoError(t, err)
+	require.Empty(t, result.Blocks)
+	require.True(t, result.Complete)
+
+	// all analysis slots are busy: request waits until its context is done
+	require.NoError(t, api.cfgSem.Acquire(m.Ctx, maxConcurrentCfgAnalysis))
+	defer api.cfgSem.Release(maxConcurrentCfgAnalysis)
+	ctx, cancel := context.WithTimeout(m.Ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = api.GetContractCFG(ctx, common.HexToAddress("0x537e697c7ab75a26f9ecf0ce810e3154dfcaaf44"), latest)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
 func TestAccountRange(t *testing.T) {
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
 	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
@@ -62,6 +62,7 @@ type EthAPI interface {
 	GetBlockByHash(ctx context.Context, hash rpc.BlockNumberOrHash, fullTx bool) (map[string]interface{}, error)
 	GetBlockTransactionCountByNumber(ctx context.Context, blockNr rpc.BlockNumber) (*hexutil.Uint, error)
 	GetBlockTransactionCountByHash(ctx context.Context, blockHash common.Hash) (*hexutil.Uint, error)
+	GetBlockAccessList(ctx context.Context, numberOrHash rpc.BlockNumberOrHash) (types.BlockAccessList, error)
 
 	// Transaction related (see ./eth_txs.go)
 	GetTransactionByHash(ctx context.Context, hash common.Hash) (*ethapi.RPCTransaction, error)
@@ -104,11 +105,13 @@ type EthAPI interface {
 	ChainId(ctx context.Context) (hexutil.Uint64, error) /* called eth_protocolVersion elsewhere */
 	ProtocolVersion(_ context.Context) (hexutil.Uint, error)
 	GasPrice(_ context.Context) (*hexutil.Big, error)
+	FeeEstimate(ctx context.Context, targetBlocks rpc.DecimalOrHex, confidence float64) (*feeEstimateResult, error)
 
 	// Sending related (see ./eth_call.go)
 	Call(ctx context.Context, args ethapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *ethapi.StateOverrides) (hexutil.Bytes, error)
 	EstimateGas(ctx context.Context, argsOrNil *ethapi.CallArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *ethapi.StateOverrides) (hexutil.Uint64, error)
 	SendRawTransaction(ctx context.Context, encodedTx hexutil.Bytes) (common.Hash, error)
+	SendPrivateTransaction(ctx context.Context, encodedTx hexutil.Bytes, opts *PrivateTxnOptions) (common.Hash, error)
 	SendTransaction(_ context.Context, txObject interface{}) (common.Hash, error)
 	Sign(ctx context.Context, _ common.Address, _ hexutil.Bytes) (hexutil.Bytes, error)
 	SignTransaction(_ context.Context, txObject interface{}) (common.Hash, error)
@@ -393,6 +396,7 @@ type APIImpl struct {
 	txPool                      txpool.TxpoolClient
 	mining                      txpool.MiningClient
 	gasCache                    *GasPriceCache
+	feeEstimatePool             *FeeEstimatePoolCache
 	db                          kv.TemporalRoDB
 	GasCap                      uint64
 	FeeCap                      float64
@@ -420,6 +424,7 @@ func NewEthAPI(base *BaseAPI, db kv.TemporalRoDB, eth rpchelper.ApiBackend, txPo
 		txPool:                      txPool,
 		mining:                      mining,
 		gasCache:                    NewGasPriceCache(),
+		feeEstimatePool:             NewFeeEstimatePoolCache(),
 		GasCap:                      gascap,
 		FeeCap:                      feecap,
 		AllowUnprotectedTxs:         allowUnprotectedTxs,
@@ -18,6 +18,7 @@ package jsonrpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
@@ -32,6 +33,8 @@ import (
 	"github.com/erigontech/erigon/core"
 	"github.com/erigontech/erigon/core/state"
 	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/consensuschain"
+	"github.com/erigontech/erigon/execution/consensus"
 	bortypes "github.com/erigontech/erigon/polygon/bor/types"
 	borrawdb "github.com/erigontech/erigon/polygon/rawdb"
 	"github.com/erigontech/erigon/rpc"
@@ -445,6 +448,55 @@ func (api *APIImpl) GetBlockTransactionCountByHash(ctx context.Context, blockHas
 	return &numOfTx, nil
 }
 
+// GetBlockAccessList implements eth_getBlockAccessList. Returns accounts and storage slots accessed by the block
+// with their changes per block access index (EIP-7928), which are recovered by re-execution of the block.
+func (api *APIImpl) GetBlockAccessList(ctx context.Context, numberOrHash rpc.BlockNumberOrHash) (types.BlockAccessList, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNum, blockHash, _, err := rpchelper.GetBlockNumber(ctx, numberOrHash, tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	if blockNum == 0 {
+		return nil, errors.New("genesis block is not executed")
+	}
+	block, err := api.blockWithSenders(ctx, tx, blockHash, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, nil
+	}
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	engine, ok := api.engine().(consensus.Engine)
+	if !ok {
+		return nil, errors.New("engine is not consensus.Engine")
+	}
+
+	// state before the system txn in beginning of the block
+	stateReader, err := rpchelper.CreateHistoryStateReader(tx, api._txNumReader, blockNum, -1, chainConfig.ChainName)
+	if err != nil {
+		return nil, err
+	}
+	getHeader := func(hash common.Hash, number uint64) *types.Header {
+		h, e := api._blockReader.Header(ctx, tx, hash, number)
+		if e != nil {
+			log.Error("getHeader error", "number", number, "hash", hash, "err", e)
+		}
+		return h
+	}
+	logger := log.New("eth_getBlockAccessList")
+	chainReader := consensuschain.NewReader(chainConfig, tx, api._blockReader, logger)
+	return core.ExecuteBlockAccessList(chainConfig, vm.Config{}, core.GetHashFn(block.HeaderNoCopy(), getHeader), engine, block, stateReader, chainReader, logger)
+}
+
 func (api *APIImpl) blockByNumber(ctx context.Context, number rpc.BlockNumber, tx kv.Tx) (*types.Block, error) {
 	if number != rpc.PendingBlockNumber {
 		return api.blockByRPCNumber(ctx, number, tx)
@@ -21,6 +21,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -318,3 +319,48 @@ func TestGetBlockTransactionCountByNumber_ZeroTx(t *testing.T) {
 
 	assert.Equal(t, expectedAmount, *txCount)
 }
+
+func TestGetBlockAccessList(t *testing.T) {
+	m, chain, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+
+	// block 1 transfers 0.001 ETH to 0x01 for free
+	block := chain.Blocks[0]
+	txn := block.Transactions()[0]
+	sender, err := txn.Sender(*types.LatestSignerForChainID(nil))
+	require.NoError(t, err)
+	bal, err := api.GetBlockAccessList(m.Ctx, rpc.BlockNumberOrHashWithNumber(1))
+	require.NoError(t, err)
+	require.NoError(t, bal.Validate(1))
+
+	changes := map[common.Address]*types.AccountChanges{}
+	for _, account := range bal {
+		changes[account.Address] = account
+	}
+	require.Len(t, changes, 3)
+	require.Equal(t, []*types.NonceChange{{Index: 1, Nonce: 1}}, changes[sender].NonceChanges)
+	require.Equal(t, []*types.BalanceChange{{Index: 1, Balance: *uint256.NewInt(9_000000000000000000 - 1_000000000000000)}}, changes[sender].BalanceChanges)
+	require.Equal(t, []*types.BalanceChange{{Index: 1, Balance: *uint256.NewInt(1_000000000000000)}}, changes[*txn.GetTo()].BalanceChanges)
+	require.Len(t, changes[block.Coinbase()].BalanceChanges, 1, "block reward")
+	require.Equal(t, hexutil.Uint64(2), changes[block.Coinbase()].BalanceChanges[0].Index)
+
+	// block 3 deploys token contract, block 4 mints tokens
+	bal, err = api.GetBlockAccessList(m.Ctx, rpc.BlockNumberOrHashWithNumber(3))
+	require.NoError(t, err)
+	var code []*types.CodeChange
+	for _, account := range bal {
+		code = append(code, account.CodeChanges...)
+	}
+	require.Len(t, code, 1)
+	require.Equal(t, hexutil.Uint64(1), code[0].Index)
+	require.NotEmpty(t, code[0].Code)
+
+	bal, err = api.GetBlockAccessList(m.Ctx, rpc.BlockNumberOrHashWithNumber(4))
+	require.NoError(t, err)
+	var slots []*types.SlotChanges
+	for _, account := range bal {
+		slots = append(slots, account.StorageChanges...)
+	}
+	require.NotEmpty(t, slots)
+	require.NoError(t, bal.Validate(len(chain.Blocks[3].Transactions())))
+}
@@ -0,0 +1,254 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/eth/gasprice"
+	"github.com/erigontech/erigon/execution/consensus/misc"
+	"github.com/erigontech/erigon/rpc"
+)
+
+// maxFeeEstimateTargetBlocks - as many blocks as eth_feeHistory returns
+const maxFeeEstimateTargetBlocks = 1024
+
+type feeEstimate struct {
+	MaxFeePerGas         *hexutil.Big `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big `json:"maxPriorityFeePerGas"`
+	MaxFeePerBlobGas     *hexutil.Big `json:"maxFeePerBlobGas,omitempty"`
+}
+
+type feeEstimateResult struct {
+	BaseFee     *hexutil.Big `json:"baseFeePerGas"`               // of next block
+	BlobBaseFee *hexutil.Big `json:"baseFeePerBlobGas,omitempty"` // of next block, after Cancun
+	PoolTip     *hexutil.Big `json:"pendingPoolTip"`              // tip to outbid txpool transactions, which don't fit into target blocks
+	Slow        *feeEstimate `json:"slow"`
+	Standard    *feeEstimate `json:"standard"`
+	Fast        *feeEstimate `json:"fast"`
+}
+
+// FeeEstimate implements eth_feeEstimate. Returns fee suggestions for transaction to be included within targetBlocks.
+// Priority fee of urgency level is median of rewards of last targetBlocks blocks at percentile: confidence/2 for slow,
+// confidence for standard and (confidence+100)/2 for fast. If pending and basefee sub-pools of txpool don't fit into
+// targetBlocks, priority fee is raised to outbid transactions which don't fit. Max fees cover base fee growth during
+// targetBlocks full blocks, but at most twice the next base fee: wallets don't have to reserve more.
+func (api *APIImpl) FeeEstimate(ctx context.Context, targetBlocks rpc.DecimalOrHex, confidence float64) (*feeEstimateResult, error) {
+	if targetBlocks < 1 || targetBlocks > maxFeeEstimateTargetBlocks {
+		return nil, fmt.Errorf("targetBlocks must be in range [1, %d]: %d", maxFeeEstimateTargetBlocks, targetBlocks)
+	}
+	if confidence <= 0 || confidence > 100 {
+		return nil, fmt.Errorf("confidence must be in range (0, 100]: %f", confidence)
+	}
+
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	header := rawdb.ReadCurrentHeader(tx)
+	if header == nil {
+		return nil, errors.New("no current header")
+	}
+	config, err := api.BaseAPI.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	oracle := gasprice.NewOracle(NewGasPriceOracleBackend(tx, api.BaseAPI), ethconfig.Defaults.GPO, api.gasCache, api.logger.New("app", "gasPriceOracle"))
+	percentiles := []float64{confidence / 2, confidence, (confidence + 100) / 2}
+	_, reward, _, _, _, _, err := oracle.FeeHistory(ctx, int(targetBlocks), rpc.LatestBlockNumber, percentiles)
+	if err != nil {
+		return nil, err
+	}
+
+	baseFee, maxBaseFee := nextBaseFees(config, header, uint64(targetBlocks))
+	poolTip := new(uint256.Int)
+	if api.txPool != nil {
+		tips, err := api.feeEstimatePool.get(ctx, api.txPool, header.Hash(), uint256.MustFromBig(baseFee))
+		if err != nil {
+			return nil, err
+		}
+		poolTip = clearingTip(tips, uint64(targetBlocks)*header.GasLimit)
+	}
+
+	result := &feeEstimateResult{BaseFee: (*hexutil.Big)(baseFee), PoolTip: (*hexutil.Big)(poolTip.ToBig())}
+	var maxBlobBaseFee *big.Int
+	if blobBaseFee, maxBlobFee, ok, err := nextBlobBaseFees(config, header, uint64(targetBlocks)); err != nil {
+		return nil, err
+	} else if ok {
+		result.BlobBaseFee, maxBlobBaseFee = (*hexutil.Big)(blobBaseFee.ToBig()), maxBlobFee.ToBig()
+	}
+	levels := []**feeEstimate{&result.Slow, &result.Standard, &result.Fast}
+	for i, level := range levels {
+		tips := make([]*big.Int, 0, len(reward))
+		for _, blockRewards := range reward {
+			tips = append(tips, blockRewards[i])
+		}
+		tip := medianBig(tips)
+		if poolTip.ToBig().Cmp(tip) > 0 {
+			tip = poolTip.ToBig()
+		}
+		*level = &feeEstimate{
+			MaxFeePerGas:         (*hexutil.Big)(new(big.Int).Add(maxBaseFee, tip)),
+			MaxPriorityFeePerGas: (*hexutil.Big)(tip),
+		}
+		if maxBlobBaseFee != nil {
+			(*level).MaxFeePerBlobGas = (*hexutil.Big)(maxBlobBaseFee)
+		}
+	}
+	return result, nil
+}
+
+// nextBaseFees - base fee of next block and the highest one after blocks full blocks, capped by twice the next one
+func nextBaseFees(config *chain.Config, header *types.Header, blocks uint64) (next *big.Int, highest *big.Int) {
+	if !config.IsLondon(header.Number.Uint64() + 1) {
+		return new(big.Int), new(big.Int)
+	}
+	next = misc.CalcBaseFee(config, header)
+	limit := new(big.Int).Lsh(next, 1)
+	highest = next
+	parent := &types.Header{Number: new(big.Int).Add(header.Number, big.NewInt(1)), GasLimit: header.GasLimit, GasUsed: header.GasLimit, BaseFee: next}
+	for i := uint64(1); i < blocks && highest.Cmp(limit) < 0; i++ {
+		highest = misc.CalcBaseFee(config, parent)
+		parent = &types.Header{Number: new(big.Int).Add(parent.Number, big.NewInt(1)), GasLimit: header.GasLimit, GasUsed: header.GasLimit, BaseFee: highest}
+	}
+	if highest.Cmp(limit) > 0 {
+		highest = limit
+	}
+	return next, highest
+}
+
+// nextBlobBaseFees - blob base fee of next block and the highest one after blocks with max blobs, capped by twice the next one.
+// false before Cancun
+func nextBlobBaseFees(config *chain.Config, header *types.Header, blocks uint64) (next *uint256.Int, highest *uint256.Int, ok bool, err error) {
+	nextBlockTime := header.Time + config.SecondsPerSlot()
+	if !config.IsCancun(nextBlockTime) || header.ExcessBlobGas == nil {
+		return nil, nil, false, nil
+	}
+	excessBlobGas := misc.CalcExcessBlobGas(config, header, nextBlockTime)
+	if next, err = misc.GetBlobGasPrice(config, excessBlobGas, nextBlockTime); err != nil {
+		return nil, nil, false, err
+	}
+	limit := new(uint256.Int).Lsh(next, 1)
+	highest = next
+	for i := uint64(1); i < blocks && highest.Lt(limit); i++ {
+		blockTime := nextBlockTime + i*config.SecondsPerSlot()
+		blobGasUsed := config.GetMaxBlobGasPerBlock(blockTime)
+		excessBlobGas = misc.CalcExcessBlobGas(config, &types.Header{ExcessBlobGas: &excessBlobGas, BlobGasUsed: &blobGasUsed}, blockTime)
+		if highest, err = misc.GetBlobGasPrice(config, excessBlobGas, blockTime); err != nil {
+			return nil, nil, false, err
+		}
+	}
+	if highest.Gt(limit) {
+		highest = limit
+	}
+	return next, highest, true, nil
+}
+
+// pooledTip - effective tip and gas limit of txpool transaction
+type pooledTip struct {
+	tip *uint256.Int
+	gas uint64
+}
+
+// FeeEstimatePoolCache - pending and basefee sub-pools as pooledTip's at base fee of the next block. Txpool is read
+// once per block: transactions added since are not counted until the next block
+type FeeEstimatePoolCache struct {
+	tips       []pooledTip
+	latestHash common.Hash
+	mtx        sync.Mutex
+}
+
+func NewFeeEstimatePoolCache() *FeeEstimatePoolCache {
+	return &FeeEstimatePoolCache{}
+}
+
+// get - poolTips of txpool, cached for block with hash blockHash
+func (c *FeeEstimatePoolCache) get(ctx context.Context, txPool txpool.TxpoolClient, blockHash common.Hash, baseFee *uint256.Int) ([]pooledTip, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.tips != nil && c.latestHash == blockHash {
+		return c.tips, nil
+	}
+	reply, err := txPool.All(ctx, &txpool.AllRequest{})
+	if err != nil {
+		return nil, err
+	}
+	txns := make([]types.Transaction, 0, len(reply.Txs))
+	for _, txn := range reply.Txs {
+		if txn.TxnType != txpool.AllReply_PENDING && txn.TxnType != txpool.AllReply_BASE_FEE {
+			continue
+		}
+		decoded, err := types.DecodeWrappedTransaction(txn.RlpTx)
+		if err != nil {
+			return nil, fmt.Errorf("decoding transaction from: %x: %w", txn.RlpTx, err)
+		}
+		txns = append(txns, decoded)
+	}
+	c.tips, c.latestHash = poolTips(txns, baseFee), blockHash
+	return c.tips, nil
+}
+
+// poolTips - effective tips of transactions, the highest first. Transactions with fee cap below base fee are not counted
+func poolTips(txns []types.Transaction, baseFee *uint256.Int) []pooledTip {
+	tips := make([]pooledTip, 0, len(txns))
+	for _, txn := range txns {
+		if txn.GetFeeCap().Lt(baseFee) {
+			continue
+		}
+		tips = append(tips, pooledTip{tip: txn.GetEffectiveGasTip(baseFee), gas: txn.GetGasLimit()})
+	}
+	slices.SortFunc(tips, func(a, b pooledTip) int { return b.tip.Cmp(a.tip) })
+	return tips
+}
+
+// clearingTip - tip of the best transaction, which doesn't fit into gas capacity: new transaction has to match it.
+// 0 if all transactions fit
+func clearingTip(tips []pooledTip, capacity uint64) *uint256.Int {
+	var gas uint64
+	for _, tip := range tips {
+		gas += tip.gas
+		if gas > capacity {
+			return tip.tip
+		}
+	}
+	return new(uint256.Int)
+}
+
+func medianBig(values []*big.Int) *big.Int {
+	if len(values) == 0 {
+		return new(big.Int)
+	}
+	sorted := slices.Clone(values)
+	slices.SortFunc(sorted, func(a, b *big.Int) int { return a.Cmp(b) })
+	return new(big.Int).Set(sorted[len(sorted)/2])
+}
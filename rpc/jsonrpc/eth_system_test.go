@@ -17,16 +17,20 @@
 package jsonrpc
 
 import (
+	"bytes"
 	"context"
 	"math"
 	"math/big"
 	"testing"
 
 	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/crypto"
+	txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/core"
@@ -104,3 +108,121 @@ func createGasPriceTestKV(t *testing.T, chainSize int) *mock.MockSentry {
 
 	return m
 }
+
+func TestFeeEstimate(t *testing.T) {
+	m := createGasPriceTestKV(t, 30)
+	defer m.DB.Close()
+	eth := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	ctx := context.Background()
+
+	_, err := eth.FeeEstimate(ctx, 0, 50)
+	require.Error(t, err)
+	_, err = eth.FeeEstimate(ctx, 10, 0)
+	require.Error(t, err)
+
+	res, err := eth.FeeEstimate(ctx, 10, 50)
+	require.NoError(t, err)
+	require.Zero(t, res.PoolTip.ToInt().Sign())
+	require.Positive(t, res.Standard.MaxPriorityFeePerGas.ToInt().Sign())
+	levels := []*feeEstimate{res.Slow, res.Standard, res.Fast}
+	for i, level := range levels {
+		if i > 0 {
+			require.LessOrEqual(t, levels[i-1].MaxPriorityFeePerGas.ToInt().Cmp(level.MaxPriorityFeePerGas.ToInt()), 0)
+		}
+		maxBaseFee := new(big.Int).Sub(level.MaxFeePerGas.ToInt(), level.MaxPriorityFeePerGas.ToInt())
+		require.GreaterOrEqual(t, maxBaseFee.Cmp(res.BaseFee.ToInt()), 0)
+		require.LessOrEqual(t, maxBaseFee.Cmp(new(big.Int).Lsh(res.BaseFee.ToInt(), 1)), 0)
+	}
+
+	next, err := eth.FeeEstimate(ctx, 1, 50)
+	require.NoError(t, err)
+	require.Zero(t, next.BaseFee.ToInt().Cmp(new(big.Int).Sub(next.Standard.MaxFeePerGas.ToInt(), next.Standard.MaxPriorityFeePerGas.ToInt())))
+}
+
+type countingTxPool struct {
+	txpool.TxpoolClient
+	reply *txpool.AllReply
+	calls int
+}
+
+func (p *countingTxPool) All(context.Context, *txpool.AllRequest, ...grpc.CallOption) (*txpool.AllReply, error) {
+	p.calls++
+	return p.reply, nil
+}
+
+func TestFeeEstimatePoolCache(t *testing.T) {
+	m := createGasPriceTestKV(t, 30)
+	defer m.DB.Close()
+	// doesn't fit into target blocks
+	txn := types.NewEIP1559Transaction(*uint256.MustFromBig(m.ChainConfig.ChainID), 0, common.Address{1}, uint256.NewInt(0), math.MaxUint32<<8,
+		nil, uint256.NewInt(1_000*common.GWei), uint256.NewInt(2_000*common.GWei), nil)
+	var buf bytes.Buffer
+	require.NoError(t, txn.MarshalBinary(&buf))
+	pool := &countingTxPool{reply: &txpool.AllReply{Txs: []*txpool.AllReply_Tx{{TxnType: txpool.AllReply_PENDING, RlpTx: buf.Bytes()}}}}
+	eth := NewEthAPI(newBaseApiForTest(m), m.DB, nil, pool, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	ctx := context.Background()
+
+	res, err := eth.FeeEstimate(ctx, 10, 50)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1_000*common.GWei), res.PoolTip.ToInt().Uint64())
+	require.Equal(t, res.PoolTip, res.Slow.MaxPriorityFeePerGas)
+
+	// txpool is read once per block
+	pool.reply = &txpool.AllReply{}
+	res, err = eth.FeeEstimate(ctx, 1, 50)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1_000*common.GWei), res.PoolTip.ToInt().Uint64())
+	require.Equal(t, 1, pool.calls)
+}
+
+func TestPoolClearingTip(t *testing.T) {
+	baseFee := uint256.NewInt(100)
+	txn := func(tip, feeCap, gas uint64) types.Transaction {
+		return &types.DynamicFeeTransaction{
+			CommonTx: types.CommonTx{GasLimit: gas},
+			TipCap:   uint256.NewInt(tip),
+			FeeCap:   uint256.NewInt(feeCap),
+		}
+	}
+	txns := []types.Transaction{
+		txn(5, 1000, 30_000),
+		txn(50, 1000, 30_000),
+		txn(20, 110, 30_000), // effective tip 10
+		txn(100, 99, 30_000), // below base fee
+	}
+	tips := poolTips(txns, baseFee)
+	require.Len(t, tips, 3)
+	require.Zero(t, clearingTip(tips, 90_000).Uint64())
+	require.Equal(t, uint64(5), clearingTip(tips, 89_999).Uint64())
+	require.Equal(t, uint64(10), clearingTip(tips, 59_999).Uint64())
+	require.Equal(t, uint64(50), clearingTip(tips, 29_999).Uint64())
+	require.Zero(t, clearingTip(poolTips(nil, baseFee), 0).Uint64())
+}
+
+func TestNextBaseFees(t *testing.T) {
+	config := chain.AllProtocolChanges
+	excessBlobGas, blobGasUsed := uint64(0), uint64(0)
+	header := &types.Header{Number: big.NewInt(10), GasLimit: 30_000_000, GasUsed: 15_000_000, BaseFee: big.NewInt(1000),
+		ExcessBlobGas: &excessBlobGas, BlobGasUsed: &blobGasUsed}
+	for _, tt := range []struct {
+		blocks  uint64
+		highest int64
+	}{{1, 1000}, {2, 1125}, {3, 1265}, {100, 2000}} {
+		next, highest := nextBaseFees(config, header, tt.blocks)
+		require.Equal(t, int64(1000), next.Int64())
+		require.Equal(t, tt.highest, highest.Int64(), "blocks %d", tt.blocks)
+	}
+
+	next, highest, ok, err := nextBlobBaseFees(config, header, 1)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, next, highest)
+	_, highest, ok, err = nextBlobBaseFees(config, header, 100)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, new(uint256.Int).Lsh(next, 1), highest)
+
+	_, _, ok, err = nextBlobBaseFees(chain.TestChainConfig, header, 1)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -10,10 +10,43 @@ import (
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	txPoolProto "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
 	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/rpc/rpchelper"
 )
 
 // SendRawTransaction implements eth_sendRawTransaction. Creates new message call transaction or a contract creation for previously-signed transactions.
 func (api *APIImpl) SendRawTransaction(ctx context.Context, encodedTx hexutil.Bytes) (common.Hash, error) {
+	return api.sendRawTransaction(ctx, &txPoolProto.AddRequest{RlpTxs: [][]byte{encodedTx}})
+}
+
+// PrivateTxnOptions - options of eth_sendPrivateTransaction
+type PrivateTxnOptions struct {
+	MaxBlockNumber *hexutil.Uint64 `json:"maxBlockNumber"` // transaction is dropped if not included until this block
+}
+
+// SendPrivateTransaction implements eth_sendPrivateTransaction. Same as eth_sendRawTransaction, but transaction is never
+// propagated to peers - it can be included only in blocks produced by this node.
+func (api *APIImpl) SendPrivateTransaction(ctx context.Context, encodedTx hexutil.Bytes, opts *PrivateTxnOptions) (common.Hash, error) {
+	req := &txPoolProto.AddRequest{RlpTxs: [][]byte{encodedTx}, Private: true}
+	if opts != nil && opts.MaxBlockNumber != nil {
+		tx, err := api.db.BeginTemporalRo(ctx)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		defer tx.Rollback()
+		latest, err := rpchelper.GetLatestBlockNumber(tx)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if uint64(*opts.MaxBlockNumber) <= latest {
+			return common.Hash{}, fmt.Errorf("maxBlockNumber %d must be greater than latest block %d", *opts.MaxBlockNumber, latest)
+		}
+		req.MaxBlockNumber = uint64(*opts.MaxBlockNumber)
+	}
+	return api.sendRawTransaction(ctx, req)
+}
+
+func (api *APIImpl) sendRawTransaction(ctx context.Context, req *txPoolProto.AddRequest) (common.Hash, error) {
+	encodedTx := req.RlpTxs[0]
 	txn, err := types.DecodeWrappedTransaction(encodedTx)
 	if err != nil {
 		return common.Hash{}, err
@@ -52,7 +85,7 @@ func (api *APIImpl) SendRawTransaction(ctx context.Context, encodedTx hexutil.By
 	}
 
 	hash := txn.Hash()
-	res, err := api.txPool.Add(ctx, &txPoolProto.AddRequest{RlpTxs: [][]byte{encodedTx}})
+	res, err := api.txPool.Add(ctx, req)
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -22,6 +22,7 @@ import (
 	"strconv"
 
 	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/gointerfaces"
@@ -29,12 +30,16 @@ import (
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/rpc/ethapi"
+	"github.com/erigontech/erigon/txnprovider/txpool"
 )
 
 // TxPoolAPI the interface for the txpool_ RPC commands
 type TxPoolAPI interface {
 	Content(ctx context.Context) (map[string]map[string]map[string]*ethapi.RPCTransaction, error)
 	ContentFrom(ctx context.Context, addr common.Address) (map[string]map[string]*ethapi.RPCTransaction, error)
+	GetTransactionStatus(ctx context.Context, hash common.Hash) (*TxnStatus, error)
+	Export(ctx context.Context, path string) (hexutil.Uint, error)
+	Import(ctx context.Context, path string) (map[string]hexutil.Uint, error)
 }
 
 // TxPoolAPIImpl data structure to store things needed for net_ commands
@@ -65,6 +70,7 @@ func (api *TxPoolAPIImpl) Content(ctx context.Context) (map[string]map[string]ma
 		"queued":  make(map[string]map[string]*ethapi.RPCTransaction),
 	}
 
+	private := make(map[common.Hash]struct{})
 	pending := make(map[common.Address][]types.Transaction, 8)
 	baseFee := make(map[common.Address][]types.Transaction, 8)
 	queued := make(map[common.Address][]types.Transaction, 8)
@@ -74,6 +80,9 @@ func (api *TxPoolAPIImpl) Content(ctx context.Context) (map[string]map[string]ma
 			return nil, fmt.Errorf("decoding transaction from: %x: %w", reply.Txs[i].RlpTx, err)
 		}
 		addr := gointerfaces.ConvertH160toAddress(reply.Txs[i].Sender)
+		if reply.Txs[i].Private {
+			private[txn.Hash()] = struct{}{}
+		}
 		switch reply.Txs[i].TxnType {
 		case proto_txpool.AllReply_PENDING:
 			if _, ok := pending[addr]; !ok {
@@ -111,7 +120,7 @@ func (api *TxPoolAPIImpl) Content(ctx context.Context) (map[string]map[string]ma
 	for account, txs := range pending {
 		dump := make(map[string]*ethapi.RPCTransaction)
 		for _, txn := range txs {
-			dump[strconv.FormatUint(txn.GetNonce(), 10)] = newRPCPendingTransaction(txn, curHeader, cc)
+			dump[strconv.FormatUint(txn.GetNonce(), 10)] = newRPCPoolTransaction(txn, curHeader, cc, private)
 		}
 		content["pending"][account.Hex()] = dump
 	}
@@ -119,7 +128,7 @@ func (api *TxPoolAPIImpl) Content(ctx context.Context) (map[string]map[string]ma
 	for account, txs := range baseFee {
 		dump := make(map[string]*ethapi.RPCTransaction)
 		for _, txn := range txs {
-			dump[strconv.FormatUint(txn.GetNonce(), 10)] = newRPCPendingTransaction(txn, curHeader, cc)
+			dump[strconv.FormatUint(txn.GetNonce(), 10)] = newRPCPoolTransaction(txn, curHeader, cc, private)
 		}
 		content["baseFee"][account.Hex()] = dump
 	}
@@ -127,7 +136,7 @@ func (api *TxPoolAPIImpl) Content(ctx context.Context) (map[string]map[string]ma
 	for account, txs := range queued {
 		dump := make(map[string]*ethapi.RPCTransaction)
 		for _, txn := range txs {
-			dump[strconv.FormatUint(txn.GetNonce(), 10)] = newRPCPendingTransaction(txn, curHeader, cc)
+			dump[strconv.FormatUint(txn.GetNonce(), 10)] = newRPCPoolTransaction(txn, curHeader, cc, private)
 		}
 		content["queued"][account.Hex()] = dump
 	}
@@ -146,6 +155,7 @@ func (api *TxPoolAPIImpl) ContentFrom(ctx context.Context, addr common.Address)
 		"queued":  make(map[string]*ethapi.RPCTransaction),
 	}
 
+	private := make(map[common.Hash]struct{})
 	pending := make([]types.Transaction, 0, 4)
 	baseFee := make([]types.Transaction, 0, 4)
 	queued := make([]types.Transaction, 0, 4)
@@ -158,6 +168,9 @@ func (api *TxPoolAPIImpl) ContentFrom(ctx context.Context, addr common.Address)
 		if sender != addr {
 			continue
 		}
+		if reply.Txs[i].Private {
+			private[txn.Hash()] = struct{}{}
+		}
 
 		switch reply.Txs[i].TxnType {
 		case proto_txpool.AllReply_PENDING:
@@ -186,24 +199,30 @@ func (api *TxPoolAPIImpl) ContentFrom(ctx context.Context, addr common.Address)
 	// Flatten the pending transactions
 	dump := make(map[string]*ethapi.RPCTransaction)
 	for _, txn := range pending {
-		dump[strconv.FormatUint(txn.GetNonce(), 10)] = newRPCPendingTransaction(txn, curHeader, cc)
+		dump[strconv.FormatUint(txn.GetNonce(), 10)] = newRPCPoolTransaction(txn, curHeader, cc, private)
 	}
 	content["pending"] = dump
 	// Flatten the baseFee transactions
 	dump = make(map[string]*ethapi.RPCTransaction)
 	for _, txn := range baseFee {
-		dump[strconv.FormatUint(txn.GetNonce(), 10)] = newRPCPendingTransaction(txn, curHeader, cc)
+		dump[strconv.FormatUint(txn.GetNonce(), 10)] = newRPCPoolTransaction(txn, curHeader, cc, private)
 	}
 	content["baseFee"] = dump
 	// Flatten the queued transactions
 	dump = make(map[string]*ethapi.RPCTransaction)
 	for _, txn := range queued {
-		dump[strconv.FormatUint(txn.GetNonce(), 10)] = newRPCPendingTransaction(txn, curHeader, cc)
+		dump[strconv.FormatUint(txn.GetNonce(), 10)] = newRPCPoolTransaction(txn, curHeader, cc, private)
 	}
 	content["queued"] = dump
 	return content, nil
 }
 
+func newRPCPoolTransaction(txn types.Transaction, current *types.Header, config *chain.Config, private map[common.Hash]struct{}) *ethapi.RPCTransaction {
+	rpcTxn := newRPCPendingTransaction(txn, current, config)
+	_, rpcTxn.Private = private[txn.Hash()]
+	return rpcTxn
+}
+
 // Status returns the number of pending and queued transaction in the pool.
 func (api *TxPoolAPIImpl) Status(ctx context.Context) (map[string]hexutil.Uint, error) {
 	reply, err := api.pool.Status(ctx, &proto_txpool.StatusRequest{})
@@ -217,6 +236,74 @@ func (api *TxPoolAPIImpl) Status(ctx context.Context) (map[string]hexutil.Uint,
 	}, nil
 }
 
+// TxnStatus - lifecycle status of transaction in txpool
+type TxnStatus struct {
+	Status        string          `json:"status"` // unknown, pending, baseFee, queued or discarded
+	Private       bool            `json:"private,omitempty"`
+	DiscardReason string          `json:"discardReason,omitempty"`
+	DiscardTime   *hexutil.Uint64 `json:"discardTime,omitempty"` // unix seconds
+	ReplacedBy    *common.Hash    `json:"replacedBy,omitempty"`  // replacement transaction, if replaced by price bump
+	MinedBlock    *hexutil.Uint64 `json:"minedBlock,omitempty"`  // block which included the transaction, if seen by txpool
+}
+
+// GetTransactionStatus returns sub-pool of the transaction, or why and when it was discarded.
+// History of discarded transactions is bounded: it covers last few thousand blocks.
+func (api *TxPoolAPIImpl) GetTransactionStatus(ctx context.Context, hash common.Hash) (*TxnStatus, error) {
+	reply, err := api.pool.TransactionStatus(ctx, &proto_txpool.TransactionStatusRequest{Hash: gointerfaces.ConvertHashToH256(hash)})
+	if err != nil {
+		return nil, err
+	}
+	status := &TxnStatus{Private: reply.Private}
+	switch reply.Status {
+	case proto_txpool.TransactionStatusReply_PENDING:
+		status.Status = "pending"
+	case proto_txpool.TransactionStatusReply_BASE_FEE:
+		status.Status = "baseFee"
+	case proto_txpool.TransactionStatusReply_QUEUED:
+		status.Status = "queued"
+	case proto_txpool.TransactionStatusReply_DISCARDED:
+		status.Status = "discarded"
+		status.DiscardReason = reply.DiscardReason
+		discardTime := hexutil.Uint64(reply.DiscardTime)
+		status.DiscardTime = &discardTime
+		if reply.ReplacedBy != nil {
+			replacedBy := common.Hash(gointerfaces.ConvertH256ToHash(reply.ReplacedBy))
+			status.ReplacedBy = &replacedBy
+		}
+		if reply.MinedBlock != 0 {
+			minedBlock := hexutil.Uint64(reply.MinedBlock)
+			status.MinedBlock = &minedBlock
+		}
+	default:
+		status.Status = "unknown"
+	}
+	return status, nil
+}
+
+// Export streams all transactions of the pool (with blob sidecars) to new file at path on rpcdaemon host.
+// Returns number of written transactions.
+func (api *TxPoolAPIImpl) Export(ctx context.Context, path string) (hexutil.Uint, error) {
+	count, err := txpool.ExportToFile(ctx, api.pool, path)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint(count), nil
+}
+
+// Import streams file at path on rpcdaemon host, written by txpool_export, to the pool. Transactions pass usual
+// validation: local ones are added immediately, remote ones are queued.
+func (api *TxPoolAPIImpl) Import(ctx context.Context, path string) (map[string]hexutil.Uint, error) {
+	reply, err := txpool.ImportFromFile(ctx, api.pool, path)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]hexutil.Uint{
+		"added":     hexutil.Uint(reply.Added),
+		"discarded": hexutil.Uint(reply.Discarded),
+		"queued":    hexutil.Uint(reply.Queued),
+	}, nil
+}
+
 /*
 
 // Inspect retrieves the content of the transaction pool and flattens it into an
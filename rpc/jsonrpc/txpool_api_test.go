@@ -78,3 +78,82 @@ func TestTxPoolContent(t *testing.T) {
 	require.Equal(status["pending"], hexutil.Uint(1))
 	require.Equal(status["queued"], hexutil.Uint(0))
 }
+
+func TestTxPoolContentPrivate(t *testing.T) {
+	m, require := mock.MockWithTxPool(t), require.New(t)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 1, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.Address{1})
+	})
+	require.NoError(err)
+	err = m.InsertChain(chain)
+	require.NoError(err)
+
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, m)
+	txPool := txpool.NewTxpoolClient(conn)
+	ff := rpchelper.New(ctx, rpchelper.DefaultFiltersConfig, nil, txPool, txpool.NewMiningClient(conn), func() {}, m.Log)
+	api := NewTxPoolAPI(NewBaseApi(ff, kvcache.New(kvcache.DefaultCoherentConfig), m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs, nil), m.DB, txPool)
+
+	txn, err := types.SignTx(types.NewTransaction(0, common.Address{1}, uint256.NewInt(1234), params.TxGas, uint256.NewInt(10*common.GWei), nil), *types.LatestSignerForChainID(m.ChainConfig.ChainID), m.Key)
+	require.NoError(err)
+
+	buf := bytes.NewBuffer(nil)
+	err = txn.MarshalBinary(buf)
+	require.NoError(err)
+
+	reply, err := txPool.Add(ctx, &txpool.AddRequest{RlpTxs: [][]byte{buf.Bytes()}, Private: true, MaxBlockNumber: 10})
+	require.NoError(err)
+	for _, res := range reply.Imported {
+		require.Equal(txpool.ImportResult_SUCCESS, res, fmt.Sprintf("%s", reply.Errors))
+	}
+
+	content, err := api.Content(ctx)
+	require.NoError(err)
+
+	sender := m.Address.String()
+	require.Len(content["pending"][sender], 1)
+	require.True(content["pending"][sender]["0"].Private)
+}
+
+func TestTxPoolGetTransactionStatus(t *testing.T) {
+	m, require := mock.MockWithTxPool(t), require.New(t)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 1, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.Address{1})
+	})
+	require.NoError(err)
+	err = m.InsertChain(chain)
+	require.NoError(err)
+
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, m)
+	txPool := txpool.NewTxpoolClient(conn)
+	ff := rpchelper.New(ctx, rpchelper.DefaultFiltersConfig, nil, txPool, txpool.NewMiningClient(conn), func() {}, m.Log)
+	api := NewTxPoolAPI(NewBaseApi(ff, kvcache.New(kvcache.DefaultCoherentConfig), m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs, nil), m.DB, txPool)
+
+	signer := *types.LatestSignerForChainID(m.ChainConfig.ChainID)
+	add := func(tip uint64) common.Hash {
+		txn, err := types.SignTx(types.NewTransaction(0, common.Address{1}, uint256.NewInt(1234), params.TxGas, uint256.NewInt(tip), nil), signer, m.Key)
+		require.NoError(err)
+		buf := bytes.NewBuffer(nil)
+		require.NoError(txn.MarshalBinary(buf))
+		reply, err := txPool.Add(ctx, &txpool.AddRequest{RlpTxs: [][]byte{buf.Bytes()}})
+		require.NoError(err)
+		require.Equal(txpool.ImportResult_SUCCESS, reply.Imported[0], fmt.Sprintf("%s", reply.Errors))
+		return txn.Hash()
+	}
+
+	status, err := api.GetTransactionStatus(ctx, common.Hash{1})
+	require.NoError(err)
+	require.Equal("unknown", status.Status)
+
+	replaced := add(10 * common.GWei)
+	status, err = api.GetTransactionStatus(ctx, replaced)
+	require.NoError(err)
+	require.Equal("pending", status.Status)
+
+	replacement := add(20 * common.GWei)
+	status, err = api.GetTransactionStatus(ctx, replaced)
+	require.NoError(err)
+	require.Equal("discarded", status.Status)
+	require.Equal("replaced by transaction with higher tip", status.DiscardReason)
+	require.Equal(&replacement, status.ReplacedBy)
+	require.Nil(status.MinedBlock)
+}
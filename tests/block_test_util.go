@@ -45,6 +45,7 @@ import (
 	"github.com/erigontech/erigon/core"
 	"github.com/erigontech/erigon/core/state"
 	"github.com/erigontech/erigon/eth/ethconsensusconfig"
+	"github.com/erigontech/erigon/eth/tracers"
 	"github.com/erigontech/erigon/turbo/services"
 	"github.com/erigontech/erigon/turbo/stages/mock"
 )
@@ -60,6 +61,11 @@ func (bt *BlockTest) UnmarshalJSON(in []byte) error {
 	return json.Unmarshal(in, &bt.json)
 }
 
+// Network - fork of the test
+func (bt *BlockTest) Network() string {
+	return bt.json.Network
+}
+
 type btJSON struct {
 	Blocks     []btBlock             `json:"blocks"`
 	Genesis    btHeader              `json:"genesisBlockHeader"`
@@ -116,23 +122,38 @@ type btHeaderMarshaling struct {
 	ExcessBlobGas *math.HexOrDecimal64
 }
 
-func (bt *BlockTest) Run(t *testing.T, checkStateRoot bool) error {
+func (bt *BlockTest) Run(tb testing.TB, checkStateRoot bool) error {
+	return bt.run(tb, checkStateRoot, nil)
+}
+
+// RunWithTracer - same as Run, but outside of `go test` (e.g. by `evm blocktest`) and blocks are executed with given
+// tracer (if not nil)
+func (bt *BlockTest) RunWithTracer(checkStateRoot bool, tracer *tracers.Tracer) (err error) {
+	defer recoverMockPanic(&err)
+	return bt.run(nil, checkStateRoot, tracer)
+}
+
+// recoverMockPanic - mock sentry created without testing.TB panics where it would otherwise fail the test
+func recoverMockPanic(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("panic: %v", r)
+	}
+}
+
+func (bt *BlockTest) run(tb testing.TB, checkStateRoot bool, tracer *tracers.Tracer) error {
 	config, ok := Forks[bt.json.Network]
 	if !ok {
 		return UnsupportedForkError{bt.json.Network}
 	}
 
 	engine := ethconsensusconfig.CreateConsensusEngineBareBones(context.Background(), config, log.New())
-	m := mock.MockWithGenesisEngine(t, bt.genesis(config), engine, false, checkStateRoot)
+	m := mock.MockWithGenesisEngineTracer(tb, bt.genesis(config), engine, false, checkStateRoot, tracer)
 	defer m.Close()
 
 	bt.br = m.BlockReader
 	// import pre accounts & construct test genesis block & state root
-	if m.Genesis.Hash() != bt.json.Genesis.Hash {
-		return fmt.Errorf("genesis block hash doesn't match test: computed=%x, test=%x", m.Genesis.Hash().Bytes()[:6], bt.json.Genesis.Hash[:6])
-	}
-	if m.Genesis.Root() != bt.json.Genesis.StateRoot {
-		return fmt.Errorf("genesis block state root does not match test: computed=%x, test=%x", m.Genesis.Root().Bytes()[:6], bt.json.Genesis.StateRoot[:6])
+	if err := bt.validateGenesis(m); err != nil {
+		return err
 	}
 
 	validBlocks, err := bt.insertBlocks(m)
@@ -146,6 +167,24 @@ func (bt *BlockTest) Run(t *testing.T, checkStateRoot bool) error {
 	}
 	defer tx.Rollback()
 
+	if err := bt.validateHead(tx, m); err != nil {
+		return err
+	}
+	return bt.validateImportedHeaders(tx, validBlocks, m)
+}
+
+func (bt *BlockTest) validateGenesis(m *mock.MockSentry) error {
+	if m.Genesis.Hash() != bt.json.Genesis.Hash {
+		return fmt.Errorf("genesis block hash doesn't match test: computed=%x, test=%x", m.Genesis.Hash().Bytes()[:6], bt.json.Genesis.Hash[:6])
+	}
+	if m.Genesis.Root() != bt.json.Genesis.StateRoot {
+		return fmt.Errorf("genesis block state root does not match test: computed=%x, test=%x", m.Genesis.Root().Bytes()[:6], bt.json.Genesis.StateRoot[:6])
+	}
+	return nil
+}
+
+// validateHead - checks last block hash and post state
+func (bt *BlockTest) validateHead(tx kv.Tx, m *mock.MockSentry) error {
 	cmlast := rawdb.ReadHeadBlockHash(tx)
 	if common.Hash(bt.json.BestBlock) != cmlast {
 		return fmt.Errorf("last block hash validation mismatch: want: %x, have: %x", bt.json.BestBlock, cmlast)
@@ -154,8 +193,7 @@ func (bt *BlockTest) Run(t *testing.T, checkStateRoot bool) error {
 	if err := bt.validatePostState(newDB); err != nil {
 		return fmt.Errorf("post state validation failed: %w", err)
 	}
-
-	return bt.validateImportedHeaders(tx, validBlocks, m)
+	return nil
 }
 
 func (bt *BlockTest) genesis(config *chain.Config) *types.Genesis {
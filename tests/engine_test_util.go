@@ -0,0 +1,216 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/eth/ethconsensusconfig"
+	"github.com/erigontech/erigon/eth/tracers"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
+	"github.com/erigontech/erigon/turbo/snapshotsync"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+// An EngineTest checks handling of blocks sent via Engine API: "blockchain_test_engine" fixtures of execution-spec-tests.
+type EngineTest struct {
+	json etJSON
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (et *EngineTest) UnmarshalJSON(in []byte) error {
+	return json.Unmarshal(in, &et.json)
+}
+
+type etJSON struct {
+	btJSON
+	Payloads []etPayload `json:"engineNewPayloads"`
+}
+
+type etPayload struct {
+	Params                   []json.RawMessage `json:"params"`
+	NewPayloadVersion        string            `json:"newPayloadVersion"`
+	ForkchoiceUpdatedVersion string            `json:"forkchoiceUpdatedVersion"`
+	ValidationError          string            `json:"validationError"`
+	ErrorCode                json.RawMessage   `json:"errorCode"`
+}
+
+// EngineAPI - part of engine API used by EngineTest. Implemented by engineapi.EngineServer, which isn't imported here
+// as it would create an import cycle with rpc/jsonrpc tests.
+type EngineAPI interface {
+	NewPayloadV1(ctx context.Context, payload *engine_types.ExecutionPayload) (*engine_types.PayloadStatus, error)
+	NewPayloadV2(ctx context.Context, payload *engine_types.ExecutionPayload) (*engine_types.PayloadStatus, error)
+	NewPayloadV3(ctx context.Context, payload *engine_types.ExecutionPayload, expectedBlobHashes []common.Hash, parentBeaconBlockRoot *common.Hash) (*engine_types.PayloadStatus, error)
+	NewPayloadV4(ctx context.Context, payload *engine_types.ExecutionPayload, expectedBlobHashes []common.Hash, parentBeaconBlockRoot *common.Hash, executionRequests []hexutil.Bytes) (*engine_types.PayloadStatus, error)
+	ForkchoiceUpdatedV1(ctx context.Context, forkChoiceState *engine_types.ForkChoiceState, payloadAttributes *engine_types.PayloadAttributes) (*engine_types.ForkChoiceUpdatedResponse, error)
+	ForkchoiceUpdatedV2(ctx context.Context, forkChoiceState *engine_types.ForkChoiceState, payloadAttributes *engine_types.PayloadAttributes) (*engine_types.ForkChoiceUpdatedResponse, error)
+	ForkchoiceUpdatedV3(ctx context.Context, forkChoiceState *engine_types.ForkChoiceState, payloadAttributes *engine_types.PayloadAttributes) (*engine_types.ForkChoiceUpdatedResponse, error)
+}
+
+// Network - fork of the test
+func (et *EngineTest) Network() string {
+	return et.json.Network
+}
+
+// Run - sends payloads of the test to Engine API in order, moving head to every valid one, then checks last block hash
+// and post state. Blocks are executed with given tracer (if not nil), engine API for the node is made by newEngineAPI.
+// Doesn't need `go test`: used by `evm enginetest`.
+func (et *EngineTest) Run(tracer *tracers.Tracer, newEngineAPI func(m *mock.MockSentry) EngineAPI) (err error) {
+	defer recoverMockPanic(&err)
+	config, ok := Forks[et.json.Network]
+	if !ok {
+		return UnsupportedForkError{et.json.Network}
+	}
+
+	bt := &BlockTest{json: et.json.btJSON}
+	engine := ethconsensusconfig.CreateConsensusEngineBareBones(context.Background(), config, log.New())
+	m := mock.MockWithGenesisEngineTracer(nil, bt.genesis(config), engine, false, true, tracer)
+	defer m.Close()
+
+	bt.br = m.BlockReader
+	if err := bt.validateGenesis(m); err != nil {
+		return err
+	}
+
+	// there are no snapshots to download: mark them as ready, otherwise execution module reports that it's busy
+	for _, snapshots := range []snapshotsync.BlockSnapshots{m.BlockReader.Snapshots(), m.BlockReader.BorSnapshots()} {
+		snapshots.DownloadComplete()
+		if err := snapshots.OpenFolder(); err != nil {
+			return err
+		}
+	}
+	api := newEngineAPI(m)
+	for i, p := range et.json.Payloads {
+		if err := p.send(m.Ctx, api); err != nil {
+			return fmt.Errorf("payload #%d: %w", i, err)
+		}
+		// forkchoice update keeps committing after the reply: wait for it, as node would be busy for the next payload
+		if err := waitExecutionReady(m); err != nil {
+			return err
+		}
+	}
+
+	tx, err := m.DB.BeginRo(m.Ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	return bt.validateHead(tx, m)
+}
+
+func waitExecutionReady(m *mock.MockSentry) error {
+	for {
+		reply, err := m.Eth1ExecutionService.Ready(m.Ctx, &emptypb.Empty{})
+		if err != nil {
+			return err
+		}
+		if reply.Ready {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func (p *etPayload) expectsFailure() bool {
+	return p.ValidationError != "" || (len(p.ErrorCode) > 0 && !bytes.Equal(p.ErrorCode, []byte("null")))
+}
+
+// send - calls engine_newPayload and, if payload is expected to be valid, engine_forkchoiceUpdated with it as head
+func (p *etPayload) send(ctx context.Context, api EngineAPI) error {
+	var (
+		payload               *engine_types.ExecutionPayload
+		expectedBlobHashes    []common.Hash
+		parentBeaconBlockRoot *common.Hash
+		executionRequests     []hexutil.Bytes
+	)
+	params := []any{&payload, &expectedBlobHashes, &parentBeaconBlockRoot, &executionRequests}
+	if len(p.Params) > len(params) {
+		return fmt.Errorf("too many params: %d", len(p.Params))
+	}
+	for i, param := range p.Params {
+		if err := json.Unmarshal(param, params[i]); err != nil {
+			return fmt.Errorf("decoding param #%d: %w", i, err)
+		}
+	}
+	if payload == nil {
+		return errors.New("missing execution payload")
+	}
+
+	var status *engine_types.PayloadStatus
+	var err error
+	switch p.NewPayloadVersion {
+	case "1":
+		status, err = api.NewPayloadV1(ctx, payload)
+	case "2":
+		status, err = api.NewPayloadV2(ctx, payload)
+	case "3":
+		status, err = api.NewPayloadV3(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot)
+	case "4":
+		status, err = api.NewPayloadV4(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot, executionRequests)
+	default:
+		return fmt.Errorf("unsupported newPayloadVersion: %q", p.NewPayloadVersion)
+	}
+	if p.expectsFailure() {
+		if err == nil && status.Status != engine_types.InvalidStatus {
+			return fmt.Errorf("block %d %x: expected failure (%s), got status %s", payload.BlockNumber, payload.BlockHash, p.ValidationError, status.Status)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("block %d %x: %w", payload.BlockNumber, payload.BlockHash, err)
+	}
+	if status.Status != engine_types.ValidStatus {
+		return fmt.Errorf("block %d %x: status %s, validation error: %v", payload.BlockNumber, payload.BlockHash, status.Status, validationError(status))
+	}
+
+	forkChoiceState := &engine_types.ForkChoiceState{HeadHash: payload.BlockHash}
+	var fcu *engine_types.ForkChoiceUpdatedResponse
+	switch p.ForkchoiceUpdatedVersion {
+	case "1":
+		fcu, err = api.ForkchoiceUpdatedV1(ctx, forkChoiceState, nil)
+	case "2":
+		fcu, err = api.ForkchoiceUpdatedV2(ctx, forkChoiceState, nil)
+	case "3":
+		fcu, err = api.ForkchoiceUpdatedV3(ctx, forkChoiceState, nil)
+	default:
+		return fmt.Errorf("unsupported forkchoiceUpdatedVersion: %q", p.ForkchoiceUpdatedVersion)
+	}
+	if err != nil {
+		return fmt.Errorf("forkchoice update to block %d %x: %w", payload.BlockNumber, payload.BlockHash, err)
+	}
+	if fcu.PayloadStatus.Status != engine_types.ValidStatus {
+		return fmt.Errorf("forkchoice update to block %d %x: status %s, validation error: %v", payload.BlockNumber, payload.BlockHash, fcu.PayloadStatus.Status, validationError(fcu.PayloadStatus))
+	}
+	return nil
+}
+
+func validationError(status *engine_types.PayloadStatus) error {
+	if status.ValidationError == nil {
+		return nil
+	}
+	return status.ValidationError.Error()
+}
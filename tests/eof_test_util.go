@@ -0,0 +1,88 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon/core/vm"
+)
+
+// An EOFTest checks validation of EOF containers: "eof_tests" fixtures of execution-spec-tests.
+type EOFTest struct {
+	json eofJSON
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (et *EOFTest) UnmarshalJSON(in []byte) error {
+	return json.Unmarshal(in, &et.json)
+}
+
+type eofJSON struct {
+	Vectors map[string]eofVector `json:"vectors"`
+}
+
+type eofVector struct {
+	Code          hexutil.Bytes        `json:"code"`
+	ContainerKind string               `json:"containerKind"`
+	Results       map[string]eofResult `json:"results"`
+}
+
+type eofResult struct {
+	Exception string `json:"exception"`
+	Result    bool   `json:"result"`
+}
+
+// Forks - forks, which validation results of the test are given for
+func (et *EOFTest) Forks() []string {
+	var forks []string
+	for _, vector := range et.json.Vectors {
+		for fork := range vector.Results {
+			if !slices.Contains(forks, fork) {
+				forks = append(forks, fork)
+			}
+		}
+	}
+	slices.Sort(forks)
+	return forks
+}
+
+// Run - validates containers of the test vectors in order of their names, returns the first unexpected result
+func (et *EOFTest) Run() error {
+	for _, name := range slices.Sorted(maps.Keys(et.json.Vectors)) {
+		vector := et.json.Vectors[name]
+		kind := vm.RuntimeContainer
+		if vector.ContainerKind == "INITCODE" {
+			kind = vm.InitcodeContainer
+		}
+		_, err := vm.ValidateEOF(vector.Code, kind)
+		for _, fork := range slices.Sorted(maps.Keys(vector.Results)) {
+			expected := vector.Results[fork]
+			if expected.Result && err != nil {
+				return fmt.Errorf("vector %s, %s: unexpected validation error: %w", name, fork, err)
+			}
+			if !expected.Result && err == nil {
+				return fmt.Errorf("vector %s, %s: expected validation error %s", name, fork, expected.Exception)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,485 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package t8n generates random state tests, executes them by t8ntool in-process and cross-checks
+// post-state root and receipts with an external t8n-compatible tool, e.g. `evm t8n` of another client.
+package t8n
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/math"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/cmd/evm/t8ntool"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/vm"
+)
+
+const (
+	// ExternalEnv - command of the external tool, flags of t8n are appended to it, e.g. "evm t8n" or "evmone-t8n"
+	ExternalEnv = "T8N_EXTERNAL"
+	// FixturesEnv - directory where minimized divergent cases are saved, see DefaultFixturesDir
+	FixturesEnv = "T8N_FIXTURES"
+
+	DefaultFixturesDir = "testdata/divergent"
+
+	chainID = 1
+)
+
+// Forks of generated tests. All are post-merge, so there is no mining reward, which t8n tools treat differently.
+var Forks = []string{"Paris", "Shanghai", "Cancun", "Prague"}
+
+var (
+	coinbase = common.HexToAddress("0xc94f5374fce5edbc8e2a8697c15331677e6ebf0b")
+	keys     = []*ecdsa.PrivateKey{
+		mustKey("45a915e4d060149eb4365960e6a7a45f334393093061116b197e3240065ff2d8"),
+		mustKey("0202020202020202020202020202020202020202020202020202020202020202"),
+	}
+	contracts = []common.Address{
+		common.HexToAddress("0x000000000000000000000000000000000000c0de"),
+		common.HexToAddress("0x000000000000000000000000000000000000c0df"),
+		common.HexToAddress("0x000000000000000000000000000000000000c0e0"),
+	}
+	// opcodes which are defined in some fork, pushes are emitted separately
+	opcodes []vm.OpCode
+	// system contracts of the forks, Prague fails the block if its request contracts have no code
+	cancunContracts = []common.Address{params.BeaconRootsAddress}
+	pragueContracts = []common.Address{params.BeaconRootsAddress, params.HistoryStorageAddress,
+		params.WithdrawalRequestAddress, params.ConsolidationRequestAddress}
+	systemAlloc = core.DeveloperPoSGenesisBlock(common.Address{}).Alloc
+)
+
+func init() {
+	for op := vm.OpCode(0); op < vm.OpCode(0xff); op++ {
+		if !op.IsPushWithImmediateArgs() && !strings.HasPrefix(op.String(), "opcode ") {
+			opcodes = append(opcodes, op)
+		}
+	}
+}
+
+func mustKey(hex string) *ecdsa.PrivateKey {
+	key, err := crypto.HexToECDSA(hex)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// StateTest - pre-state, block environment and txs of a single block transition,
+// in the format of t8n inputs: `jq '{alloc,env,txs}'` turns it into stdin of `evm t8n`
+type StateTest struct {
+	Fork  string             `json:"fork"`
+	Alloc types.GenesisAlloc `json:"alloc"`
+	Env   *Env               `json:"env"`
+	Txs   []*Tx              `json:"txs"`
+}
+
+type Env struct {
+	Coinbase    common.Address         `json:"currentCoinbase"`
+	Random      *hexutil.Big           `json:"currentRandom"`
+	GasLimit    hexutil.Uint64         `json:"currentGasLimit"`
+	Number      hexutil.Uint64         `json:"currentNumber"`
+	Timestamp   hexutil.Uint64         `json:"currentTimestamp"`
+	BaseFee     *hexutil.Big           `json:"currentBaseFee"`
+	BlockHashes map[string]common.Hash `json:"blockHashes"`
+	Withdrawals *[]*types.Withdrawal   `json:"withdrawals,omitempty"` // pointer, because empty list is required since Shanghai and forbidden before
+
+	ExcessBlobGas    *hexutil.Uint64 `json:"currentExcessBlobGas,omitempty"`
+	ParentBeaconRoot *common.Hash    `json:"parentBeaconBlockRoot,omitempty"`
+}
+
+// Tx - unsigned txn with the key of its sender, t8n tools sign it
+type Tx struct {
+	Type                 hexutil.Uint64    `json:"type"`
+	ChainID              *hexutil.Big      `json:"chainId,omitempty"`
+	Nonce                hexutil.Uint64    `json:"nonce"`
+	To                   *common.Address   `json:"to"`
+	Gas                  hexutil.Uint64    `json:"gas"`
+	GasPrice             *hexutil.Big      `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big      `json:"value"`
+	Input                hexutil.Bytes     `json:"input"`
+	AccessList           *types.AccessList `json:"accessList,omitempty"`
+	V                    *hexutil.Big      `json:"v"`
+	R                    *hexutil.Big      `json:"r"`
+	S                    *hexutil.Big      `json:"s"`
+	SecretKey            common.Hash       `json:"secretKey"`
+}
+
+// source of the fuzzer input, zeroes after the end
+type source []byte
+
+func (s *source) byte() byte {
+	if len(*s) == 0 {
+		return 0
+	}
+	b := (*s)[0]
+	*s = (*s)[1:]
+	return b
+}
+
+func (s *source) intn(n int) int { return int(s.byte()) % n }
+
+func (s *source) bytes(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = s.byte()
+	}
+	return b
+}
+
+func (s *source) code(maxOps int) []byte {
+	var code []byte
+	for n := s.intn(maxOps + 1); n > 0; n-- {
+		if s.byte()%2 == 0 {
+			// operands are mostly small, so that memory offsets, slots and jump destinations make sense
+			code = append(code, byte(vm.PUSH1), s.byte()%64)
+			continue
+		}
+		op := opcodes[s.intn(len(opcodes))]
+		code = append(code, byte(op))
+	}
+	return code
+}
+
+// Generate deterministically turns fuzzer input into a valid state test: senders are funded,
+// nonces are sequential, fees cover the base fee. Txs call the generated contracts or create new ones.
+func Generate(data []byte) *StateTest {
+	s := source(data)
+	test := &StateTest{
+		Fork:  Forks[s.intn(len(Forks))],
+		Alloc: types.GenesisAlloc{},
+		Env: &Env{
+			Coinbase:    coinbase,
+			Random:      (*hexutil.Big)(new(big.Int).SetBytes(s.bytes(4))),
+			GasLimit:    30_000_000,
+			Number:      1,
+			Timestamp:   1000,
+			BaseFee:     (*hexutil.Big)(big.NewInt(7)),
+			BlockHashes: map[string]common.Hash{"0": crypto.Keccak256Hash([]byte{0})},
+		},
+	}
+	for _, key := range keys {
+		test.Alloc[crypto.PubkeyToAddress(key.PublicKey)] = types.GenesisAccount{Balance: big.NewInt(common.Ether)}
+	}
+	for _, addr := range contracts[:1+s.intn(len(contracts))] {
+		account := types.GenesisAccount{Balance: big.NewInt(int64(s.byte())), Code: s.code(32)}
+		if n := s.intn(4); n > 0 {
+			account.Storage = map[common.Hash]common.Hash{}
+			for ; n > 0; n-- {
+				account.Storage[common.BigToHash(big.NewInt(int64(s.intn(4))))] = common.BigToHash(big.NewInt(int64(s.byte())))
+			}
+		}
+		test.Alloc[addr] = account
+	}
+	if test.Fork != "Paris" {
+		withdrawals := []*types.Withdrawal{}
+		for i := s.intn(3); i > 0; i-- {
+			withdrawals = append(withdrawals, &types.Withdrawal{Index: uint64(len(withdrawals)), Validator: uint64(s.byte()),
+				Address: contracts[s.intn(len(contracts))], Amount: uint64(s.byte())})
+		}
+		test.Env.Withdrawals = &withdrawals
+	}
+	var system []common.Address
+	switch test.Fork {
+	case "Cancun":
+		system = cancunContracts
+	case "Prague":
+		system = pragueContracts
+	}
+	if system != nil {
+		excessBlobGas := hexutil.Uint64(uint64(s.intn(4)) * params.BlobGasPerBlob)
+		parentBeaconRoot := common.BytesToHash(s.bytes(4))
+		test.Env.ExcessBlobGas, test.Env.ParentBeaconRoot = &excessBlobGas, &parentBeaconRoot
+		for _, addr := range system {
+			test.Alloc[addr] = systemAlloc[addr]
+		}
+	}
+
+	nonces := make([]uint64, len(keys))
+	for i := 1 + s.intn(4); i > 0; i-- {
+		sender := s.intn(len(keys))
+		tx := &Tx{
+			Nonce:     hexutil.Uint64(nonces[sender]),
+			Gas:       hexutil.Uint64(21_000 + s.intn(256)*2_000),
+			Value:     (*hexutil.Big)(big.NewInt(int64(s.byte()))),
+			V:         new(hexutil.Big),
+			R:         new(hexutil.Big),
+			S:         new(hexutil.Big),
+			SecretKey: common.BytesToHash(crypto.FromECDSA(keys[sender])),
+		}
+		nonces[sender]++
+		if to := s.intn(len(contracts) + 1); to < len(contracts) {
+			tx.To = &contracts[to]
+			tx.Input = s.bytes(s.intn(33))
+		} else {
+			tx.Gas += 53_000
+			tx.Input = s.code(32) // init code
+		}
+		if s.byte()%2 == 0 {
+			tx.GasPrice = (*hexutil.Big)(big.NewInt(10))
+		} else {
+			tx.Type = types.DynamicFeeTxType
+			tx.ChainID = (*hexutil.Big)(big.NewInt(chainID))
+			tx.MaxFeePerGas = (*hexutil.Big)(big.NewInt(10))
+			tx.MaxPriorityFeePerGas = (*hexutil.Big)(big.NewInt(int64(s.intn(4))))
+			tx.AccessList = &types.AccessList{}
+		}
+		test.Txs = append(test.Txs, tx)
+	}
+	return test
+}
+
+func (test *StateTest) clone() *StateTest {
+	b, err := json.Marshal(test)
+	if err != nil {
+		panic(err)
+	}
+	var c StateTest
+	if err := json.Unmarshal(b, &c); err != nil {
+		panic(err)
+	}
+	return &c
+}
+
+// Result - parts of t8n output, which are compared
+type Result struct {
+	StateRoot    common.Hash         `json:"stateRoot"`
+	ReceiptsRoot common.Hash         `json:"receiptsRoot"`
+	LogsHash     common.Hash         `json:"logsHash"`
+	GasUsed      math.HexOrDecimal64 `json:"gasUsed"`
+	Receipts     []struct {
+		TxHash            common.Hash    `json:"transactionHash"`
+		Status            hexutil.Uint64 `json:"status"`
+		CumulativeGasUsed hexutil.Uint64 `json:"cumulativeGasUsed"`
+		ContractAddress   common.Address `json:"contractAddress"`
+	} `json:"receipts"`
+	Rejected []struct {
+		Index int    `json:"index"`
+		Err   string `json:"error"`
+	} `json:"rejected,omitempty"`
+}
+
+// RunLocal executes the test by t8ntool in-process
+func RunLocal(test *StateTest) (*Result, error) {
+	b, err := json.Marshal(test)
+	if err != nil {
+		return nil, err
+	}
+	var in t8ntool.Input
+	if err := json.Unmarshal(b, &in); err != nil {
+		return nil, err
+	}
+	res, _, _, err := t8ntool.Transition(&in, test.Fork, chainID, nil)
+	if err != nil {
+		return nil, err
+	}
+	// through json, the same way as the output of the external tool
+	if b, err = json.Marshal(res); err != nil {
+		return nil, err
+	}
+	var result Result
+	return &result, json.Unmarshal(b, &result)
+}
+
+// RunExternal executes the test by the external tool, command is its path followed by arguments
+func RunExternal(command []string, test *StateTest) (*Result, error) {
+	dir, err := os.MkdirTemp("", "t8n")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	for name, obj := range map[string]any{"alloc.json": test.Alloc, "env.json": test.Env, "txs.json": test.Txs} {
+		b, err := json.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), b, 0644); err != nil { //nolint:gosec
+			return nil, err
+		}
+	}
+	args := append(slices.Clone(command[1:]),
+		"--input.alloc="+filepath.Join(dir, "alloc.json"),
+		"--input.env="+filepath.Join(dir, "env.json"),
+		"--input.txs="+filepath.Join(dir, "txs.json"),
+		"--output.basedir="+dir,
+		"--output.result=result.json",
+		"--output.alloc=post.json",
+		"--state.fork="+test.Fork,
+		fmt.Sprintf("--state.chainid=%d", chainID),
+	)
+	cmd := exec.Command(command[0], args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", command[0], err, out)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "result.json"))
+	if err != nil {
+		return nil, err
+	}
+	var result Result
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, fmt.Errorf("%s: result: %w", command[0], err)
+	}
+	return &result, nil
+}
+
+// Divergence - state test with different outcomes of t8ntool and the external tool
+type Divergence struct {
+	*StateTest
+	Diff        []string `json:"diff"`
+	Local       *Result  `json:"erigon,omitempty"`
+	LocalErr    string   `json:"erigonError,omitempty"`
+	External    *Result  `json:"external,omitempty"`
+	ExternalErr string   `json:"externalError,omitempty"`
+}
+
+// Compare executes the test by both tools, it returns nil if their outcomes are the same.
+// Failure of only one of them is a divergence too, as generated tests are valid.
+func Compare(command []string, test *StateTest) *Divergence {
+	d := &Divergence{StateTest: test}
+	var localErr, externalErr error
+	d.Local, localErr = RunLocal(test)
+	d.External, externalErr = RunExternal(command, test)
+	switch {
+	case localErr != nil && externalErr != nil:
+		return nil
+	case localErr != nil:
+		d.LocalErr = localErr.Error()
+		d.Diff = append(d.Diff, "erigon failed: "+d.LocalErr)
+	case externalErr != nil:
+		d.ExternalErr = externalErr.Error()
+		d.Diff = append(d.Diff, "external failed: "+d.ExternalErr)
+	default:
+		d.Diff = diff(d.Local, d.External)
+	}
+	if len(d.Diff) == 0 {
+		return nil
+	}
+	return d
+}
+
+func diff(a, b *Result) (res []string) {
+	check := func(what string, x, y any) {
+		if x != y {
+			res = append(res, fmt.Sprintf("%s: erigon %v, external %v", what, x, y))
+		}
+	}
+	check("stateRoot", a.StateRoot, b.StateRoot)
+	check("receiptsRoot", a.ReceiptsRoot, b.ReceiptsRoot)
+	check("logsHash", a.LogsHash, b.LogsHash)
+	check("gasUsed", a.GasUsed, b.GasUsed)
+	check("receipts", len(a.Receipts), len(b.Receipts))
+	for i := range min(len(a.Receipts), len(b.Receipts)) {
+		ra, rb := a.Receipts[i], b.Receipts[i]
+		check(fmt.Sprintf("receipt %d txHash", i), ra.TxHash, rb.TxHash)
+		check(fmt.Sprintf("receipt %d status", i), ra.Status, rb.Status)
+		check(fmt.Sprintf("receipt %d cumulativeGasUsed", i), ra.CumulativeGasUsed, rb.CumulativeGasUsed)
+		check(fmt.Sprintf("receipt %d contractAddress", i), ra.ContractAddress, rb.ContractAddress)
+	}
+	rejected := func(r *Result) (indices []int) {
+		for _, rej := range r.Rejected {
+			indices = append(indices, rej.Index)
+		}
+		return indices
+	}
+	check("rejected", fmt.Sprint(rejected(a)), fmt.Sprint(rejected(b)))
+	return res
+}
+
+// reductions - copies of the test, each smaller by a txn, withdrawal, account, storage slot or half of code or input
+func (test *StateTest) reductions() (res []*StateTest) {
+	for i := range test.Txs {
+		c := test.clone()
+		c.Txs = slices.Delete(c.Txs, i, i+1)
+		res = append(res, c)
+		if len(test.Txs[i].Input) > 0 {
+			c = test.clone()
+			c.Txs[i].Input = c.Txs[i].Input[:len(c.Txs[i].Input)/2]
+			res = append(res, c)
+		}
+	}
+	if test.Env.Withdrawals != nil {
+		for i := range *test.Env.Withdrawals {
+			c := test.clone()
+			*c.Env.Withdrawals = slices.Delete(*c.Env.Withdrawals, i, i+1)
+			res = append(res, c)
+		}
+	}
+	for addr, account := range test.Alloc {
+		c := test.clone()
+		delete(c.Alloc, addr)
+		res = append(res, c)
+		for slot := range account.Storage {
+			c = test.clone()
+			delete(c.Alloc[addr].Storage, slot)
+			res = append(res, c)
+		}
+		if len(account.Code) > 0 {
+			c = test.clone()
+			a := c.Alloc[addr]
+			a.Code = a.Code[:len(a.Code)/2]
+			c.Alloc[addr] = a
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// Minimize greedily reduces the test of the divergence while check still finds one. Every reduction makes
+// the test strictly smaller, so it terminates.
+func Minimize(d *Divergence, check func(*StateTest) *Divergence) *Divergence {
+	for reduced := true; reduced; {
+		reduced = false
+		for _, c := range d.reductions() {
+			if smaller := check(c); smaller != nil {
+				d, reduced = smaller, true
+				break
+			}
+		}
+	}
+	return d
+}
+
+// Save writes the divergence as a JSON fixture into dir, named by the hash of the test
+func (d *Divergence) Save(dir string) (string, error) {
+	test, err := json.Marshal(d.StateTest)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%x.json", d.Fork, crypto.Keccak256(test)[:8]))
+	return path, os.WriteFile(path, b, 0644) //nolint:gosec
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package t8n
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/crypto"
+)
+
+// Fuzz executes generated state tests by t8ntool. If T8N_EXTERNAL is set, the results are compared with
+// the external tool and minimized divergent cases are saved to T8N_FIXTURES:
+//
+//	T8N_EXTERNAL="evm t8n" go test ./tests/fuzzers/t8n -run=^$ -fuzz=Fuzz
+func Fuzz(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{1, 0xde, 0xad, 0xbe, 0xef, 2, 0x10, 5, 1, 0x60, 3, 0x55, 1, 0, 2, 7, 1, 1, 0x80, 3, 2, 4, 1, 2, 3, 4, 1})
+	f.Add([]byte("0123456789abcdefghijklmnopqrstuvwxyz0123456789abcdefghijklmnopqrstuvwxyz"))
+
+	command := strings.Fields(os.Getenv(ExternalEnv))
+	fixtures := os.Getenv(FixturesEnv)
+	if fixtures == "" {
+		fixtures = DefaultFixturesDir
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		test := Generate(data)
+		if len(command) == 0 {
+			if _, err := RunLocal(test); err != nil {
+				t.Fatal(err)
+			}
+			return
+		}
+		check := func(test *StateTest) *Divergence { return Compare(command, test) }
+		if d := check(test); d != nil {
+			d = Minimize(d, check)
+			path, err := d.Save(fixtures)
+			require.NoError(t, err)
+			t.Fatalf("divergence, saved to %s:\n%s", path, strings.Join(d.Diff, "\n"))
+		}
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+	data := []byte("0123456789abcdefghijklmnopqrstuvwxyz0123456789abcdefghijklmnopqrstuvwxyz")
+	test := Generate(data)
+	require.Equal(t, test, Generate(data))
+	require.NotEmpty(t, test.Txs)
+
+	res, err := RunLocal(test)
+	require.NoError(t, err)
+	require.Len(t, res.Receipts, len(test.Txs)-len(res.Rejected))
+}
+
+func TestGenerateForks(t *testing.T) {
+	t.Parallel()
+	for i, fork := range Forks {
+		// the first byte picks the fork
+		test := Generate(append([]byte{byte(i)}, "0123456789abcdefghijklmnopqrstuvwxyz"...))
+		require.Equal(t, fork, test.Fork)
+		_, err := RunLocal(test)
+		require.NoError(t, err, fork)
+	}
+}
+
+func TestMinimize(t *testing.T) {
+	t.Parallel()
+	// diverges while there is a contract creation
+	check := func(test *StateTest) *Divergence {
+		for _, tx := range test.Txs {
+			if tx.To == nil {
+				return &Divergence{StateTest: test, Diff: []string{"creation"}}
+			}
+		}
+		return nil
+	}
+	var d *Divergence
+	for i := byte(0); d == nil || len(d.Txs) < 2; i++ {
+		d = check(Generate(crypto.Keccak256([]byte{i})))
+	}
+
+	d = Minimize(d, check)
+	require.Len(t, d.Txs, 1)
+	require.Nil(t, d.Txs[0].To)
+	require.Empty(t, d.Txs[0].Input)
+	require.Empty(t, d.Alloc)
+
+	path, err := d.Save(t.TempDir())
+	require.NoError(t, err)
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"diff": [`)
+}
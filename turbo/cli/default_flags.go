@@ -39,6 +39,9 @@ var DefaultFlags = []cli.Flag{
 	&utils.TxPoolGlobalQueueFlag,
 	&utils.TxPoolTraceSendersFlag,
 	&utils.TxPoolCommitEveryFlag,
+	&utils.TxPoolOrderingFlag,
+	&utils.TxPoolLocalTipWeightFlag,
+	&utils.TxPoolPrioritySendersFlag,
 	&PruneDistanceFlag,
 	&PruneBlocksDistanceFlag,
 	&PruneModeFlag,
@@ -75,6 +78,9 @@ var DefaultFlags = []cli.Flag{
 	&utils.HTTPTraceFlag,
 	&utils.HTTPDebugSingleFlag,
 	&utils.StateCacheFlag,
+	&utils.StateCacheStorageFlag,
+	&utils.StateCacheStorageSlotsFlag,
+	&utils.StateCacheStoragePinnedFlag,
 	&utils.RpcBatchConcurrencyFlag,
 	&utils.RpcStreamingDisableFlag,
 	&utils.DBReadConcurrencyFlag,
@@ -95,6 +101,7 @@ var DefaultFlags = []cli.Flag{
 	&AuthRpcReadTimeoutFlag,
 	&AuthRpcWriteTimeoutFlag,
 	&AuthRpcIdleTimeoutFlag,
+	&AuthRpcRecordFlag,
 	&EvmCallTimeoutFlag,
 	&OverlayGetLogsFlag,
 	&OverlayReplayBlockFlag,
@@ -109,6 +116,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.SnapStopFlag,
 	&utils.SnapStateStopFlag,
 	&utils.SnapSkipStateSnapshotDownloadFlag,
+	&utils.SnapRecsplitWorkersFlag,
 	&utils.DbPageSizeFlag,
 	&utils.DbSizeLimitFlag,
 	&utils.DbWriteMapFlag,
@@ -136,6 +144,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.MaxPeersFlag,
 	&utils.ChainFlag,
 	&utils.DeveloperPeriodFlag,
+	&utils.DeveloperCliqueFlag,
 	&utils.VMEnableDebugFlag,
 	&utils.NetworkIdFlag,
 	&utils.PersistReceiptsV2Flag,
@@ -158,6 +167,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.MinerNoVerfiyFlag,
 	&utils.MinerSigningKeyFileFlag,
 	&utils.MinerRecommitIntervalFlag,
+	&utils.MinerPayloadRebuildIntervalFlag,
 	&utils.SentryAddrFlag,
 	&utils.SentryLogPeerInfoFlag,
 	&utils.DownloaderAddrFlag,
@@ -251,4 +261,5 @@ var DefaultFlags = []cli.Flag{
 	&utils.GDBMeFlag,
 
 	&utils.ExperimentalConcurrentCommitmentFlag,
+	&utils.ExperimentalEVMFuseFlag,
 }
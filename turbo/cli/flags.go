@@ -203,6 +203,10 @@ var (
 		Usage: "Maximum amount of time to wait for the next request when keep-alives are enabled. If authrpc.timeouts.idle is zero, the value of authrpc.timeouts.read is used.",
 		Value: rpccfg.DefaultHTTPTimeouts.IdleTimeout,
 	}
+	AuthRpcRecordFlag = cli.StringFlag{
+		Name:  "authrpc.record",
+		Usage: "Append every Engine API request with its response to this JSONL file - to reproduce CL interaction offline by `integration replay_engine`",
+	}
 
 	EvmCallTimeoutFlag = cli.DurationFlag{
 		Name:  "rpc.evmtimeout",
@@ -432,6 +436,7 @@ func setEmbeddedRpcDaemon(ctx *cli.Context, cfg *nodecfg.Config, logger log.Logg
 		AuthRpcHTTPListenAddress: ctx.String(utils.AuthRpcAddr.Name),
 		AuthRpcPort:              ctx.Int(utils.AuthRpcPort.Name),
 		JWTSecretPath:            jwtSecretPath,
+		AuthRpcRecordPath:        ctx.String(AuthRpcRecordFlag.Name),
 		TraceRequests:            ctx.Bool(utils.HTTPTraceFlag.Name),
 		DebugSingleRequest:       ctx.Bool(utils.HTTPDebugSingleFlag.Name),
 		HttpCORSDomain:           common.CliString2Array(ctx.String(utils.HTTPCORSDomainFlag.Name)),
@@ -506,6 +511,27 @@ func setEmbeddedRpcDaemon(ctx *cli.Context, cfg *nodecfg.Config, logger log.Logg
 		utils.Fatalf("Invalid state.cache value provided")
 	}
 
+	c.StateCache.StorageCacheSize = c.StateCache.CacheSize / 2
+	if ctx.IsSet(utils.StateCacheStorageFlag.Name) {
+		err = c.StateCache.StorageCacheSize.UnmarshalText([]byte(ctx.String(utils.StateCacheStorageFlag.Name)))
+		if err != nil {
+			utils.Fatalf("Invalid state.cache.storage value provided")
+		}
+		if c.StateCache.StorageCacheSize > c.StateCache.CacheSize {
+			utils.Fatalf("state.cache.storage can't be bigger than state.cache")
+		}
+	}
+	c.StateCache.CacheSize -= c.StateCache.StorageCacheSize
+	c.StateCache.StorageSlotsPerContract = ctx.Int(utils.StateCacheStorageSlotsFlag.Name)
+	pinned := ctx.StringSlice(utils.StateCacheStoragePinnedFlag.Name)
+	c.StateCache.PinnedContracts = make([]common.Address, 0, len(pinned))
+	for _, addr := range pinned {
+		if !common.IsHexAddress(addr) {
+			utils.Fatalf("Invalid state.cache.storage.pinned value provided: %s", addr)
+		}
+		c.StateCache.PinnedContracts = append(c.StateCache.PinnedContracts, common.HexToAddress(addr))
+	}
+
 	/*
 		rootCmd.PersistentFlags().BoolVar(&cfg.GRPCServerEnabled, "grpc", false, "Enable GRPC server")
 		rootCmd.PersistentFlags().StringVar(&cfg.GRPCListenAddress, "grpc.addr", node.DefaultGRPCHost, "GRPC server listening interface")
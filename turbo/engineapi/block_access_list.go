@@ -0,0 +1,74 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engineapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/consensuschain"
+	"github.com/erigontech/erigon/execution/consensus"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
+)
+
+// checkBlockAccessList - EIP-7928 check of payload, which execution layer validated: block access list of payload must
+// be the same as the one of its execution on top of parent state, otherwise payload is invalid. If parent state is not
+// available (e.g. payload on top of non-canonical block), the list is not checked.
+func (s *EngineServer) checkBlockAccessList(ctx context.Context, req *engine_types.ExecutionPayload, block *types.Block) (*engine_types.PayloadStatus, error) {
+	engine, ok := s.engine.(consensus.Engine)
+	if !ok {
+		s.logger.Debug("[NewPayload] no consensus engine to check block access list", "height", req.BlockNumber, "hash", req.BlockHash)
+		return nil, nil
+	}
+	stateReader, tx, err := s.parentStateReader(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if stateReader == nil {
+		s.logger.Debug("[NewPayload] no state of parent to check block access list", "height", req.BlockNumber, "hash", req.BlockHash)
+		return nil, nil
+	}
+	defer tx.Rollback()
+
+	getHeader := func(hash common.Hash, number uint64) *types.Header {
+		h, err := s.blockReader.Header(ctx, tx, hash, number)
+		if err != nil {
+			s.logger.Debug("[NewPayload] getHeader error", "number", number, "hash", hash, "err", err)
+		}
+		return h
+	}
+	chainReader := consensuschain.NewReader(s.config, tx, s.blockReader, s.logger)
+	blockAccessList, err := core.ExecuteBlockAccessList(s.config, vm.Config{}, core.GetHashFn(block.HeaderNoCopy(), getHeader), engine, block, stateReader, chainReader, s.logger)
+	if err != nil {
+		// the block is valid, so its execution must succeed
+		return nil, fmt.Errorf("block access list of block %d (%x): %w", req.BlockNumber, req.BlockHash, err)
+	}
+	if expected, actual := req.BlockAccessList.Hash(), blockAccessList.Hash(); expected != actual {
+		s.logger.Warn("[NewPayload] block access list mismatch", "height", req.BlockNumber, "hash", req.BlockHash, "expected", expected, "actual", actual)
+		latestValidHash := req.ParentHash
+		return &engine_types.PayloadStatus{
+			Status:          engine_types.InvalidStatus,
+			ValidationError: engine_types.NewStringifiedErrorFromString(fmt.Sprintf("block access list mismatch: hash %x, execution %x", expected, actual)),
+			LatestValidHash: &latestValidHash,
+		}, nil
+	}
+	return nil, nil
+}
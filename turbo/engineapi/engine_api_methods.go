@@ -20,6 +20,7 @@ var ourCapabilities = []string{
 	"engine_newPayloadV2",
 	"engine_newPayloadV3",
 	"engine_newPayloadV4",
+	"engine_newPayloadV5",
 	"engine_getPayloadV1",
 	"engine_getPayloadV2",
 	"engine_getPayloadV3",
@@ -28,6 +29,9 @@ var ourCapabilities = []string{
 	"engine_getPayloadBodiesByRangeV1",
 	"engine_getClientVersionV1",
 	"engine_getBlobsV1",
+	"engine_getBlobsV2",
+	"engine_getInclusionListV1",
+	"engine_updatePayloadWithInclusionListV1",
 }
 
 // Returns the most recent version of the payload(for the payloadID) at the time of receiving the call
@@ -97,20 +101,20 @@ func (e *EngineServer) ForkchoiceUpdatedV3(ctx context.Context, forkChoiceState
 // NewPayloadV1 processes new payloads (blocks) from the beacon chain without withdrawals.
 // See https://github.com/ethereum/execution-apis/blob/main/src/engine/paris.md#engine_newpayloadv1
 func (e *EngineServer) NewPayloadV1(ctx context.Context, payload *engine_types.ExecutionPayload) (*engine_types.PayloadStatus, error) {
-	return e.newPayload(ctx, payload, nil, nil, nil, clparams.BellatrixVersion)
+	return e.newPayload(ctx, payload, nil, nil, nil, nil, clparams.BellatrixVersion)
 }
 
 // NewPayloadV2 processes new payloads (blocks) from the beacon chain with withdrawals.
 // See https://github.com/ethereum/execution-apis/blob/main/src/engine/shanghai.md#engine_newpayloadv2
 func (e *EngineServer) NewPayloadV2(ctx context.Context, payload *engine_types.ExecutionPayload) (*engine_types.PayloadStatus, error) {
-	return e.newPayload(ctx, payload, nil, nil, nil, clparams.CapellaVersion)
+	return e.newPayload(ctx, payload, nil, nil, nil, nil, clparams.CapellaVersion)
 }
 
 // NewPayloadV3 processes new payloads (blocks) from the beacon chain with withdrawals & blob gas.
 // See https://github.com/ethereum/execution-apis/blob/main/src/engine/cancun.md#engine_newpayloadv3
 func (e *EngineServer) NewPayloadV3(ctx context.Context, payload *engine_types.ExecutionPayload,
 	expectedBlobHashes []common.Hash, parentBeaconBlockRoot *common.Hash) (*engine_types.PayloadStatus, error) {
-	return e.newPayload(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot, nil, clparams.DenebVersion)
+	return e.newPayload(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot, nil, nil, clparams.DenebVersion)
 }
 
 // NewPayloadV4 processes new payloads (blocks) from the beacon chain with withdrawals, blob gas and requests.
@@ -119,7 +123,15 @@ func (e *EngineServer) NewPayloadV4(ctx context.Context, payload *engine_types.E
 	expectedBlobHashes []common.Hash, parentBeaconBlockRoot *common.Hash, executionRequests []hexutil.Bytes) (*engine_types.PayloadStatus, error) {
 	// TODO(racytech): add proper version or refactor this part
 	// add all version ralated checks here so the newpayload doesn't have to deal with checks
-	return e.newPayload(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot, executionRequests, clparams.ElectraVersion)
+	return e.newPayload(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot, executionRequests, nil, clparams.ElectraVersion)
+}
+
+// NewPayloadV5 processes new payloads like NewPayloadV4 and checks that they satisfy inclusion list (EIP-7805).
+// FOCIL is not scheduled for any mainnet fork: payloads are accepted after FocilTime of chain config.
+// See https://eips.ethereum.org/EIPS/eip-7805#engine-api
+func (e *EngineServer) NewPayloadV5(ctx context.Context, payload *engine_types.ExecutionPayload,
+	expectedBlobHashes []common.Hash, parentBeaconBlockRoot *common.Hash, executionRequests []hexutil.Bytes, inclusionList []hexutil.Bytes) (*engine_types.PayloadStatus, error) {
+	return e.newPayloadWithInclusionList(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot, executionRequests, inclusionList)
 }
 
 // Returns an array of execution payload bodies referenced by their block hashes
@@ -172,3 +184,24 @@ func (e *EngineServer) GetBlobsV1(ctx context.Context, blobHashes []common.Hash)
 	e.logger.Debug("[GetBlobsV1] Received Request", "hashes", len(blobHashes))
 	return e.getBlobs(ctx, blobHashes)
 }
+
+// GetBlobsV2 - blobs with cell proofs (EIP-7594) from txpool. Returns null unless all blobs are known
+// See https://github.com/ethereum/execution-apis/blob/main/src/engine/osaka.md#engine_getblobsv2
+func (e *EngineServer) GetBlobsV2(ctx context.Context, blobHashes []common.Hash) ([]*engine_types.BlobAndProofV2, error) {
+	e.logger.Debug("[GetBlobsV2] Received Request", "hashes", len(blobHashes))
+	return e.getBlobsV2(ctx, blobHashes)
+}
+
+// GetInclusionListV1 - inclusion list (EIP-7805) for block on top of parentHash: best txpool transactions up to MaxBytesPerInclusionList
+// See https://eips.ethereum.org/EIPS/eip-7805#engine-api
+func (e *EngineServer) GetInclusionListV1(ctx context.Context, parentHash common.Hash) ([]hexutil.Bytes, error) {
+	e.logger.Debug("[GetInclusionListV1] Received Request", "parentHash", parentHash)
+	return e.getInclusionList(ctx, parentHash)
+}
+
+// UpdatePayloadWithInclusionListV1 - makes payload being built include transactions of inclusion list (EIP-7805)
+// See https://eips.ethereum.org/EIPS/eip-7805#engine-api
+func (e *EngineServer) UpdatePayloadWithInclusionListV1(ctx context.Context, payloadID hexutil.Bytes, inclusionList []hexutil.Bytes) (*hexutil.Bytes, error) {
+	e.logger.Debug("[UpdatePayloadWithInclusionListV1] Received Request", "payloadId", payloadID, "txns", len(inclusionList))
+	return e.updatePayloadWithInclusionList(ctx, payloadID, inclusionList)
+}
@@ -0,0 +1,228 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engineapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
+)
+
+// EngineRecord - one call of Engine API, recordings are JSONL files: one record per line
+type EngineRecord struct {
+	Time   time.Time          `json:"time"` // when request was received
+	Took   time.Duration      `json:"took"`
+	Method string             `json:"method"`
+	Params []json.RawMessage  `json:"params"`
+	Result json.RawMessage    `json:"result,omitempty"`
+	Error  *EngineRecordError `json:"error,omitempty"`
+}
+
+type EngineRecordError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// EngineRecorder - appends every engine_* request with its response to file. Payloads of engine_newPayload contain
+// whole blocks, so recording is enough to reproduce CL interaction on fresh datadir by `integration replay_engine`
+type EngineRecorder struct {
+	lock   sync.Mutex
+	file   *os.File
+	w      *bufio.Writer
+	logger log.Logger
+}
+
+func NewEngineRecorder(path string, logger log.Logger) (*EngineRecorder, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &EngineRecorder{file: f, w: bufio.NewWriter(f), logger: logger}, nil
+}
+
+// Record - result and err are pointers to results of call: it's called by defer
+func (r *EngineRecorder) Record(method string, start time.Time, result any, err *error, params ...any) {
+	rec := EngineRecord{Time: start, Took: time.Since(start), Method: method, Params: make([]json.RawMessage, len(params))}
+	var marshalErr error
+	for i, param := range params {
+		if rec.Params[i], marshalErr = json.Marshal(param); marshalErr != nil {
+			r.logger.Warn("[engine recorder] failed to marshal params", "method", method, "err", marshalErr)
+			return
+		}
+	}
+	if err != nil && *err != nil {
+		rec.Error = &EngineRecordError{Code: -32000, Message: (*err).Error()}
+		var rpcErr rpc.Error
+		if errors.As(*err, &rpcErr) {
+			rec.Error.Code = rpcErr.ErrorCode()
+		}
+	} else if rec.Result, marshalErr = json.Marshal(result); marshalErr != nil {
+		r.logger.Warn("[engine recorder] failed to marshal result", "method", method, "err", marshalErr)
+		return
+	}
+	line, marshalErr := json.Marshal(rec)
+	if marshalErr != nil {
+		r.logger.Warn("[engine recorder] failed to marshal record", "method", method, "err", marshalErr)
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.file == nil {
+		return
+	}
+	// flush every record: recording is used to investigate crashes too
+	if _, err := r.w.Write(append(line, '\n')); err != nil {
+		r.logger.Warn("[engine recorder] failed to write record", "method", method, "err", err)
+		return
+	}
+	if err := r.w.Flush(); err != nil {
+		r.logger.Warn("[engine recorder] failed to write record", "method", method, "err", err)
+	}
+}
+
+func (r *EngineRecorder) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := errors.Join(r.w.Flush(), r.file.Close())
+	r.file = nil
+	return err
+}
+
+// engineAPIRecorder - engine namespace of EngineServer, which records every call
+type engineAPIRecorder struct {
+	srv *EngineServer
+	rec *EngineRecorder
+}
+
+var _ EngineAPI = (*engineAPIRecorder)(nil)
+
+func (r *engineAPIRecorder) NewPayloadV1(ctx context.Context, payload *engine_types.ExecutionPayload) (res *engine_types.PayloadStatus, err error) {
+	defer r.rec.Record("engine_newPayloadV1", time.Now(), &res, &err, payload)
+	return r.srv.NewPayloadV1(ctx, payload)
+}
+
+func (r *engineAPIRecorder) NewPayloadV2(ctx context.Context, payload *engine_types.ExecutionPayload) (res *engine_types.PayloadStatus, err error) {
+	defer r.rec.Record("engine_newPayloadV2", time.Now(), &res, &err, payload)
+	return r.srv.NewPayloadV2(ctx, payload)
+}
+
+func (r *engineAPIRecorder) NewPayloadV3(ctx context.Context, payload *engine_types.ExecutionPayload,
+	expectedBlobHashes []common.Hash, parentBeaconBlockRoot *common.Hash) (res *engine_types.PayloadStatus, err error) {
+	defer r.rec.Record("engine_newPayloadV3", time.Now(), &res, &err, payload, expectedBlobHashes, parentBeaconBlockRoot)
+	return r.srv.NewPayloadV3(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot)
+}
+
+func (r *engineAPIRecorder) NewPayloadV4(ctx context.Context, payload *engine_types.ExecutionPayload,
+	expectedBlobHashes []common.Hash, parentBeaconBlockRoot *common.Hash, executionRequests []hexutil.Bytes) (res *engine_types.PayloadStatus, err error) {
+	defer r.rec.Record("engine_newPayloadV4", time.Now(), &res, &err, payload, expectedBlobHashes, parentBeaconBlockRoot, executionRequests)
+	return r.srv.NewPayloadV4(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot, executionRequests)
+}
+
+func (r *engineAPIRecorder) NewPayloadV5(ctx context.Context, payload *engine_types.ExecutionPayload,
+	expectedBlobHashes []common.Hash, parentBeaconBlockRoot *common.Hash, executionRequests []hexutil.Bytes, inclusionList []hexutil.Bytes) (res *engine_types.PayloadStatus, err error) {
+	defer r.rec.Record("engine_newPayloadV5", time.Now(), &res, &err, payload, expectedBlobHashes, parentBeaconBlockRoot, executionRequests, inclusionList)
+	return r.srv.NewPayloadV5(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot, executionRequests, inclusionList)
+}
+
+func (r *engineAPIRecorder) ForkchoiceUpdatedV1(ctx context.Context, forkChoiceState *engine_types.ForkChoiceState, payloadAttributes *engine_types.PayloadAttributes) (res *engine_types.ForkChoiceUpdatedResponse, err error) {
+	defer r.rec.Record("engine_forkchoiceUpdatedV1", time.Now(), &res, &err, forkChoiceState, payloadAttributes)
+	return r.srv.ForkchoiceUpdatedV1(ctx, forkChoiceState, payloadAttributes)
+}
+
+func (r *engineAPIRecorder) ForkchoiceUpdatedV2(ctx context.Context, forkChoiceState *engine_types.ForkChoiceState, payloadAttributes *engine_types.PayloadAttributes) (res *engine_types.ForkChoiceUpdatedResponse, err error) {
+	defer r.rec.Record("engine_forkchoiceUpdatedV2", time.Now(), &res, &err, forkChoiceState, payloadAttributes)
+	return r.srv.ForkchoiceUpdatedV2(ctx, forkChoiceState, payloadAttributes)
+}
+
+func (r *engineAPIRecorder) ForkchoiceUpdatedV3(ctx context.Context, forkChoiceState *engine_types.ForkChoiceState, payloadAttributes *engine_types.PayloadAttributes) (res *engine_types.ForkChoiceUpdatedResponse, err error) {
+	defer r.rec.Record("engine_forkchoiceUpdatedV3", time.Now(), &res, &err, forkChoiceState, payloadAttributes)
+	return r.srv.ForkchoiceUpdatedV3(ctx, forkChoiceState, payloadAttributes)
+}
+
+func (r *engineAPIRecorder) GetPayloadV1(ctx context.Context, payloadID hexutil.Bytes) (res *engine_types.ExecutionPayload, err error) {
+	defer r.rec.Record("engine_getPayloadV1", time.Now(), &res, &err, payloadID)
+	return r.srv.GetPayloadV1(ctx, payloadID)
+}
+
+func (r *engineAPIRecorder) GetPayloadV2(ctx context.Context, payloadID hexutil.Bytes) (res *engine_types.GetPayloadResponse, err error) {
+	defer r.rec.Record("engine_getPayloadV2", time.Now(), &res, &err, payloadID)
+	return r.srv.GetPayloadV2(ctx, payloadID)
+}
+
+func (r *engineAPIRecorder) GetPayloadV3(ctx context.Context, payloadID hexutil.Bytes) (res *engine_types.GetPayloadResponse, err error) {
+	defer r.rec.Record("engine_getPayloadV3", time.Now(), &res, &err, payloadID)
+	return r.srv.GetPayloadV3(ctx, payloadID)
+}
+
+func (r *engineAPIRecorder) GetPayloadV4(ctx context.Context, payloadID hexutil.Bytes) (res *engine_types.GetPayloadResponse, err error) {
+	defer r.rec.Record("engine_getPayloadV4", time.Now(), &res, &err, payloadID)
+	return r.srv.GetPayloadV4(ctx, payloadID)
+}
+
+func (r *engineAPIRecorder) GetPayloadBodiesByHashV1(ctx context.Context, hashes []common.Hash) (res []*engine_types.ExecutionPayloadBody, err error) {
+	defer r.rec.Record("engine_getPayloadBodiesByHashV1", time.Now(), &res, &err, hashes)
+	return r.srv.GetPayloadBodiesByHashV1(ctx, hashes)
+}
+
+func (r *engineAPIRecorder) GetPayloadBodiesByRangeV1(ctx context.Context, start, count hexutil.Uint64) (res []*engine_types.ExecutionPayloadBody, err error) {
+	defer r.rec.Record("engine_getPayloadBodiesByRangeV1", time.Now(), &res, &err, start, count)
+	return r.srv.GetPayloadBodiesByRangeV1(ctx, start, count)
+}
+
+func (r *engineAPIRecorder) GetClientVersionV1(ctx context.Context, callerVersion *engine_types.ClientVersionV1) (res []engine_types.ClientVersionV1, err error) {
+	defer r.rec.Record("engine_getClientVersionV1", time.Now(), &res, &err, callerVersion)
+	return r.srv.GetClientVersionV1(ctx, callerVersion)
+}
+
+func (r *engineAPIRecorder) GetBlobsV1(ctx context.Context, blobHashes []common.Hash) (res []*engine_types.BlobAndProofV1, err error) {
+	defer r.rec.Record("engine_getBlobsV1", time.Now(), &res, &err, blobHashes)
+	return r.srv.GetBlobsV1(ctx, blobHashes)
+}
+
+func (r *engineAPIRecorder) GetBlobsV2(ctx context.Context, blobHashes []common.Hash) (res []*engine_types.BlobAndProofV2, err error) {
+	defer r.rec.Record("engine_getBlobsV2", time.Now(), &res, &err, blobHashes)
+	return r.srv.GetBlobsV2(ctx, blobHashes)
+}
+
+func (r *engineAPIRecorder) GetInclusionListV1(ctx context.Context, parentHash common.Hash) (res []hexutil.Bytes, err error) {
+	defer r.rec.Record("engine_getInclusionListV1", time.Now(), &res, &err, parentHash)
+	return r.srv.GetInclusionListV1(ctx, parentHash)
+}
+
+func (r *engineAPIRecorder) UpdatePayloadWithInclusionListV1(ctx context.Context, payloadID hexutil.Bytes, inclusionList []hexutil.Bytes) (res *hexutil.Bytes, err error) {
+	defer r.rec.Record("engine_updatePayloadWithInclusionListV1", time.Now(), &res, &err, payloadID, inclusionList)
+	return r.srv.UpdatePayloadWithInclusionListV1(ctx, payloadID, inclusionList)
+}
+
+func (r *engineAPIRecorder) ExchangeCapabilities(fromCl []string) (res []string) {
+	defer r.rec.Record("engine_exchangeCapabilities", time.Now(), &res, nil, fromCl)
+	return r.srv.ExchangeCapabilities(fromCl)
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engineapi
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
+)
+
+type replayTestAPI struct {
+	EngineAPI
+	newPayloads []*engine_types.PayloadStatus // nil - error
+	blockHashes []common.Hash
+	forkChoices []*engine_types.ForkChoiceState
+}
+
+func (a *replayTestAPI) NewPayloadV3(_ context.Context, payload *engine_types.ExecutionPayload, _ []common.Hash, _ *common.Hash) (*engine_types.PayloadStatus, error) {
+	a.blockHashes = append(a.blockHashes, payload.BlockHash)
+	status := a.newPayloads[0]
+	a.newPayloads = a.newPayloads[1:]
+	if status == nil {
+		return nil, &rpc.InvalidParamsError{Message: "invalid params"}
+	}
+	return status, nil
+}
+
+func (a *replayTestAPI) ForkchoiceUpdatedV3(_ context.Context, forkChoiceState *engine_types.ForkChoiceState, payloadAttributes *engine_types.PayloadAttributes) (*engine_types.ForkChoiceUpdatedResponse, error) {
+	if payloadAttributes != nil {
+		panic("payload attributes must not be replayed")
+	}
+	a.forkChoices = append(a.forkChoices, forkChoiceState)
+	return &engine_types.ForkChoiceUpdatedResponse{PayloadStatus: &engine_types.PayloadStatus{Status: engine_types.ValidStatus, LatestValidHash: &forkChoiceState.HeadHash}}, nil
+}
+
+func TestEngineRecordReplay(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "engine.jsonl")
+	rec, err := NewEngineRecorder(path, log.New())
+	require.NoError(t, err)
+
+	block1, block2, block3 := common.Hash{1}, common.Hash{2}, common.Hash{3}
+	root := common.Hash{0xbe}
+	now := time.Now()
+	var noErr error
+	invalidParamsErr := error(&rpc.InvalidParamsError{Message: "invalid params"})
+	valid1 := &engine_types.PayloadStatus{Status: engine_types.ValidStatus, LatestValidHash: &block1}
+	rec.Record("engine_newPayloadV3", now, &valid1, &noErr, &engine_types.ExecutionPayload{BlockHash: block1}, []common.Hash{}, &root)
+	fcu := &engine_types.ForkChoiceUpdatedResponse{PayloadStatus: valid1, PayloadId: &hexutil.Bytes{1}}
+	rec.Record("engine_forkchoiceUpdatedV3", now, &fcu, &noErr, &engine_types.ForkChoiceState{HeadHash: block1}, &engine_types.PayloadAttributes{Timestamp: 1})
+	rec.Record("engine_getPayloadV3", now, &engine_types.GetPayloadResponse{}, &noErr, hexutil.Bytes{1})
+	var nilStatus *engine_types.PayloadStatus
+	rec.Record("engine_newPayloadV3", now, &nilStatus, &invalidParamsErr, &engine_types.ExecutionPayload{BlockHash: block2}, []common.Hash{}, &root)
+	valid3 := &engine_types.PayloadStatus{Status: engine_types.ValidStatus, LatestValidHash: &block3}
+	rec.Record("engine_newPayloadV3", now, &valid3, &noErr, &engine_types.ExecutionPayload{BlockHash: block3}, []common.Hash{}, &root)
+	require.NoError(t, rec.Close())
+	rec.Record("engine_newPayloadV3", now, &valid3, &noErr, &engine_types.ExecutionPayload{BlockHash: block3}, []common.Hash{}, &root) // ignored after Close
+
+	api := &replayTestAPI{newPayloads: []*engine_types.PayloadStatus{
+		valid1,
+		nil,
+		{Status: engine_types.InvalidStatus, LatestValidHash: &block1, ValidationError: engine_types.NewStringifiedErrorFromString("bad block")},
+	}}
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	res, err := ReplayEngineRecording(context.Background(), api, f, log.New())
+	require.NoError(t, err)
+	require.Equal(t, ReplayResult{Replayed: 4, Skipped: 1, Divergences: 1}, res)
+	require.Equal(t, []common.Hash{block1, block2, block3}, api.blockHashes)
+	require.Len(t, api.forkChoices, 1)
+	require.Equal(t, block1, api.forkChoices[0].HeadHash)
+}
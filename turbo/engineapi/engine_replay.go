@@ -0,0 +1,219 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engineapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
+)
+
+// ReplayResult - summary of ReplayEngineRecording
+type ReplayResult struct {
+	Replayed    int // engine_newPayload and engine_forkchoiceUpdated calls
+	Skipped     int // other calls: they don't change chain
+	Divergences int // replayed calls which returned different payload status or error than recorded
+}
+
+// replayedStatus - part of PayloadStatus which is compared with recording
+type replayedStatus struct {
+	Status          engine_types.EngineStatus `json:"status"`
+	LatestValidHash *common.Hash              `json:"latestValidHash"`
+	ValidationError *string                   `json:"validationError"`
+}
+
+func (s *replayedStatus) String() string {
+	if s == nil {
+		return "<nil>"
+	}
+	res := string(s.Status)
+	if s.LatestValidHash != nil {
+		res += fmt.Sprintf(" latestValidHash=%x", *s.LatestValidHash)
+	}
+	if s.ValidationError != nil {
+		res += " validationError=" + *s.ValidationError
+	}
+	return res
+}
+
+// equal - validation errors are not compared: messages change between versions
+func (s *replayedStatus) equal(other *replayedStatus) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+	if s.Status != other.Status || (s.LatestValidHash == nil) != (other.LatestValidHash == nil) {
+		return false
+	}
+	return s.LatestValidHash == nil || *s.LatestValidHash == *other.LatestValidHash
+}
+
+func newReplayedStatus(status *engine_types.PayloadStatus) *replayedStatus {
+	if status == nil {
+		return nil
+	}
+	res := &replayedStatus{Status: status.Status, LatestValidHash: status.LatestValidHash}
+	if status.ValidationError != nil && status.ValidationError.Error() != nil {
+		validationErr := status.ValidationError.Error().Error()
+		res.ValidationError = &validationErr
+	}
+	return res
+}
+
+var errSkipReplay = errors.New("call is not replayed")
+
+// ReplayEngineRecording - feeds engine_newPayload and engine_forkchoiceUpdated calls of recording, written by EngineRecorder,
+// to api in recorded order and reports calls which returned different payload status than recorded. Payload attributes
+// are dropped from engine_forkchoiceUpdated: block building depends on txpool and is not reproducible.
+func ReplayEngineRecording(ctx context.Context, api EngineAPI, r io.Reader, logger log.Logger) (ReplayResult, error) {
+	var res ReplayResult
+	dec := json.NewDecoder(r)
+	for i := 0; ; i++ {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		var rec EngineRecord
+		if err := dec.Decode(&rec); errors.Is(err, io.EOF) {
+			return res, nil
+		} else if err != nil {
+			return res, fmt.Errorf("engine replay: record %d: %w", i, err)
+		}
+
+		status, callErr, err := replayEngineCall(ctx, api, &rec)
+		if errors.Is(err, errSkipReplay) {
+			res.Skipped++
+			continue
+		}
+		if err != nil {
+			return res, fmt.Errorf("engine replay: record %d %s: %w", i, rec.Method, err)
+		}
+		res.Replayed++
+
+		var expected *replayedStatus
+		if rec.Error == nil {
+			if expected, err = recordedStatus(&rec); err != nil {
+				return res, fmt.Errorf("engine replay: record %d %s: %w", i, rec.Method, err)
+			}
+		}
+		switch {
+		case rec.Error != nil && callErr != nil:
+			logger.Debug("[engine replay] call failed as recorded", "record", i, "method", rec.Method, "err", callErr)
+		case rec.Error != nil:
+			res.Divergences++
+			logger.Warn("[engine replay] divergence", "record", i, "method", rec.Method, "time", rec.Time, "expected_err", rec.Error.Message, "got", status)
+		case callErr != nil:
+			res.Divergences++
+			logger.Warn("[engine replay] divergence", "record", i, "method", rec.Method, "time", rec.Time, "expected", expected, "got_err", callErr)
+		case !expected.equal(status):
+			res.Divergences++
+			logger.Warn("[engine replay] divergence", "record", i, "method", rec.Method, "time", rec.Time, "expected", expected, "got", status)
+		default:
+			logger.Debug("[engine replay] call returned recorded status", "record", i, "method", rec.Method, "status", status)
+		}
+	}
+}
+
+// replayEngineCall - err is for malformed records, callErr - error returned by api
+func replayEngineCall(ctx context.Context, api EngineAPI, rec *EngineRecord) (status *replayedStatus, callErr error, err error) {
+	switch rec.Method {
+	case "engine_newPayloadV1", "engine_newPayloadV2", "engine_newPayloadV3", "engine_newPayloadV4", "engine_newPayloadV5":
+		var (
+			payload               *engine_types.ExecutionPayload
+			expectedBlobHashes    []common.Hash
+			parentBeaconBlockRoot *common.Hash
+			executionRequests     []hexutil.Bytes
+			inclusionList         []hexutil.Bytes
+		)
+		if err := decodeRecordParams(rec.Params, &payload, &expectedBlobHashes, &parentBeaconBlockRoot, &executionRequests, &inclusionList); err != nil {
+			return nil, nil, err
+		}
+		var res *engine_types.PayloadStatus
+		switch rec.Method {
+		case "engine_newPayloadV1":
+			res, callErr = api.NewPayloadV1(ctx, payload)
+		case "engine_newPayloadV2":
+			res, callErr = api.NewPayloadV2(ctx, payload)
+		case "engine_newPayloadV3":
+			res, callErr = api.NewPayloadV3(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot)
+		case "engine_newPayloadV4":
+			res, callErr = api.NewPayloadV4(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot, executionRequests)
+		default:
+			res, callErr = api.NewPayloadV5(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot, executionRequests, inclusionList)
+		}
+		return newReplayedStatus(res), callErr, nil
+	case "engine_forkchoiceUpdatedV1", "engine_forkchoiceUpdatedV2", "engine_forkchoiceUpdatedV3":
+		var forkChoiceState *engine_types.ForkChoiceState
+		if err := decodeRecordParams(rec.Params, &forkChoiceState); err != nil {
+			return nil, nil, err
+		}
+		var res *engine_types.ForkChoiceUpdatedResponse
+		switch rec.Method {
+		case "engine_forkchoiceUpdatedV1":
+			res, callErr = api.ForkchoiceUpdatedV1(ctx, forkChoiceState, nil)
+		case "engine_forkchoiceUpdatedV2":
+			res, callErr = api.ForkchoiceUpdatedV2(ctx, forkChoiceState, nil)
+		default:
+			res, callErr = api.ForkchoiceUpdatedV3(ctx, forkChoiceState, nil)
+		}
+		if res != nil {
+			status = newReplayedStatus(res.PayloadStatus)
+		}
+		return status, callErr, nil
+	default:
+		return nil, nil, errSkipReplay
+	}
+}
+
+func recordedStatus(rec *EngineRecord) (*replayedStatus, error) {
+	if len(rec.Result) == 0 {
+		return nil, nil
+	}
+	var status *replayedStatus
+	if strings.HasPrefix(rec.Method, "engine_newPayload") {
+		if err := json.Unmarshal(rec.Result, &status); err != nil {
+			return nil, err
+		}
+		return status, nil
+	}
+	var res *struct {
+		PayloadStatus *replayedStatus `json:"payloadStatus"`
+	}
+	if err := json.Unmarshal(rec.Result, &res); err != nil {
+		return nil, err
+	}
+	if res != nil {
+		status = res.PayloadStatus
+	}
+	return status, nil
+}
+
+// decodeRecordParams - decodes params to dst in order, missing trailing params are left as is
+func decodeRecordParams(params []json.RawMessage, dst ...any) error {
+	for i := 0; i < len(params) && i < len(dst); i++ {
+		if err := json.Unmarshal(params[i], dst[i]); err != nil {
+			return fmt.Errorf("param %d: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/empty"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/common/math"
+	libkzg "github.com/erigontech/erigon-lib/crypto/kzg"
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	execution "github.com/erigontech/erigon-lib/gointerfaces/executionproto"
 	txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
@@ -55,6 +56,7 @@ import (
 	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
 	"github.com/erigontech/erigon/turbo/services"
 	"github.com/erigontech/erigon/turbo/stages/headerdownload"
+	"github.com/erigontech/erigon/txnprovider"
 )
 
 var caplinEnabledLog = "Caplin is enabled, so the engine API cannot be used. for external CL use --externalcl"
@@ -71,7 +73,11 @@ type EngineServer struct {
 	test             bool
 	caplin           bool // we need to send errors for caplin.
 	executionService execution.ExecutionClient
-	txpool           txpool.TxpoolClient // needed for getBlobs
+	txpool           txpool.TxpoolClient     // needed for getBlobs
+	txnProvider      txnprovider.TxnProvider // needed for inclusion lists, nil if txpool is disabled
+	db               kv.TemporalRoDB         // needed for inclusion lists and block access lists checks
+	blockReader      services.FullBlockReader
+	engine           consensus.EngineReader // needed for block access lists checks
 
 	chainRW eth1_chain_reader.ChainReaderWriterEth1
 	lock    sync.Mutex
@@ -86,13 +92,14 @@ const fcuTimeout = 1000 // according to mathematics: 1000 millisecods = 1 second
 
 func NewEngineServer(logger log.Logger, config *chain.Config, executionService execution.ExecutionClient,
 	hd *headerdownload.HeaderDownload,
-	blockDownloader *engine_block_downloader.EngineBlockDownloader, caplin, test, proposing, consuming bool) *EngineServer {
+	blockDownloader *engine_block_downloader.EngineBlockDownloader, txnProvider txnprovider.TxnProvider, caplin, test, proposing, consuming bool) *EngineServer {
 	chainRW := eth1_chain_reader.NewChainReaderEth1(config, executionService, fcuTimeout)
 	srv := &EngineServer{
 		logger:            logger,
 		config:            config,
 		executionService:  executionService,
 		blockDownloader:   blockDownloader,
+		txnProvider:       txnProvider,
 		chainRW:           chainRW,
 		proposing:         proposing,
 		hd:                hd,
@@ -117,13 +124,30 @@ func (e *EngineServer) Start(
 	eth rpchelper.ApiBackend,
 	txPool txpool.TxpoolClient,
 	mining txpool.MiningClient,
-) {
+) error {
+	var engineAPI EngineAPI = e
+	if httpConfig.AuthRpcRecordPath != "" {
+		recorder, err := NewEngineRecorder(httpConfig.AuthRpcRecordPath, e.logger)
+		if err != nil {
+			return fmt.Errorf("open Engine API recording %s: %w", httpConfig.AuthRpcRecordPath, err)
+		}
+		e.logger.Info("Recording Engine API calls", "path", httpConfig.AuthRpcRecordPath)
+		engineAPI = &engineAPIRecorder{srv: e, rec: recorder}
+		go func() {
+			<-ctx.Done()
+			recorder.Close()
+		}()
+	}
+
 	if !e.caplin {
 		e.engineLogSpamer.Start(ctx)
 	}
 	base := jsonrpc.NewBaseApi(filters, stateCache, blockReader, httpConfig.WithDatadir, httpConfig.EvmCallTimeout, engineReader, httpConfig.Dirs, nil)
 	ethImpl := jsonrpc.NewEthAPI(base, db, eth, txPool, mining, httpConfig.Gascap, httpConfig.Feecap, httpConfig.ReturnDataLimit, httpConfig.AllowUnprotectedTxs, httpConfig.MaxGetProofRewindBlockCount, httpConfig.WebsocketSubscribeLogsChannelSize, e.logger)
 	e.txpool = txPool
+	e.db = db
+	e.blockReader = blockReader
+	e.engine = engineReader
 
 	apiList := []rpc.API{
 		{
@@ -134,13 +158,14 @@ func (e *EngineServer) Start(
 		}, {
 			Namespace: "engine",
 			Public:    true,
-			Service:   EngineAPI(e),
+			Service:   engineAPI,
 			Version:   "1.0",
 		}}
 
 	if err := cli.StartRpcServerWithJwtAuthentication(ctx, httpConfig, apiList, e.logger); err != nil {
 		e.logger.Error(err.Error())
 	}
+	return nil
 }
 
 func (s *EngineServer) checkWithdrawalsPresence(time uint64, withdrawals types.Withdrawals) error {
@@ -167,7 +192,7 @@ func (s *EngineServer) checkRequestsPresence(version clparams.StateVersion, exec
 
 // EngineNewPayload validates and possibly executes payload
 func (s *EngineServer) newPayload(ctx context.Context, req *engine_types.ExecutionPayload,
-	expectedBlobHashes []common.Hash, parentBeaconBlockRoot *common.Hash, executionRequests []hexutil.Bytes, version clparams.StateVersion,
+	expectedBlobHashes []common.Hash, parentBeaconBlockRoot *common.Hash, executionRequests []hexutil.Bytes, inclusionList []hexutil.Bytes, version clparams.StateVersion,
 ) (*engine_types.PayloadStatus, error) {
 	if !s.consuming.Load() {
 		return nil, errors.New("engine payload consumption is not enabled")
@@ -260,7 +285,9 @@ func (s *EngineServer) newPayload(ctx context.Context, req *engine_types.Executi
 	if (!s.config.IsCancun(header.Time) && version >= clparams.DenebVersion) ||
 		(s.config.IsCancun(header.Time) && version < clparams.DenebVersion) ||
 		(!s.config.IsPrague(header.Time) && version >= clparams.ElectraVersion) ||
-		(s.config.IsPrague(header.Time) && version < clparams.ElectraVersion) {
+		(s.config.IsPrague(header.Time) && version < clparams.ElectraVersion) ||
+		(!s.config.IsFocil(header.Time) && version >= clparams.FocilVersion) ||
+		(s.config.IsFocil(header.Time) && version < clparams.FocilVersion) {
 		return nil, &rpc.UnsupportedForkError{Message: "Unsupported fork"}
 	}
 
@@ -293,6 +320,19 @@ func (s *EngineServer) newPayload(ctx context.Context, req *engine_types.Executi
 		}, nil
 	}
 
+	if req.BlockAccessList != nil {
+		if !s.config.IsBlockAccessList(header.Time) {
+			return nil, &rpc.InvalidParamsError{Message: "Unexpected blockAccessList before its fork"}
+		}
+		if err := req.BlockAccessList.Validate(len(transactions)); err != nil {
+			s.logger.Warn("[NewPayload] invalid block access list", "err", err)
+			return &engine_types.PayloadStatus{
+				Status:          engine_types.InvalidStatus,
+				ValidationError: engine_types.NewStringifiedErrorFromString(fmt.Sprintf("invalid block access list: %v", err)),
+			}, nil
+		}
+	}
+
 	if version >= clparams.DenebVersion {
 		err := ethutils.ValidateBlobs(req.BlobGasUsed.Uint64(), s.config.GetMaxBlobGasPerBlock(header.Time), s.config.GetMaxBlobsPerBlock(header.Time), expectedBlobHashes, &transactions)
 		if errors.Is(err, ethutils.ErrNilBlobHashes) {
@@ -317,6 +357,17 @@ func (s *EngineServer) newPayload(ctx context.Context, req *engine_types.Executi
 		}
 	}
 
+	// before execution: block which doesn't satisfy inclusion list must not be marked valid
+	if version >= clparams.FocilVersion {
+		unsatisfiedStatus, err := s.checkInclusionList(ctx, req, inclusionList)
+		if err != nil {
+			return nil, err
+		}
+		if unsatisfiedStatus != nil {
+			return unsatisfiedStatus, nil
+		}
+	}
+
 	possibleStatus, err := s.getQuickPayloadStatusIfPossible(ctx, blockHash, uint64(req.BlockNumber), header.ParentHash, nil, true)
 	if err != nil {
 		return nil, err
@@ -347,6 +398,17 @@ func (s *EngineServer) newPayload(ctx context.Context, req *engine_types.Executi
 		return nil, payloadStatus.CriticalError
 	}
 
+	// block access list isn't committed to by the block hash: mismatch makes only this payload invalid, not the block
+	if req.BlockAccessList != nil && payloadStatus.Status == engine_types.ValidStatus {
+		invalidStatus, err := s.checkBlockAccessList(ctx, req, block)
+		if err != nil {
+			return nil, err
+		}
+		if invalidStatus != nil {
+			return invalidStatus, nil
+		}
+	}
+
 	if version == clparams.ElectraVersion && s.printPectraBanner && payloadStatus.Status == engine_types.ValidStatus {
 		s.printPectraBanner = false
 		log.Info(engine_helpers.PectraBanner)
@@ -914,15 +976,20 @@ func (e *EngineServer) SetConsuming(consuming bool) {
 	e.consuming.Store(consuming)
 }
 
-func (e *EngineServer) getBlobs(ctx context.Context, blobHashes []common.Hash) ([]*engine_types.BlobAndProofV1, error) {
+// getBlobsFromPool - blobs with blob proofs, or with concatenated cell proofs if cellProofs
+func (e *EngineServer) getBlobsFromPool(ctx context.Context, blobHashes []common.Hash, cellProofs bool) (*txpool.GetBlobsReply, error) {
 	if len(blobHashes) > 128 {
 		return nil, &engine_helpers.TooLargeRequestErr
 	}
-	req := &txpool.GetBlobsRequest{BlobHashes: make([]*typesproto.H256, len(blobHashes))}
+	req := &txpool.GetBlobsRequest{BlobHashes: make([]*typesproto.H256, len(blobHashes)), CellProofs: cellProofs}
 	for i := range blobHashes {
 		req.BlobHashes[i] = gointerfaces.ConvertHashToH256(blobHashes[i])
 	}
-	res, err := e.txpool.GetBlobs(ctx, req)
+	return e.txpool.GetBlobs(ctx, req)
+}
+
+func (e *EngineServer) getBlobs(ctx context.Context, blobHashes []common.Hash) ([]*engine_types.BlobAndProofV1, error) {
+	res, err := e.getBlobsFromPool(ctx, blobHashes, false)
 	if err != nil {
 		return nil, err
 	}
@@ -944,6 +1011,31 @@ func (e *EngineServer) getBlobs(ctx context.Context, blobHashes []common.Hash) (
 	return ret, nil
 }
 
+// getBlobsV2 - all or nothing: nil if any of blobs is not known
+func (e *EngineServer) getBlobsV2(ctx context.Context, blobHashes []common.Hash) ([]*engine_types.BlobAndProofV2, error) {
+	res, err := e.getBlobsFromPool(ctx, blobHashes, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(blobHashes) != len(res.Blobs) || len(blobHashes) != len(res.Proofs) { // Some fault in the underlying txpool, but still return sane resp
+		log.Warn("[GetBlobsV2] txpool returned unexpected number of blobs and proofs in response, returning nil")
+		return nil, nil
+	}
+	ret := make([]*engine_types.BlobAndProofV2, len(blobHashes))
+	for i := range res.Blobs {
+		if res.Blobs[i] == nil || len(res.Proofs[i]) != libkzg.CellsPerExtBlob*types.LEN_48 {
+			e.logger.Debug("[GetBlobsV2] blob is not known", "hash", blobHashes[i], "requested", len(blobHashes))
+			return nil, nil
+		}
+		ret[i] = &engine_types.BlobAndProofV2{Blob: res.Blobs[i], CellProofs: make([]hexutil.Bytes, libkzg.CellsPerExtBlob)}
+		for j := range ret[i].CellProofs {
+			ret[i].CellProofs[j] = res.Proofs[i][j*types.LEN_48 : (j+1)*types.LEN_48]
+		}
+	}
+	e.logger.Debug("[GetBlobsV2]", "blobs", len(ret))
+	return ret, nil
+}
+
 func waitForStuff(maxWait time.Duration, waitCondnF func() (bool, error)) (bool, error) {
 	shouldWait, err := waitCondnF()
 	if err != nil || !shouldWait {
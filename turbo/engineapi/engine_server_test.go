@@ -26,6 +26,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
+	libkzg "github.com/erigontech/erigon-lib/crypto/kzg"
 	"github.com/erigontech/erigon-lib/direct"
 	sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
 	txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
@@ -111,8 +112,8 @@ func TestGetBlobsV1(t *testing.T) {
 
 	executionRpc := direct.NewExecutionClientDirect(mockSentry.Eth1ExecutionService)
 	eth := rpcservices.NewRemoteBackend(nil, mockSentry.DB, mockSentry.BlockReader)
-	engineServer := NewEngineServer(mockSentry.Log, mockSentry.ChainConfig, executionRpc, mockSentry.HeaderDownload(), nil, false, true, false, true)
-	engineServer.Start(ctx, &httpcfg.HttpCfg{}, mockSentry.DB, mockSentry.BlockReader, ff, nil, mockSentry.Engine, eth, txPool, nil)
+	engineServer := NewEngineServer(mockSentry.Log, mockSentry.ChainConfig, executionRpc, mockSentry.HeaderDownload(), nil, nil, false, true, false, true)
+	require.NoError(engineServer.Start(ctx, &httpcfg.HttpCfg{}, mockSentry.DB, mockSentry.BlockReader, ff, nil, mockSentry.Engine, eth, txPool, nil))
 
 	err = wrappedTxn.MarshalBinaryWrapped(buf)
 	require.NoError(err)
@@ -128,3 +129,52 @@ func TestGetBlobsV1(t *testing.T) {
 	require.Equal(blobsResp[1].Proof, hexutil.Bytes(wrappedTxn.Proofs[0][:]))
 	require.Equal(blobsResp[2].Proof, hexutil.Bytes(wrappedTxn.Proofs[1][:]))
 }
+
+func TestGetBlobsV2(t *testing.T) {
+	logger := log.New()
+	buf := bytes.NewBuffer(nil)
+	mockSentry, require := mock.MockWithTxPoolOsaka(t), require.New(t)
+	oneBlockStep(mockSentry, require, t)
+
+	wrappedTxn := types.MakeWrappedBlobTxn(uint256.MustFromBig(mockSentry.ChainConfig.ChainID))
+	txn, err := types.SignTx(wrappedTxn, *types.LatestSignerForChainID(mockSentry.ChainConfig.ChainID), mockSentry.Key)
+	require.NoError(err)
+	dt := &wrappedTxn.Tx.DynamicFeeTransaction
+	v, r, s := txn.RawSignatureValues()
+	dt.V.Set(v)
+	dt.R.Set(r)
+	dt.S.Set(s)
+
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, mockSentry)
+	txPool := direct.NewTxPoolClient(mockSentry.TxPoolGrpcServer)
+
+	ff := rpchelper.New(ctx, rpchelper.DefaultFiltersConfig, nil, txPool, txpool.NewMiningClient(conn), func() {}, mockSentry.Log)
+	api := jsonrpc.NewEthAPI(newBaseApiForTest(mockSentry), mockSentry.DB, nil, txPool, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, logger)
+
+	executionRpc := direct.NewExecutionClientDirect(mockSentry.Eth1ExecutionService)
+	eth := rpcservices.NewRemoteBackend(nil, mockSentry.DB, mockSentry.BlockReader)
+	engineServer := NewEngineServer(mockSentry.Log, mockSentry.ChainConfig, executionRpc, mockSentry.HeaderDownload(), nil, nil, false, true, false, true)
+	require.NoError(engineServer.Start(ctx, &httpcfg.HttpCfg{}, mockSentry.DB, mockSentry.BlockReader, ff, nil, mockSentry.Engine, eth, txPool, nil))
+
+	// local txn with blob proofs gets cell proofs after Osaka
+	err = wrappedTxn.MarshalBinaryWrapped(buf)
+	require.NoError(err)
+	_, err = api.SendRawTransaction(ctx, buf.Bytes())
+	require.NoError(err)
+
+	blobsResp, err := engineServer.GetBlobsV2(ctx, wrappedTxn.Tx.BlobVersionedHashes)
+	require.NoError(err)
+	require.Len(blobsResp, 2)
+	for i, blobResp := range blobsResp {
+		require.Equal(hexutil.Bytes(wrappedTxn.Blobs[i][:]), blobResp.Blob)
+		require.Len(blobResp.CellProofs, libkzg.CellsPerExtBlob)
+		cellProofs, err := libkzg.ComputeCellProofs(wrappedTxn.Blobs[i][:])
+		require.NoError(err)
+		require.Equal(hexutil.Bytes(cellProofs[0][:]), blobResp.CellProofs[0])
+	}
+
+	// all or nothing
+	blobsResp, err = engineServer.GetBlobsV2(ctx, append([]common.Hash{{}}, wrappedTxn.Tx.BlobVersionedHashes...))
+	require.NoError(err)
+	require.Nil(blobsResp)
+}
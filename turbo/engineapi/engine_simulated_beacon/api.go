@@ -0,0 +1,59 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engine_simulated_beacon
+
+import (
+	"context"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon/rpc"
+)
+
+// DevAPI - `dev` namespace: manual control of block production of dev chain
+type DevAPI struct {
+	beacon *SimulatedBeacon
+}
+
+func (b *SimulatedBeacon) API() rpc.API {
+	return rpc.API{
+		Namespace: "dev",
+		Public:    true,
+		Service:   &DevAPI{beacon: b},
+		Version:   "1.0",
+	}
+}
+
+// Mine implements dev_mine. Produces block immediately and returns its hash.
+func (api *DevAPI) Mine(ctx context.Context) (common.Hash, error) {
+	payload, err := api.beacon.Mine(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return payload.BlockHash, nil
+}
+
+// SetBlockTime implements dev_setBlockTime. Sets timestamp of next produced block.
+func (api *DevAPI) SetBlockTime(_ context.Context, timestamp hexutil.Uint64) error {
+	return api.beacon.SetNextBlockTime(uint64(timestamp))
+}
+
+// AddWithdrawal implements dev_addWithdrawal. Queues withdrawal of amount Gwei into next block.
+func (api *DevAPI) AddWithdrawal(_ context.Context, address common.Address, amount hexutil.Uint64) error {
+	api.beacon.AddWithdrawal(address, uint64(amount))
+	return nil
+}
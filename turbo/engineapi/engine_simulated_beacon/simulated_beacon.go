@@ -0,0 +1,310 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engine_simulated_beacon
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/crypto/kzg"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/engineapi"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_helpers"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
+)
+
+const (
+	// DefaultBuildTime - time given to block builder to fill payload with transactions
+	DefaultBuildTime = 500 * time.Millisecond
+	// SyntheticWithdrawalAmount - amount (in Gwei) of withdrawal to fee recipient included into every block
+	SyntheticWithdrawalAmount = 1_000_000_000 // 1 ETH
+
+	engineRetryTimeout  = time.Minute
+	engineRetryInterval = 50 * time.Millisecond
+)
+
+// SimulatedBeacon - in-process consensus layer of dev chain. Produces blocks through Engine API
+// (forkchoiceUpdated -> getPayload -> newPayload -> forkchoiceUpdated) every period, or on demand by Mine.
+// Every block gets synthetic withdrawal to fee recipient and synthetic parent beacon block root.
+type SimulatedBeacon struct {
+	engine       engineapi.EngineAPI
+	config       *chain.Config
+	period       time.Duration // 0 - produce blocks only on demand
+	buildTime    time.Duration
+	feeRecipient common.Address
+	logger       log.Logger
+
+	mu              sync.Mutex // serializes block production
+	head            common.Hash
+	headTime        uint64
+	slot            uint64
+	nextTime        uint64 // timestamp of next block set by SetNextBlockTime, 0 - wall clock
+	withdrawalIndex uint64
+	withdrawals     []*types.Withdrawal // queued by AddWithdrawal, go to next block
+}
+
+func New(engine engineapi.EngineAPI, config *chain.Config, head *types.Header, period time.Duration, feeRecipient common.Address, logger log.Logger) *SimulatedBeacon {
+	return &SimulatedBeacon{
+		engine:       engine,
+		config:       config,
+		period:       period,
+		buildTime:    DefaultBuildTime,
+		feeRecipient: feeRecipient,
+		logger:       logger,
+		head:         head.Hash(),
+		headTime:     head.Time,
+		slot:         head.Number.Uint64(),
+	}
+}
+
+// SetBuildTime - time between payload building start and getPayload
+func (b *SimulatedBeacon) SetBuildTime(d time.Duration) { b.buildTime = d }
+
+// Run produces blocks every period until ctx is done. Does nothing if period is 0.
+func (b *SimulatedBeacon) Run(ctx context.Context) error {
+	if b.period == 0 {
+		<-ctx.Done()
+		return nil
+	}
+	b.logger.Info("[simulated-beacon] started", "period", b.period, "feeRecipient", b.feeRecipient)
+	ticker := time.NewTicker(b.period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := b.Mine(ctx); err != nil {
+				if errors.Is(err, context.Canceled) {
+					return nil
+				}
+				b.logger.Warn("[simulated-beacon] block production failed", "err", err)
+			}
+		}
+	}
+}
+
+// SetNextBlockTime - timestamp of next produced block. Must be greater than timestamp of head.
+func (b *SimulatedBeacon) SetNextBlockTime(timestamp uint64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if timestamp <= b.headTime {
+		return fmt.Errorf("block time %d must be greater than head block time %d", timestamp, b.headTime)
+	}
+	b.nextTime = timestamp
+	return nil
+}
+
+// AddWithdrawal - queues withdrawal of amount Gwei to address into next block
+func (b *SimulatedBeacon) AddWithdrawal(address common.Address, amount uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.withdrawals = append(b.withdrawals, &types.Withdrawal{Address: address, Amount: amount})
+}
+
+// Mine produces one block on top of head and makes it canonical head
+func (b *SimulatedBeacon) Mine(ctx context.Context) (*engine_types.ExecutionPayload, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	timestamp := max(uint64(time.Now().Unix()), b.headTime+1)
+	if b.nextTime != 0 {
+		timestamp = b.nextTime
+	}
+	slot := b.slot + 1
+	var slotBytes [8]byte
+	binary.BigEndian.PutUint64(slotBytes[:], slot)
+	beaconRoot := crypto.Keccak256Hash([]byte("simulated-beacon-root"), slotBytes[:])
+
+	attributes := &engine_types.PayloadAttributes{
+		Timestamp:             hexutil.Uint64(timestamp),
+		PrevRandao:            crypto.Keccak256Hash(b.head[:]),
+		SuggestedFeeRecipient: b.feeRecipient,
+	}
+	withdrawalIndex := b.withdrawalIndex
+	if b.config.IsShanghai(timestamp) {
+		// queue is not touched until payload is accepted: failed block production keeps withdrawals for next one
+		withdrawals := append(slices.Clone(b.withdrawals), &types.Withdrawal{Address: b.feeRecipient, Amount: SyntheticWithdrawalAmount})
+		for i, w := range withdrawals {
+			withdrawals[i] = &types.Withdrawal{Index: withdrawalIndex, Validator: slot, Address: w.Address, Amount: w.Amount}
+			withdrawalIndex++
+		}
+		attributes.Withdrawals = withdrawals
+	}
+	if b.config.IsCancun(timestamp) {
+		attributes.ParentBeaconBlockRoot = &beaconRoot
+	}
+
+	fcuState := &engine_types.ForkChoiceState{HeadHash: b.head, SafeBlockHash: b.head, FinalizedBlockHash: b.head}
+	fcu, err := retrySyncing(ctx, func() (*engine_types.ForkChoiceUpdatedResponse, engine_types.EngineStatus, error) {
+		r, err := b.forkchoiceUpdated(ctx, fcuState, attributes, timestamp)
+		if err != nil {
+			return nil, "", err
+		}
+		return r, r.PayloadStatus.Status, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("forkchoiceUpdated with attributes: %w", err)
+	}
+	if fcu.PayloadStatus.Status != engine_types.ValidStatus || fcu.PayloadId == nil {
+		return nil, fmt.Errorf("forkchoiceUpdated with attributes: status %s, payloadId %v", fcu.PayloadStatus.Status, fcu.PayloadId)
+	}
+
+	if err := common.Sleep(ctx, b.buildTime); err != nil {
+		return nil, err
+	}
+	built, err := b.getPayload(ctx, *fcu.PayloadId, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("getPayload: %w", err)
+	}
+	payload := built.ExecutionPayload
+
+	status, err := retrySyncing(ctx, func() (*engine_types.PayloadStatus, engine_types.EngineStatus, error) {
+		r, err := b.newPayload(ctx, built, &beaconRoot)
+		if err != nil {
+			return nil, "", err
+		}
+		return r, r.Status, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("newPayload: %w", err)
+	}
+	if status.Status != engine_types.ValidStatus {
+		return nil, fmt.Errorf("newPayload: status %s, err %v", status.Status, status.ValidationError)
+	}
+
+	newHead := payload.BlockHash
+	fcuState = &engine_types.ForkChoiceState{HeadHash: newHead, SafeBlockHash: newHead, FinalizedBlockHash: newHead}
+	fcu, err = retrySyncing(ctx, func() (*engine_types.ForkChoiceUpdatedResponse, engine_types.EngineStatus, error) {
+		r, err := b.forkchoiceUpdated(ctx, fcuState, nil, timestamp)
+		if err != nil {
+			return nil, "", err
+		}
+		return r, r.PayloadStatus.Status, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("forkchoiceUpdated: %w", err)
+	}
+	if fcu.PayloadStatus.Status != engine_types.ValidStatus {
+		return nil, fmt.Errorf("forkchoiceUpdated: status %s", fcu.PayloadStatus.Status)
+	}
+
+	b.head, b.headTime, b.slot = newHead, uint64(payload.Timestamp), slot
+	b.nextTime = 0
+	if attributes.Withdrawals != nil {
+		b.withdrawals, b.withdrawalIndex = nil, withdrawalIndex
+	}
+	b.logger.Info("[simulated-beacon] block produced", "number", uint64(payload.BlockNumber), "hash", newHead, "txs", len(payload.Transactions))
+	return payload, nil
+}
+
+func (b *SimulatedBeacon) forkchoiceUpdated(ctx context.Context, state *engine_types.ForkChoiceState, attributes *engine_types.PayloadAttributes, timestamp uint64) (*engine_types.ForkChoiceUpdatedResponse, error) {
+	switch {
+	case b.config.IsCancun(timestamp):
+		return b.engine.ForkchoiceUpdatedV3(ctx, state, attributes)
+	case b.config.IsShanghai(timestamp):
+		return b.engine.ForkchoiceUpdatedV2(ctx, state, attributes)
+	default:
+		return b.engine.ForkchoiceUpdatedV1(ctx, state, attributes)
+	}
+}
+
+// getPayload - retries while execution is busy with previous block
+func (b *SimulatedBeacon) getPayload(ctx context.Context, payloadId hexutil.Bytes, timestamp uint64) (*engine_types.GetPayloadResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, engineRetryTimeout)
+	defer cancel()
+	for {
+		var resp *engine_types.GetPayloadResponse
+		var err error
+		switch {
+		case b.config.IsPrague(timestamp):
+			resp, err = b.engine.GetPayloadV4(ctx, payloadId)
+		case b.config.IsCancun(timestamp):
+			resp, err = b.engine.GetPayloadV3(ctx, payloadId)
+		case b.config.IsShanghai(timestamp):
+			resp, err = b.engine.GetPayloadV2(ctx, payloadId)
+		default:
+			var payload *engine_types.ExecutionPayload
+			if payload, err = b.engine.GetPayloadV1(ctx, payloadId); err == nil {
+				resp = &engine_types.GetPayloadResponse{ExecutionPayload: payload}
+			}
+		}
+		var rpcErr rpc.Error
+		if err == nil || !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != engine_helpers.UnknownPayloadErr.Code { // execution is busy
+			return resp, err
+		}
+		if err := common.Sleep(ctx, engineRetryInterval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (b *SimulatedBeacon) newPayload(ctx context.Context, built *engine_types.GetPayloadResponse, beaconRoot *common.Hash) (*engine_types.PayloadStatus, error) {
+	payload := built.ExecutionPayload
+	timestamp := uint64(payload.Timestamp)
+	if !b.config.IsCancun(timestamp) {
+		if b.config.IsShanghai(timestamp) {
+			return b.engine.NewPayloadV2(ctx, payload)
+		}
+		return b.engine.NewPayloadV1(ctx, payload)
+	}
+	blobHashes := make([]common.Hash, 0)
+	if built.BlobsBundle != nil {
+		for _, commitment := range built.BlobsBundle.Commitments {
+			var c [48]byte
+			copy(c[:], commitment)
+			blobHashes = append(blobHashes, common.Hash(kzg.KZGToVersionedHash(c)))
+		}
+	}
+	if b.config.IsPrague(timestamp) {
+		requests := built.ExecutionRequests
+		if requests == nil {
+			requests = make([]hexutil.Bytes, 0)
+		}
+		return b.engine.NewPayloadV4(ctx, payload, blobHashes, beaconRoot, requests)
+	}
+	return b.engine.NewPayloadV3(ctx, payload, blobHashes, beaconRoot)
+}
+
+// retrySyncing - repeats f while engine responds SYNCING (e.g. execution module is still starting)
+func retrySyncing[T any](ctx context.Context, f func() (*T, engine_types.EngineStatus, error)) (*T, error) {
+	ctx, cancel := context.WithTimeout(ctx, engineRetryTimeout)
+	defer cancel()
+	for {
+		res, status, err := f()
+		if err != nil {
+			return nil, err
+		}
+		if status != engine_types.SyncingStatus {
+			return res, nil
+		}
+		if err := common.Sleep(ctx, engineRetryInterval); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engine_simulated_beacon
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/turbo/engineapi"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_helpers"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
+)
+
+// fakeEngine - builds payload out of attributes, records calls
+type fakeEngine struct {
+	engineapi.EngineAPI
+	calls      []string
+	head       common.Hash
+	attributes *engine_types.PayloadAttributes
+	number     uint64
+	busy       int  // getPayload responds UnknownPayload this many times
+	invalid    bool // newPayload responds Invalid
+	beaconRoot *common.Hash
+}
+
+func (e *fakeEngine) ForkchoiceUpdatedV3(_ context.Context, state *engine_types.ForkChoiceState, attributes *engine_types.PayloadAttributes) (*engine_types.ForkChoiceUpdatedResponse, error) {
+	e.calls = append(e.calls, "fcu3")
+	e.head = state.HeadHash
+	resp := &engine_types.ForkChoiceUpdatedResponse{PayloadStatus: &engine_types.PayloadStatus{Status: engine_types.ValidStatus}}
+	if attributes != nil {
+		e.attributes = attributes
+		id := hexutil.Bytes{1}
+		resp.PayloadId = &id
+	}
+	return resp, nil
+}
+
+func (e *fakeEngine) GetPayloadV4(_ context.Context, _ hexutil.Bytes) (*engine_types.GetPayloadResponse, error) {
+	e.calls = append(e.calls, "get4")
+	if e.busy > 0 {
+		e.busy--
+		return nil, &engine_helpers.UnknownPayloadErr
+	}
+	e.number++
+	return &engine_types.GetPayloadResponse{ExecutionPayload: &engine_types.ExecutionPayload{
+		ParentHash:  e.head,
+		BlockHash:   common.Hash{byte(e.number)},
+		BlockNumber: hexutil.Uint64(e.number),
+		Timestamp:   e.attributes.Timestamp,
+		Withdrawals: e.attributes.Withdrawals,
+	}}, nil
+}
+
+func (e *fakeEngine) NewPayloadV4(_ context.Context, _ *engine_types.ExecutionPayload, blobHashes []common.Hash, beaconRoot *common.Hash, requests []hexutil.Bytes) (*engine_types.PayloadStatus, error) {
+	e.calls = append(e.calls, "new4")
+	if e.invalid || blobHashes == nil || requests == nil {
+		return &engine_types.PayloadStatus{Status: engine_types.InvalidStatus}, nil
+	}
+	e.beaconRoot = beaconRoot
+	return &engine_types.PayloadStatus{Status: engine_types.ValidStatus}, nil
+}
+
+func TestSimulatedBeacon_Mine(t *testing.T) {
+	ctx := context.Background()
+	config := chain.AllProtocolChanges
+	genesis := &types.Header{Number: big.NewInt(0), Time: 100}
+	feeRecipient := common.Address{0xfe}
+	engine := &fakeEngine{busy: 2}
+
+	b := New(engine, config, genesis, 0, feeRecipient, log.New())
+	b.SetBuildTime(0)
+
+	payload, err := b.Mine(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"fcu3", "get4", "get4", "get4", "new4", "fcu3"}, engine.calls)
+	require.Equal(t, genesis.Hash(), payload.ParentHash)
+	require.Equal(t, payload.BlockHash, engine.head)
+	require.NotNil(t, engine.beaconRoot)
+	require.Equal(t, []*types.Withdrawal{{Index: 0, Validator: 1, Address: feeRecipient, Amount: SyntheticWithdrawalAmount}}, payload.Withdrawals)
+
+	// queued withdrawal goes before synthetic one, indices continue
+	b.AddWithdrawal(common.Address{1}, 5)
+	require.Error(t, b.SetNextBlockTime(uint64(payload.Timestamp)))
+	nextTime := uint64(payload.Timestamp) + 1000
+	require.NoError(t, b.SetNextBlockTime(nextTime))
+	firstRoot := *engine.beaconRoot
+
+	payload2, err := b.Mine(ctx)
+	require.NoError(t, err)
+	require.Equal(t, payload.BlockHash, payload2.ParentHash)
+	require.Equal(t, nextTime, uint64(payload2.Timestamp))
+	require.Equal(t, []*types.Withdrawal{
+		{Index: 1, Validator: 2, Address: common.Address{1}, Amount: 5},
+		{Index: 2, Validator: 2, Address: feeRecipient, Amount: SyntheticWithdrawalAmount},
+	}, payload2.Withdrawals)
+	require.NotEqual(t, firstRoot, *engine.beaconRoot)
+
+	// next block time is reset to wall clock
+	payload3, err := b.Mine(ctx)
+	require.NoError(t, err)
+	require.Equal(t, nextTime+1, uint64(payload3.Timestamp))
+
+	// failed block keeps queued withdrawals untouched
+	b.AddWithdrawal(common.Address{2}, 7)
+	engine.invalid = true
+	_, err = b.Mine(ctx)
+	require.Error(t, err)
+	require.Equal(t, []*types.Withdrawal{{Address: common.Address{2}, Amount: 7}}, b.withdrawals)
+	engine.invalid = false
+	payload4, err := b.Mine(ctx)
+	require.NoError(t, err)
+	require.Equal(t, payload3.BlockHash, payload4.ParentHash)
+	require.Equal(t, []*types.Withdrawal{
+		{Index: 4, Validator: 4, Address: common.Address{2}, Amount: 7},
+		{Index: 5, Validator: 4, Address: feeRecipient, Amount: SyntheticWithdrawalAmount},
+	}, payload4.Withdrawals)
+	require.Empty(t, b.withdrawals)
+}
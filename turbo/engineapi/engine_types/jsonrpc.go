@@ -49,6 +49,8 @@ type ExecutionPayload struct {
 	Withdrawals   []*types.Withdrawal `json:"withdrawals"`
 	BlobGasUsed   *hexutil.Uint64     `json:"blobGasUsed"`
 	ExcessBlobGas *hexutil.Uint64     `json:"excessBlobGas"`
+	// optional, if present it's checked against the block access list of payload execution (EIP-7928)
+	BlockAccessList *types.BlockAccessList `json:"blockAccessList,omitempty"`
 }
 
 // PayloadAttributes represent the attributes required to start assembling a payload
@@ -87,6 +89,12 @@ type BlobAndProofV1 struct {
 	Proof hexutil.Bytes `json:"proof" gencodec:"required"`
 }
 
+// BlobAndProofV2 holds one item for engine_getBlobsV2
+type BlobAndProofV2 struct {
+	Blob       hexutil.Bytes   `json:"blob" gencodec:"required"`
+	CellProofs []hexutil.Bytes `json:"proofs" gencodec:"required"`
+}
+
 type ExecutionPayloadBody struct {
 	Transactions []hexutil.Bytes     `json:"transactions" gencodec:"required"`
 	Withdrawals  []*types.Withdrawal `json:"withdrawals"  gencodec:"required"`
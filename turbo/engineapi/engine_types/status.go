@@ -24,4 +24,6 @@ const (
 	SyncingStatus          EngineStatus = "SYNCING"
 	AcceptedStatus         EngineStatus = "ACCEPTED"
 	InvalidBlockHashStatus EngineStatus = "INVALID_BLOCK_HASH"
+	// InclusionListUnsatisfiedStatus - payload is valid, but lacks transactions of inclusion list (EIP-7805) it could contain
+	InclusionListUnsatisfiedStatus EngineStatus = "INCLUSION_LIST_UNSATISFIED"
 )
@@ -0,0 +1,326 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engineapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/fixedgas"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	execution "github.com/erigontech/erigon-lib/gointerfaces/executionproto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_helpers"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+	"github.com/erigontech/erigon/txnprovider"
+)
+
+// MaxBytesPerInclusionList - EIP-7805 limit of total size of transactions of inclusion list
+const MaxBytesPerInclusionList = 8192
+
+// getInclusionList - best txpool transactions, which fit into MaxBytesPerInclusionList. Blob transactions and private
+// ones (which are not propagated to peers) are never included
+func (s *EngineServer) getInclusionList(ctx context.Context, parentHash common.Hash) ([]hexutil.Bytes, error) {
+	if s.caplin {
+		s.logger.Crit(caplinEnabledLog)
+		return nil, errCaplinEnabled
+	}
+	s.engineLogSpamer.RecordRequest()
+
+	parent := s.chainRW.GetHeaderByHash(ctx, parentHash)
+	if parent == nil {
+		return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("unknown parent hash %x", parentHash)}
+	}
+	inclusionList := make([]hexutil.Bytes, 0)
+	if s.txnProvider == nil {
+		return inclusionList, nil
+	}
+	txns, err := s.txnProvider.ProvideTxns(ctx,
+		txnprovider.WithParentBlockNum(parent.Number.Uint64()),
+		txnprovider.WithGasTarget(parent.GasLimit),
+		txnprovider.WithBlobGasTarget(0),
+		txnprovider.WithTxnIdsFilter(mapset.NewThreadUnsafeSet[[32]byte]()),
+		txnprovider.WithoutPrivateTxns(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	size := 0
+	for _, txn := range txns {
+		var buf bytes.Buffer
+		if err := txn.MarshalBinary(&buf); err != nil {
+			return nil, err
+		}
+		// smaller transactions of lower priority may still fit
+		if size+buf.Len() > MaxBytesPerInclusionList {
+			continue
+		}
+		size += buf.Len()
+		inclusionList = append(inclusionList, buf.Bytes())
+	}
+	return inclusionList, nil
+}
+
+// updatePayloadWithInclusionList - block builder rebuilds payload with transactions of inclusion list ahead of txpool ones
+func (s *EngineServer) updatePayloadWithInclusionList(ctx context.Context, payloadID hexutil.Bytes, inclusionList []hexutil.Bytes) (*hexutil.Bytes, error) {
+	if s.caplin {
+		s.logger.Crit(caplinEnabledLog)
+		return nil, errCaplinEnabled
+	}
+	if !s.proposing {
+		return nil, errors.New("execution layer not running as a proposer. enable proposer by taking out the --proposer.disable flag on startup")
+	}
+	if len(payloadID) != 8 {
+		return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("invalid payloadId length: %d", len(payloadID))}
+	}
+	if err := checkInclusionListSize(inclusionList); err != nil {
+		return nil, err
+	}
+	req := &execution.UpdateInclusionListRequest{Id: binary.BigEndian.Uint64(payloadID), InclusionList: make([][]byte, len(inclusionList))}
+	for i, txn := range inclusionList {
+		req.InclusionList[i] = txn
+	}
+	resp, err := s.executionService.UpdateInclusionList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Busy {
+		s.logger.Warn("Cannot update payload with inclusion list, execution is busy", "payloadId", payloadID)
+		return nil, &engine_helpers.UnknownPayloadErr
+	}
+	if !resp.Found {
+		return nil, &engine_helpers.UnknownPayloadErr
+	}
+	return &payloadID, nil
+}
+
+func checkInclusionListSize(inclusionList []hexutil.Bytes) error {
+	size := 0
+	for _, txn := range inclusionList {
+		size += len(txn)
+	}
+	if size > MaxBytesPerInclusionList {
+		return &rpc.InvalidParamsError{Message: fmt.Sprintf("inclusion list size %d exceeds %d bytes", size, MaxBytesPerInclusionList)}
+	}
+	return nil
+}
+
+func (s *EngineServer) newPayloadWithInclusionList(ctx context.Context, req *engine_types.ExecutionPayload,
+	expectedBlobHashes []common.Hash, parentBeaconBlockRoot *common.Hash, executionRequests []hexutil.Bytes, inclusionList []hexutil.Bytes,
+) (*engine_types.PayloadStatus, error) {
+	if inclusionList == nil {
+		return nil, &rpc.InvalidParamsError{Message: "missing inclusion list"}
+	}
+	if err := checkInclusionListSize(inclusionList); err != nil {
+		return nil, err
+	}
+	return s.newPayload(ctx, req, expectedBlobHashes, parentBeaconBlockRoot, executionRequests, inclusionList, clparams.FocilVersion)
+}
+
+// checkInclusionList - INCLUSION_LIST_UNSATISFIED status if payload doesn't satisfy inclusion list, nil otherwise.
+// Doesn't need payload execution, so it's done before the block is executed and marked valid
+func (s *EngineServer) checkInclusionList(ctx context.Context, req *engine_types.ExecutionPayload, inclusionList []hexutil.Bytes) (*engine_types.PayloadStatus, error) {
+	if len(inclusionList) == 0 {
+		return nil, nil
+	}
+	stateReader, tx, err := s.parentStateReader(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if stateReader == nil {
+		// e.g. payload on top of non-canonical block: transactions of inclusion list can't be validated
+		s.logger.Debug("[NewPayload] no state of parent to check inclusion list", "height", req.BlockNumber, "hash", req.BlockHash)
+		return nil, nil
+	}
+	defer tx.Rollback()
+	satisfied, err := inclusionListSatisfied(s.config, req, inclusionList, stateReader)
+	if err != nil {
+		return nil, err
+	}
+	if !satisfied {
+		s.logger.Debug("[NewPayload] inclusion list unsatisfied", "height", req.BlockNumber, "hash", req.BlockHash)
+		return &engine_types.PayloadStatus{Status: engine_types.InclusionListUnsatisfiedStatus}, nil
+	}
+	return nil, nil
+}
+
+// parentStateReader - state after parent block of payload if parent is canonical and executed, nil otherwise.
+// Reader is valid until tx is rolled back.
+func (s *EngineServer) parentStateReader(ctx context.Context, req *engine_types.ExecutionPayload) (state.StateReader, kv.TemporalTx, error) {
+	if s.db == nil || req.BlockNumber == 0 {
+		return nil, nil, nil
+	}
+	tx, err := s.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	parentNum := uint64(req.BlockNumber) - 1
+	canonicalHash, ok, err := s.blockReader.CanonicalHash(ctx, tx, parentNum)
+	if err != nil {
+		tx.Rollback()
+		return nil, nil, err
+	}
+	executed, err := stages.GetStageProgress(tx, stages.Execution)
+	if err != nil {
+		tx.Rollback()
+		return nil, nil, err
+	}
+	if !ok || canonicalHash != req.ParentHash || executed < parentNum {
+		tx.Rollback()
+		return nil, nil, nil
+	}
+	if executed == parentNum {
+		return rpchelper.NewLatestStateReader(tx), tx, nil
+	}
+	txNumsReader := rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(ctx, s.blockReader))
+	stateReader, err := rpchelper.CreateHistoryStateReader(tx, txNumsReader, parentNum+1, -1, s.config.ChainName)
+	if err != nil {
+		// history is pruned
+		s.logger.Debug("[NewPayload] no history of parent state", "height", parentNum, "err", err)
+		tx.Rollback()
+		return nil, nil, nil
+	}
+	return stateReader, tx, nil
+}
+
+// inclusionListSatisfied - EIP-7805 check of valid payload: inclusion list is unsatisfied if some of its transactions
+// is not in payload, though it could be appended to it. Post-state of payload is estimated from parent state conservatively:
+// balance of sender is lowered by the most its transactions in payload may spend and senders, which may have code
+// after payload (EIP-7702), are not checked. So inclusion list is never reported unsatisfied by mistake.
+func inclusionListSatisfied(config *chain.Config, req *engine_types.ExecutionPayload, inclusionList []hexutil.Bytes, stateReader state.StateReader) (bool, error) {
+	blockNum, blockTime := uint64(req.BlockNumber), uint64(req.Timestamp)
+	signer := types.MakeSigner(config, blockNum, blockTime)
+	rules := config.Rules(blockNum, blockTime)
+	txs := make([][]byte, len(req.Transactions))
+	for i, txn := range req.Transactions {
+		txs[i] = txn
+	}
+	txns, err := types.DecodeTransactions(txs)
+	if err != nil {
+		return false, err
+	}
+
+	included := make(map[common.Hash]struct{}, len(txns))
+	nonces := map[common.Address]uint64{}
+	spendings := map[common.Address]*uint256.Int{}
+	authorities := map[common.Address]struct{}{}
+	for _, txn := range txns {
+		included[txn.Hash()] = struct{}{}
+		sender, err := txn.Sender(*signer)
+		if err != nil {
+			return false, err
+		}
+		nonces[sender]++
+		if spending, ok := spendings[sender]; ok {
+			spending.Add(spending, maxTxnCost(txn))
+		} else {
+			spendings[sender] = maxTxnCost(txn)
+		}
+		if setCodeTxn, ok := txn.(*types.SetCodeTransaction); ok {
+			for _, auth := range setCodeTxn.GetAuthorizations() {
+				authority, err := auth.RecoverSigner(bytes.NewBuffer(nil), make([]byte, 32))
+				if err != nil {
+					continue // invalid authorizations are skipped by execution too
+				}
+				authorities[*authority] = struct{}{}
+			}
+		}
+	}
+
+	gasLeft := uint64(req.GasLimit) - uint64(req.GasUsed)
+	baseFee := new(uint256.Int)
+	if req.BaseFeePerGas != nil {
+		baseFee.SetFromBig(req.BaseFeePerGas.ToInt())
+	}
+	for _, encoded := range inclusionList {
+		txn, err := types.DecodeTransaction(encoded)
+		if err != nil {
+			continue // invalid transactions of inclusion list are ignored
+		}
+		if _, ok := included[txn.Hash()]; ok {
+			continue
+		}
+		if txn.Type() == types.BlobTxType || txn.GetGasLimit() > gasLeft || txn.GetFeeCap().Lt(baseFee) {
+			continue
+		}
+		var authorizationsLen uint64
+		if setCodeTxn, ok := txn.(*types.SetCodeTransaction); ok {
+			authorizationsLen = uint64(len(setCodeTxn.GetAuthorizations()))
+		}
+		accessList := txn.GetAccessList()
+		intrinsicGas, floorGas, overflow := fixedgas.IntrinsicGas(txn.GetData(), uint64(len(accessList)), uint64(accessList.StorageKeys()),
+			txn.GetTo() == nil, rules.IsHomestead, rules.IsIstanbul, rules.IsShanghai, rules.IsPrague, false, authorizationsLen)
+		if overflow || txn.GetGasLimit() < max(intrinsicGas, floorGas) {
+			continue
+		}
+		sender, err := txn.Sender(*signer)
+		if err != nil {
+			continue
+		}
+		if _, ok := authorities[sender]; ok {
+			continue
+		}
+		account, err := stateReader.ReadAccountData(sender)
+		if err != nil {
+			return false, err
+		}
+		if account == nil || !account.IsEmptyCodeHash() || account.Nonce+nonces[sender] != txn.GetNonce() {
+			continue
+		}
+		balance := account.Balance
+		if spending, ok := spendings[sender]; ok {
+			if balance.Lt(spending) {
+				continue
+			}
+			balance.Sub(&balance, spending)
+		}
+		if balance.Lt(maxTxnCost(txn)) {
+			continue
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// maxTxnCost - the most transaction may spend: value and gas paid at fee caps
+func maxTxnCost(txn types.Transaction) *uint256.Int {
+	cost := new(uint256.Int).SetUint64(txn.GetGasLimit())
+	cost.Mul(cost, txn.GetFeeCap())
+	cost.Add(cost, txn.GetValue())
+	if blobTxn, ok := txn.(*types.BlobTx); ok && blobTxn.MaxFeePerBlobGas != nil {
+		blobCost := new(uint256.Int).SetUint64(blobTxn.GetBlobGas())
+		cost.Add(cost, blobCost.Mul(blobCost, blobTxn.MaxFeePerBlobGas))
+	}
+	return cost
+}
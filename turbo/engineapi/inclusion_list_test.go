@@ -0,0 +1,167 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engineapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
+)
+
+type inclusionListTestState struct {
+	state.StateReader
+	accounts map[common.Address]*accounts.Account
+}
+
+func (s *inclusionListTestState) ReadAccountData(address common.Address) (*accounts.Account, error) {
+	return s.accounts[address], nil
+}
+
+func TestInclusionListSatisfied(t *testing.T) {
+	t.Parallel()
+	config := chain.AllProtocolChanges
+	signer := types.LatestSigner(config)
+	const gasPrice, gasLimit = 10, 100_000
+
+	signedTxn := func(key *ecdsa.PrivateKey, nonce, gas, value uint64) hexutil.Bytes {
+		txn := &types.LegacyTx{
+			CommonTx: types.CommonTx{Nonce: nonce, GasLimit: gas, To: &common.Address{0xaa}, Value: uint256.NewInt(value)},
+			GasPrice: uint256.NewInt(gasPrice),
+		}
+		signed, err := types.SignTx(txn, *signer, key)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, signed.MarshalBinary(&buf))
+		return buf.Bytes()
+	}
+	newKey := func() (*ecdsa.PrivateKey, common.Address) {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		return key, crypto.PubkeyToAddress(key.PublicKey)
+	}
+	inBlockKey, inBlockSender := newKey()
+	poorKey, poorSender := newKey()
+	richKey, richSender := newKey()
+	contractKey, contractSender := newKey()
+	unknownKey, _ := newKey()
+
+	account := func(nonce, balance uint64) *accounts.Account {
+		acc := accounts.NewAccount()
+		acc.Nonce = nonce
+		acc.Balance.SetUint64(balance)
+		return &acc
+	}
+	contract := account(0, 1_000_000_000)
+	contract.CodeHash = common.Hash{0xcc}
+	stateReader := &inclusionListTestState{accounts: map[common.Address]*accounts.Account{
+		inBlockSender:  account(5, 1_000_000_000),
+		poorSender:     account(0, 21_000*gasPrice),
+		richSender:     account(3, 1_000_000_000),
+		contractSender: contract,
+	}}
+
+	blockTxn := signedTxn(inBlockKey, 5, 21_000, 1)
+	req := &engine_types.ExecutionPayload{
+		BlockNumber:   1,
+		Timestamp:     1,
+		GasLimit:      gasLimit,
+		GasUsed:       21_000,
+		BaseFeePerGas: (*hexutil.Big)(big.NewInt(1)),
+		Transactions:  []hexutil.Bytes{blockTxn},
+	}
+	tests := []struct {
+		name          string
+		inclusionList []hexutil.Bytes
+		satisfied     bool
+	}{
+		{"empty", []hexutil.Bytes{}, true},
+		{"included", []hexutil.Bytes{blockTxn}, true},
+		{"undecodable", []hexutil.Bytes{{0x01, 0x02}}, true},
+		{"valid", []hexutil.Bytes{signedTxn(richKey, 3, 21_000, 1)}, false},
+		{"valid after block transactions of sender", []hexutil.Bytes{signedTxn(inBlockKey, 6, 21_000, 1)}, false},
+		{"nonce used in block", []hexutil.Bytes{signedTxn(inBlockKey, 5, 21_000, 2)}, true},
+		{"nonce gap", []hexutil.Bytes{signedTxn(richKey, 4, 21_000, 1)}, true},
+		{"insufficient balance", []hexutil.Bytes{signedTxn(poorKey, 0, 21_000, 1)}, true},
+		{"exceeds gas left", []hexutil.Bytes{signedTxn(richKey, 3, gasLimit-21_000+1, 1)}, true},
+		{"below intrinsic gas", []hexutil.Bytes{signedTxn(richKey, 3, 20_000, 1)}, true},
+		{"unknown sender", []hexutil.Bytes{signedTxn(unknownKey, 0, 21_000, 1)}, true},
+		{"sender has code", []hexutil.Bytes{signedTxn(contractKey, 0, 21_000, 1)}, true},
+		{"one of many valid", []hexutil.Bytes{signedTxn(poorKey, 0, 21_000, 1), blockTxn, signedTxn(richKey, 3, 21_000, 1)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			satisfied, err := inclusionListSatisfied(config, req, tt.inclusionList, stateReader)
+			require.NoError(t, err)
+			require.Equal(t, tt.satisfied, satisfied)
+		})
+	}
+}
+
+func TestCheckInclusionListSize(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, checkInclusionListSize([]hexutil.Bytes{make([]byte, MaxBytesPerInclusionList/2), make([]byte, MaxBytesPerInclusionList/2)}))
+	require.Error(t, checkInclusionListSize([]hexutil.Bytes{make([]byte, MaxBytesPerInclusionList/2), make([]byte, MaxBytesPerInclusionList/2+1)}))
+}
+
+func TestNewPayloadV5ForkGate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	newServer := func(focilTime *big.Int) *EngineServer {
+		config := *chain.AllProtocolChanges
+		config.FocilTime = focilTime
+		return NewEngineServer(log.New(), &config, nil, nil, nil, nil, false, true, false, true)
+	}
+	blobGas := hexutil.Uint64(0)
+	payload := &engine_types.ExecutionPayload{
+		LogsBloom:     make(hexutil.Bytes, types.BloomByteLength),
+		BaseFeePerGas: (*hexutil.Big)(big.NewInt(1)),
+		BlockNumber:   1,
+		Timestamp:     1,
+		Withdrawals:   []*types.Withdrawal{},
+		BlobGasUsed:   &blobGas,
+		ExcessBlobGas: &blobGas,
+	}
+	var unsupportedFork *rpc.UnsupportedForkError
+
+	// FOCIL is not active
+	_, err := newServer(nil).NewPayloadV5(ctx, payload, []common.Hash{}, &common.Hash{}, []hexutil.Bytes{}, []hexutil.Bytes{})
+	require.ErrorAs(t, err, &unsupportedFork)
+
+	// FOCIL is active
+	srv := newServer(big.NewInt(0))
+	_, err = srv.NewPayloadV4(ctx, payload, []common.Hash{}, &common.Hash{}, []hexutil.Bytes{})
+	require.ErrorAs(t, err, &unsupportedFork)
+	status, err := srv.NewPayloadV5(ctx, payload, []common.Hash{}, &common.Hash{}, []hexutil.Bytes{}, []hexutil.Bytes{})
+	require.NoError(t, err)
+	require.Equal(t, engine_types.InvalidStatus, status.Status) // passed fork checks, block hash is wrong
+}
@@ -0,0 +1,281 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package historical provides typed read-only access to state of any block of an Erigon datadir.
+//
+//	db, err := historical.Open(ctx, "/path/to/datadir", logger)
+//	...
+//	defer db.Close()
+//	st, err := db.StateAt(ctx, 20_000_000)
+//	...
+//	defer st.Close()
+//	balance, err := st.Balance(addr)
+//
+// Datadir is opened without creation of any files and without exclusive locks, so it's safe to use while Erigon is running.
+package historical
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/holiman/uint256"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/config3"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/kv/temporal"
+	"github.com/erigontech/erigon-lib/log/v3"
+	libstate "github.com/erigontech/erigon-lib/state"
+	ee "github.com/erigontech/erigon-lib/state/entity_extras"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/turbo/services"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+// ErrBlockNotFound is returned when block is not in canonical chain yet
+var ErrBlockNotFound = errors.New("block not found")
+
+// DB - read-only view of chain database and state files
+type DB struct {
+	db          kv.TemporalRoDB
+	blockReader services.FullBlockReader
+	txNums      rawdbv3.TxNumsReader
+
+	// set only if datadir was opened by Open
+	snapshots *freezeblocks.RoSnapshots
+	agg       *libstate.Aggregator
+	closeDB   func()
+}
+
+// New wraps already opened database. Caller is responsible for closing db.
+func New(db kv.TemporalRoDB, blockReader services.FullBlockReader) *DB {
+	return &DB{
+		db:          db,
+		blockReader: blockReader,
+		txNums:      rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(context.Background(), blockReader)),
+	}
+}
+
+// Open opens datadir in read-only mode: databases and files are never created or modified
+func Open(ctx context.Context, dataDir string, logger log.Logger) (*DB, error) {
+	dirs := datadir.New(dataDir)
+	ok, err := ee.CheckSaltFilesExist(dirs)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ee.ErrCannotStartWithoutSaltFiles
+	}
+
+	const roTxLimit = 9_000
+	rawDB, err := mdbx.New(kv.ChainDB, logger).Path(dirs.Chaindata).
+		RoTxsLimiter(semaphore.NewWeighted(roTxLimit)).
+		Accede(true). // must not create db and must not block erigon
+		Open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var chainName string
+	if err := rawDB.View(ctx, func(tx kv.Tx) error {
+		genesisHash, err := rawdb.ReadCanonicalHash(tx, 0)
+		if err != nil {
+			return err
+		}
+		cc, err := core.ReadChainConfig(tx, genesisHash)
+		if err != nil {
+			return err
+		}
+		if cc == nil {
+			return errors.New("chain config not found in db. Need start erigon at least once on this db")
+		}
+		chainName = cc.ChainName
+		return nil
+	}); err != nil {
+		rawDB.Close()
+		return nil, err
+	}
+
+	snapCfg := ethconfig.NewSnapCfg(true, false, false, chainName)
+	snapCfg.NoDownloader = true
+	snapshots := freezeblocks.NewRoSnapshots(snapCfg, dirs.Snap, 0, logger)
+
+	agg, err := libstate.NewAggregator(ctx, dirs, config3.DefaultStepSize, rawDB, logger)
+	if err != nil {
+		snapshots.Close()
+		rawDB.Close()
+		return nil, fmt.Errorf("create aggregator: %w", err)
+	}
+	tdb, err := temporal.New(rawDB, agg)
+	if err != nil {
+		agg.Close()
+		snapshots.Close()
+		rawDB.Close()
+		return nil, err
+	}
+
+	db := New(tdb, freezeblocks.NewBlockReader(snapshots, nil, nil, nil))
+	db.snapshots, db.agg, db.closeDB = snapshots, agg, tdb.Close
+	if err := db.Reopen(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Reopen picks up block and state files created (or merged) by running Erigon since last Open/Reopen.
+// No-op for DB created by New.
+func (db *DB) Reopen() error {
+	if db.agg == nil {
+		return nil
+	}
+	if err := db.snapshots.OpenFolder(); err != nil {
+		return err
+	}
+	if err := db.agg.ReloadSalt(); err != nil {
+		return fmt.Errorf("agg ReloadSalt: %w", err)
+	}
+	return db.agg.OpenFolder()
+}
+
+func (db *DB) Close() {
+	if db.closeDB == nil {
+		return
+	}
+	db.closeDB()
+	db.snapshots.Close()
+}
+
+// StateAt returns state after execution of block blockNum. Returned State holds read transaction - must be closed.
+func (db *DB) StateAt(ctx context.Context, blockNum uint64) (*State, error) {
+	tx, err := db.db.BeginTemporalRo(ctx) //nolint:gocritic
+	if err != nil {
+		return nil, err
+	}
+	st, err := db.stateAt(ctx, tx, blockNum)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return st, nil
+}
+
+func (db *DB) stateAt(ctx context.Context, tx kv.TemporalTx, blockNum uint64) (*State, error) {
+	_, ok, err := db.blockReader.CanonicalHash(ctx, tx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrBlockNotFound, blockNum)
+	}
+	maxTxNum, err := db.txNums.Max(tx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	r := state.NewHistoryReaderV3()
+	r.SetTx(tx)
+	txNum := maxTxNum + 1 // state before first txNum of next block: all changes of blockNum are applied
+	if txNum < r.StateHistoryStartFrom() {
+		return nil, fmt.Errorf("block %d: %w", blockNum, state.PrunedError)
+	}
+	r.SetTxNum(txNum)
+	return &State{tx: tx, reader: r, blockNum: blockNum}, nil
+}
+
+// State - state of one block
+type State struct {
+	tx       kv.TemporalTx
+	reader   *state.HistoryReaderV3
+	blockNum uint64
+}
+
+var _ state.HistoricalStateReader = (*state.HistoryReaderV3)(nil)
+
+func (s *State) Close()           { s.tx.Rollback() }
+func (s *State) BlockNum() uint64 { return s.blockNum }
+func (s *State) TxNum() uint64    { return s.reader.GetTxNum() }
+
+// Reader returns underlying state reader, which can be used for EVM execution on top of this state
+func (s *State) Reader() state.StateReader { return s.reader }
+
+// Exists returns false for accounts which were never created or were destroyed
+func (s *State) Exists(addr common.Address) (bool, error) {
+	acc, err := s.reader.ReadAccountData(addr)
+	return acc != nil, err
+}
+
+func (s *State) Balance(addr common.Address) (*uint256.Int, error) {
+	acc, err := s.reader.ReadAccountData(addr)
+	if err != nil || acc == nil {
+		return new(uint256.Int), err
+	}
+	return &acc.Balance, nil
+}
+
+func (s *State) Nonce(addr common.Address) (uint64, error) {
+	acc, err := s.reader.ReadAccountData(addr)
+	if err != nil || acc == nil {
+		return 0, err
+	}
+	return acc.Nonce, nil
+}
+
+func (s *State) Code(addr common.Address) ([]byte, error) {
+	return s.reader.ReadAccountCode(addr)
+}
+
+func (s *State) Storage(addr common.Address, slot common.Hash) (*uint256.Int, error) {
+	enc, err := s.reader.ReadAccountStorage(addr, slot)
+	if err != nil {
+		return nil, err
+	}
+	return new(uint256.Int).SetBytes(enc), nil
+}
+
+// IterateStorage calls fn for every non-empty storage slot of addr in ascending order of slot.
+// Iteration stops when fn returns false.
+func (s *State) IterateStorage(addr common.Address, fn func(slot common.Hash, value *uint256.Int) (bool, error)) error {
+	to, _ := kv.NextSubtree(addr[:])
+	it, err := s.tx.RangeAsOf(kv.StorageDomain, addr[:], to, s.reader.GetTxNum(), order.Asc, -1)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for it.HasNext() {
+		k, v, err := it.Next()
+		if err != nil {
+			return err
+		}
+		if len(v) == 0 {
+			continue
+		}
+		next, err := fn(common.BytesToHash(k[length.Addr:]), new(uint256.Int).SetBytes(v))
+		if err != nil || !next {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package historical
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+func TestStateAt(t *testing.T) {
+	ctx := context.Background()
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	receiver := common.Address{1}
+	contract := common.Address{2}
+	code := []byte{0x60, 0x00, 0x60, 0x00, 0xf3} // return(0, 0)
+
+	gspec := &types.Genesis{
+		Config: chain.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			sender: {Balance: big.NewInt(common.Ether)},
+			contract: {
+				Balance: big.NewInt(0),
+				Nonce:   1,
+				Code:    code,
+				Storage: map[common.Hash]common.Hash{{3}: {4}, {1}: {2}},
+			},
+		},
+	}
+	m := mock.MockWithGenesis(t, gspec, key, false)
+	signer := types.LatestSignerForChainID(nil)
+	const blocks, value = 3, 1000
+	chainPack, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, blocks, func(i int, b *core.BlockGen) {
+		txn, err := types.SignTx(types.NewTransaction(uint64(i), receiver, uint256.NewInt(value), 21000, uint256.NewInt(common.GWei), nil), *signer, key)
+		require.NoError(t, err)
+		b.AddTx(txn)
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.InsertChain(chainPack))
+
+	db := New(m.DB, m.BlockReader)
+	defer db.Close()
+
+	for blockNum := uint64(0); blockNum <= blocks; blockNum++ {
+		st, err := db.StateAt(ctx, blockNum)
+		require.NoError(t, err)
+
+		balance, err := st.Balance(receiver)
+		require.NoError(t, err)
+		require.Equal(t, uint256.NewInt(blockNum*value), balance, blockNum)
+
+		nonce, err := st.Nonce(sender)
+		require.NoError(t, err)
+		require.Equal(t, blockNum, nonce)
+
+		exists, err := st.Exists(receiver)
+		require.NoError(t, err)
+		require.Equal(t, blockNum > 0, exists)
+		st.Close()
+	}
+
+	st, err := db.StateAt(ctx, 0)
+	require.NoError(t, err)
+	defer st.Close()
+
+	gotCode, err := st.Code(contract)
+	require.NoError(t, err)
+	require.Equal(t, code, gotCode)
+
+	slot, err := st.Storage(contract, common.Hash{1})
+	require.NoError(t, err)
+	require.Equal(t, uint256.NewInt(0).SetBytes(common.Hash{2}.Bytes()), slot)
+
+	var slots []common.Hash
+	require.NoError(t, st.IterateStorage(contract, func(slot common.Hash, value *uint256.Int) (bool, error) {
+		slots = append(slots, slot)
+		return true, nil
+	}))
+	require.Equal(t, []common.Hash{{1}, {3}}, slots)
+
+	_, err = db.StateAt(ctx, blocks+1)
+	require.ErrorIs(t, err, ErrBlockNotFound)
+}
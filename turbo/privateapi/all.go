@@ -35,7 +35,7 @@ import (
 	"github.com/erigontech/erigon-lib/log/v3"
 )
 
-func StartGrpc(kv *remotedbserver.KvServer, ethBackendSrv *EthBackendServer, txPoolServer txpoolproto.TxpoolServer,
+func StartGrpc(kv *remotedbserver.KvServer, changeStreamServer remote.ChangeStreamServer, ethBackendSrv *EthBackendServer, txPoolServer txpoolproto.TxpoolServer,
 	miningServer txpoolproto.MiningServer, bridgeServer *bridge.BackendServer, heimdallServer *heimdall.BackendServer,
 	addr string, rateLimit uint32, creds credentials.TransportCredentials, healthCheck bool, logger log.Logger) (*grpc.Server, error) {
 	logger.Info("Starting private RPC server", "on", addr)
@@ -60,6 +60,9 @@ func StartGrpc(kv *remotedbserver.KvServer, ethBackendSrv *EthBackendServer, txP
 	}
 
 	remote.RegisterKVServer(grpcServer, kv)
+	if changeStreamServer != nil {
+		remote.RegisterChangeStreamServer(grpcServer, changeStreamServer)
+	}
 	var healthServer *health.Server
 	if healthCheck {
 		healthServer = health.NewServer()
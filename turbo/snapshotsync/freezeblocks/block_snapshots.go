@@ -595,7 +595,7 @@ func dumpRange(ctx context.Context, f snaptype.FileInfo, dumper dumpFunc, firstK
 
 	p := &background.Progress{}
 
-	if err := f.Type.BuildIndexes(ctx, f, nil, chainConfig, tmpDir, p, lvl, logger); err != nil {
+	if err := f.Type.BuildIndexes(ctx, f, nil, chainConfig, tmpDir, workers, p, lvl, logger); err != nil {
 		return lastKeyValue, err
 	}
 
@@ -118,7 +118,7 @@ func (m *Merger) mergeSubSegment(ctx context.Context, v *View, sn snaptype.FileI
 	// new way to build index
 	if doIndex {
 		p := &background.Progress{}
-		if err = buildIdx(ctx, sn, indexBuilder, m.chainConfig, m.tmpDir, p, m.lvl, m.logger); err != nil {
+		if err = buildIdx(ctx, sn, indexBuilder, m.chainConfig, m.tmpDir, v.s.Cfg().RecsplitWorkers, p, m.lvl, m.logger); err != nil {
 			return
 		}
 		err = newDirtySegment.openIdx(snapDir)
@@ -130,9 +130,9 @@ func (m *Merger) mergeSubSegment(ctx context.Context, v *View, sn snaptype.FileI
 	return
 }
 
-func buildIdx(ctx context.Context, sn snaptype.FileInfo, indexBuilder snaptype.IndexBuilder, chainConfig *chain.Config, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) error {
+func buildIdx(ctx context.Context, sn snaptype.FileInfo, indexBuilder snaptype.IndexBuilder, chainConfig *chain.Config, tmpDir string, workers int, p *background.Progress, lvl log.Lvl, logger log.Logger) error {
 	//log.Info("[snapshots] build idx", "file", sn.Name())
-	if err := sn.Type.BuildIndexes(ctx, sn, indexBuilder, chainConfig, tmpDir, p, lvl, logger); err != nil {
+	if err := sn.Type.BuildIndexes(ctx, sn, indexBuilder, chainConfig, tmpDir, workers, p, lvl, logger); err != nil {
 		return fmt.Errorf("buildIdx: %s: %s", sn.Type, err)
 	}
 	//log.Info("[snapshots] finish build idx", "file", fName)
@@ -1431,7 +1431,7 @@ func (s *RoSnapshots) buildMissedIndices(logPrefix string, ctx context.Context,
 					ps.Add(p)
 					defer notifySegmentIndexingFinished(info.Name())
 					defer ps.Delete(p)
-					if err := t.BuildIndexes(gCtx, info, indexBuilder, chainConfig, tmpDir, p, log.LvlInfo, logger); err != nil {
+					if err := t.BuildIndexes(gCtx, info, indexBuilder, chainConfig, tmpDir, s.cfg.RecsplitWorkers, p, log.LvlInfo, logger); err != nil {
 						// unsuccessful indexing should allow other indexing to finish
 						fmu.Lock()
 						failedIndexes[info.Name()] = err
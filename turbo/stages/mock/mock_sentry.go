@@ -133,6 +133,7 @@ type MockSentry struct {
 	ReceiptsReader *receipts.Generator
 	posStagedSync  *stagedsync.Sync
 	bgComponentsEg errgroup.Group
+	ownTmpdir      string
 }
 
 func (ms *MockSentry) Close() {
@@ -146,7 +147,17 @@ func (ms *MockSentry) Close() {
 	if ms.DB != nil {
 		ms.DB.Close()
 	}
-	if err := ms.bgComponentsEg.Wait(); err != nil {
+	err := ms.bgComponentsEg.Wait()
+	if ms.ownTmpdir != "" {
+		_ = os.RemoveAll(ms.ownTmpdir)
+	}
+	if err != nil {
+		if ms.tb == nil {
+			if !errors.Is(err, context.Canceled) {
+				ms.Log.Warn("[mock] background component exited with error", "err", err)
+			}
+			return
+		}
 		require.Equal(ms.tb, context.Canceled, err) // upon waiting for clean exit we should get ctx cancelled
 	}
 }
@@ -241,6 +252,12 @@ func MockWithGenesisEngine(tb testing.TB, gspec *types.Genesis, engine consensus
 	return MockWithEverything(tb, gspec, key, prune.DefaultMode, engine, blockBufferSize, false, withPosDownloader, checkStateRoot)
 }
 
+// MockWithGenesisEngineTracer - same as MockWithGenesisEngine, but executes blocks with given tracer
+func MockWithGenesisEngineTracer(tb testing.TB, gspec *types.Genesis, engine consensus.Engine, withPosDownloader, checkStateRoot bool, tracer *tracers.Tracer) *MockSentry {
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	return mockWithEverything(tb, gspec, key, prune.DefaultMode, engine, blockBufferSize, false, withPosDownloader, checkStateRoot, tracer)
+}
+
 func MockWithGenesisPruneMode(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateKey, blockBufferSize int, prune prune.Mode, withPosDownloader bool) *MockSentry {
 	var engine consensus.Engine
 
@@ -258,9 +275,26 @@ func MockWithGenesisPruneMode(tb testing.TB, gspec *types.Genesis, key *ecdsa.Pr
 func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateKey, prune prune.Mode,
 	engine consensus.Engine, blockBufferSize int, withTxPool, withPosDownloader, checkStateRoot bool,
 ) *MockSentry {
-	tmpdir := os.TempDir()
+	return mockWithEverything(tb, gspec, key, prune, engine, blockBufferSize, withTxPool, withPosDownloader, checkStateRoot, nil)
+}
+
+func mockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateKey, prune prune.Mode,
+	engine consensus.Engine, blockBufferSize int, withTxPool, withPosDownloader, checkStateRoot bool, tracer *tracers.Tracer,
+) *MockSentry {
+	vmConfig := &vm.Config{}
+	if tracer != nil {
+		vmConfig.Tracer = tracer.Hooks
+	}
+
+	var tmpdir, ownTmpdir string
 	if tb != nil {
 		tmpdir = tb.TempDir()
+	} else {
+		var err error
+		if tmpdir, err = os.MkdirTemp("", "mock-sentry-"); err != nil {
+			panic(err)
+		}
+		ownTmpdir = tmpdir // removed by Close, as there is no tb to clean it up
 	}
 	ctrl := gomock.NewController(tb)
 	dirs := datadir.New(tmpdir)
@@ -303,6 +337,7 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 	mock := &MockSentry{
 		Ctx: ctx, cancel: ctxCancel, DB: db,
 		tb:             tb,
+		ownTmpdir:      ownTmpdir,
 		Log:            logger,
 		Dirs:           dirs,
 		Engine:         engine,
@@ -365,7 +400,11 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 			}),
 		)
 		if err != nil {
-			tb.Fatal(err)
+			if tb != nil {
+				tb.Fatal(err)
+			} else {
+				panic(err)
+			}
 		}
 
 		mock.StreamWg.Add(1)
@@ -380,7 +419,7 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 		terseLogger.SetHandler(log.LvlFilterHandler(log.LvlWarn, log.StderrHandler))
 		// Needs its own notifications to not update RPC daemon and txpool about pending blocks
 		stateSync := stages2.NewInMemoryExecution(mock.Ctx, mock.DB, &cfg, mock.sentriesClient,
-			dirs, notifications, mock.BlockReader, blockWriter, nil, terseLogger)
+			dirs, notifications, mock.BlockReader, blockWriter, nil, terseLogger, tracer)
 		chainReader := consensuschain.NewReader(mock.ChainConfig, txc.Tx, mock.BlockReader, logger)
 		// We start the mining step
 		if err := stages2.StateStep(ctx, chainReader, mock.Engine, txc, stateSync, header, body, unwindPoint, headersChain, bodiesChain, true); err != nil {
@@ -520,7 +559,7 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 				cfg.BatchSize,
 				mock.ChainConfig,
 				mock.Engine,
-				&vm.Config{},
+				vmConfig,
 				mock.Notifications,
 				cfg.StateStream,
 				/*stateStream=*/ false,
@@ -536,12 +575,13 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 		logger, stages.ModeApplyingBlocks,
 	)
 
-	var tracer *tracers.Tracer
-	if dir, ok := os.LookupEnv("MOCK_SENTRY_DEBUG_TRACER_OUTPUT_DIR"); ok {
-		tracer = debugtracer.New(dir, debugtracer.WithRecordOptions(debugtracer.RecordOptions{
-			DisableOnOpcodeStackRecording:  true,
-			DisableOnOpcodeMemoryRecording: true,
-		}))
+	if tracer == nil {
+		if dir, ok := os.LookupEnv("MOCK_SENTRY_DEBUG_TRACER_OUTPUT_DIR"); ok {
+			tracer = debugtracer.New(dir, debugtracer.WithRecordOptions(debugtracer.RecordOptions{
+				DisableOnOpcodeStackRecording:  true,
+				DisableOnOpcodeMemoryRecording: true,
+			}))
+		}
 	}
 
 	cfg.Genesis = gspec
@@ -549,7 +589,7 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 		snapDownloader, mock.BlockReader, blockRetire, nil, forkValidator, logger, tracer, checkStateRoot)
 	mock.posStagedSync = stagedsync.New(cfg.Sync, pipelineStages, stagedsync.PipelineUnwindOrder, stagedsync.PipelinePruneOrder, logger, stages.ModeApplyingBlocks)
 
-	mock.Eth1ExecutionService = eth1.NewEthereumExecutionModule(mock.BlockReader, mock.DB, mock.posStagedSync, forkValidator, mock.ChainConfig, assembleBlockPOS, nil, mock.Notifications.Accumulator, mock.Notifications.RecentLogs, mock.Notifications.StateChangesConsumer, logger, engine, cfg.Sync, ctx)
+	mock.Eth1ExecutionService = eth1.NewEthereumExecutionModule(mock.BlockReader, mock.DB, mock.posStagedSync, forkValidator, mock.ChainConfig, assembleBlockPOS, cfg.Miner.PayloadRebuild, nil, mock.Notifications.Accumulator, mock.Notifications.RecentLogs, mock.Notifications.StateChangesConsumer, logger, engine, cfg.Sync, ctx)
 
 	mock.sentriesClient.Hd.StartPoSDownloader(mock.Ctx, sendHeaderRequest, penalize)
 
@@ -603,7 +643,11 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 		TopBlock: mock.Genesis,
 	}
 	if err = mock.InsertChain(c); err != nil {
-		tb.Fatal(err)
+		if tb != nil {
+			tb.Fatal(err)
+		} else {
+			panic(err)
+		}
 	}
 
 	return mock
@@ -656,6 +700,23 @@ func MockWithTxPoolCancun(t *testing.T) *MockSentry {
 	return MockWithEverything(t, gspec, key, prune.DefaultMode, ethash.NewFaker(), blockBufferSize, true, false, checkStateRoot)
 }
 
+func MockWithTxPoolOsaka(t *testing.T) *MockSentry {
+	funds := big.NewInt(1 * common.Ether)
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	chainConfig := *chain.AllProtocolChanges
+	chainConfig.OsakaTime = common.Big0
+	gspec := &types.Genesis{
+		Config: &chainConfig,
+		Alloc: types.GenesisAlloc{
+			address: {Balance: funds},
+		},
+	}
+
+	checkStateRoot := true
+	return MockWithEverything(t, gspec, key, prune.DefaultMode, ethash.NewFaker(), blockBufferSize, true, false, checkStateRoot)
+}
+
 func MockWithZeroTTD(t *testing.T, withPosDownloader bool) *MockSentry {
 	funds := big.NewInt(1 * common.Ether)
 	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
@@ -32,6 +32,7 @@ type TxnProvider interface {
 	//   - WithGasTarget
 	//   - WithBlobGasTarget
 	//   - WithTxnIdsFilter
+	//   - WithoutPrivateTxns
 	ProvideTxns(ctx context.Context, opts ...ProvideOption) ([]types.Transaction, error)
 }
 
@@ -73,13 +74,21 @@ func WithTxnIdsFilter(txnIdsFilter mapset.Set[[32]byte]) ProvideOption {
 	}
 }
 
+// WithoutPrivateTxns - skip transactions which are not propagated to peers, e.g. for inclusion lists
+func WithoutPrivateTxns() ProvideOption {
+	return func(opt *ProvideOptions) {
+		opt.SkipPrivateTxns = true
+	}
+}
+
 type ProvideOptions struct {
-	BlockTime      uint64
-	ParentBlockNum uint64
-	Amount         int
-	GasTarget      uint64
-	BlobGasTarget  uint64
-	TxnIdsFilter   mapset.Set[[32]byte]
+	BlockTime       uint64
+	ParentBlockNum  uint64
+	Amount          int
+	GasTarget       uint64
+	BlobGasTarget   uint64
+	TxnIdsFilter    mapset.Set[[32]byte]
+	SkipPrivateTxns bool
 }
 
 func ApplyProvideOptions(opts ...ProvideOption) ProvideOptions {
@@ -83,6 +83,7 @@ func Assemble(
 		agraBlock,
 		cancunTime,
 		pragueTime,
+		chainConfig.OsakaTime,
 		chainConfig.BlobSchedule,
 		sentryClients,
 		stateChangesClient,
@@ -36,6 +36,9 @@ type metaTxn struct {
 	bestIndex                 int
 	worstIndex                int
 	timestamp                 uint64 // when it was added to pool
+	arrival                   uint64 // sequence number of addition to pool
+	maxArrival                uint64 // max arrival of this and all txns with lower nonces of this sender
+	prioritized               bool   // this and all txns with lower nonces of this sender are local or from priority sender
 	subPool                   SubPoolMarker
 	currentSubPool            SubPoolType
 	minedBlockNum             uint64
@@ -62,25 +65,7 @@ func (mt *metaTxn) better(than *metaTxn, pendingBaseFee uint256.Int) bool {
 
 	switch mt.currentSubPool {
 	case PendingSubPool:
-		var effectiveTip, thanEffectiveTip uint256.Int
-		if mt.minFeeCap.Cmp(&pendingBaseFee) >= 0 {
-			difference := uint256.NewInt(0)
-			difference.Sub(&mt.minFeeCap, &pendingBaseFee)
-			if difference.Cmp(uint256.NewInt(mt.minTip)) <= 0 {
-				effectiveTip = *difference
-			} else {
-				effectiveTip = *uint256.NewInt(mt.minTip)
-			}
-		}
-		if than.minFeeCap.Cmp(&pendingBaseFee) >= 0 {
-			difference := uint256.NewInt(0)
-			difference.Sub(&than.minFeeCap, &pendingBaseFee)
-			if difference.Cmp(uint256.NewInt(than.minTip)) <= 0 {
-				thanEffectiveTip = *difference
-			} else {
-				thanEffectiveTip = *uint256.NewInt(than.minTip)
-			}
-		}
+		effectiveTip, thanEffectiveTip := mt.effectiveTip(pendingBaseFee), than.effectiveTip(pendingBaseFee)
 		if effectiveTip.Cmp(&thanEffectiveTip) != 0 {
 			return effectiveTip.Cmp(&thanEffectiveTip) > 0
 		}
@@ -109,6 +94,18 @@ func (mt *metaTxn) better(than *metaTxn, pendingBaseFee uint256.Int) bool {
 	return mt.timestamp < than.timestamp
 }
 
+// effectiveTip - tip which sender (and all txns with lower nonces of this sender) pays on top of pendingBaseFee
+func (mt *metaTxn) effectiveTip(pendingBaseFee uint256.Int) uint256.Int {
+	var effectiveTip uint256.Int
+	if mt.minFeeCap.Cmp(&pendingBaseFee) >= 0 {
+		effectiveTip.Sub(&mt.minFeeCap, &pendingBaseFee)
+		if effectiveTip.GtUint64(mt.minTip) {
+			effectiveTip.SetUint64(mt.minTip)
+		}
+	}
+	return effectiveTip
+}
+
 func (mt *metaTxn) worse(than *metaTxn, pendingBaseFee uint256.Int) bool {
 	subPool := mt.subPool
 	thanSubPool := than.subPool
@@ -48,11 +48,19 @@ func WithP2PSenderWg(wg *sync.WaitGroup) Option {
 	}
 }
 
+// WithOrderingPolicy - overrides ordering policy chosen by txpoolcfg.Config.Ordering
+func WithOrderingPolicy(ordering OrderingPolicy) Option {
+	return func(o *options) {
+		o.ordering = ordering
+	}
+}
+
 type options struct {
 	feeCalculator     FeeCalculator
 	poolDBInitializer poolDBInitializer
 	p2pSenderWg       *sync.WaitGroup
 	p2pFetcherWg      *sync.WaitGroup
+	ordering          OrderingPolicy
 }
 
 func applyOpts(opts ...Option) options {
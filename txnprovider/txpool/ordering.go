@@ -0,0 +1,160 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"fmt"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+// OrderingPolicy - order of transactions in pending sub-pool. best/YieldBest offer transactions
+// to block builder in Better order, promote evicts transactions from pending sub-pool in Worse order.
+//
+// To preserve nonce order of every sender, policies must compare only fields which are cumulative over
+// sender's nonces (calculated by onSenderStateChange: minTip, minFeeCap, maxArrival, prioritized),
+// and break ties by nonceDistance: then transaction never goes before lower-nonce transaction of the same sender.
+type OrderingPolicy interface {
+	// Better - a must be offered to block builder before b
+	Better(a, b *metaTxn, pendingBaseFee uint256.Int) bool
+	// Worse - a must be evicted from pending sub-pool before b
+	Worse(a, b *metaTxn, pendingBaseFee uint256.Int) bool
+}
+
+func NewOrderingPolicy(cfg txpoolcfg.Config) (OrderingPolicy, error) {
+	switch cfg.Ordering {
+	case txpoolcfg.TipOrdering, "":
+		return TipOrderingPolicy{}, nil
+	case txpoolcfg.FIFOOrdering:
+		return FIFOOrderingPolicy{}, nil
+	case txpoolcfg.WeightedLocalOrdering:
+		return WeightedLocalOrderingPolicy{Weight: max(cfg.LocalTipWeight, 1)}, nil
+	default:
+		return nil, fmt.Errorf("unknown txpool ordering: %q", cfg.Ordering)
+	}
+}
+
+// TipOrderingPolicy - by effective tip (default). Local transactions go before remote ones.
+type TipOrderingPolicy struct{}
+
+func (TipOrderingPolicy) Better(a, b *metaTxn, pendingBaseFee uint256.Int) bool {
+	return a.better(b, pendingBaseFee)
+}
+
+func (TipOrderingPolicy) Worse(a, b *metaTxn, pendingBaseFee uint256.Int) bool {
+	return a.worse(b, pendingBaseFee)
+}
+
+// FIFOOrderingPolicy - by arrival to pool, local transactions have no priority. Newest transactions are evicted first.
+type FIFOOrderingPolicy struct{}
+
+func (FIFOOrderingPolicy) Better(a, b *metaTxn, pendingBaseFee uint256.Int) bool {
+	if c := compareSubPoolMarkers(a, b, pendingBaseFee); c != 0 {
+		return c > 0
+	}
+	if a.maxArrival != b.maxArrival {
+		return a.maxArrival < b.maxArrival
+	}
+	if a.nonceDistance != b.nonceDistance {
+		return a.nonceDistance < b.nonceDistance
+	}
+	return a.arrival < b.arrival
+}
+
+func (FIFOOrderingPolicy) Worse(a, b *metaTxn, pendingBaseFee uint256.Int) bool {
+	if c := compareSubPoolMarkers(a, b, pendingBaseFee); c != 0 {
+		return c < 0
+	}
+	if a.maxArrival != b.maxArrival {
+		return a.maxArrival > b.maxArrival
+	}
+	if a.nonceDistance != b.nonceDistance {
+		return a.nonceDistance > b.nonceDistance
+	}
+	return a.arrival > b.arrival
+}
+
+// WeightedLocalOrderingPolicy - by effective tip, multiplied by Weight for local transactions
+// and transactions of txpoolcfg.Config.PrioritySenders
+type WeightedLocalOrderingPolicy struct {
+	Weight uint64
+}
+
+func (p WeightedLocalOrderingPolicy) Better(a, b *metaTxn, pendingBaseFee uint256.Int) bool {
+	if c := compareSubPoolMarkers(a, b, pendingBaseFee); c != 0 {
+		return c > 0
+	}
+	aTip, bTip := p.weightedTip(a, pendingBaseFee), p.weightedTip(b, pendingBaseFee)
+	if c := aTip.Cmp(&bTip); c != 0 {
+		return c > 0
+	}
+	if a.nonceDistance != b.nonceDistance {
+		return a.nonceDistance < b.nonceDistance
+	}
+	if a.cumulativeBalanceDistance != b.cumulativeBalanceDistance {
+		return a.cumulativeBalanceDistance < b.cumulativeBalanceDistance
+	}
+	return a.timestamp < b.timestamp
+}
+
+func (p WeightedLocalOrderingPolicy) Worse(a, b *metaTxn, pendingBaseFee uint256.Int) bool {
+	if c := compareSubPoolMarkers(a, b, pendingBaseFee); c != 0 {
+		return c < 0
+	}
+	aTip, bTip := p.weightedTip(a, pendingBaseFee), p.weightedTip(b, pendingBaseFee)
+	if c := aTip.Cmp(&bTip); c != 0 {
+		return c < 0
+	}
+	if a.nonceDistance != b.nonceDistance {
+		return a.nonceDistance > b.nonceDistance
+	}
+	if a.cumulativeBalanceDistance != b.cumulativeBalanceDistance {
+		return a.cumulativeBalanceDistance > b.cumulativeBalanceDistance
+	}
+	return a.timestamp > b.timestamp
+}
+
+func (p WeightedLocalOrderingPolicy) weightedTip(mt *metaTxn, pendingBaseFee uint256.Int) uint256.Int {
+	tip := mt.effectiveTip(pendingBaseFee)
+	if mt.prioritized {
+		tip.Mul(&tip, uint256.NewInt(p.Weight))
+	}
+	return tip
+}
+
+// compareSubPoolMarkers - compares sub-pool markers (including enough fee cap for pending block)
+// of transactions: 1 if a has better markers, -1 if worse, 0 if equal.
+// Unlike metaTxn.better, IsLocal marker is ignored: policy decides how to treat local transactions.
+func compareSubPoolMarkers(a, b *metaTxn, pendingBaseFee uint256.Int) int {
+	subPool, thanSubPool := a.subPool&^IsLocal, b.subPool&^IsLocal
+	if a.minFeeCap.Cmp(&pendingBaseFee) >= 0 {
+		subPool |= EnoughFeeCapBlock
+	}
+	if b.minFeeCap.Cmp(&pendingBaseFee) >= 0 {
+		thanSubPool |= EnoughFeeCapBlock
+	}
+	switch {
+	case subPool > thanSubPool:
+		return 1
+	case subPool < thanSubPool:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -34,8 +34,8 @@ type PendingPool struct {
 	t     SubPoolType
 }
 
-func NewPendingSubPool(t SubPoolType, limit int) *PendingPool {
-	return &PendingPool{limit: limit, t: t, best: &bestSlice{ms: []*metaTxn{}}, worst: &WorstQueue{ms: []*metaTxn{}}}
+func NewPendingSubPool(t SubPoolType, limit int, ordering OrderingPolicy) *PendingPool {
+	return &PendingPool{limit: limit, t: t, best: &bestSlice{ms: []*metaTxn{}, ordering: ordering}, worst: &WorstQueue{ms: []*metaTxn{}, ordering: ordering}}
 }
 
 func (p *PendingPool) EnforceWorstInvariants() {
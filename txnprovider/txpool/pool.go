@@ -23,6 +23,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"math/big"
 	"sync"
@@ -67,6 +68,10 @@ const DefaultBlockGasLimit = uint64(36000000)
 // by the peer.
 const txMaxBroadcastSize = 4 * 1024
 
+// minedPrivateTxnKeepBlocks - mined private txn without max block number stays private until its block is finalized, but
+// no longer than this number of blocks: if the block is unwound, the txn is back in pool and must not be propagated.
+const minedPrivateTxnKeepBlocks = 128
+
 // Pool is interface for the transaction pool
 // This interface exists for the convenience of testing, and not yet because
 // there are multiple implementations
@@ -84,7 +89,7 @@ type Pool interface {
 	FilterKnownIdHashes(tx kv.Tx, hashes Hashes) (unknownHashes Hashes, err error)
 	Started() bool
 	GetRlp(tx kv.Tx, hash []byte) ([]byte, error)
-	GetBlobs(blobhashes []common.Hash) ([][]byte, [][]byte)
+	GetBlobs(blobhashes []common.Hash, cellProofs bool) ([][]byte, [][]byte)
 	AddNewGoodPeer(peerID PeerID)
 }
 
@@ -115,16 +120,21 @@ type TxPool struct {
 	unprocessedRemoteByHash map[string]int                                  // to reject duplicates
 	byHash                  map[string]*metaTxn                             // txn_hash => txn : only those records not committed to db yet
 	discardReasonsLRU       *simplelru.LRU[string, txpoolcfg.DiscardReason] // txn_hash => discard_reason : non-persisted
+	statusHistory           *txnStatusHistory                               // txn_hash => status of discarded txn : persisted, for txpool_getTransactionStatus
 	pending                 *PendingPool
 	baseFee                 *SubPool
 	queued                  *SubPool
 	minedBlobTxnsByBlock    map[uint64][]*metaTxn            // (blockNum => slice): cache of recently mined blobs
 	minedBlobTxnsByHash     map[string]*metaTxn              // (hash => mt): map of recently mined blobs
 	isLocalLRU              *simplelru.LRU[string, struct{}] // txn_hash => is_local : to restore isLocal flag of unwinded transactions
+	privateTxns             map[string]uint64                // txn_hash => max block number (0 - no limit) : private txns are never propagated to peers
+	minedPrivateTxns        map[string]uint64                // txn_hash => block number : private txns without max block number, which left pool
 	newPendingTxns          chan Announcements               // notifications about new txns in Pending sub-pool
 	all                     *BySenderAndNonce                // senderID => (sorted map of txn nonce => *metaTxn)
 	deletedTxns             []*metaTxn                       // list of discarded txns since last db commit
 	promoted                Announcements
+	prioritySenders         map[common.Address]struct{} // senders which txns are treated as local by WeightedLocalOrderingPolicy
+	arrivals                uint64                      // counter of added txns - arrival order for FIFOOrderingPolicy
 	cfg                     txpoolcfg.Config
 	chainID                 uint256.Int
 	lastSeenBlock           atomic.Uint64
@@ -143,6 +153,8 @@ type TxPool struct {
 	isPostCancun            atomic.Bool
 	pragueTime              *uint64
 	isPostPrague            atomic.Bool
+	osakaTime               *uint64
+	isPostOsaka             atomic.Bool
 	blobSchedule            *chain.BlobSchedule
 	feeCalculator           FeeCalculator
 	p2pFetcher              *Fetch
@@ -178,6 +190,7 @@ func New(
 	agraBlock *big.Int,
 	cancunTime *big.Int,
 	pragueTime *big.Int,
+	osakaTime *big.Int,
 	blobSchedule *chain.BlobSchedule,
 	sentryClients []sentryproto.SentryClient,
 	stateChangesClient StateChangesClient,
@@ -196,6 +209,17 @@ func New(
 	if err != nil {
 		return nil, err
 	}
+	statusHistoryLimit, statusHistoryBlocks := cfg.StatusHistoryLimit, cfg.StatusHistoryBlocks
+	if statusHistoryLimit <= 0 {
+		statusHistoryLimit = txpoolcfg.DefaultConfig.StatusHistoryLimit
+	}
+	if statusHistoryBlocks == 0 {
+		statusHistoryBlocks = txpoolcfg.DefaultConfig.StatusHistoryBlocks
+	}
+	statusHistory, err := newTxnStatusHistory(statusHistoryLimit, statusHistoryBlocks)
+	if err != nil {
+		return nil, err
+	}
 
 	byNonce := &BySenderAndNonce{
 		tree:              btree.NewG[*metaTxn](32, SortByNonceLess),
@@ -207,6 +231,16 @@ func New(
 	for _, sender := range cfg.TracedSenders {
 		tracedSenders[common.BytesToAddress([]byte(sender))] = struct{}{}
 	}
+	prioritySenders := make(map[common.Address]struct{})
+	for _, sender := range cfg.PrioritySenders {
+		prioritySenders[common.BytesToAddress([]byte(sender))] = struct{}{}
+	}
+	ordering := options.ordering
+	if ordering == nil {
+		if ordering, err = NewOrderingPolicy(cfg); err != nil {
+			return nil, err
+		}
+	}
 
 	lock := &sync.Mutex{}
 
@@ -215,15 +249,19 @@ func New(
 		lastSeenCond:            sync.NewCond(lock),
 		byHash:                  map[string]*metaTxn{},
 		isLocalLRU:              localsHistory,
+		privateTxns:             map[string]uint64{},
+		minedPrivateTxns:        map[string]uint64{},
 		discardReasonsLRU:       discardHistory,
+		statusHistory:           statusHistory,
 		all:                     byNonce,
 		recentlyConnectedPeers:  &recentlyConnectedPeers{},
-		pending:                 NewPendingSubPool(PendingSubPool, cfg.PendingSubPoolLimit),
+		pending:                 NewPendingSubPool(PendingSubPool, cfg.PendingSubPoolLimit, ordering),
 		baseFee:                 NewSubPool(BaseFeeSubPool, cfg.BaseFeeSubPoolLimit),
 		queued:                  NewSubPool(QueuedSubPool, cfg.QueuedSubPoolLimit),
 		newPendingTxns:          newTxns,
 		_stateCache:             cache,
 		senders:                 newSendersBatch(tracedSenders),
+		prioritySenders:         prioritySenders,
 		poolDB:                  poolDB,
 		_chainDB:                chainDB,
 		cfg:                     cfg,
@@ -273,6 +311,13 @@ func New(
 		pragueTimeU64 := pragueTime.Uint64()
 		res.pragueTime = &pragueTimeU64
 	}
+	if osakaTime != nil {
+		if !osakaTime.IsUint64() {
+			return nil, errors.New("osakaTime overflow")
+		}
+		osakaTimeU64 := osakaTime.Uint64()
+		res.osakaTime = &osakaTimeU64
+	}
 
 	res.p2pFetcher = NewFetch(ctx, sentryClients, res, stateChangesClient, poolDB, chainID, logger, opts...)
 	res.p2pSender = NewSend(ctx, sentryClients, logger, opts...)
@@ -440,6 +485,15 @@ func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remote.StateChang
 	if err = p.removeMined(p.all, minedTxns.Txns); err != nil {
 		return err
 	}
+	for _, txn := range minedTxns.Txns {
+		hashS := string(txn.IDHash[:])
+		p.statusHistory.mined(hashS, block)
+		if maxBlockNumber, ok := p.privateTxns[hashS]; ok && maxBlockNumber == 0 {
+			p.minedPrivateTxns[hashS] = block
+		}
+	}
+	p.statusHistory.prune(block)
+	p.discardExpiredPrivateTxnsLocked(block, stateChanges.FinalizedBlock)
 
 	var announcements Announcements
 	announcements, err = p.addTxnsOnNewBlock(block, cacheView, stateChanges, p.senders, unwindTxns, /* newTxns */
@@ -633,6 +687,9 @@ func (p *TxPool) AppendLocalAnnouncements(types []byte, sizes []uint32, hashes [
 		if txn.subPool&IsLocal == 0 {
 			continue
 		}
+		if _, private := p.privateTxns[hash]; private {
+			continue
+		}
 		types = append(types, txn.TxnSlot.Type)
 		sizes = append(sizes, txn.TxnSlot.Size)
 		hashes = append(hashes, hash...)
@@ -648,6 +705,9 @@ func (p *TxPool) AppendRemoteAnnouncements(types []byte, sizes []uint32, hashes
 		if txn.subPool&IsLocal != 0 {
 			continue
 		}
+		if _, private := p.privateTxns[hash]; private {
+			continue
+		}
 		types = append(types, txn.TxnSlot.Type)
 		sizes = append(sizes, txn.TxnSlot.Size)
 		hashes = append(hashes, hash...)
@@ -735,7 +795,9 @@ func (p *TxPool) getCachedBlobTxnLocked(tx kv.Tx, hash []byte) (*metaTxn, error)
 	parseCtx := NewTxnParseContext(p.chainID)
 	parseCtx.WithSender(false)
 	txnSlot := &TxnSlot{}
-	parseCtx.ParseTransaction(txnRlp, 0, txnSlot, nil, false, true, nil)
+	if _, err := parseCtx.ParseTransaction(txnRlp, 0, txnSlot, nil, false, true, nil); err != nil {
+		return nil, fmt.Errorf("TxPool.getCachedBlobTxnLocked: ParseTransaction: %w", err)
+	}
 	return newMetaTxn(txnSlot, false, 0), nil
 }
 
@@ -746,6 +808,26 @@ func (p *TxPool) IsLocal(idHash []byte) bool {
 	return p.isLocalLRU.Contains(hashS)
 }
 
+// IsPrivate - txn was submitted by AddPrivateTxns: it's not propagated to peers
+func (p *TxPool) IsPrivate(idHash []byte) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	_, ok := p.privateTxns[string(idHash)]
+	return ok
+}
+
+// TxnStatus - sub-pool of transaction, or discard reason of recently discarded transaction. false if transaction is unknown
+func (p *TxPool) TxnStatus(idHash []byte) (TxnStatus, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	hashS := string(idHash)
+	if mt, ok := p.byHash[hashS]; ok {
+		_, private := p.privateTxns[hashS]
+		return TxnStatus{SubPool: mt.currentSubPool, Private: private}, true
+	}
+	return p.statusHistory.get(hashS)
+}
+
 func (p *TxPool) AddNewGoodPeer(peerID PeerID) {
 	p.recentlyConnectedPeers.AddPeer(peerID)
 }
@@ -877,6 +959,10 @@ func (p *TxPool) ProvideTxns(ctx context.Context, opts ...txnprovider.ProvideOpt
 			return nil, err
 		}
 
+		if provideOptions.SkipPrivateTxns && p.IsPrivate(txn.Hash().Bytes()) {
+			continue
+		}
+
 		var sender common.Address
 		copy(sender[:], txnsRlp.Senders.At(i))
 		txn.SetSender(sender)
@@ -932,6 +1018,44 @@ func toBlobs(_blobs [][]byte) []gokzg4844.BlobRef {
 	return blobs
 }
 
+// toCellProofsWrapper - replaces blob proofs of txn with cell proofs, and its wrapper with EIP-7594 one: [tx_payload_body, 1, blobs, commitments, cell_proofs].
+func toCellProofsWrapper(txn *TxnSlot) error {
+	if txn.Rlp == nil {
+		return errors.New("no rlp of blob txn")
+	}
+	cellProofs := make([]gokzg4844.KZGProof, 0, len(txn.Blobs)*libkzg.CellsPerExtBlob)
+	for _, blob := range txn.Blobs {
+		blobCellProofs, err := libkzg.ComputeCellProofs(blob)
+		if err != nil {
+			return err
+		}
+		cellProofs = append(cellProofs, blobCellProofs...)
+	}
+	wrapped, err := types.DecodeWrappedTransaction(txn.Rlp)
+	if err != nil {
+		return err
+	}
+	wrapper, ok := wrapped.(*types.BlobTxWrapper)
+	if !ok {
+		return fmt.Errorf("unexpected blob txn type %T", wrapped)
+	}
+	wrapper.WrapperVersion = types.BlobWrapperVersion1
+	wrapper.Proofs = make(types.KZGProofs, len(cellProofs))
+	for i := range cellProofs {
+		wrapper.Proofs[i] = types.KZGProof(cellProofs[i])
+	}
+	var buf bytes.Buffer
+	if err := wrapper.MarshalBinaryWrapped(&buf); err != nil {
+		return err
+	}
+	txn.Rlp = buf.Bytes()
+	txn.Size = uint32(len(txn.Rlp))
+	txn.BlobWrapperVersion = types.BlobWrapperVersion1
+	txn.Proofs = nil
+	txn.CellProofs = cellProofs
+	return nil
+}
+
 func (p *TxPool) validateTx(txn *TxnSlot, isLocal bool, stateCache kvcache.CacheView) txpoolcfg.DiscardReason {
 	isShanghai := p.isShanghai() || p.isAgra()
 	if isShanghai && txn.Creation && txn.DataLen > params.MaxInitCodeSize {
@@ -951,9 +1075,21 @@ func (p *TxPool) validateTx(txn *TxnSlot, isLocal bool, stateCache kvcache.Cache
 		if blobCount > p.GetMaxBlobsPerBlock() {
 			return txpoolcfg.TooManyBlobs
 		}
+		isOsaka := p.isOsaka()
+		if txn.BlobWrapperVersion == types.BlobWrapperVersion1 && !isOsaka {
+			return txpoolcfg.TypeNotActivated // EIP-7594 wrapper with cell proofs
+		}
+		if txn.BlobWrapperVersion != types.BlobWrapperVersion1 && isOsaka {
+			// local txns are converted by addLocalTxns, computing cell proofs for peers' txns is too expensive
+			return txpoolcfg.BlobTxWithoutCells
+		}
 		equalNumber := len(txn.BlobHashes) == len(txn.Blobs) &&
-			len(txn.Blobs) == len(txn.Commitments) &&
-			len(txn.Commitments) == len(txn.Proofs)
+			len(txn.Blobs) == len(txn.Commitments)
+		if txn.BlobWrapperVersion == types.BlobWrapperVersion1 {
+			equalNumber = equalNumber && len(txn.CellProofs) == len(txn.Blobs)*libkzg.CellsPerExtBlob
+		} else {
+			equalNumber = equalNumber && len(txn.Commitments) == len(txn.Proofs)
+		}
 
 		if !equalNumber {
 			return txpoolcfg.UnequalBlobTxExt
@@ -965,11 +1101,18 @@ func (p *TxPool) validateTx(txn *TxnSlot, isLocal bool, stateCache kvcache.Cache
 			}
 		}
 
-		// https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#verify_blob_kzg_proof_batch
-		kzgCtx := libkzg.Ctx()
-		err := kzgCtx.VerifyBlobKZGProofBatch(toBlobs(txn.Blobs), txn.Commitments, txn.Proofs)
-		if err != nil {
-			return txpoolcfg.UnmatchedBlobTxExt
+		if txn.BlobWrapperVersion == types.BlobWrapperVersion1 {
+			// https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/polynomial-commitments-sampling.md#verify_cell_kzg_proof_batch
+			if err := libkzg.VerifyCellProofs(toBlobs(txn.Blobs), txn.Commitments, txn.CellProofs); err != nil {
+				return txpoolcfg.UnmatchedBlobTxExt
+			}
+		} else {
+			// https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#verify_blob_kzg_proof_batch
+			kzgCtx := libkzg.Ctx()
+			err := kzgCtx.VerifyBlobKZGProofBatch(toBlobs(txn.Blobs), txn.Commitments, txn.Proofs)
+			if err != nil {
+				return txpoolcfg.UnmatchedBlobTxExt
+			}
 		}
 
 		if !isLocal && (p.all.blobCount(txn.SenderID)+uint64(len(txn.BlobHashes))) > p.cfg.BlobSlots {
@@ -1181,6 +1324,10 @@ func (p *TxPool) isPrague() bool {
 	return isTimeBasedForkActivated(&p.isPostPrague, p.pragueTime)
 }
 
+func (p *TxPool) isOsaka() bool {
+	return isTimeBasedForkActivated(&p.isPostOsaka, p.osakaTime)
+}
+
 func (p *TxPool) GetMaxBlobsPerBlock() uint64 {
 	return p.blobSchedule.MaxBlobsPerBlock(p.isPrague())
 }
@@ -1200,8 +1347,8 @@ func (p *TxPool) ValidateSerializedTxn(serializedTxn []byte) error {
 		// to validate whether they fit into the pool or not.
 		txnMaxSize = 4 * txnSlotSize // 128KB
 
-		// Should be enough for a transaction with 6 blobs
-		blobTxnMaxSize = 800_000
+		// Should be enough for a transaction with 6 blobs and their cell proofs (EIP-7594)
+		blobTxnMaxSize = 850_000
 	)
 	txnType, err := PeekTransactionType(serializedTxn)
 	if err != nil {
@@ -1318,6 +1465,17 @@ func fillDiscardReasons(reasons []txpoolcfg.DiscardReason, newTxns TxnSlots, dis
 }
 
 func (p *TxPool) AddLocalTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcfg.DiscardReason, error) {
+	return p.addLocalTxns(ctx, newTxns, false, 0)
+}
+
+// AddPrivateTxns - adds txns as local, but never propagates them to peers (and to new txns subscribers):
+// they can be included only in blocks built by this node. Txns not included until maxBlockNumber
+// (0 - no limit) are dropped from pool.
+func (p *TxPool) AddPrivateTxns(ctx context.Context, newTxns TxnSlots, maxBlockNumber uint64) ([]txpoolcfg.DiscardReason, error) {
+	return p.addLocalTxns(ctx, newTxns, true, maxBlockNumber)
+}
+
+func (p *TxPool) addLocalTxns(ctx context.Context, newTxns TxnSlots, private bool, maxBlockNumber uint64) ([]txpoolcfg.DiscardReason, error) {
 	coreDb, cache := p.chainDB()
 	coreTx, err := coreDb.BeginTemporalRo(ctx)
 	if err != nil {
@@ -1330,6 +1488,19 @@ func (p *TxPool) AddLocalTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcf
 		return nil, err
 	}
 
+	// EIP-7594: after Osaka blobs are propagated with cell proofs. Computing them is slow, so it's done before taking
+	// the lock. Txn, which can't be converted, is rejected by validateTx
+	if p.isOsaka() {
+		for _, txn := range newTxns.Txns {
+			if txn.Type != BlobTxnType || txn.BlobWrapperVersion == types.BlobWrapperVersion1 {
+				continue
+			}
+			if err := toCellProofsWrapper(txn); err != nil {
+				p.logger.Debug("[txpool] failed to compute cell proofs", "idHash", fmt.Sprintf("%x", txn.IDHash), "err", err)
+			}
+		}
+	}
+
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -1342,6 +1513,21 @@ func (p *TxPool) AddLocalTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcf
 		return nil, err
 	}
 
+	// mark before adding: promoted txns must not be announced
+	var markedPrivate []string
+	if private {
+		for _, txn := range newTxns.Txns {
+			hashS := string(txn.IDHash[:])
+			if _, ok := p.byHash[hashS]; ok {
+				continue // already known (maybe already propagated) - not private
+			}
+			if _, ok := p.privateTxns[hashS]; !ok {
+				p.privateTxns[hashS] = maxBlockNumber
+				markedPrivate = append(markedPrivate, hashS)
+			}
+		}
+	}
+
 	announcements, addReasons, err := p.addTxns(p.lastSeenBlock.Load(), cacheView, p.senders, newTxns,
 		p.pendingBaseFee.Load(), p.pendingBlobFee.Load(), p.blockGasLimit.Load(), true, p.logger)
 	if err == nil {
@@ -1351,6 +1537,9 @@ func (p *TxPool) AddLocalTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcf
 			}
 		}
 	} else {
+		for _, hashS := range markedPrivate {
+			delete(p.privateTxns, hashS)
+		}
 		return nil, err
 	}
 	p.promoted.Reset()
@@ -1366,6 +1555,11 @@ func (p *TxPool) AddLocalTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcf
 			p.promoted.Append(txn.Type, txn.Size, txn.IDHash[:])
 		}
 	}
+	for _, hashS := range markedPrivate {
+		if _, ok := p.byHash[hashS]; !ok { // rejected
+			delete(p.privateTxns, hashS)
+		}
+	}
 	if p.promoted.Len() > 0 {
 		select {
 		case p.newPendingTxns <- p.promoted.Copy():
@@ -1413,6 +1607,8 @@ func (p *TxPool) addTxns(blockNum uint64, cacheView kvcache.CacheView, senders *
 			continue
 		}
 		mt := newMetaTxn(txn, newTxns.IsLocal[i], blockNum)
+		p.arrivals++
+		mt.arrival = p.arrivals
 
 		if reason := p.addLocked(mt, &announcements); reason != txpoolcfg.NotSet {
 			discardReasons[i] = reason
@@ -1465,6 +1661,8 @@ func (p *TxPool) addTxnsOnNewBlock(blockNum uint64, cacheView kvcache.CacheView,
 			continue
 		}
 		mt := newMetaTxn(txn, newTxns.IsLocal[i], blockNum)
+		p.arrivals++
+		mt.arrival = p.arrivals
 		if reason := p.addLocked(mt, &announcements); reason != txpoolcfg.NotSet {
 			p.discardLocked(mt, reason)
 			continue
@@ -1590,6 +1788,7 @@ func (p *TxPool) addLocked(mt *metaTxn, announcements *Announcements) txpoolcfg.
 		}
 
 		p.discardLocked(found, txpoolcfg.ReplacedByHigherTip)
+		p.statusHistory.replaced(string(found.TxnSlot.IDHash[:]), mt.TxnSlot.IDHash)
 	}
 
 	// Don't add blob txn to queued if it's less than current pending blob base fee
@@ -1627,6 +1826,7 @@ func (p *TxPool) addLocked(mt *metaTxn, announcements *Announcements) txpoolcfg.
 
 	hashStr := string(mt.TxnSlot.IDHash[:])
 	p.byHash[hashStr] = mt
+	p.statusHistory.remove(hashStr)
 
 	if replaced := p.all.replaceOrInsert(mt, p.logger); replaced != nil {
 		if assert.Enable {
@@ -1669,6 +1869,7 @@ func (p *TxPool) discardLocked(mt *metaTxn, reason txpoolcfg.DiscardReason) {
 	p.deletedTxns = append(p.deletedTxns, mt)
 	p.all.delete(mt, reason, p.logger)
 	p.discardReasonsLRU.Add(hashStr, reason)
+	p.statusHistory.discarded(hashStr, reason, p.lastSeenBlock.Load(), time.Now())
 	if mt.TxnSlot.Type == BlobTxnType {
 		t := p.totalBlobsInPool.Load()
 		p.totalBlobsInPool.Store(t - uint64(len(mt.TxnSlot.BlobHashes)))
@@ -1680,7 +1881,46 @@ func (p *TxPool) discardLocked(mt *metaTxn, reason txpoolcfg.DiscardReason) {
 	}
 }
 
-func (p *TxPool) getBlobsAndProofByBlobHashLocked(blobHashes []common.Hash) ([][]byte, [][]byte) {
+// discardExpiredPrivateTxnsLocked - drops private txns which can't be included anymore: next block is after their max block number.
+// Mined private txns are remembered until max block number - to stay private if unwound. Without max block number they are
+// remembered until the block, where they left pool, is finalized or minedPrivateTxnKeepBlocks passed.
+func (p *TxPool) discardExpiredPrivateTxnsLocked(blockNum, finalizedBlock uint64) {
+	for hashS, maxBlockNumber := range p.privateTxns {
+		expired := maxBlockNumber != 0 && maxBlockNumber <= blockNum
+		mt, ok := p.byHash[hashS]
+		if !ok {
+			if maxBlockNumber == 0 {
+				minedBlock, known := p.minedPrivateTxns[hashS]
+				if !known { // discarded, or restored from db
+					p.minedPrivateTxns[hashS] = blockNum
+					continue
+				}
+				expired = (finalizedBlock != 0 && minedBlock <= finalizedBlock) || minedBlock+minedPrivateTxnKeepBlocks <= blockNum
+			}
+			if expired {
+				delete(p.privateTxns, hashS)
+				delete(p.minedPrivateTxns, hashS)
+			}
+			continue
+		}
+		delete(p.minedPrivateTxns, hashS) // back in pool after unwind
+		if !expired {
+			continue
+		}
+		switch mt.currentSubPool {
+		case PendingSubPool:
+			p.pending.Remove(mt, "private-expired", p.logger)
+		case BaseFeeSubPool:
+			p.baseFee.Remove(mt, "private-expired", p.logger)
+		case QueuedSubPool:
+			p.queued.Remove(mt, "private-expired", p.logger)
+		}
+		p.discardLocked(mt, txpoolcfg.PrivateTxnExpired)
+		delete(p.privateTxns, hashS)
+	}
+}
+
+func (p *TxPool) getBlobsAndProofByBlobHashLocked(blobHashes []common.Hash, cellProofs bool) ([][]byte, [][]byte) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 	blobs := make([][]byte, len(blobHashes))
@@ -1694,14 +1934,30 @@ func (p *TxPool) getBlobsAndProofByBlobHashLocked(blobHashes []common.Hash) ([][
 		if !ok || mt == nil {
 			continue
 		}
+		if cellProofs {
+			if len(mt.TxnSlot.CellProofs) == 0 {
+				continue
+			}
+			blobCellProofs := mt.TxnSlot.CellProofs[th.index*libkzg.CellsPerExtBlob : (th.index+1)*libkzg.CellsPerExtBlob]
+			proofs[i] = make([]byte, 0, len(blobCellProofs)*len(blobCellProofs[0]))
+			for _, proof := range blobCellProofs {
+				proofs[i] = append(proofs[i], proof[:]...)
+			}
+		} else {
+			if len(mt.TxnSlot.Proofs) == 0 { // EIP-7594 wrapper: only cell proofs are known
+				continue
+			}
+			proofs[i] = mt.TxnSlot.Proofs[th.index][:]
+		}
 		blobs[i] = mt.TxnSlot.Blobs[th.index]
-		proofs[i] = mt.TxnSlot.Proofs[th.index][:]
 	}
 	return blobs, proofs
 }
 
-func (p *TxPool) GetBlobs(blobHashes []common.Hash) ([][]byte, [][]byte) {
-	return p.getBlobsAndProofByBlobHashLocked(blobHashes)
+// GetBlobs - blobs with their proofs: one blob proof per blob, or libkzg.CellsPerExtBlob concatenated cell proofs
+// per blob if cellProofs. Blobs which are not known or don't have requested proofs are nil
+func (p *TxPool) GetBlobs(blobHashes []common.Hash, cellProofs bool) ([][]byte, [][]byte) {
+	return p.getBlobsAndProofByBlobHashLocked(blobHashes, cellProofs)
 }
 
 // Cache recently mined blobs in anticipation of reorg, delete finalized ones
@@ -1856,6 +2112,9 @@ func (p *TxPool) onSenderStateChange(senderID uint64, senderNonce uint64, sender
 	cumulativeRequiredBalance := uint256.NewInt(0)
 	minFeeCap := uint256.NewInt(0).SetAllOne()
 	minTip := uint64(math.MaxUint64)
+	maxArrival := uint64(0)
+	_, prioritySender := p.prioritySenders[p.senders.senderID2Addr[senderID]]
+	prioritized := true
 	var toDel []*metaTxn // can't delete items while iterate them
 
 	p.all.ascend(senderID, func(mt *metaTxn) bool {
@@ -1910,6 +2169,10 @@ func (p *TxPool) onSenderStateChange(senderID uint64, senderNonce uint64, sender
 			minTip = min(minTip, mt.TxnSlot.Tip.Uint64())
 		}
 		mt.minTip = minTip
+		maxArrival = max(maxArrival, mt.arrival)
+		mt.maxArrival = maxArrival
+		prioritized = prioritized && (prioritySender || mt.subPool&IsLocal != 0)
+		mt.prioritized = prioritized
 
 		mt.nonceDistance = 0
 		if mt.TxnSlot.Nonce > senderNonce { // no uint underflow
@@ -2180,6 +2443,9 @@ func (p *TxPool) Run(ctx context.Context) error {
 				if err := p.poolDB.View(ctx, func(tx kv.Tx) error {
 					for i := 0; i < announcements.Len(); i++ {
 						t, size, hash := announcements.At(i)
+						if p.IsPrivate(hash) {
+							continue
+						}
 						slotRlp, err := p.GetRlp(tx, hash)
 						if err != nil {
 							return err
@@ -2388,6 +2654,12 @@ func (p *TxPool) flushLocked(tx kv.RwTx) (err error) {
 	if err := PutLastSeenBlock(tx, p.lastSeenBlock.Load(), encID); err != nil {
 		return err
 	}
+	if err := PutPrivateTxns(tx, p.privateTxns, v); err != nil {
+		return err
+	}
+	if err := p.statusHistory.flush(tx); err != nil {
+		return err
+	}
 
 	// clean - in-memory data structure as later as possible - because if during this txn will happen error,
 	// DB will stay consistent but some in-memory structures may be already cleaned, and retry will not work
@@ -2437,6 +2709,14 @@ func (p *TxPool) fromDB(ctx context.Context, tx kv.Tx, coreTx kv.TemporalTx) err
 		}
 		p.isLocalLRU.Add(string(v), struct{}{})
 	}
+	privateTxns, err := PrivateTxns(tx)
+	if err != nil {
+		return err
+	}
+	maps.Copy(p.privateTxns, privateTxns)
+	if err := p.statusHistory.load(tx); err != nil {
+		return err
+	}
 
 	txns := TxnSlots{}
 	parseCtx := NewTxnParseContext(p.chainID)
@@ -2572,16 +2852,19 @@ func (p *TxPool) logStats() {
 }
 
 // Deprecated need switch to streaming-like
-func (p *TxPool) deprecatedForEach(_ context.Context, f func(rlp []byte, sender common.Address, t SubPoolType), tx kv.Tx) {
+func (p *TxPool) deprecatedForEach(_ context.Context, f func(rlp []byte, sender common.Address, t SubPoolType, private bool), tx kv.Tx) {
 	var txns []*metaTxn
 	var senders []common.Address
+	var private []bool
 
 	p.lock.Lock()
 
 	p.all.ascendAll(func(mt *metaTxn) bool {
 		if sender, found := p.senders.senderID2Addr[mt.TxnSlot.SenderID]; found {
+			_, isPrivate := p.privateTxns[string(mt.TxnSlot.IDHash[:])]
 			txns = append(txns, mt)
 			senders = append(senders, sender)
+			private = append(private, isPrivate)
 		}
 
 		return true
@@ -2604,7 +2887,7 @@ func (p *TxPool) deprecatedForEach(_ context.Context, f func(rlp []byte, sender
 			slotRlp = v[20:]
 		}
 
-		f(slotRlp, senders[i], txns[i].currentSubPool)
+		f(slotRlp, senders[i], txns[i].currentSubPool, private[i])
 	}
 }
 
@@ -29,6 +29,7 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
 )
 
 var PoolChainConfigKey = []byte("chain_config")
@@ -36,6 +37,7 @@ var PoolLastSeenBlockKey = []byte("last_seen_block")
 var PoolPendingBaseFeeKey = []byte("pending_base_fee")
 var PoolPendingBlobFeeKey = []byte("pending_blob_fee")
 var PoolStateVersion = []byte("state_version")
+var PoolPrivateTxnsKey = []byte("private_txns")
 
 func getExecutionProgress(db kv.Getter) (uint64, error) {
 	data, err := db.GetOne(kv.SyncStageProgress, []byte("Execution"))
@@ -75,6 +77,59 @@ func PutLastSeenBlock(tx kv.Putter, n uint64, buf []byte) error {
 	return nil
 }
 
+// PrivateTxns - txn_hash => max block number of txns submitted as private
+func PrivateTxns(tx kv.Getter) (map[string]uint64, error) {
+	v, err := tx.GetOne(kv.PoolInfo, PoolPrivateTxnsKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(v)%(32+8) != 0 {
+		return nil, fmt.Errorf("invalid private txns value length in pool db: %d", len(v))
+	}
+	privateTxns := make(map[string]uint64, len(v)/(32+8))
+	for ; len(v) > 0; v = v[32+8:] {
+		privateTxns[string(v[:32])] = binary.BigEndian.Uint64(v[32:])
+	}
+	return privateTxns, nil
+}
+
+func PutPrivateTxns(tx kv.Putter, privateTxns map[string]uint64, buf []byte) error {
+	buf = buf[:0]
+	for hash, maxBlockNumber := range privateTxns {
+		buf = append(buf, hash...)
+		buf = binary.BigEndian.AppendUint64(buf, maxBlockNumber)
+	}
+	return tx.Put(kv.PoolInfo, PoolPrivateTxnsKey, buf)
+}
+
+// EncodeTxnStatus - discard_reason(1) + discard_time(8) + discard_block(8) + mined_block(8) + optional replaced_by(32)
+func EncodeTxnStatus(status TxnStatus, buf []byte) []byte {
+	buf = append(buf, byte(status.DiscardReason))
+	buf = binary.BigEndian.AppendUint64(buf, status.DiscardTime)
+	buf = binary.BigEndian.AppendUint64(buf, status.DiscardBlock)
+	buf = binary.BigEndian.AppendUint64(buf, status.MinedBlock)
+	if status.ReplacedBy != (common.Hash{}) {
+		buf = append(buf, status.ReplacedBy[:]...)
+	}
+	return buf
+}
+
+func DecodeTxnStatus(v []byte) (TxnStatus, error) {
+	if len(v) != 1+3*8 && len(v) != 1+3*8+32 {
+		return TxnStatus{}, fmt.Errorf("invalid txn status value length in pool db: %d", len(v))
+	}
+	status := TxnStatus{
+		DiscardReason: txpoolcfg.DiscardReason(v[0]),
+		DiscardTime:   binary.BigEndian.Uint64(v[1:]),
+		DiscardBlock:  binary.BigEndian.Uint64(v[9:]),
+		MinedBlock:    binary.BigEndian.Uint64(v[17:]),
+	}
+	if len(v) > 1+3*8 {
+		status.ReplacedBy = common.Hash(v[25:])
+	}
+	return status, nil
+}
+
 func ChainConfig(tx kv.Getter) (*chain.Config, error) {
 	v, err := tx.GetOne(kv.PoolInfo, PoolChainConfigKey)
 	if err != nil {
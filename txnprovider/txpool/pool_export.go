@@ -0,0 +1,307 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/erigontech/erigon-lib/common"
+	txpool_proto "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+// Export file format - not tied to pool db, can be moved between nodes:
+//
+//	header, then records until EOF:
+//	flags(1) + sender(20) + [max_block_number(uvarint) if private] + rlp_len(uvarint) + rlp
+//
+// rlp - as it's stored by pool: blob transactions are wrapped with sidecars.
+var txnsExportHeader = []byte("erigon-txpool-export-v1\n")
+
+const (
+	exportedLocal   byte = 1 << 0
+	exportedPrivate byte = 1 << 1
+
+	maxExportedTxnSize = 16 << 20 // protection from corrupted files
+)
+
+// ImportedTxns - result of ImportTxns
+type ImportedTxns struct {
+	Added     int // local and private txns accepted by pool
+	Discarded int // local and private txns rejected by pool, and unparsable txns
+	Queued    int // remote txns: added to batch of remote txns and will pass usual validation
+}
+
+// ExportTxns - writes all transactions of all sub-pools to w. Returns number of written transactions
+func (p *TxPool) ExportTxns(ctx context.Context, w io.Writer) (int, error) {
+	type exported struct {
+		hash           common.Hash
+		rlp            []byte
+		sender         common.Address
+		flags          byte
+		maxBlockNumber uint64
+	}
+	var txns []exported
+	p.lock.Lock()
+	p.all.ascendAll(func(mt *metaTxn) bool {
+		sender, ok := p.senders.senderID2Addr[mt.TxnSlot.SenderID]
+		if !ok {
+			return true
+		}
+		txn := exported{hash: mt.TxnSlot.IDHash, rlp: mt.TxnSlot.Rlp, sender: sender}
+		if mt.subPool&IsLocal != 0 {
+			txn.flags |= exportedLocal
+		}
+		if maxBlockNumber, ok := p.privateTxns[string(mt.TxnSlot.IDHash[:])]; ok {
+			txn.flags |= exportedPrivate
+			txn.maxBlockNumber = maxBlockNumber
+		}
+		txns = append(txns, txn)
+		return true
+	})
+	p.lock.Unlock()
+
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(txnsExportHeader); err != nil {
+		return 0, err
+	}
+	var written int
+	if err := p.poolDB.View(ctx, func(tx kv.Tx) error {
+		var buf []byte
+		for _, txn := range txns {
+			rlpTxn := txn.rlp
+			if rlpTxn == nil {
+				v, err := tx.GetOne(kv.PoolTransaction, txn.hash[:])
+				if err != nil {
+					return err
+				}
+				if v == nil { // discarded after it was collected
+					continue
+				}
+				rlpTxn = v[20:]
+			}
+			buf = append(buf[:0], txn.flags)
+			buf = append(buf, txn.sender[:]...)
+			if txn.flags&exportedPrivate != 0 {
+				buf = binary.AppendUvarint(buf, txn.maxBlockNumber)
+			}
+			buf = binary.AppendUvarint(buf, uint64(len(rlpTxn)))
+			if _, err := bw.Write(buf); err != nil {
+				return err
+			}
+			if _, err := bw.Write(rlpTxn); err != nil {
+				return err
+			}
+			written++
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	if err := bw.Flush(); err != nil {
+		return 0, err
+	}
+	return written, nil
+}
+
+// ImportTxns - adds transactions written by ExportTxns: local txns by AddLocalTxns, private txns by AddPrivateTxns
+// and remote txns by AddRemoteTxns - all of them pass usual validation
+func (p *TxPool) ImportTxns(ctx context.Context, r io.Reader) (ImportedTxns, error) {
+	var res ImportedTxns
+	br := bufio.NewReader(r)
+	header := make([]byte, len(txnsExportHeader))
+	if _, err := io.ReadFull(br, header); err != nil {
+		return res, fmt.Errorf("txpool import: read header: %w", err)
+	}
+	if !bytes.Equal(header, txnsExportHeader) {
+		return res, fmt.Errorf("txpool import: unexpected header: %q", header)
+	}
+
+	parseCtx := NewTxnParseContext(p.chainID).ChainIDRequired()
+	parseCtx.ValidateRLP(p.ValidateSerializedTxn)
+	var local, remote TxnSlots
+	private := map[uint64]*TxnSlots{}
+	// read without pool db transaction: already known txns are rejected when added
+	if err := func() error {
+		for {
+			flags, err := br.ReadByte()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			var sender common.Address
+			if _, err := io.ReadFull(br, sender[:]); err != nil {
+				return fmt.Errorf("txpool import: read sender: %w", err)
+			}
+			var maxBlockNumber uint64
+			if flags&exportedPrivate != 0 {
+				if maxBlockNumber, err = binary.ReadUvarint(br); err != nil {
+					return fmt.Errorf("txpool import: read max block number: %w", err)
+				}
+			}
+			size, err := binary.ReadUvarint(br)
+			if err != nil {
+				return fmt.Errorf("txpool import: read rlp size: %w", err)
+			}
+			if size > maxExportedTxnSize {
+				return fmt.Errorf("txpool import: too big transaction: %d", size)
+			}
+			rlpTxn := make([]byte, size) // not reused: TxnSlot keeps reference to it
+			if _, err := io.ReadFull(br, rlpTxn); err != nil {
+				return fmt.Errorf("txpool import: read rlp: %w", err)
+			}
+
+			slot, recovered := &TxnSlot{}, common.Address{}
+			if _, err := parseCtx.ParseTransaction(rlpTxn, 0, slot, recovered[:], false /* hasEnvelope */, true /* wrappedWithBlobs */, nil); err != nil {
+				p.logger.Debug("[txpool] import: parse transaction", "err", err)
+				res.Discarded++
+				continue
+			}
+			if recovered != sender {
+				p.logger.Debug("[txpool] import: sender mismatch", "expected", sender, "recovered", recovered)
+				res.Discarded++
+				continue
+			}
+			switch {
+			case flags&exportedPrivate != 0:
+				if private[maxBlockNumber] == nil {
+					private[maxBlockNumber] = &TxnSlots{}
+				}
+				private[maxBlockNumber].Append(slot, sender[:], true)
+			case flags&exportedLocal != 0:
+				local.Append(slot, sender[:], true)
+			default:
+				remote.Append(slot, sender[:], false)
+			}
+		}
+	}(); err != nil {
+		return res, err
+	}
+
+	countReasons := func(reasons []txpoolcfg.DiscardReason) {
+		for _, reason := range reasons {
+			if reason == txpoolcfg.Success {
+				res.Added++
+			} else {
+				res.Discarded++
+			}
+		}
+	}
+	if len(local.Txns) > 0 {
+		reasons, err := p.AddLocalTxns(ctx, local)
+		if err != nil {
+			return res, err
+		}
+		countReasons(reasons)
+	}
+	for maxBlockNumber, txns := range private {
+		reasons, err := p.AddPrivateTxns(ctx, *txns, maxBlockNumber)
+		if err != nil {
+			return res, err
+		}
+		countReasons(reasons)
+	}
+	switch {
+	case len(remote.Txns) == 0:
+	case p.cfg.NoGossip: // remote txns are not accepted
+		res.Discarded += len(remote.Txns)
+	default:
+		p.AddRemoteTxns(ctx, remote)
+		res.Queued = len(remote.Txns)
+	}
+	return res, nil
+}
+
+// importChunkSize - max size of dump part sent in one ImportRequest
+const importChunkSize = 1 << 20
+
+// ExportToFile - streams dump of all sub-pools from txpool service to new file at path. Returns number of exported
+// transactions. File is not written if it exists, and is removed if export fails.
+func ExportToFile(ctx context.Context, client txpool_proto.TxpoolClient, path string) (count uint64, err error) {
+	stream, err := client.Export(ctx, &txpool_proto.ExportRequest{})
+	if err != nil {
+		return 0, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(path)
+		}
+	}()
+	for {
+		reply, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		if _, err := f.Write(reply.Chunk); err != nil {
+			return 0, err
+		}
+		count += reply.Count
+	}
+	return count, f.Sync()
+}
+
+// ImportFromFile - streams file at path, written by ExportToFile, to txpool service, which adds its transactions
+func ImportFromFile(ctx context.Context, client txpool_proto.TxpoolClient, path string) (*txpool_proto.ImportReply, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	stream, err := client.Import(ctx)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, importChunkSize)
+	for {
+		n, err := io.ReadFull(f, buf)
+		if n > 0 {
+			if err := stream.Send(&txpool_proto.ImportRequest{Chunk: buf[:n]}); err != nil {
+				if errors.Is(err, io.EOF) { // server stopped reading, actual error returned by CloseAndRecv
+					break
+				}
+				return nil, err
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
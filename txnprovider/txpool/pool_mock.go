@@ -195,18 +195,18 @@ func (c *MockPoolFilterKnownIdHashesCall) DoAndReturn(f func(kv.Tx, Hashes) (Has
 }
 
 // GetBlobs mocks base method.
-func (m *MockPool) GetBlobs(blobhashes []common.Hash) ([][]byte, [][]byte) {
+func (m *MockPool) GetBlobs(blobhashes []common.Hash, cellProofs bool) ([][]byte, [][]byte) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBlobs", blobhashes)
+	ret := m.ctrl.Call(m, "GetBlobs", blobhashes, cellProofs)
 	ret0, _ := ret[0].([][]byte)
 	ret1, _ := ret[1].([][]byte)
 	return ret0, ret1
 }
 
 // GetBlobs indicates an expected call of GetBlobs.
-func (mr *MockPoolMockRecorder) GetBlobs(blobhashes any) *MockPoolGetBlobsCall {
+func (mr *MockPoolMockRecorder) GetBlobs(blobhashes, cellProofs any) *MockPoolGetBlobsCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlobs", reflect.TypeOf((*MockPool)(nil).GetBlobs), blobhashes)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlobs", reflect.TypeOf((*MockPool)(nil).GetBlobs), blobhashes, cellProofs)
 	return &MockPoolGetBlobsCall{Call: call}
 }
 
@@ -222,13 +222,13 @@ func (c *MockPoolGetBlobsCall) Return(arg0, arg1 [][]byte) *MockPoolGetBlobsCall
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockPoolGetBlobsCall) Do(f func([]common.Hash) ([][]byte, [][]byte)) *MockPoolGetBlobsCall {
+func (c *MockPoolGetBlobsCall) Do(f func([]common.Hash, bool) ([][]byte, [][]byte)) *MockPoolGetBlobsCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockPoolGetBlobsCall) DoAndReturn(f func([]common.Hash) ([][]byte, [][]byte)) *MockPoolGetBlobsCall {
+func (c *MockPoolGetBlobsCall) DoAndReturn(f func([]common.Hash, bool) ([][]byte, [][]byte)) *MockPoolGetBlobsCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
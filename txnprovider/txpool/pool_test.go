@@ -19,10 +19,14 @@ package txpool
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
 	"fmt"
 	"math"
 	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
@@ -38,6 +42,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/u256"
 	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/crypto/kzg"
+	"github.com/erigontech/erigon-lib/direct"
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
 	"github.com/erigontech/erigon-lib/kv"
@@ -60,7 +65,7 @@ func TestNonceFromAddress(t *testing.T) {
 	db := memdb.NewTestPoolDB(t)
 	cfg := txpoolcfg.DefaultConfig
 	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
-	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
 	require.NoError(err)
 	require.NotEqual(pool, nil)
 	var stateVersionID uint64 = 0
@@ -314,7 +319,7 @@ func TestMultipleAuthorizations(t *testing.T) {
 
 	cfg := txpoolcfg.DefaultConfig
 	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
-	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, common.Big0 /* shanghaiTime */, nil /* agraBlock */, common.Big0 /* cancunTime */, common.Big0 /* pragueTime */, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, common.Big0 /* shanghaiTime */, nil /* agraBlock */, common.Big0 /* cancunTime */, common.Big0 /* pragueTime */, nil /* osakaTime */, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
 	require.NoError(t, err)
 	require.NotEqual(t, pool, nil)
 
@@ -439,7 +444,7 @@ func TestReplaceWithHigherFee(t *testing.T) {
 	t.Cleanup(cancel)
 	cfg := txpoolcfg.DefaultConfig
 	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
-	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
 	require.NoError(err)
 	require.NotNil(pool)
 	var stateVersionID uint64 = 0
@@ -562,7 +567,7 @@ func TestReverseNonces(t *testing.T) {
 	t.Cleanup(cancel)
 	cfg := txpoolcfg.DefaultConfig
 	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
-	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
 	require.NoError(err)
 	require.NotEqual(pool, nil)
 	var stateVersionID uint64 = 0
@@ -692,7 +697,7 @@ func TestTxnPoke(t *testing.T) {
 	t.Cleanup(cancel)
 	cfg := txpoolcfg.DefaultConfig
 	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
-	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
 	require.NoError(err)
 	require.NotEqual(pool, nil)
 	var stateVersionID uint64 = 0
@@ -929,7 +934,7 @@ func TestShanghaiValidateTxn(t *testing.T) {
 			asrt.NoError(err)
 			defer sd.Close()
 			cache := kvcache.NewDummy()
-			pool, err := New(ctx, ch, nil, coreDB, cfg, cache, *u256.N1, shanghaiTime, nil /* agraBlock */, nil /* cancunTime */, nil, nil, nil, nil, func() {}, nil, nil, logger, WithFeeCalculator(nil))
+			pool, err := New(ctx, ch, nil, coreDB, cfg, cache, *u256.N1, shanghaiTime, nil /* agraBlock */, nil /* cancunTime */, nil, nil, nil, nil, nil, func() {}, nil, nil, logger, WithFeeCalculator(nil))
 			asrt.NoError(err)
 
 			sndr := accounts3.Account{Nonce: 0, Balance: *uint256.NewInt(math.MaxUint64)}
@@ -975,7 +980,7 @@ func TestTooHighGasLimitTxnValidation(t *testing.T) {
 	t.Cleanup(cancel)
 	cfg := txpoolcfg.DefaultConfig
 	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
-	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
 	require.NoError(err)
 	require.NotEqual(pool, nil)
 	var stateVersionID uint64 = 0
@@ -1041,7 +1046,7 @@ func TestSetCodeTxnValidationWithLargeAuthorizationValues(t *testing.T) {
 	cache := kvcache.NewDummy()
 	logger := log.New()
 	pool, err := New(ctx, ch, nil, coreDB, cfg, cache, chainID, common.Big0 /* shanghaiTime */, nil, /* agraBlock */
-		common.Big0 /* cancunTime */, common.Big0 /* pragueTime */, nil, nil, nil, func() {}, nil, nil, logger, WithFeeCalculator(nil))
+		common.Big0 /* cancunTime */, common.Big0 /* pragueTime */, nil /* osakaTime */, nil, nil, nil, func() {}, nil, nil, logger, WithFeeCalculator(nil))
 	require.NoError(t, err)
 	pool.blockGasLimit.Store(30_000_000)
 	tx, err := coreDB.BeginTemporalRw(ctx)
@@ -1090,7 +1095,7 @@ func TestBlobTxnReplacement(t *testing.T) {
 	t.Cleanup(cancel)
 	cfg := txpoolcfg.DefaultConfig
 	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
-	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, common.Big0, nil, common.Big0, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, common.Big0, nil, common.Big0, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
 	require.NoError(err)
 
 	require.NotEqual(pool, nil)
@@ -1274,7 +1279,7 @@ func TestDropRemoteAtNoGossip(t *testing.T) {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
-	txnPool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, nil, func() {}, nil, nil, logger, WithFeeCalculator(nil))
+	txnPool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, nil, nil, func() {}, nil, nil, logger, WithFeeCalculator(nil))
 	require.NoError(err)
 	require.NotEqual(txnPool, nil)
 
@@ -1373,6 +1378,489 @@ func TestDropRemoteAtNoGossip(t *testing.T) {
 	assert.True(checkAnnouncementEmpty())
 }
 
+func TestOrderingPolicies(t *testing.T) {
+	type txn struct {
+		sender byte
+		nonce  uint64
+		tip    uint64
+		local  bool
+	}
+	// in order of arrival
+	txns := []txn{
+		{sender: 1, nonce: 0, tip: 10_000},
+		{sender: 2, nonce: 0, tip: 3_000, local: true},
+		{sender: 1, nonce: 1, tip: 50_000}, // higher tip than previous nonce of sender
+		{sender: 3, nonce: 0, tip: 20_000},
+		{sender: 1, nonce: 2, tip: 5_000},
+	}
+	a0, b0, a1, c0, a2 := txns[0], txns[1], txns[2], txns[3], txns[4]
+
+	tests := []struct {
+		ordering txpoolcfg.Ordering
+		best     []txn
+	}{
+		{ordering: txpoolcfg.TipOrdering, best: []txn{b0, c0, a0, a1, a2}},           // local first, then by effective tip
+		{ordering: txpoolcfg.FIFOOrdering, best: []txn{a0, b0, a1, c0, a2}},          // by arrival, locals have no priority
+		{ordering: txpoolcfg.WeightedLocalOrdering, best: []txn{c0, a0, a1, b0, a2}}, // tips of local b0 and priority sender c0 doubled
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.ordering), func(t *testing.T) {
+			require := require.New(t)
+			ch := make(chan Announcements, 100)
+			coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+			db := memdb.NewTestPoolDB(t)
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			cfg := txpoolcfg.DefaultConfig
+			cfg.Ordering = tt.ordering
+			cfg.PrioritySenders = []string{string(common.Address{3}.Bytes())}
+			sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+			pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+			require.NoError(err)
+			require.NoError(pool.start(ctx))
+
+			change := &remote.StateChangeBatch{
+				PendingBlockBaseFee: 100_000,
+				BlockGasLimit:       1_000_000,
+				ChangeBatch: []*remote.StateChange{
+					{BlockHeight: 0, BlockHash: gointerfaces.ConvertHashToH256([32]byte{})},
+				},
+			}
+			for sender := byte(1); sender <= 3; sender++ {
+				acc := accounts3.Account{Balance: *uint256.NewInt(1 * common.Ether), Incarnation: 1}
+				change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+					Action:  remote.Action_UPSERT,
+					Address: gointerfaces.ConvertAddressToH160(common.Address{sender}),
+					Data:    accounts3.SerialiseV3(&acc),
+				})
+			}
+			require.NoError(pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+
+			for i, txn := range txns {
+				var slots TxnSlots
+				slot := &TxnSlot{Tip: *uint256.NewInt(txn.tip), FeeCap: *uint256.NewInt(3_000_000), Gas: 100_000, Nonce: txn.nonce}
+				slot.IDHash[0] = byte(i + 1)
+				slots.Append(slot, common.Address{txn.sender}.Bytes(), true)
+				if txn.local {
+					reasons, err := pool.AddLocalTxns(ctx, slots)
+					require.NoError(err)
+					require.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+					continue
+				}
+				pool.AddRemoteTxns(ctx, slots)
+				require.NoError(pool.processRemoteTxns(ctx))
+			}
+
+			pool.lock.Lock()
+			defer pool.lock.Unlock()
+			require.Equal(len(txns), pool.pending.Len())
+			best := make([]txn, 0, len(txns))
+			nonces := map[byte]uint64{}
+			for _, mt := range pool.pending.best.ms {
+				tx := txns[mt.TxnSlot.IDHash[0]-1]
+				require.Equal(nonces[tx.sender], tx.nonce, "nonce order of sender %d is broken", tx.sender)
+				nonces[tx.sender]++
+				best = append(best, tx)
+			}
+			require.Equal(tt.best, best)
+			// highest nonce of sender is evicted first
+			require.Equal(a2.nonce, pool.pending.Worst().TxnSlot.Nonce)
+		})
+	}
+}
+
+func TestPrivateTxns(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan Announcements, 100)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	cfg := txpoolcfg.DefaultConfig
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	require.NoError(err)
+
+	var addr [20]byte
+	addr[0] = 1
+	acc := accounts3.Account{Balance: *uint256.NewInt(1 * common.Ether), Incarnation: 1}
+	newBlock := func(blockNum uint64) *remote.StateChangeBatch {
+		return &remote.StateChangeBatch{
+			PendingBlockBaseFee: 100_000,
+			BlockGasLimit:       1_000_000,
+			ChangeBatch: []*remote.StateChange{{
+				BlockHeight: blockNum,
+				BlockHash:   gointerfaces.ConvertHashToH256([32]byte{byte(blockNum)}),
+				Changes: []*remote.AccountChange{{
+					Action:  remote.Action_UPSERT,
+					Address: gointerfaces.ConvertAddressToH160(addr),
+					Data:    accounts3.SerialiseV3(&acc),
+				}},
+			}},
+		}
+	}
+	require.NoError(pool.OnNewBlock(ctx, newBlock(0), TxnSlots{}, TxnSlots{}, TxnSlots{}))
+
+	newTxn := func(nonce uint64) TxnSlots {
+		var slots TxnSlots
+		slot := &TxnSlot{Tip: *uint256.NewInt(300_000), FeeCap: *uint256.NewInt(300_000), Gas: 100_000, Nonce: nonce, Rlp: []byte{byte(nonce)}}
+		slot.IDHash[0] = byte(nonce + 1)
+		slots.Append(slot, addr[:], true)
+		return slots
+	}
+	private, public := newTxn(0), newTxn(1)
+	reasons, err := pool.AddPrivateTxns(ctx, private, 2)
+	require.NoError(err)
+	require.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+	reasons, err = pool.AddLocalTxns(ctx, public)
+	require.NoError(err)
+	require.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+
+	require.True(pool.IsPrivate(private.Txns[0].IDHash[:]))
+	require.False(pool.IsPrivate(public.Txns[0].IDHash[:]))
+	txnTypes, _, hashes := pool.AppendAllAnnouncements(nil, nil, nil)
+	require.Len(txnTypes, 1)
+	require.Equal(public.Txns[0].IDHash[:], []byte(hashes))
+
+	tx, err := db.BeginRo(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	privateByNonce := map[uint64]bool{}
+	pool.deprecatedForEach(ctx, func(rlp []byte, sender common.Address, t SubPoolType, private bool) {
+		assert.Equal(PendingSubPool, t)
+		privateByNonce[uint64(rlp[0])] = private
+	}, tx)
+	require.Equal(map[uint64]bool{0: true, 1: false}, privateByNonce)
+
+	// still can be included into block 2
+	require.NoError(pool.OnNewBlock(ctx, newBlock(1), TxnSlots{}, TxnSlots{}, TxnSlots{}))
+	pending, _, _ := pool.CountContent()
+	require.Equal(2, pending)
+
+	// can't be included anymore - dropped. Next nonce can't be executed anymore
+	require.NoError(pool.OnNewBlock(ctx, newBlock(2), TxnSlots{}, TxnSlots{}, TxnSlots{}))
+	pending, _, queued := pool.CountContent()
+	require.Equal(0, pending)
+	require.Equal(1, queued)
+	reason, ok := pool.discardReasonsLRU.Get(string(private.Txns[0].IDHash[:]))
+	require.True(ok)
+	require.Equal(txpoolcfg.PrivateTxnExpired, reason)
+	require.False(pool.IsPrivate(private.Txns[0].IDHash[:]))
+}
+
+func TestPrivateTxnUnwind(t *testing.T) {
+	require := require.New(t)
+	ch := make(chan Announcements, 100)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	cfg := txpoolcfg.DefaultConfig
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	require.NoError(err)
+
+	var addr [20]byte
+	addr[0] = 1
+	acc := accounts3.Account{Balance: *uint256.NewInt(1 * common.Ether), Incarnation: 1}
+	newBlock := func(blockNum uint64, fork byte, finalized uint64) *remote.StateChangeBatch {
+		return &remote.StateChangeBatch{
+			PendingBlockBaseFee: 100_000,
+			BlockGasLimit:       1_000_000,
+			FinalizedBlock:      finalized,
+			ChangeBatch: []*remote.StateChange{{
+				BlockHeight: blockNum,
+				BlockHash:   gointerfaces.ConvertHashToH256([32]byte{byte(blockNum), fork}),
+				Changes: []*remote.AccountChange{{
+					Action:  remote.Action_UPSERT,
+					Address: gointerfaces.ConvertAddressToH160(addr),
+					Data:    accounts3.SerialiseV3(&acc),
+				}},
+			}},
+		}
+	}
+	require.NoError(pool.OnNewBlock(ctx, newBlock(0, 0, 0), TxnSlots{}, TxnSlots{}, TxnSlots{}))
+
+	newTxn := func() TxnSlots {
+		var slots TxnSlots
+		slot := &TxnSlot{Tip: *uint256.NewInt(300_000), FeeCap: *uint256.NewInt(300_000), Gas: 100_000, Rlp: []byte{1}}
+		slot.IDHash[0] = 1
+		slots.Append(slot, addr[:], true)
+		return slots
+	}
+	private := newTxn()
+	hash := private.Txns[0].IDHash
+	reasons, err := pool.AddPrivateTxns(ctx, private, 0 /* maxBlockNumber */)
+	require.NoError(err)
+	require.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+
+	// mined in block 1, then block 1 is unwound: txn is back in pool and is still private
+	acc.Nonce = 1
+	require.NoError(pool.OnNewBlock(ctx, newBlock(1, 0, 0), TxnSlots{}, TxnSlots{}, newTxn()))
+	pending, _, _ := pool.CountContent()
+	require.Zero(pending)
+	require.True(pool.IsPrivate(hash[:]))
+	require.NoError(pool.OnNewBlock(ctx, newBlock(2, 0, 0), TxnSlots{}, TxnSlots{}, TxnSlots{}))
+	require.True(pool.IsPrivate(hash[:]))
+
+	acc.Nonce = 0
+	require.NoError(pool.OnNewBlock(ctx, newBlock(1, 1, 0), newTxn(), TxnSlots{}, TxnSlots{}))
+	pending, _, _ = pool.CountContent()
+	require.Equal(1, pending)
+	require.True(pool.IsPrivate(hash[:]))
+	txnTypes, _, _ := pool.AppendAllAnnouncements(nil, nil, nil)
+	require.Empty(txnTypes)
+
+	// mined again: forgotten once its block is finalized
+	acc.Nonce = 1
+	require.NoError(pool.OnNewBlock(ctx, newBlock(2, 1, 0), TxnSlots{}, TxnSlots{}, newTxn()))
+	require.NoError(pool.OnNewBlock(ctx, newBlock(3, 1, 1), TxnSlots{}, TxnSlots{}, TxnSlots{}))
+	require.True(pool.IsPrivate(hash[:]))
+	require.NoError(pool.OnNewBlock(ctx, newBlock(4, 1, 2), TxnSlots{}, TxnSlots{}, TxnSlots{}))
+	require.False(pool.IsPrivate(hash[:]))
+
+	// without finality - after minedPrivateTxnKeepBlocks
+	acc.Nonce = 1
+	private = newTxn()
+	private.Txns[0].Nonce = 1
+	private.Txns[0].IDHash[0] = 2
+	reasons, err = pool.AddPrivateTxns(ctx, private, 0 /* maxBlockNumber */)
+	require.NoError(err)
+	require.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+	acc.Nonce = 2
+	require.NoError(pool.OnNewBlock(ctx, newBlock(5, 1, 0), TxnSlots{}, TxnSlots{}, private))
+	require.NoError(pool.OnNewBlock(ctx, newBlock(5+minedPrivateTxnKeepBlocks-1, 1, 0), TxnSlots{}, TxnSlots{}, TxnSlots{}))
+	require.True(pool.IsPrivate(private.Txns[0].IDHash[:]))
+	require.NoError(pool.OnNewBlock(ctx, newBlock(5+minedPrivateTxnKeepBlocks, 1, 0), TxnSlots{}, TxnSlots{}, TxnSlots{}))
+	require.False(pool.IsPrivate(private.Txns[0].IDHash[:]))
+}
+
+func TestTxnStatus(t *testing.T) {
+	require := require.New(t)
+	ch := make(chan Announcements, 100)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	cfg := txpoolcfg.DefaultConfig
+	cfg.StatusHistoryBlocks = 5
+	newPool := func() *TxPool {
+		sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+		pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+		require.NoError(err)
+		return pool
+	}
+	pool := newPool()
+
+	var addr [20]byte
+	addr[0] = 1
+	acc := accounts3.Account{Balance: *uint256.NewInt(1 * common.Ether), Incarnation: 1}
+	newBlock := func(blockNum uint64) *remote.StateChangeBatch {
+		return &remote.StateChangeBatch{
+			PendingBlockBaseFee: 100_000,
+			BlockGasLimit:       1_000_000,
+			ChangeBatch: []*remote.StateChange{{
+				BlockHeight: blockNum,
+				BlockHash:   gointerfaces.ConvertHashToH256([32]byte{byte(blockNum)}),
+				Changes: []*remote.AccountChange{{
+					Action:  remote.Action_UPSERT,
+					Address: gointerfaces.ConvertAddressToH160(addr),
+					Data:    accounts3.SerialiseV3(&acc),
+				}},
+			}},
+		}
+	}
+	require.NoError(pool.OnNewBlock(ctx, newBlock(0), TxnSlots{}, TxnSlots{}, TxnSlots{}))
+
+	newTxn := func(id byte, tip uint64) TxnSlots {
+		var slots TxnSlots
+		slot := &TxnSlot{Tip: *uint256.NewInt(tip), FeeCap: *uint256.NewInt(tip), Gas: 100_000, Rlp: []byte{id}}
+		slot.IDHash[0] = id
+		slots.Append(slot, addr[:], true)
+		return slots
+	}
+	replaced, replacement := newTxn(1, 300_000), newTxn(2, 400_000)
+	replacedHash, replacementHash := replaced.Txns[0].IDHash, replacement.Txns[0].IDHash
+
+	_, ok := pool.TxnStatus(replacedHash[:])
+	require.False(ok)
+	reasons, err := pool.AddLocalTxns(ctx, replaced)
+	require.NoError(err)
+	require.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+	status, ok := pool.TxnStatus(replacedHash[:])
+	require.True(ok)
+	require.Equal(TxnStatus{SubPool: PendingSubPool}, status)
+
+	reasons, err = pool.AddLocalTxns(ctx, replacement)
+	require.NoError(err)
+	require.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+	status, ok = pool.TxnStatus(replacedHash[:])
+	require.True(ok)
+	require.Equal(txpoolcfg.ReplacedByHigherTip, status.DiscardReason)
+	require.Equal(common.Hash(replacementHash), status.ReplacedBy)
+	require.NotZero(status.DiscardTime)
+
+	acc.Nonce = 1
+	require.NoError(pool.OnNewBlock(ctx, newBlock(1), TxnSlots{}, TxnSlots{}, replacement))
+	status, ok = pool.TxnStatus(replacementHash[:])
+	require.True(ok)
+	require.Equal(txpoolcfg.Mined, status.DiscardReason)
+	require.Equal(uint64(1), status.MinedBlock)
+
+	// history survives restart
+	_, err = pool.flush(ctx)
+	require.NoError(err)
+	pool = newPool()
+	require.NoError(pool.start(ctx))
+	status, ok = pool.TxnStatus(replacedHash[:])
+	require.True(ok)
+	require.Equal(txpoolcfg.ReplacedByHigherTip, status.DiscardReason)
+	require.Equal(common.Hash(replacementHash), status.ReplacedBy)
+	status, ok = pool.TxnStatus(replacementHash[:])
+	require.True(ok)
+	require.Equal(uint64(1), status.MinedBlock)
+
+	// and is pruned after StatusHistoryBlocks
+	require.NoError(pool.OnNewBlock(ctx, newBlock(5), TxnSlots{}, TxnSlots{}, TxnSlots{}))
+	_, ok = pool.TxnStatus(replacedHash[:])
+	require.True(ok)
+	require.NoError(pool.OnNewBlock(ctx, newBlock(6), TxnSlots{}, TxnSlots{}, TxnSlots{}))
+	_, ok = pool.TxnStatus(replacedHash[:])
+	require.False(ok)
+	_, err = pool.flush(ctx)
+	require.NoError(err)
+	tx, err := db.BeginRo(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	cnt, err := tx.Count(kv.PoolTxnStatus)
+	require.NoError(err)
+	require.Zero(cnt)
+}
+
+func TestTxnStatusHistoryPruneRemarked(t *testing.T) {
+	require := require.New(t)
+	h, err := newTxnStatusHistory(100, 5)
+	require.NoError(err)
+
+	now := time.Now()
+	h.discarded("old", txpoolcfg.ReplacedByHigherTip, 1, now)
+	h.discarded("new", txpoolcfg.ReplacedByHigherTip, 4, now)
+	// re-marking moves "old" to the front of LRU, but it still must be pruned by its discard block
+	h.mined("old", 5)
+	h.replaced("old", common.Hash{1})
+
+	h.prune(7)
+	_, ok := h.get("old")
+	require.False(ok)
+	status, ok := h.get("new")
+	require.True(ok)
+	require.Equal(uint64(4), status.DiscardBlock)
+	require.Contains(h.evicted, "old")
+
+	h.prune(10)
+	_, ok = h.get("new")
+	require.False(ok)
+	require.Empty(h.byBlock)
+}
+
+func TestExportImport(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	var keys []*ecdsa.PrivateKey
+	acc := accounts3.Account{Balance: *uint256.NewInt(1 * common.Ether), Incarnation: 1}
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee: 100_000,
+		BlockGasLimit:       1_000_000,
+		ChangeBatch:         []*remote.StateChange{{BlockHeight: 0, BlockHash: gointerfaces.ConvertHashToH256([32]byte{})}},
+	}
+	for i := 0; i < 3; i++ {
+		key, err := crypto.GenerateKey()
+		require.NoError(err)
+		keys = append(keys, key)
+		change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+			Action:  remote.Action_UPSERT,
+			Address: gointerfaces.ConvertAddressToH160(crypto.PubkeyToAddress(key.PublicKey)),
+			Data:    accounts3.SerialiseV3(&acc),
+		})
+	}
+	newPool := func() *TxPool {
+		coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+		sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+		pool, err := New(ctx, make(chan Announcements, 100), memdb.NewTestPoolDB(t), coreDB, txpoolcfg.DefaultConfig, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+		require.NoError(err)
+		require.NoError(pool.start(ctx))
+		require.NoError(pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+		return pool
+	}
+	newTxn := func(key *ecdsa.PrivateKey, isLocal bool) TxnSlots {
+		txn, err := types.SignTx(types.NewTransaction(0, common.Address{1}, uint256.NewInt(1), params.TxGas, uint256.NewInt(300_000), nil), *types.LatestSignerForChainID(big.NewInt(1)), key)
+		require.NoError(err)
+		var buf bytes.Buffer
+		require.NoError(txn.MarshalBinary(&buf))
+		var slots TxnSlots
+		slots.Resize(1)
+		slots.Txns[0] = &TxnSlot{}
+		slots.IsLocal[0] = isLocal
+		_, err = NewTxnParseContext(*u256.N1).ParseTransaction(buf.Bytes(), 0, slots.Txns[0], slots.Senders.At(0), false, true, nil)
+		require.NoError(err)
+		return slots
+	}
+	local, private, remoteTxn := newTxn(keys[0], true), newTxn(keys[1], true), newTxn(keys[2], false)
+
+	pool := newPool()
+	_, err := pool.AddLocalTxns(ctx, local)
+	require.NoError(err)
+	_, err = pool.AddPrivateTxns(ctx, private, 10)
+	require.NoError(err)
+	pool.AddRemoteTxns(ctx, remoteTxn)
+	require.NoError(pool.processRemoteTxns(ctx))
+	pending, _, _ := pool.CountContent()
+	require.Equal(3, pending)
+	_, err = pool.flush(ctx) // RLP of flushed txns is read from db
+	require.NoError(err)
+
+	var dump bytes.Buffer
+	count, err := pool.ExportTxns(ctx, &dump)
+	require.NoError(err)
+	require.Equal(3, count)
+
+	pool = newPool()
+	imported, err := pool.ImportTxns(ctx, bytes.NewReader(dump.Bytes()))
+	require.NoError(err)
+	require.Equal(ImportedTxns{Added: 2, Queued: 1}, imported)
+	require.NoError(pool.processRemoteTxns(ctx))
+	pending, _, _ = pool.CountContent()
+	require.Equal(3, pending)
+	require.True(pool.IsLocal(local.Txns[0].IDHash[:]))
+	require.True(pool.IsPrivate(private.Txns[0].IDHash[:]))
+	require.False(pool.IsLocal(remoteTxn.Txns[0].IDHash[:]))
+
+	// same dump streamed over grpc to file and back, already known
+	client := direct.NewTxPoolClient(NewGrpcServer(ctx, pool, nil, nil, *u256.N1, log.New()))
+	path := filepath.Join(t.TempDir(), "txpool.dump")
+	streamedCount, err := ExportToFile(ctx, client, path)
+	require.NoError(err)
+	require.Equal(uint64(3), streamedCount)
+	_, err = ExportToFile(ctx, client, path)
+	require.ErrorIs(err, os.ErrExist) // existing file is not overwritten
+	importReply, err := ImportFromFile(ctx, client, path)
+	require.NoError(err)
+	require.Equal(uint64(2), importReply.Discarded)
+	require.Equal(uint64(1), importReply.Queued) // known remote txn is dropped by processRemoteTxns
+	require.NoError(pool.processRemoteTxns(ctx))
+	pending, _, _ = pool.CountContent()
+	require.Equal(3, pending)
+
+	_, err = pool.ImportTxns(ctx, bytes.NewReader([]byte("not a txpool export")))
+	require.Error(err)
+}
+
 func TestBlobSlots(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 	ch := make(chan Announcements, 5)
@@ -1385,7 +1873,7 @@ func TestBlobSlots(t *testing.T) {
 	cfg.TotalBlobPoolLimit = 20
 
 	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
-	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, common.Big0, nil, common.Big0, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, common.Big0, nil, common.Big0, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
 	require.NoError(err)
 	require.NotEqual(pool, nil)
 	var stateVersionID uint64 = 0
@@ -1468,7 +1956,7 @@ func TestGetBlobsV1(t *testing.T) {
 	cfg.TotalBlobPoolLimit = 20
 
 	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
-	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, common.Big0, nil, common.Big0, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, common.Big0, nil, common.Big0, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
 	require.NoError(err)
 	require.NotEqual(pool, nil)
 	pool.blockGasLimit.Store(30000000)
@@ -1526,12 +2014,141 @@ func TestGetBlobsV1(t *testing.T) {
 	}
 	blobHashes = append(blobHashes, blobTxn.BlobHashes...)
 
-	blobs, proofs := pool.GetBlobs(blobHashes)
+	blobs, proofs := pool.GetBlobs(blobHashes, false)
 	require.Equal(len(blobs), len(blobHashes))
 	require.Equal(len(proofs), len(blobHashes))
 	assert.Equal(blobTxn.Blobs, blobs)
 	assert.Equal(blobTxn.Proofs[0][:], proofs[0])
 	assert.Equal(blobTxn.Proofs[1][:], proofs[1])
+
+	// no cell proofs before Osaka
+	blobs, proofs = pool.GetBlobs(blobHashes, true)
+	assert.Equal([][]byte{nil, nil}, blobs)
+	assert.Equal([][]byte{nil, nil}, proofs)
+}
+
+func TestGetBlobsV2(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan Announcements, 5)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	cfg := txpoolcfg.DefaultConfig
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, common.Big0, nil, common.Big0, common.Big0, common.Big0 /* osakaTime */, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	require.NoError(err)
+	pool.blockGasLimit.Store(30000000)
+
+	var addr [20]byte
+	addr[0] = 1
+	acc := accounts3.Account{Balance: *uint256.NewInt(1 * common.Ether), Incarnation: 1}
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee:  200_000,
+		BlockGasLimit:        math.MaxUint64,
+		PendingBlobFeePerGas: 100_000,
+		ChangeBatch: []*remote.StateChange{{
+			BlockHeight: 0,
+			BlockHash:   gointerfaces.ConvertHashToH256([32]byte{}),
+			Changes: []*remote.AccountChange{{
+				Action:  remote.Action_UPSERT,
+				Address: gointerfaces.ConvertAddressToH160(addr),
+				Data:    accounts3.SerialiseV3(&acc),
+			}},
+		}},
+	}
+	require.NoError(pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+
+	// txns are propagated with cell proofs after Osaka: txn with blob proofs is converted
+	txnSlots := TxnSlots{}
+	blobTxn := makeBlobTxn() // makes a txn with 2 blobs
+	blobTxn.IDHash[0] = uint8(3)
+	blobTxn.Nonce = 0
+	blobTxn.Gas = 50000
+	txnSlots.Append(&blobTxn, addr[:], true)
+	reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+	require.NoError(err)
+	for _, reason := range reasons {
+		assert.Equal(txpoolcfg.Success, reason, reason.String())
+	}
+	require.Equal(types.BlobWrapperVersion1, blobTxn.BlobWrapperVersion)
+	require.Len(blobTxn.CellProofs, 2*kzg.CellsPerExtBlob)
+	require.Empty(blobTxn.Proofs)
+
+	wrapped, err := types.DecodeWrappedTransaction(blobTxn.Rlp)
+	require.NoError(err)
+	require.Equal(types.BlobWrapperVersion1, wrapped.(*types.BlobTxWrapper).WrapperVersion)
+	require.NoError(kzg.VerifyCellProofs(toBlobs(blobTxn.Blobs), blobTxn.Commitments, blobTxn.CellProofs))
+	parsed := TxnSlot{}
+	parseCtx := NewTxnParseContext(*uint256.NewInt(5))
+	parseCtx.WithSender(false)
+	_, err = parseCtx.ParseTransaction(blobTxn.Rlp, 0, &parsed, nil, false, true, nil)
+	require.NoError(err)
+	require.Equal(blobTxn.CellProofs, parsed.CellProofs)
+	require.Equal(blobTxn.Commitments, parsed.Commitments)
+
+	blobs, proofs := pool.GetBlobs(blobTxn.BlobHashes, true)
+	require.Equal(blobTxn.Blobs, blobs)
+	require.Len(proofs, 2)
+	for i := range proofs {
+		require.Len(proofs[i], kzg.CellsPerExtBlob*48)
+		assert.Equal(blobTxn.CellProofs[i*kzg.CellsPerExtBlob][:], proofs[i][:48])
+	}
+	// blob proofs are not known
+	blobs, _ = pool.GetBlobs(blobTxn.BlobHashes, false)
+	assert.Equal([][]byte{nil, nil}, blobs)
+}
+
+func TestBlobTxnWithoutCellsRemote(t *testing.T) {
+	require := require.New(t)
+	ch := make(chan Announcements, 5)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	cfg := txpoolcfg.DefaultConfig
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, common.Big0, nil, common.Big0, common.Big0, common.Big0 /* osakaTime */, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	require.NoError(err)
+	require.NoError(pool.start(ctx))
+	pool.blockGasLimit.Store(30000000)
+
+	var addr [20]byte
+	addr[0] = 1
+	acc := accounts3.Account{Balance: *uint256.NewInt(1 * common.Ether), Incarnation: 1}
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee:  200_000,
+		BlockGasLimit:        math.MaxUint64,
+		PendingBlobFeePerGas: 100_000,
+		ChangeBatch: []*remote.StateChange{{
+			BlockHeight: 0,
+			BlockHash:   gointerfaces.ConvertHashToH256([32]byte{}),
+			Changes: []*remote.AccountChange{{
+				Action:  remote.Action_UPSERT,
+				Address: gointerfaces.ConvertAddressToH160(addr),
+				Data:    accounts3.SerialiseV3(&acc),
+			}},
+		}},
+	}
+	require.NoError(pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+
+	// gossiped txn with blob proofs is rejected: cell proofs are not computed for peers
+	txnSlots := TxnSlots{}
+	blobTxn := makeBlobTxn() // makes a txn with 2 blobs
+	blobTxn.IDHash[0] = uint8(3)
+	blobTxn.Nonce = 0
+	blobTxn.Gas = 50000
+	txnSlots.Append(&blobTxn, addr[:], false)
+	pool.AddRemoteTxns(ctx, txnSlots)
+	require.NoError(pool.processRemoteTxns(ctx))
+	pending, _, _ := pool.CountContent()
+	require.Zero(pending)
+	require.Empty(blobTxn.CellProofs)
+
+	blobs, _ := pool.GetBlobs(blobTxn.BlobHashes, true)
+	require.Equal([][]byte{nil, nil}, blobs)
 }
 
 func TestGasLimitChanged(t *testing.T) {
@@ -1543,7 +2160,7 @@ func TestGasLimitChanged(t *testing.T) {
 	db := memdb.NewTestPoolDB(t)
 	cfg := txpoolcfg.DefaultConfig
 	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
-	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
 	require.NoError(err)
 	require.NotEqual(pool, nil)
 	var stateVersionID uint64 = 0
@@ -1627,7 +2244,7 @@ func BenchmarkProcessRemoteTxns(b *testing.B) {
 	b.Cleanup(cancel)
 	cfg := txpoolcfg.DefaultConfig
 	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
-	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
 	require.NoError(err)
 	require.NotEqual(pool, nil)
 
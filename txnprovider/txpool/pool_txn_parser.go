@@ -198,6 +198,18 @@ func (ctx *TxnParseContext) ParseTransaction(payload []byte, pos int, slot *TxnS
 			return 0, fmt.Errorf("%w: unexpected leftover after blob txn body", ErrParseTxn)
 		}
 
+		if p < wrapperDataPos+wrapperDataLen && payload[p] < 0xc0 { // EIP-7594 wrapper version, lists of old wrapper follow body
+			var version uint64
+			p, version, err = rlp.ParseU64(payload, p)
+			if err != nil {
+				return 0, fmt.Errorf("%w: blob wrapper version: %s", ErrParseTxn, err) //nolint
+			}
+			if version != uint64(types.BlobWrapperVersion1) {
+				return 0, fmt.Errorf("%w: unsupported blob wrapper version: %d", ErrParseTxn, version)
+			}
+			slot.BlobWrapperVersion = types.BlobWrapperVersion1
+		}
+
 		dataPos, dataLen, err = rlp.ParseList(payload, p)
 		if err != nil {
 			return 0, fmt.Errorf("%w: blobs len: %s", ErrParseTxn, err) //nolint
@@ -248,7 +260,11 @@ func (ctx *TxnParseContext) ParseTransaction(payload []byte, pos int, slot *TxnS
 			}
 			var proof gokzg4844.KZGProof
 			copy(proof[:], payload[proofPos:proofPos+48])
-			slot.Proofs = append(slot.Proofs, proof)
+			if slot.BlobWrapperVersion == types.BlobWrapperVersion1 {
+				slot.CellProofs = append(slot.CellProofs, proof)
+			} else {
+				slot.Proofs = append(slot.Proofs, proof)
+			}
 			proofPos += 48
 		}
 		if proofPos != dataPos+dataLen {
@@ -847,6 +863,10 @@ type TxnSlot struct {
 	Commitments []gokzg4844.KZGCommitment
 	Proofs      []gokzg4844.KZGProof
 
+	// EIP-7594: PeerDAS
+	BlobWrapperVersion byte                 // 0 - Proofs are blob proofs, types.BlobWrapperVersion1 - blob proofs are not known
+	CellProofs         []gokzg4844.KZGProof // kzg.CellsPerExtBlob proofs per blob: received in version 1 wrapper or computed after Osaka
+
 	AuthAndNonces []AuthAndNonce // Indexed authorization signers + nonces for EIP-7702 txns (type-4)
 
 	// RIP-7560: account abstraction
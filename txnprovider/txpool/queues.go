@@ -23,6 +23,7 @@ import "github.com/holiman/uint256"
 type bestSlice struct {
 	ms             []*metaTxn
 	pendingBaseFee uint64
+	ordering       OrderingPolicy
 }
 
 func (s *bestSlice) Len() int {
@@ -35,7 +36,7 @@ func (s *bestSlice) Swap(i, j int) {
 }
 
 func (s *bestSlice) Less(i, j int) bool {
-	return s.ms[i].better(s.ms[j], *uint256.NewInt(s.pendingBaseFee))
+	return s.ordering.Better(s.ms[i], s.ms[j], *uint256.NewInt(s.pendingBaseFee))
 }
 
 func (s *bestSlice) UnsafeRemove(i *metaTxn) {
@@ -90,6 +91,7 @@ func (p *BestQueue) Pop() interface{} {
 type WorstQueue struct {
 	ms             []*metaTxn
 	pendingBaseFee uint64
+	ordering       OrderingPolicy
 }
 
 func (p *WorstQueue) Len() int {
@@ -97,7 +99,7 @@ func (p *WorstQueue) Len() int {
 }
 
 func (p *WorstQueue) Less(i, j int) bool {
-	return p.ms[i].worse(p.ms[j], *uint256.NewInt(p.pendingBaseFee))
+	return p.ordering.Worse(p.ms[i], p.ms[j], *uint256.NewInt(p.pendingBaseFee))
 }
 
 func (p *WorstQueue) Swap(i, j int) {
@@ -67,7 +67,7 @@ type SubPool struct {
 }
 
 func NewSubPool(t SubPoolType, limit int) *SubPool {
-	return &SubPool{limit: limit, t: t, best: &BestQueue{}, worst: &WorstQueue{}}
+	return &SubPool{limit: limit, t: t, best: &BestQueue{}, worst: &WorstQueue{ordering: TipOrderingPolicy{}}}
 }
 
 func (p *SubPool) EnforceInvariants() {
@@ -0,0 +1,200 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+// TxnStatus - lifecycle status of transaction: sub-pool if transaction is in pool,
+// otherwise why and when it was discarded
+type TxnStatus struct {
+	SubPool       SubPoolType // 0 if transaction is not in pool
+	Private       bool
+	DiscardReason txpoolcfg.DiscardReason
+	DiscardTime   uint64      // unix seconds
+	DiscardBlock  uint64      // last seen block at discard time
+	MinedBlock    uint64      // 0 if transaction was not seen in mined block
+	ReplacedBy    common.Hash // replacement transaction if ReplacedByHigherTip
+}
+
+// txnStatusHistory - bounded history of discarded transactions: keeps last `limit` transactions discarded within
+// last `blocks` blocks. It's persisted incrementally: only changes since last flush are written to db.
+type txnStatusHistory struct {
+	lru     *simplelru.LRU[string, TxnStatus]
+	blocks  uint64
+	byBlock map[uint64]map[string]struct{} // discard_block : txn_hashes. LRU order is not block order: mined/replaced re-add old entries
+	dirty   map[string]struct{}            // txn_hash : changed since last flush
+	evicted map[string]struct{}            // txn_hash : removed since last flush
+}
+
+func newTxnStatusHistory(limit int, blocks uint64) (*txnStatusHistory, error) {
+	h := &txnStatusHistory{
+		blocks:  blocks,
+		byBlock: map[uint64]map[string]struct{}{},
+		dirty:   map[string]struct{}{},
+		evicted: map[string]struct{}{},
+	}
+	lru, err := simplelru.NewLRU[string, TxnStatus](limit, func(hash string, status TxnStatus) {
+		h.unindex(hash, status.DiscardBlock)
+		delete(h.dirty, hash)
+		h.evicted[hash] = struct{}{}
+	})
+	if err != nil {
+		return nil, err
+	}
+	h.lru = lru
+	return h, nil
+}
+
+func (h *txnStatusHistory) add(hash string, status TxnStatus) {
+	if prev, ok := h.lru.Peek(hash); ok && prev.DiscardBlock != status.DiscardBlock {
+		h.unindex(hash, prev.DiscardBlock)
+	}
+	h.lru.Add(hash, status)
+	h.index(hash, status.DiscardBlock)
+	delete(h.evicted, hash)
+	h.dirty[hash] = struct{}{}
+}
+
+func (h *txnStatusHistory) index(hash string, block uint64) {
+	hashes, ok := h.byBlock[block]
+	if !ok {
+		hashes = map[string]struct{}{}
+		h.byBlock[block] = hashes
+	}
+	hashes[hash] = struct{}{}
+}
+
+func (h *txnStatusHistory) unindex(hash string, block uint64) {
+	hashes, ok := h.byBlock[block]
+	if !ok {
+		return
+	}
+	delete(hashes, hash)
+	if len(hashes) == 0 {
+		delete(h.byBlock, block)
+	}
+}
+
+func (h *txnStatusHistory) discarded(hash string, reason txpoolcfg.DiscardReason, block uint64, now time.Time) {
+	h.add(hash, TxnStatus{DiscardReason: reason, DiscardTime: uint64(now.Unix()), DiscardBlock: block})
+}
+
+func (h *txnStatusHistory) replaced(hash string, by common.Hash) {
+	if status, ok := h.lru.Peek(hash); ok {
+		status.ReplacedBy = by
+		h.add(hash, status)
+	}
+}
+
+// mined - sets mined block of discarded transaction. Transactions which were not in pool are not recorded
+func (h *txnStatusHistory) mined(hash string, block uint64) {
+	if status, ok := h.lru.Peek(hash); ok {
+		status.MinedBlock = block
+		h.add(hash, status)
+	}
+}
+
+// remove - transaction is back in pool (for example, after unwind)
+func (h *txnStatusHistory) remove(hash string) {
+	h.lru.Remove(hash)
+}
+
+func (h *txnStatusHistory) get(hash string) (TxnStatus, bool) {
+	return h.lru.Peek(hash)
+}
+
+// prune - removes transactions discarded more than `blocks` blocks before given block
+func (h *txnStatusHistory) prune(block uint64) {
+	for discardBlock, hashes := range h.byBlock {
+		if discardBlock+h.blocks >= block {
+			continue
+		}
+		for hash := range hashes {
+			h.lru.Remove(hash) // eviction callback unindexes it
+		}
+	}
+}
+
+func (h *txnStatusHistory) flush(tx kv.RwTx) error {
+	for hash := range h.evicted {
+		if err := tx.Delete(kv.PoolTxnStatus, []byte(hash)); err != nil {
+			return err
+		}
+	}
+	var buf []byte
+	for hash := range h.dirty {
+		status, ok := h.lru.Peek(hash)
+		if !ok {
+			continue
+		}
+		buf = EncodeTxnStatus(status, buf[:0])
+		if err := tx.Put(kv.PoolTxnStatus, []byte(hash), buf); err != nil {
+			return err
+		}
+	}
+	clear(h.evicted)
+	clear(h.dirty)
+	return nil
+}
+
+// load - restores history from db: older transactions are evicted first
+func (h *txnStatusHistory) load(tx kv.Tx) error {
+	type entry struct {
+		hash   string
+		status TxnStatus
+	}
+	var entries []entry
+	it, err := tx.Range(kv.PoolTxnStatus, nil, nil, order.Asc, kv.Unlim)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for it.HasNext() {
+		k, v, err := it.Next()
+		if err != nil {
+			return err
+		}
+		status, err := DecodeTxnStatus(v)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{hash: string(k), status: status})
+	}
+	slices.SortFunc(entries, func(a, b entry) int {
+		if c := cmp.Compare(a.status.DiscardBlock, b.status.DiscardBlock); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.status.DiscardTime, b.status.DiscardTime)
+	})
+	for _, e := range entries {
+		h.lru.Add(e.hash, e.status) // overflow goes to evicted - and will be deleted from db on next flush
+		h.index(e.hash, e.status.DiscardBlock)
+		delete(h.evicted, e.hash)
+	}
+	return nil
+}
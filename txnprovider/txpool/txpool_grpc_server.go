@@ -17,9 +17,11 @@
 package txpool
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"net"
 	"sync"
@@ -55,11 +57,15 @@ type txPool interface {
 	PeekBest(ctx context.Context, n int, txns *TxnsRlp, onTopOf, availableGas, availableBlobGas uint64) (bool, error)
 	GetRlp(tx kv.Tx, hash []byte) ([]byte, error)
 	AddLocalTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcfg.DiscardReason, error)
-	deprecatedForEach(_ context.Context, f func(rlp []byte, sender common.Address, t SubPoolType), tx kv.Tx)
+	AddPrivateTxns(ctx context.Context, newTxns TxnSlots, maxBlockNumber uint64) ([]txpoolcfg.DiscardReason, error)
+	deprecatedForEach(_ context.Context, f func(rlp []byte, sender common.Address, t SubPoolType, private bool), tx kv.Tx)
 	CountContent() (int, int, int)
 	IdHashKnown(tx kv.Tx, hash []byte) (bool, error)
 	NonceFromAddress(addr [20]byte) (nonce uint64, inPool bool)
-	GetBlobs(blobhashes []common.Hash) (blobs [][]byte, proofs [][]byte)
+	GetBlobs(blobhashes []common.Hash, cellProofs bool) (blobs [][]byte, proofs [][]byte)
+	TxnStatus(idHash []byte) (TxnStatus, bool)
+	ExportTxns(ctx context.Context, w io.Writer) (int, error)
+	ImportTxns(ctx context.Context, r io.Reader) (ImportedTxns, error)
 }
 
 var _ txpool_proto.TxpoolServer = (*GrpcServer)(nil)   // compile-time interface check
@@ -98,6 +104,15 @@ func (*GrpcDisabled) Status(ctx context.Context, request *txpool_proto.StatusReq
 func (*GrpcDisabled) Nonce(ctx context.Context, request *txpool_proto.NonceRequest) (*txpool_proto.NonceReply, error) {
 	return nil, ErrPoolDisabled
 }
+func (*GrpcDisabled) TransactionStatus(ctx context.Context, request *txpool_proto.TransactionStatusRequest) (*txpool_proto.TransactionStatusReply, error) {
+	return nil, ErrPoolDisabled
+}
+func (*GrpcDisabled) Export(request *txpool_proto.ExportRequest, server txpool_proto.Txpool_ExportServer) error {
+	return ErrPoolDisabled
+}
+func (*GrpcDisabled) Import(server txpool_proto.Txpool_ImportServer) error {
+	return ErrPoolDisabled
+}
 
 type GrpcServer struct {
 	txpool_proto.UnimplementedTxpoolServer
@@ -137,11 +152,12 @@ func (s *GrpcServer) All(ctx context.Context, _ *txpool_proto.AllRequest) (*txpo
 	defer tx.Rollback()
 	reply := &txpool_proto.AllReply{}
 	reply.Txs = make([]*txpool_proto.AllReply_Tx, 0, 32)
-	s.txPool.deprecatedForEach(ctx, func(rlp []byte, sender common.Address, t SubPoolType) {
+	s.txPool.deprecatedForEach(ctx, func(rlp []byte, sender common.Address, t SubPoolType, private bool) {
 		reply.Txs = append(reply.Txs, &txpool_proto.AllReply_Tx{
 			Sender:  gointerfaces.ConvertAddressToH160(sender),
 			TxnType: convertSubPoolType(t),
 			RlpTx:   common.Copy(rlp),
+			Private: private,
 		})
 	}, tx)
 	return reply, nil
@@ -208,7 +224,12 @@ func (s *GrpcServer) Add(ctx context.Context, in *txpool_proto.AddRequest) (*txp
 		}
 	}
 
-	discardReasons, err := s.txPool.AddLocalTxns(ctx, slots)
+	var discardReasons []txpoolcfg.DiscardReason
+	if in.Private {
+		discardReasons, err = s.txPool.AddPrivateTxns(ctx, slots, in.MaxBlockNumber)
+	} else {
+		discardReasons, err = s.txPool.AddLocalTxns(ctx, slots)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -238,7 +259,7 @@ func (s *GrpcServer) GetBlobs(ctx context.Context, in *txpool_proto.GetBlobsRequ
 	for i := range in.BlobHashes {
 		hashes[i] = gointerfaces.ConvertH256ToHash(in.BlobHashes[i])
 	}
-	blobs, proofs := s.txPool.GetBlobs(hashes)
+	blobs, proofs := s.txPool.GetBlobs(hashes, in.CellProofs)
 	reply := &txpool_proto.GetBlobsReply{Blobs: blobs, Proofs: proofs}
 	return reply, nil
 }
@@ -254,7 +275,7 @@ func mapDiscardReasonToProto(reason txpoolcfg.DiscardReason) txpool_proto.Import
 	case txpoolcfg.InvalidSender, txpoolcfg.NegativeValue, txpoolcfg.OversizedData, txpoolcfg.InitCodeTooLarge,
 		txpoolcfg.RLPTooLong, txpoolcfg.InvalidCreateTxn, txpoolcfg.NoBlobs, txpoolcfg.TooManyBlobs,
 		txpoolcfg.TypeNotActivated, txpoolcfg.UnequalBlobTxExt, txpoolcfg.BlobHashCheckFail,
-		txpoolcfg.UnmatchedBlobTxExt, txpoolcfg.NoAuthorizations:
+		txpoolcfg.UnmatchedBlobTxExt, txpoolcfg.NoAuthorizations, txpoolcfg.BlobTxWithoutCells:
 		// TODO(EIP-7702) TypeNotActivated may be transient (e.g. a set code transaction is submitted 1 sec prior to the Pectra activation)
 		return txpool_proto.ImportResult_INVALID
 	default:
@@ -318,6 +339,91 @@ func (s *GrpcServer) Nonce(ctx context.Context, in *txpool_proto.NonceRequest) (
 	}, nil
 }
 
+// returns status of transaction in pool, or why it was recently discarded
+func (s *GrpcServer) TransactionStatus(ctx context.Context, in *txpool_proto.TransactionStatusRequest) (*txpool_proto.TransactionStatusReply, error) {
+	hash := gointerfaces.ConvertH256ToHash(in.Hash)
+	status, ok := s.txPool.TxnStatus(hash[:])
+	if !ok {
+		return &txpool_proto.TransactionStatusReply{Status: txpool_proto.TransactionStatusReply_UNKNOWN}, nil
+	}
+	reply := &txpool_proto.TransactionStatusReply{Private: status.Private}
+	switch status.SubPool {
+	case PendingSubPool:
+		reply.Status = txpool_proto.TransactionStatusReply_PENDING
+	case BaseFeeSubPool:
+		reply.Status = txpool_proto.TransactionStatusReply_BASE_FEE
+	case QueuedSubPool:
+		reply.Status = txpool_proto.TransactionStatusReply_QUEUED
+	default:
+		reply.Status = txpool_proto.TransactionStatusReply_DISCARDED
+		reply.DiscardReason = status.DiscardReason.String()
+		reply.DiscardTime = status.DiscardTime
+		reply.MinedBlock = status.MinedBlock
+		if status.ReplacedBy != (common.Hash{}) {
+			reply.ReplacedBy = gointerfaces.ConvertHashToH256(status.ReplacedBy)
+		}
+	}
+	return reply, nil
+}
+
+// exportChunkSize - max size of dump part sent in one ExportReply
+const exportChunkSize = 1 << 20
+
+// streams all transactions of pool, dump is split into chunks of at most exportChunkSize (unless single txn is bigger)
+func (s *GrpcServer) Export(in *txpool_proto.ExportRequest, stream txpool_proto.Txpool_ExportServer) error {
+	w := bufio.NewWriterSize(exportChunkWriter(func(p []byte) error {
+		return stream.Send(&txpool_proto.ExportReply{Chunk: common.CopyBytes(p)})
+	}), exportChunkSize)
+	count, err := s.txPool.ExportTxns(stream.Context(), w)
+	if err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return stream.Send(&txpool_proto.ExportReply{Count: uint64(count)})
+}
+
+// adds transactions from dump streamed by Export
+func (s *GrpcServer) Import(stream txpool_proto.Txpool_ImportServer) error {
+	res, err := s.txPool.ImportTxns(stream.Context(), &importChunkReader{stream: stream})
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&txpool_proto.ImportReply{
+		Added:     uint64(res.Added),
+		Discarded: uint64(res.Discarded),
+		Queued:    uint64(res.Queued),
+	})
+}
+
+type exportChunkWriter func(p []byte) error
+
+func (w exportChunkWriter) Write(p []byte) (int, error) {
+	if err := w(p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+type importChunkReader struct {
+	stream txpool_proto.Txpool_ImportServer
+	chunk  []byte
+}
+
+func (r *importChunkReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err // io.EOF when client closed the stream
+		}
+		r.chunk = req.Chunk
+	}
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
+
 // NewSlotsStreams - it's safe to use this class as non-pointer
 type NewSlotsStreams struct {
 	chans map[uint]txpool_proto.Txpool_OnAddServer
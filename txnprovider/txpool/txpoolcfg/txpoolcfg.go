@@ -60,6 +60,35 @@ type Config struct {
 
 	// Account Abstraction
 	AllowAA bool
+
+	// order of transactions in pending sub-pool: in this order block builder receives them
+	Ordering        Ordering
+	LocalTipWeight  uint64   // effective tip multiplier of local and priority-senders txns, for WeightedLocalOrdering
+	PrioritySenders []string // List of senders which txns are treated as local by WeightedLocalOrdering
+
+	// history of discarded txns for txpool_getTransactionStatus: persisted, keeps at most StatusHistoryLimit
+	// txns discarded within last StatusHistoryBlocks blocks
+	StatusHistoryLimit  int
+	StatusHistoryBlocks uint64
+}
+
+// Ordering - policy of transactions ordering in pending sub-pool.
+// Any policy preserves nonce order of transactions of the same sender.
+type Ordering string
+
+const (
+	TipOrdering           Ordering = "tip"            // by effective tip
+	FIFOOrdering          Ordering = "fifo"           // by arrival to pool
+	WeightedLocalOrdering Ordering = "weighted-local" // by effective tip, multiplied by LocalTipWeight for local and priority-senders txns
+)
+
+func ParseOrdering(s string) (Ordering, error) {
+	switch o := Ordering(s); o {
+	case TipOrdering, FIFOOrdering, WeightedLocalOrdering:
+		return o, nil
+	default:
+		return "", fmt.Errorf("unknown txpool ordering: %q, supported: %s, %s, %s", s, TipOrdering, FIFOOrdering, WeightedLocalOrdering)
+	}
 }
 
 var DefaultConfig = Config{
@@ -81,6 +110,12 @@ var DefaultConfig = Config{
 
 	NoGossip:     false,
 	MdbxWriteMap: false,
+
+	Ordering:       TipOrdering,
+	LocalTipWeight: 2,
+
+	StatusHistoryLimit:  100_000,
+	StatusHistoryBlocks: 4096,
 }
 
 type DiscardReason uint8
@@ -123,6 +158,8 @@ const (
 	ErrAuthorityReserved DiscardReason = 34 // EIP-7702 transaction with authority already reserved
 	InvalidAA            DiscardReason = 35 // Invalid RIP-7560 transaction
 	ErrGetCode           DiscardReason = 36 // Error getting code during AA validation
+	PrivateTxnExpired    DiscardReason = 37 // Private transaction was not included until its max block number
+	BlobTxWithoutCells   DiscardReason = 38 // After Osaka (EIP-7594) blob transactions from peers must be wrapped with cell proofs
 )
 
 func (r DiscardReason) String() string {
@@ -201,6 +238,10 @@ func (r DiscardReason) String() string {
 		return "RIP-7560 transaction failed validation"
 	case ErrGetCode:
 		return "error getting account code during RIP-7560 validation"
+	case PrivateTxnExpired:
+		return "private transaction not included until max block number"
+	case BlobTxWithoutCells:
+		return "blob transaction must have cell proofs after Osaka"
 	default:
 		panic(fmt.Sprintf("discard reason: %d", r))
 	}